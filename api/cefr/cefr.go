@@ -0,0 +1,149 @@
+// Package cefr estimates a learner's effective CEFR level from how they do
+// with content at each level: how many of its questions they pass, how much
+// of it they finish and how many words they look up while reading it.
+//
+// The estimate is the highest level the learner is comfortable at, with a
+// confidence that grows with the evidence behind it, so a handful of answers
+// can suggest a change but only a steady record makes it certain.
+package cefr
+
+import (
+	"squeak-shared/qnagen"
+)
+
+// Recommendations for the learner's declared level.
+const (
+	RECOMMEND_UP   = "up"
+	RECOMMEND_DOWN = "down"
+	RECOMMEND_STAY = "stay"
+)
+
+const (
+	// MIN_EVIDENCE is how many answers and readings a level needs before it
+	// is used for the estimate.
+	MIN_EVIDENCE = 5
+	// A learner is comfortable at a level from COMFORTABLE mastery and
+	// struggles with it below STRUGGLING.
+	COMFORTABLE = 0.7
+	STRUGGLING  = 0.5
+	// CONFIDENCE_EVIDENCE is the evidence at which confidence is one half.
+	CONFIDENCE_EVIDENCE = 10
+	// MAX_LOOKUP_DENSITY is the lookups per 100 words read at which reading
+	// counts as too hard.
+	MAX_LOOKUP_DENSITY = 5.0
+	// AUTO_ADJUST_CONFIDENCE is the confidence an estimate needs before it
+	// replaces a learner's declared level, reached with 30 answers and
+	// readings at the deciding level.
+	AUTO_ADJUST_CONFIDENCE = 0.75
+)
+
+// How much each signal counts towards mastery of a level. Signals without
+// data are left out and the others scaled up.
+const (
+	PASS_RATE_WEIGHT  = 0.5
+	COMPLETION_WEIGHT = 0.3
+	LOOKUP_WEIGHT     = 0.2
+)
+
+// Evidence is what a learner did with content at one level.
+type Evidence struct {
+	Level     string `json:"level"`
+	Attempts  int    `json:"attempts"` // questions answered
+	Passed    int    `json:"passed"`
+	Started   int    `json:"started"` // news and stories opened
+	Completed int    `json:"completed"`
+	WordsRead int    `json:"words_read"` // in the content opened whose length is known
+	Lookups   int    `json:"lookups"`    // of words looked up in that content
+}
+
+// Count is how many answers and readings the evidence is made of.
+func (e Evidence) Count() int {
+	return e.Attempts + e.Started
+}
+
+// Mastery is how comfortable the learner is at the level, from 0 to 1. It is
+// false when there is nothing to go on.
+func (e Evidence) Mastery() (float64, bool) {
+	total, weights := 0.0, 0.0
+	if e.Attempts > 0 {
+		total += PASS_RATE_WEIGHT * float64(e.Passed) / float64(e.Attempts)
+		weights += PASS_RATE_WEIGHT
+	}
+	if e.Started > 0 {
+		total += COMPLETION_WEIGHT * float64(e.Completed) / float64(e.Started)
+		weights += COMPLETION_WEIGHT
+	}
+	if e.WordsRead > 0 {
+		density := float64(e.Lookups) * 100 / float64(e.WordsRead)
+		total += LOOKUP_WEIGHT * (1 - min(density/MAX_LOOKUP_DENSITY, 1))
+		weights += LOOKUP_WEIGHT
+	}
+	if weights == 0 {
+		return 0, false
+	}
+	return total / weights, true
+}
+
+// Estimate is a learner's estimated level.
+type Estimate struct {
+	Level          string
+	Confidence     float64 // from 0 to 1
+	Recommendation string  // for the declared level
+}
+
+func index(level string) int {
+	for i, l := range qnagen.Levels {
+		if l == level {
+			return i
+		}
+	}
+	return -1
+}
+
+// EstimateLevel estimates the level of a learner who declared current. It is
+// the highest level with enough evidence that they are comfortable at. If
+// there is none, it is the lowest level with enough evidence, or the one
+// below that if they struggle with it. Without enough evidence at any level
+// the estimate is current with no confidence.
+func EstimateLevel(current string, evidence []Evidence) Estimate {
+	byLevel := make([]*Evidence, len(qnagen.Levels))
+	for i := range evidence {
+		if l := index(evidence[i].Level); l >= 0 && byLevel[l] == nil {
+			byLevel[l] = &evidence[i]
+		}
+	}
+
+	deciding, estimate := -1, -1
+	for i, e := range byLevel {
+		if e == nil || e.Count() < MIN_EVIDENCE {
+			continue
+		}
+		mastery, _ := e.Mastery()
+		if deciding < 0 {
+			// the lowest level with evidence, until a comfortable one is found
+			deciding, estimate = i, i
+			if mastery < STRUGGLING {
+				estimate = max(i-1, 0)
+			}
+		}
+		if mastery >= COMFORTABLE {
+			deciding, estimate = i, i
+		}
+	}
+	if deciding < 0 {
+		return Estimate{Level: current, Recommendation: RECOMMEND_STAY}
+	}
+
+	count := float64(byLevel[deciding].Count())
+	result := Estimate{
+		Level:          qnagen.Levels[estimate],
+		Confidence:     count / (count + CONFIDENCE_EVIDENCE),
+		Recommendation: RECOMMEND_STAY,
+	}
+	if currentIndex := index(current); estimate > currentIndex && currentIndex >= 0 {
+		result.Recommendation = RECOMMEND_UP
+	} else if estimate < currentIndex {
+		result.Recommendation = RECOMMEND_DOWN
+	}
+	return result
+}
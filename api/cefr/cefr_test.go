@@ -0,0 +1,71 @@
+package cefr
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMastery(t *testing.T) {
+	if _, ok := (Evidence{Level: "B1"}).Mastery(); ok {
+		t.Error("expected no mastery without evidence")
+	}
+
+	mastery, ok := Evidence{Attempts: 4, Passed: 3}.Mastery()
+	if !ok || mastery != 0.75 {
+		t.Errorf("expected the pass rate alone, got %v", mastery)
+	}
+
+	// all passed, half finished, 2.5 lookups per 100 words
+	mastery, _ = Evidence{Attempts: 2, Passed: 2, Started: 2, Completed: 1, WordsRead: 400, Lookups: 10}.Mastery()
+	if want := 0.5*1 + 0.3*0.5 + 0.2*0.5; math.Abs(mastery-want) > 1e-9 {
+		t.Errorf("expected mastery %v, got %v", want, mastery)
+	}
+
+	mastery, _ = Evidence{WordsRead: 100, Lookups: 50}.Mastery()
+	if mastery != 0 {
+		t.Errorf("expected lookup density past the maximum to count as 0, got %v", mastery)
+	}
+}
+
+func TestEstimateWithoutEvidence(t *testing.T) {
+	estimate := EstimateLevel("B1", []Evidence{{Level: "B1", Attempts: MIN_EVIDENCE - 1}})
+	if estimate.Level != "B1" || estimate.Confidence != 0 || estimate.Recommendation != RECOMMEND_STAY {
+		t.Errorf("expected the declared level with no confidence, got %+v", estimate)
+	}
+}
+
+func TestEstimateMovesUp(t *testing.T) {
+	estimate := EstimateLevel("B1", []Evidence{
+		{Level: "B1", Attempts: 20, Passed: 19},
+		{Level: "B2", Attempts: 10, Passed: 8},
+	})
+	if estimate.Level != "B2" || estimate.Recommendation != RECOMMEND_UP {
+		t.Fatalf("expected B2, got %+v", estimate)
+	}
+	if estimate.Confidence != 0.5 {
+		t.Errorf("expected the confidence of 10 answers at B2, got %v", estimate.Confidence)
+	}
+}
+
+func TestEstimateMovesDown(t *testing.T) {
+	estimate := EstimateLevel("B1", []Evidence{
+		{Level: "A2", Attempts: 10, Passed: 9},
+		{Level: "B1", Attempts: 20, Passed: 5, Started: 4},
+	})
+	if estimate.Level != "A2" || estimate.Recommendation != RECOMMEND_DOWN {
+		t.Errorf("expected A2, got %+v", estimate)
+	}
+
+	// struggling at the only level with evidence
+	estimate = EstimateLevel("B1", []Evidence{{Level: "B1", Attempts: 30, Passed: 6}})
+	if estimate.Level != "A2" || estimate.Recommendation != RECOMMEND_DOWN || estimate.Confidence != 0.75 {
+		t.Errorf("expected A2, got %+v", estimate)
+	}
+}
+
+func TestEstimateStaysWhenBorderline(t *testing.T) {
+	estimate := EstimateLevel("B1", []Evidence{{Level: "B1", Attempts: 10, Passed: 6}})
+	if estimate.Level != "B1" || estimate.Recommendation != RECOMMEND_STAY {
+		t.Errorf("expected B1, got %+v", estimate)
+	}
+}
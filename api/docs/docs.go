@@ -367,45 +367,55 @@ const docTemplate = `{
                 }
             }
         },
-        "/news": {
+        "/catalog/languages": {
             "get": {
-                "description": "Get news content by ID",
-                "consumes": [
+                "description": "List the languages content is generated in, with their CEFR levels, content types and topics",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "catalog"
+                ],
+                "summary": "List supported languages",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CatalogLanguagesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/catalog/topics": {
+            "get": {
+                "description": "List the topics content is generated for, optionally only those available in one language",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "catalog"
                 ],
-                "summary": "Get news content",
+                "summary": "List supported topics",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Content ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
+                        "description": "Only topics available in this language",
+                        "name": "language",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetNewsResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.CatalogTopicsResponse"
                         }
                     },
                     "500": {
@@ -417,91 +427,99 @@ const docTemplate = `{
                 }
             }
         },
-        "/news/query": {
+        "/editor/content": {
             "get": {
-                "description": "Get news content by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List news and stories with a status, newest first. Editors only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "news"
+                    "editor"
                 ],
-                "summary": "Get news content",
+                "summary": "List content for review",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Language",
-                        "name": "language",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CEFR",
-                        "name": "cefr",
-                        "in": "query",
-                        "required": true
+                        "description": "draft, in_review (default), published or retracted",
+                        "name": "status",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Subject",
-                        "name": "subject",
-                        "in": "query",
-                        "required": true
+                        "description": "News, Story or All (default)",
+                        "name": "content_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "Page",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "Page size",
                         "name": "pagesize",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.NewsItem"
-                            }
+                            "$ref": "#/definitions/models.EditorContentListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/organization": {
+        "/editor/content/item": {
             "get": {
-                "description": "Check Organization for Teacher",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get news or a story whatever its status, with its body. News also has its sources and how each sentence relates to them. Editors only.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "editor"
+                ],
+                "summary": "Get content for review",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "News or Story",
+                        "name": "content_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Check Organization for Teacher",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.OrganizationResponse"
+                            "$ref": "#/definitions/models.EditorContentResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -515,9 +533,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/create": {
+        "/editor/content/publish": {
             "post": {
-                "description": "Create Organization. Automatically adds the calling user as a teacher.",
+                "description": "Publish a draft, in review or retracted news article or story, making it visible to learners. Editors only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -525,17 +543,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "editor"
                 ],
-                "summary": "Create Organization",
+                "summary": "Publish content",
                 "parameters": [
                     {
-                        "description": "Create organization request",
+                        "description": "Publish request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CreateOrganizationRequest"
+                            "$ref": "#/definitions/models.EditorStatusRequest"
                         }
                     }
                 ],
@@ -543,11 +561,23 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateOrganizationResponse"
+                            "$ref": "#/definitions/models.EditorStatusResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -555,9 +585,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/organization/join": {
+        "/editor/content/retract": {
             "post": {
-                "description": "Join Organization that has been created by another admin.",
+                "description": "Retract a news article or story, hiding it from learners. Editors only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -565,17 +595,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "editor"
                 ],
-                "summary": "Join Organization",
+                "summary": "Retract content",
                 "parameters": [
                     {
-                        "description": "Join organization request",
+                        "description": "Retract request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.JoinOrganizationRequest"
+                            "$ref": "#/definitions/models.EditorStatusRequest"
                         }
                     }
                 ],
@@ -583,44 +613,33 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JoinOrganizationResponse"
+                            "$ref": "#/definitions/models.EditorStatusResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/organization/payments": {
-            "get": {
-                "description": "Ping Payments",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "organization"
-                ],
-                "summary": "Ping Payments",
-                "responses": {
-                    "200": {
-                        "description": "OK",
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/models.PaymentsResponse"
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/organization/payments/cancel-subscription-eop": {
+        "/editor/content/update": {
             "post": {
-                "description": "Cancel a Stripe subscription at the end of the period",
+                "description": "Replace the title, preview text and body of news (body) or a story (story_pages). Fields that are not set are kept. Editing the body retires the questions and deletes the audiobook made from it; answers to retired questions are kept. Editors only.",
                 "consumes": [
                     "application/json"
                 ],
@@ -628,17 +647,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "editor"
                 ],
-                "summary": "Cancel a Stripe subscription at the end of the period",
+                "summary": "Edit content",
                 "parameters": [
                     {
-                        "description": "Cancel subscription request",
+                        "description": "Edit content request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.CancelSubscriptionRequest"
+                            "$ref": "#/definitions/models.EditorUpdateRequest"
                         }
                     }
                 ],
@@ -646,7 +665,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CancelSubscriptionResponse"
+                            "$ref": "#/definitions/models.EditorContentItem"
                         }
                     },
                     "400": {
@@ -654,39 +673,51 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/organization/payments/create-checkout-session": {
-            "post": {
-                "description": "Creates a checkout session and redirects to Stripe's payment page",
-                "consumes": [
-                    "application/json"
-                ],
+        "/feed": {
+            "get": {
+                "description": "Get news and stories ranked for the user from their profile: content on their topics at their level first, with some content one level up, news and stories mixed and content they have read left out. Each item has its score and the reasons for it, and the weights used are returned with the page. Students only get content their teacher accepted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "organization"
+                    "feed"
                 ],
-                "summary": "Create a Stripe checkout session",
+                "summary": "Get feed",
                 "parameters": [
                     {
-                        "description": "Create checkout session request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateCheckoutSessionRequest"
-                        }
+                        "type": "string",
+                        "description": "Page size, 10 by default and at most 50",
+                        "name": "pagesize",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Redirect to Stripe Checkout",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateCheckoutSessionResponse"
+                            "$ref": "#/definitions/models.FeedResponse"
                         }
                     },
                     "400": {
@@ -694,13 +725,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/profile": {
+        "/news": {
             "get": {
-                "description": "Get the user's profile information",
+                "description": "Get news content by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -708,18 +745,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "news"
+                ],
+                "summary": "Get news content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Content ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetProfileResponse"
+                            "$ref": "#/definitions/models.GetNewsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -727,9 +785,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/profile/upsert": {
-            "post": {
-                "description": "Create or update the user's profile",
+        "/news/query": {
+            "get": {
+                "description": "Get news content by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -737,35 +795,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "profile"
+                    "news"
                 ],
-                "summary": "Upsert user profile",
+                "summary": "Get news content",
                 "parameters": [
                     {
-                        "description": "Profile information",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CEFR",
+                        "name": "cefr",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject",
+                        "name": "subject",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size",
+                        "name": "pagesize",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UpsertProfileRequest"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.NewsItem"
+                            }
                         }
                     }
+                }
+            }
+        },
+        "/organization": {
+            "get": {
+                "description": "Check Organization for Teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
+                "tags": [
+                    "organization"
+                ],
+                "summary": "Check Organization for Teacher",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UpsertProfileResponse"
+                            "$ref": "#/definitions/models.OrganizationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -773,9 +883,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/progress": {
-            "get": {
-                "description": "Get the user's progress for today",
+        "/organization/create": {
+            "post": {
+                "description": "Create Organization. Automatically adds the calling user as a teacher.",
                 "consumes": [
                     "application/json"
                 ],
@@ -783,22 +893,39 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "progress"
+                    "organization"
+                ],
+                "summary": "Create Organization",
+                "parameters": [
+                    {
+                        "description": "Create organization request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateOrganizationRequest"
+                        }
+                    }
                 ],
-                "summary": "Get today's progress",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TodayProgressResponse"
+                            "$ref": "#/definitions/models.CreateOrganizationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/progress/increment": {
-            "get": {
-                "description": "Increment the number of questions completed for today",
+        "/organization/join": {
+            "post": {
+                "description": "Join Organization that has been created by another admin.",
                 "consumes": [
                     "application/json"
                 ],
@@ -806,27 +933,29 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "progress"
+                    "organization"
                 ],
-                "summary": "Increment questions completed",
+                "summary": "Join Organization",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Amount to increment by",
-                        "name": "amount",
-                        "in": "query",
-                        "required": true
+                        "description": "Join organization request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JoinOrganizationRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.IncrementProgressResponse"
+                            "$ref": "#/definitions/models.JoinOrganizationResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -834,9 +963,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/progress/streak": {
+        "/organization/payments": {
             "get": {
-                "description": "Get the user's current streak and completion status",
+                "description": "Ping Payments",
                 "consumes": [
                     "application/json"
                 ],
@@ -844,22 +973,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "progress"
+                    "organization"
                 ],
-                "summary": "Get streak information",
+                "summary": "Ping Payments",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StreakResponse"
+                            "$ref": "#/definitions/models.PaymentsResponse"
                         }
                     }
                 }
             }
         },
-        "/qna": {
+        "/organization/payments/cancel-subscription-eop": {
             "post": {
-                "description": "Get an existing question or generate a new one for the given content",
+                "description": "Cancel a Stripe subscription at the end of the period",
                 "consumes": [
                     "application/json"
                 ],
@@ -867,17 +996,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "qna"
+                    "organization"
                 ],
-                "summary": "Get or generate a question",
+                "summary": "Cancel a Stripe subscription at the end of the period",
                 "parameters": [
                     {
-                        "description": "Question request parameters",
+                        "description": "Cancel subscription request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.GetQuestionRequest"
+                            "$ref": "#/definitions/models.CancelSubscriptionRequest"
                         }
                     }
                 ],
@@ -885,7 +1014,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetQuestionResponse"
+                            "$ref": "#/definitions/models.CancelSubscriptionResponse"
                         }
                     },
                     "400": {
@@ -893,19 +1022,13 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
-                        }
                     }
                 }
             }
         },
-        "/qna/evaluate": {
+        "/organization/payments/create-checkout-session": {
             "post": {
-                "description": "Evaluate a user's answer to a question",
+                "description": "Creates a checkout session and redirects to Stripe's payment page",
                 "consumes": [
                     "application/json"
                 ],
@@ -913,25 +1036,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "qna"
+                    "organization"
                 ],
-                "summary": "Evaluate an answer",
+                "summary": "Create a Stripe checkout session",
                 "parameters": [
                     {
-                        "description": "Answer evaluation request",
+                        "description": "Create checkout session request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.EvaluateAnswerRequest"
+                            "$ref": "#/definitions/models.CreateCheckoutSessionRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Redirect to Stripe Checkout",
                         "schema": {
-                            "$ref": "#/definitions/models.EvaluateAnswerResponse"
+                            "$ref": "#/definitions/models.CreateCheckoutSessionResponse"
                         }
                     },
                     "400": {
@@ -943,9 +1066,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/story": {
+        "/profile": {
             "get": {
-                "description": "Get story content by ID",
+                "description": "Get the user's profile information",
                 "consumes": [
                     "application/json"
                 ],
@@ -953,36 +1076,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "story"
-                ],
-                "summary": "Get story page content",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Content ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    }
+                    "profile"
                 ],
+                "summary": "Get user profile",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetStoryPageResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.GetProfileResponse"
                         }
                     },
                     "404": {
@@ -994,33 +1095,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/story/context": {
+        "/profile/level": {
             "get": {
-                "description": "Get story QNA context by ID",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Estimate the user's CEFR level in their learning language from the questions they pass, the content they finish and the words they look up at each level, and recommend moving their skill level up or down. Nothing is stored, see /profile/level/apply.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "story"
-                ],
-                "summary": "Get story QNA context",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Content ID",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
+                    "profile"
                 ],
+                "summary": "Get estimated level",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetStoryQNAContextResponse"
+                            "$ref": "#/definitions/models.LevelEstimateResponse"
                         }
                     },
                     "404": {
@@ -1032,72 +1121,35 @@ const docTemplate = `{
                 }
             }
         },
-        "/story/query": {
-            "get": {
-                "description": "Get story query by ID",
-                "consumes": [
-                    "application/json"
-                ],
+        "/profile/level/apply": {
+            "post": {
+                "description": "Estimate the user's level as GET /profile/level does and store the estimate. With AUTO_LEVEL_ADJUST set, a confident estimate also replaces the skill level in their profile.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "story"
-                ],
-                "summary": "Get story query",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Language",
-                        "name": "language",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CEFR",
-                        "name": "cefr",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Subject",
-                        "name": "subject",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page",
-                        "name": "page",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Page Size",
-                        "name": "pagesize",
-                        "in": "query",
-                        "required": true
-                    }
+                    "profile"
                 ],
+                "summary": "Apply estimated level",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.StoryItem"
-                            }
+                            "$ref": "#/definitions/models.LevelEstimateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/student": {
-            "get": {
-                "description": "Check if the user is a student and get their classroom info",
+        "/profile/upsert": {
+            "post": {
+                "description": "Create or update the user's profile",
                 "consumes": [
                     "application/json"
                 ],
@@ -1105,18 +1157,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "student"
+                    "profile"
+                ],
+                "summary": "Upsert user profile",
+                "parameters": [
+                    {
+                        "description": "Profile information",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpsertProfileRequest"
+                        }
+                    }
                 ],
-                "summary": "Check user student status",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.StudentStatusResponse"
+                            "$ref": "#/definitions/models.UpsertProfileResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1124,9 +1193,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/student/classroom": {
+        "/progress": {
             "get": {
-                "description": "Get classroom info for the student",
+                "description": "Get the user's progress for today",
                 "consumes": [
                     "application/json"
                 ],
@@ -1134,28 +1203,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "student"
+                    "progress"
                 ],
-                "summary": "Get classroom info",
+                "summary": "Get today's progress",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetStudentClassroomResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.TodayProgressResponse"
                         }
                     }
                 }
             }
         },
-        "/student/classroom/join": {
-            "post": {
-                "description": "Join a classroom as a student",
+        "/progress/increment": {
+            "get": {
+                "description": "Increment the number of questions completed for today",
                 "consumes": [
                     "application/json"
                 ],
@@ -1163,29 +1226,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "student"
+                    "progress"
                 ],
-                "summary": "Join classroom",
+                "summary": "Increment questions completed",
                 "parameters": [
                     {
-                        "description": "Join classroom request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.JoinClassroomRequest"
-                        }
+                        "type": "integer",
+                        "description": "Amount to increment by",
+                        "name": "amount",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.JoinClassroomResponse"
+                            "$ref": "#/definitions/models.IncrementProgressResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1193,9 +1254,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher": {
+        "/progress/streak": {
             "get": {
-                "description": "Check if the user is a teacher",
+                "description": "Get the user's current streak and completion status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1203,28 +1264,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "progress"
                 ],
-                "summary": "Check user teacher status",
+                "summary": "Get streak information",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.TeacherStatusResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/models.ErrorResponse"
+                            "$ref": "#/definitions/models.StreakResponse"
                         }
                     }
                 }
             }
         },
-        "/teacher/classroom": {
-            "get": {
-                "description": "Get classrooms",
+        "/qna": {
+            "post": {
+                "description": "Get a question of the content the user has not answered or has failed, generating one if the pool needs more",
                 "consumes": [
                     "application/json"
                 ],
@@ -1232,18 +1287,35 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "qna"
+                ],
+                "summary": "Get or generate a question",
+                "parameters": [
+                    {
+                        "description": "Question request parameters",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.GetQuestionRequest"
+                        }
+                    }
                 ],
-                "summary": "Get classrooms",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.GetClassroomListResponse"
+                            "$ref": "#/definitions/models.GetQuestionResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1251,9 +1323,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher/classroom/accept": {
+        "/qna/evaluate": {
             "post": {
-                "description": "Accept content",
+                "description": "Evaluate a user's answer to a question. Answers to multiple_choice, cloze and true_false questions are sent as a models.ObjectiveAnswerRequest, graded against the question's answer key and answered with a models.ObjectiveAnswerResponse.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1261,17 +1333,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "qna"
                 ],
-                "summary": "Accept content",
+                "summary": "Evaluate an answer",
                 "parameters": [
                     {
-                        "description": "Accept content request",
+                        "description": "Answer evaluation request",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.AcceptContentRequest"
+                            "$ref": "#/definitions/models.EvaluateAnswerRequest"
                         }
                     }
                 ],
@@ -1279,11 +1351,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.AcceptContentResponse"
+                            "$ref": "#/definitions/models.EvaluateAnswerResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1291,129 +1369,85 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher/classroom/content": {
+        "/qna/history": {
             "get": {
-                "description": "Query classroom content",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get the user's answers to questions, newest first, optionally only for one piece of content",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "qna"
                 ],
-                "summary": "Query classroom content",
+                "summary": "Get answer history",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Language",
-                        "name": "language",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "CEFR",
-                        "name": "cefr",
-                        "in": "query",
-                        "required": true
+                        "description": "News or Story, requires id",
+                        "name": "content_type",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Subject",
-                        "name": "subject",
-                        "in": "query",
-                        "required": true
+                        "description": "Content ID",
+                        "name": "id",
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "Page",
                         "name": "page",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     },
                     {
                         "type": "string",
                         "description": "Page size",
                         "name": "pagesize",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Whitelist status",
-                        "name": "whitelist",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Content type",
-                        "name": "content_type",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Classroom ID",
-                        "name": "classroom_id",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/models.ClassroomContentItem"
-                            }
+                            "$ref": "#/definitions/models.QNAHistoryResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/teacher/classroom/create": {
-            "post": {
-                "description": "Create classroom",
-                "consumes": [
-                    "application/json"
-                ],
+        "/reading/continue": {
+            "get": {
+                "description": "Get the news and stories the user has started but not finished, the most recently read first, with the page to resume from",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "reading"
                 ],
-                "summary": "Create classroom",
+                "summary": "Continue reading",
                 "parameters": [
                     {
-                        "description": "Create classroom request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.CreateClassroomRequest"
-                        }
+                        "type": "string",
+                        "description": "Maximum number of items, 10 by default and at most 50",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.CreateClassroomResponse"
+                            "$ref": "#/definitions/models.ContinueReadingResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1421,9 +1455,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher/classroom/delete": {
+        "/reading/progress": {
             "post": {
-                "description": "Delete classroom",
+                "description": "Record the page the user is on in a news article or story and the time they spent reading since the last update. Stories are complete on their last page, news when completed is set or percent_complete reaches 100.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1431,17 +1465,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "reading"
                 ],
-                "summary": "Delete classroom",
+                "summary": "Update reading progress",
                 "parameters": [
                     {
-                        "description": "Delete classroom request",
+                        "description": "Reading position",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/models.DeleteClassroomRequest"
+                            "$ref": "#/definitions/models.UpdateReadingProgressRequest"
                         }
                     }
                 ],
@@ -1449,11 +1483,17 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.DeleteClassroomResponse"
+                            "$ref": "#/definitions/models.ReadingProgressResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1461,39 +1501,71 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher/classroom/reject": {
-            "post": {
-                "description": "Accept content",
-                "consumes": [
-                    "application/json"
-                ],
+        "/search": {
+            "get": {
+                "description": "Full-text search over the title, preview and body of published news and stories in a language, best match first. Words are matched by their stem in French, Spanish and English. The query takes words, \"quoted phrases\", or and -excluded words. Students in a classroom only find content accepted in it.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "search"
                 ],
-                "summary": "Accept content",
+                "summary": "Search content",
                 "parameters": [
                     {
-                        "description": "Reject content request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.RejectContentRequest"
-                        }
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CEFR",
+                        "name": "cefr",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject",
+                        "name": "subject",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "News or Story, both by default",
+                        "name": "content_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page, 1 by default",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size, 10 by default and at most 50",
+                        "name": "pagesize",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.RejectContentResponse"
+                            "$ref": "#/definitions/models.SearchResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
@@ -1501,9 +1573,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/teacher/classroom/update": {
-            "post": {
-                "description": "Update classroom",
+        "/story": {
+            "get": {
+                "description": "Get story content by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1511,25 +1583,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "teacher"
+                    "story"
                 ],
-                "summary": "Update classroom",
+                "summary": "Get story page content",
                 "parameters": [
                     {
-                        "description": "Update classroom request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/models.UpdateClassroomRequest"
-                        }
+                        "type": "string",
+                        "description": "Content ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.UpdateClassroomResponse"
+                            "$ref": "#/definitions/models.GetStoryPageResponse"
                         }
                     },
                     "403": {
@@ -1537,13 +1614,19 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/webhook": {
-            "post": {
-                "description": "Validates and processes incoming webhook events from Stripe",
+        "/story/context": {
+            "get": {
+                "description": "Get story QNA context by ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1551,208 +1634,2147 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "stripe"
+                    "story"
+                ],
+                "summary": "Get story QNA context",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Content ID",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Process Stripe webhook",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/models.WebhookResponse"
+                            "$ref": "#/definitions/models.GetStoryQNAContextResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/models.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "models.AcceptContentRequest": {
-            "type": "object",
-            "required": [
-                "classroom_id",
-                "content_type"
-            ],
-            "properties": {
-                "classroom_id": {
-                    "type": "string",
-                    "example": "123"
-                },
-                "content_id": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 123
-                },
-                "content_type": {
-                    "type": "string",
-                    "example": "News"
-                }
-            }
         },
-        "models.AcceptContentResponse": {
-            "type": "object",
-            "required": [
-                "message"
-            ],
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Content accepted successfully"
+        "/story/query": {
+            "get": {
+                "description": "Get story query by ID",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "story"
+                ],
+                "summary": "Get story query",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CEFR",
+                        "name": "cefr",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject",
+                        "name": "subject",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page Size",
+                        "name": "pagesize",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.StoryItem"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/student": {
+            "get": {
+                "description": "Check if the user is a student and get their classroom info",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Check user student status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.StudentStatusResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/classroom": {
+            "get": {
+                "description": "Get classroom info for the student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Get classroom info",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetStudentClassroomResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/student/classroom/join": {
+            "post": {
+                "description": "Join a classroom as a student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "student"
+                ],
+                "summary": "Join classroom",
+                "parameters": [
+                    {
+                        "description": "Join classroom request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.JoinClassroomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.JoinClassroomResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher": {
+            "get": {
+                "description": "Check if the user is a teacher",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Check user teacher status",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.TeacherStatusResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom": {
+            "get": {
+                "description": "Get classrooms",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Get classrooms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.GetClassroomListResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/accept": {
+            "post": {
+                "description": "Accept content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Accept content",
+                "parameters": [
+                    {
+                        "description": "Accept content request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptContentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.AcceptContentResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/content": {
+            "get": {
+                "description": "Query classroom content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Query classroom content",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Language",
+                        "name": "language",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "CEFR",
+                        "name": "cefr",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Subject",
+                        "name": "subject",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size",
+                        "name": "pagesize",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Whitelist status",
+                        "name": "whitelist",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Content type",
+                        "name": "content_type",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Classroom ID",
+                        "name": "classroom_id",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/models.ClassroomContentItem"
+                            }
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/create": {
+            "post": {
+                "description": "Create classroom",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Create classroom",
+                "parameters": [
+                    {
+                        "description": "Create classroom request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateClassroomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.CreateClassroomResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/delete": {
+            "post": {
+                "description": "Delete classroom",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Delete classroom",
+                "parameters": [
+                    {
+                        "description": "Delete classroom request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteClassroomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteClassroomResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/reject": {
+            "post": {
+                "description": "Accept content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Accept content",
+                "parameters": [
+                    {
+                        "description": "Reject content request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.RejectContentRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.RejectContentResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/teacher/classroom/update": {
+            "post": {
+                "description": "Update classroom",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "teacher"
+                ],
+                "summary": "Update classroom",
+                "parameters": [
+                    {
+                        "description": "Update classroom request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateClassroomRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.UpdateClassroomResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vocab": {
+            "get": {
+                "description": "Get the words the user saved, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocab"
+                ],
+                "summary": "Get vocabulary deck",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only words of this language",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Page size",
+                        "name": "pagesize",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VocabCardsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vocab/delete": {
+            "post": {
+                "description": "Remove a word and its reviews from the user's deck",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocab"
+                ],
+                "summary": "Delete a card",
+                "parameters": [
+                    {
+                        "description": "Card to delete",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteVocabRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.DeleteVocabResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vocab/due": {
+            "get": {
+                "description": "Get the user's cards that are due for review, the longest overdue first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocab"
+                ],
+                "summary": "Get due cards",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only words of this language",
+                        "name": "language",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Maximum number of cards, 20 by default and at most 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VocabCardsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vocab/review": {
+            "post": {
+                "description": "Grade a review of a card from 0 to 5 and schedule its next review. Only cards that are due can be reviewed. Each review counts as a completed question towards the daily goal.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocab"
+                ],
+                "summary": "Review a card",
+                "parameters": [
+                    {
+                        "description": "Review grade",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.VocabReviewRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VocabReviewResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/vocab/save": {
+            "post": {
+                "description": "Add a word the user looked up to their vocabulary deck, due for review straight away. Saving a word already in the deck counts another lookup and keeps its schedule.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "vocab"
+                ],
+                "summary": "Save a word",
+                "parameters": [
+                    {
+                        "description": "Word to save",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/models.SaveVocabRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.VocabCard"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/webhook": {
+            "post": {
+                "description": "Validates and processes incoming webhook events from Stripe",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stripe"
+                ],
+                "summary": "Process Stripe webhook",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/models.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/models.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dictionary.Entry": {
+            "type": "object",
+            "properties": {
+                "gender": {
+                    "description": "\"m\", \"f\" or empty",
+                    "type": "string"
+                },
+                "lemma": {
+                    "type": "string"
+                },
+                "pos": {
+                    "type": "string"
+                },
+                "translation": {
+                    "type": "string"
+                }
+            }
+        },
+        "dictionary.Token": {
+            "type": "object",
+            "properties": {
+                "gloss": {
+                    "description": "short translation in this context",
+                    "type": "string"
+                },
+                "lemma": {
+                    "type": "string"
+                },
+                "sentence": {
+                    "description": "index into the article's sentences, repeats included",
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.AcceptContentRequest": {
+            "type": "object",
+            "required": [
+                "classroom_id",
+                "content_type"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "content_id": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 123
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                }
+            }
+        },
+        "models.AcceptContentResponse": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Content accepted successfully"
                 }
             }
         },
         "models.AudioHealthResponse": {
             "type": "object",
             "required": [
-                "status"
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "live"
+                }
+            }
+        },
+        "models.AudiobookResponse": {
+            "type": "object",
+            "required": [
+                "expires_in",
+                "url"
+            ],
+            "properties": {
+                "expires_in": {
+                    "type": "integer",
+                    "example": 300
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://bucket.s3.amazonaws.com/path/to/file?signed-params"
+                }
+            }
+        },
+        "models.BillingAccountResponse": {
+            "type": "object",
+            "required": [
+                "canceled",
+                "expiration",
+                "plan"
+            ],
+            "properties": {
+                "canceled": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "expiration": {
+                    "type": "string",
+                    "example": "2025-01-01T00:00:00Z"
+                },
+                "plan": {
+                    "type": "string",
+                    "example": "PRO"
+                }
+            }
+        },
+        "models.BillingAccountUsageResponse": {
+            "type": "object",
+            "required": [
+                "max_natural_tts_usage",
+                "max_premium_audiobooks_usage",
+                "max_premium_stt_usage",
+                "natural_tts_usage",
+                "premium_audiobooks_usage",
+                "premium_stt_usage"
+            ],
+            "properties": {
+                "max_natural_tts_usage": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_premium_audiobooks_usage": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "max_premium_stt_usage": {
+                    "type": "integer",
+                    "example": 100
+                },
+                "natural_tts_usage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "premium_audiobooks_usage": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "premium_stt_usage": {
+                    "type": "integer",
+                    "example": 10
+                }
+            }
+        },
+        "models.CancelIndividualSubscriptionRequest": {
+            "type": "object"
+        },
+        "models.CancelIndividualSubscriptionResponse": {
+            "type": "object",
+            "required": [
+                "canceled_plan",
+                "current_expiration",
+                "success"
+            ],
+            "properties": {
+                "canceled_plan": {
+                    "type": "string",
+                    "example": "PREMIUM"
+                },
+                "current_expiration": {
+                    "type": "string",
+                    "example": "2025-03-24T12:00:00Z"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.CancelSubscriptionRequest": {
+            "type": "object"
+        },
+        "models.CancelSubscriptionResponse": {
+            "type": "object",
+            "required": [
+                "canceled_plan",
+                "current_expiration",
+                "success"
+            ],
+            "properties": {
+                "canceled_plan": {
+                    "type": "string",
+                    "example": "CLASSROOM"
+                },
+                "current_expiration": {
+                    "type": "string",
+                    "example": "2025-03-24T12:00:00Z"
+                },
+                "success": {
+                    "type": "boolean",
+                    "example": true
+                }
+            }
+        },
+        "models.CatalogLanguage": {
+            "type": "object",
+            "required": [
+                "cefr_levels",
+                "content_types",
+                "language",
+                "topics"
+            ],
+            "properties": {
+                "cefr_levels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2",
+                        "B1"
+                    ]
+                },
+                "content_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "News",
+                        "Story"
+                    ]
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Politics",
+                        "Travel"
+                    ]
+                }
+            }
+        },
+        "models.CatalogLanguagesResponse": {
+            "type": "object",
+            "required": [
+                "languages"
+            ],
+            "properties": {
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogLanguage"
+                    }
+                }
+            }
+        },
+        "models.CatalogTopic": {
+            "type": "object",
+            "required": [
+                "cefr_levels",
+                "content_types",
+                "languages",
+                "topic"
+            ],
+            "properties": {
+                "cefr_levels": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "A1",
+                        "A2",
+                        "B1"
+                    ]
+                },
+                "content_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "News"
+                    ]
+                },
+                "languages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "French",
+                        "Spanish"
+                    ]
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Politics"
+                }
+            }
+        },
+        "models.CatalogTopicsResponse": {
+            "type": "object",
+            "required": [
+                "topics"
+            ],
+            "properties": {
+                "topics": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.CatalogTopic"
+                    }
+                }
+            }
+        },
+        "models.ClassroomContentItem": {
+            "type": "object",
+            "required": [
+                "audiobook_tier",
+                "cefr_level",
+                "content_type",
+                "created_at",
+                "date_created",
+                "id",
+                "language",
+                "pages",
+                "preview_text",
+                "title",
+                "topic"
+            ],
+            "properties": {
+                "audiobook_tier": {
+                    "type": "string",
+                    "example": "NONE"
+                },
+                "cefr_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13.390612Z"
+                },
+                "date_created": {
+                    "type": "string",
+                    "example": "2025-02-26"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2479"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 10
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "# L'actualité musicale en bref\n\n## Un flot de nouveautés..."
+                },
+                "title": {
+                    "type": "string",
+                    "example": "# L'actualité musicale en bref\n\n## Un fl..."
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Music"
+                }
+            }
+        },
+        "models.ClassroomListItem": {
+            "type": "object",
+            "required": [
+                "classroom_id",
+                "name"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "name": {
+                    "type": "string",
+                    "example": "Connor"
+                },
+                "students_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "models.ContinueReadingItem": {
+            "type": "object",
+            "required": [
+                "cefr_level",
+                "content_type",
+                "id",
+                "language",
+                "preview_text",
+                "reading_status",
+                "started_at",
+                "title",
+                "topic",
+                "updated_at"
+            ],
+            "properties": {
+                "cefr_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "completed_at": {
+                    "description": "not set until the content is finished",
+                    "type": "string",
+                    "example": "2025-02-26T13:08:13Z"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "Story"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "last_page": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "percent_complete": {
+                    "type": "number",
+                    "example": 60
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Un résumé des nouvelles musicales..."
+                },
+                "reading_status": {
+                    "type": "string",
+                    "enum": [
+                        "in_progress",
+                        "read"
+                    ],
+                    "example": "in_progress"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13Z"
+                },
+                "time_spent_seconds": {
+                    "type": "integer",
+                    "example": 420
+                },
+                "title": {
+                    "type": "string",
+                    "example": "L'actualité musicale en bref"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Music"
+                },
+                "updated_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:08:13Z"
+                }
+            }
+        },
+        "models.ContinueReadingResponse": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ContinueReadingItem"
+                    }
+                }
+            }
+        },
+        "models.CreateCheckoutSessionRequest": {
+            "type": "object"
+        },
+        "models.CreateCheckoutSessionResponse": {
+            "type": "object",
+            "required": [
+                "redirect_url"
+            ],
+            "properties": {
+                "redirect_url": {
+                    "type": "string",
+                    "example": "https://checkout.stripe.com/c/pay/123"
+                }
+            }
+        },
+        "models.CreateClassroomRequest": {
+            "type": "object",
+            "required": [
+                "name"
+            ],
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Tuesday 9am"
+                },
+                "students_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                }
+            }
+        },
+        "models.CreateClassroomResponse": {
+            "type": "object",
+            "required": [
+                "classroom_id"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "string",
+                    "example": "123"
+                }
+            }
+        },
+        "models.CreateIndividualCheckoutSessionRequest": {
+            "type": "object"
+        },
+        "models.CreateIndividualCheckoutSessionResponse": {
+            "type": "object",
+            "required": [
+                "redirect_url"
+            ],
+            "properties": {
+                "redirect_url": {
+                    "type": "string",
+                    "example": "https://checkout.stripe.com/c/pay/123"
+                }
+            }
+        },
+        "models.CreateOrganizationRequest": {
+            "type": "object"
+        },
+        "models.CreateOrganizationResponse": {
+            "type": "object",
+            "required": [
+                "organization_id",
+                "teacher_id"
+            ],
+            "properties": {
+                "organization_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "teacher_id": {
+                    "type": "string",
+                    "example": "123"
+                }
+            }
+        },
+        "models.DeleteClassroomRequest": {
+            "type": "object",
+            "required": [
+                "classroom_id"
+            ],
+            "properties": {
+                "classroom_id": {
+                    "type": "string",
+                    "example": "123"
+                }
+            }
+        },
+        "models.DeleteClassroomResponse": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Classroom deleted successfully"
+                }
+            }
+        },
+        "models.DeleteVocabRequest": {
+            "type": "object",
+            "required": [
+                "card_id"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "integer",
+                    "example": 12
+                }
+            }
+        },
+        "models.DeleteVocabResponse": {
+            "type": "object",
+            "required": [
+                "message"
+            ],
+            "properties": {
+                "message": {
+                    "type": "string",
+                    "example": "Card deleted successfully"
+                }
+            }
+        },
+        "models.ERROR_CODE": {
+            "type": "string",
+            "enum": [
+                "PROFILE_NOT_FOUND",
+                "NO_TRANSCRIPT",
+                "AUTH_REQUIRED",
+                "USAGE_LIMIT_REACHED",
+                "USAGE_RESTRICTED"
+            ],
+            "x-enum-varnames": [
+                "PROFILE_NOT_FOUND",
+                "NO_TRANSCRIPT",
+                "AUTH_REQUIRED",
+                "USAGE_LIMIT_REACHED",
+                "USAGE_RESTRICTED"
+            ]
+        },
+        "models.EditorContentItem": {
+            "type": "object",
+            "required": [
+                "cefr_level",
+                "content_type",
+                "created_at",
+                "date_created",
+                "id",
+                "language",
+                "preview_text",
+                "status",
+                "title",
+                "topic"
+            ],
+            "properties": {
+                "cefr_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13.390612Z"
+                },
+                "date_created": {
+                    "type": "string",
+                    "example": "2025-02-26"
+                },
+                "edited_at": {
+                    "type": "string",
+                    "example": "2025-02-26T15:20:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2479"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "moderation": {
+                    "type": "object"
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Le Sénat a voté le budget..."
+                },
+                "readability": {
+                    "type": "object"
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": "Fixed a wrong date"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2025-02-26T15:30:00Z"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "a1b2c3d4-..."
+                },
+                "status": {
+                    "type": "string",
+                    "example": "in_review"
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Le budget est voté"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Politics"
+                }
+            }
+        },
+        "models.EditorContentListResponse": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.EditorContentItem"
+                    }
+                }
+            }
+        },
+        "models.EditorContentResponse": {
+            "type": "object",
+            "required": [
+                "cefr_level",
+                "content_type",
+                "created_at",
+                "date_created",
+                "id",
+                "language",
+                "preview_text",
+                "status",
+                "title",
+                "topic"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "# Le budget est voté\n\nLe Sénat..."
+                },
+                "cefr_level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13.390612Z"
+                },
+                "date_created": {
+                    "type": "string",
+                    "example": "2025-02-26"
+                },
+                "edited_at": {
+                    "type": "string",
+                    "example": "2025-02-26T15:20:00Z"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2479"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "moderation": {
+                    "type": "object"
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Le Sénat a voté le budget..."
+                },
+                "readability": {
+                    "type": "object"
+                },
+                "review_note": {
+                    "type": "string",
+                    "example": "Fixed a wrong date"
+                },
+                "reviewed_at": {
+                    "type": "string",
+                    "example": "2025-02-26T15:30:00Z"
+                },
+                "reviewed_by": {
+                    "type": "string",
+                    "example": "a1b2c3d4-..."
+                },
+                "source_diff": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SentenceSupport"
+                    }
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.Source"
+                    }
+                },
+                "status": {
+                    "type": "string",
+                    "example": "in_review"
+                },
+                "story_pages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Le budget est voté"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Politics"
+                }
+            }
+        },
+        "models.EditorStatusRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "id"
+            ],
+            "properties": {
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2479"
+                },
+                "note": {
+                    "type": "string",
+                    "example": "Checked against the sources"
+                }
+            }
+        },
+        "models.EditorStatusResponse": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "status": {
+                    "type": "string",
+                    "example": "published"
+                }
+            }
+        },
+        "models.EditorUpdateRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "id"
+            ],
+            "properties": {
+                "body": {
+                    "type": "string",
+                    "example": "# Le budget est voté\n\nLe Sénat..."
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "2479"
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Le Sénat a voté le budget..."
+                },
+                "story_pages": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "Le budget est voté"
+                }
+            }
+        },
+        "models.ErrorResponse": {
+            "type": "object",
+            "required": [
+                "error"
+            ],
+            "properties": {
+                "code": {
+                    "enum": [
+                        "PROFILE_NOT_FOUND",
+                        "NO_TRANSCRIPT",
+                        "AUTH_REQUIRED",
+                        "USAGE_LIMIT_REACHED",
+                        "USAGE_RESTRICTED"
+                    ],
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.ERROR_CODE"
+                        }
+                    ],
+                    "example": "PROFILE_NOT_FOUND"
+                },
+                "error": {
+                    "type": "string",
+                    "example": "Something went wrong"
+                }
+            }
+        },
+        "models.EvaluateAnswerRequest": {
+            "type": "object",
+            "required": [
+                "answer",
+                "cefr",
+                "content",
+                "question"
+            ],
+            "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Hello"
+                },
+                "cefr": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "content": {
+                    "type": "string",
+                    "example": "Bonjour, comment ça va?"
+                },
+                "question": {
+                    "type": "string",
+                    "example": "What does 'bonjour' mean?"
+                },
+                "question_id": {
+                    "description": "set to record the answer in the user's history",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "models.EvaluateAnswerResponse": {
+            "type": "object",
+            "required": [
+                "errors",
+                "evaluation",
+                "explanation",
+                "scores"
+            ],
+            "properties": {
+                "attempt_id": {
+                    "type": "integer",
+                    "example": 7
+                },
+                "errors": {
+                    "description": "mistakes in the answer, with their character offsets so they can be highlighted",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qna.AnswerError"
+                    }
+                },
+                "evaluation": {
+                    "type": "string",
+                    "example": "PASS"
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Perfect!"
+                },
+                "scores": {
+                    "description": "scores from 1 to 5",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/qna.RubricScores"
+                        }
+                    ]
+                }
+            }
+        },
+        "models.FeedItem": {
+            "type": "object",
+            "required": [
+                "cefr_level",
+                "content_type",
+                "created_at",
+                "date_created",
+                "id",
+                "language",
+                "preview_text",
+                "reading_status",
+                "reasons",
+                "title",
+                "topic"
             ],
             "properties": {
-                "status": {
+                "cefr_level": {
                     "type": "string",
-                    "example": "live"
+                    "example": "B1"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2024-02-26T13:01:13Z"
+                },
+                "date_created": {
+                    "type": "string",
+                    "example": "2024-02-26"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "pages": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Un résumé des nouvelles musicales..."
+                },
+                "reading_status": {
+                    "type": "string",
+                    "enum": [
+                        "unread",
+                        "in_progress"
+                    ],
+                    "example": "unread"
+                },
+                "reasons": {
+                    "description": "why the item scored what it did, in the order the weights were applied",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "topic",
+                        "level",
+                        "recent"
+                    ]
+                },
+                "score": {
+                    "type": "number",
+                    "example": 6.4
+                },
+                "title": {
+                    "type": "string",
+                    "example": "L'actualité musicale en bref"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Music"
                 }
             }
         },
-        "models.AudiobookResponse": {
+        "models.FeedResponse": {
             "type": "object",
             "required": [
-                "expires_in",
-                "url"
+                "items",
+                "weights"
             ],
             "properties": {
-                "expires_in": {
-                    "type": "integer",
-                    "example": 300
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.FeedItem"
+                    }
                 },
-                "url": {
+                "next_cursor": {
+                    "description": "pass as cursor to get the next page, not set on the last page",
                     "type": "string",
-                    "example": "https://bucket.s3.amazonaws.com/path/to/file?signed-params"
+                    "example": "MTc0MDU3NDg3MzAwMDAwMDoxMA"
+                },
+                "weights": {
+                    "$ref": "#/definitions/models.FeedWeights"
                 }
             }
         },
-        "models.BillingAccountResponse": {
+        "models.FeedWeights": {
+            "type": "object",
+            "properties": {
+                "in_progress": {
+                    "type": "number",
+                    "example": 1.5
+                },
+                "level": {
+                    "type": "number",
+                    "example": 2
+                },
+                "level_up": {
+                    "type": "number",
+                    "example": 1
+                },
+                "recency": {
+                    "type": "number",
+                    "example": 2
+                },
+                "repeat_type": {
+                    "description": "taken off for each item of the same content type directly before",
+                    "type": "number",
+                    "example": 2
+                },
+                "topic": {
+                    "type": "number",
+                    "example": 3
+                }
+            }
+        },
+        "models.GetClassroomListResponse": {
             "type": "object",
             "required": [
-                "canceled",
-                "expiration",
-                "plan"
+                "classrooms"
             ],
             "properties": {
-                "canceled": {
-                    "type": "boolean",
-                    "example": false
+                "classrooms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.ClassroomListItem"
+                    }
+                }
+            }
+        },
+        "models.GetNewsResponse": {
+            "type": "object",
+            "required": [
+                "cefr_level",
+                "content",
+                "content_type",
+                "date_created",
+                "dictionary",
+                "language",
+                "preview_text",
+                "sources",
+                "title",
+                "topic"
+            ],
+            "properties": {
+                "cefr_level": {
+                    "type": "string",
+                    "example": "B1"
                 },
-                "expiration": {
+                "content": {
                     "type": "string",
-                    "example": "2025-01-01T00:00:00Z"
+                    "example": "Le contenu complet de l'article..."
                 },
-                "plan": {
+                "content_type": {
                     "type": "string",
-                    "example": "PRO"
+                    "example": "News"
+                },
+                "date_created": {
+                    "type": "string",
+                    "example": "2024-02-26"
+                },
+                "dictionary": {
+                    "$ref": "#/definitions/storage.Dictionary"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "preview_text": {
+                    "type": "string",
+                    "example": "Un résumé des nouvelles musicales..."
+                },
+                "sources": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/storage.Source"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "example": "L'actualité musicale en bref"
+                },
+                "topic": {
+                    "type": "string",
+                    "example": "Music"
                 }
             }
         },
-        "models.BillingAccountUsageResponse": {
+        "models.GetProfileResponse": {
             "type": "object",
             "required": [
-                "max_natural_tts_usage",
-                "max_premium_audiobooks_usage",
-                "max_premium_stt_usage",
-                "natural_tts_usage",
-                "premium_audiobooks_usage",
-                "premium_stt_usage"
+                "interested_topics",
+                "learning_language",
+                "skill_level",
+                "username"
             ],
             "properties": {
-                "max_natural_tts_usage": {
-                    "type": "integer",
-                    "example": 100
-                },
-                "max_premium_audiobooks_usage": {
+                "daily_questions_goal": {
                     "type": "integer",
-                    "example": 100
+                    "minimum": 0,
+                    "example": 3
                 },
-                "max_premium_stt_usage": {
-                    "type": "integer",
-                    "example": 100
+                "interested_topics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "[\"NBA\"]"
+                    ]
                 },
-                "natural_tts_usage": {
-                    "type": "integer",
-                    "example": 10
+                "learning_language": {
+                    "type": "string",
+                    "example": "French"
                 },
-                "premium_audiobooks_usage": {
-                    "type": "integer",
-                    "example": 10
+                "skill_level": {
+                    "type": "string",
+                    "example": "B1"
                 },
-                "premium_stt_usage": {
-                    "type": "integer",
-                    "example": 10
+                "username": {
+                    "type": "string",
+                    "example": "connortbot"
                 }
             }
         },
-        "models.CancelIndividualSubscriptionRequest": {
-            "type": "object"
-        },
-        "models.CancelIndividualSubscriptionResponse": {
+        "models.GetQuestionRequest": {
             "type": "object",
             "required": [
-                "canceled_plan",
-                "current_expiration",
-                "success"
+                "cefr_level",
+                "content_type",
+                "id",
+                "question_type"
             ],
             "properties": {
-                "canceled_plan": {
+                "cefr_level": {
                     "type": "string",
-                    "example": "PREMIUM"
+                    "example": "B1"
                 },
-                "current_expiration": {
+                "content_type": {
                     "type": "string",
-                    "example": "2025-03-24T12:00:00Z"
+                    "example": "News"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "question_type": {
+                    "type": "string",
+                    "enum": [
+                        "vocab",
+                        "understanding",
+                        "multiple_choice",
+                        "cloze",
+                        "true_false"
+                    ],
+                    "example": "vocab"
                 }
             }
         },
-        "models.CancelSubscriptionRequest": {
-            "type": "object"
-        },
-        "models.CancelSubscriptionResponse": {
+        "models.GetQuestionResponse": {
             "type": "object",
             "required": [
-                "canceled_plan",
-                "current_expiration",
-                "success"
+                "question",
+                "question_id",
+                "question_type"
             ],
             "properties": {
-                "canceled_plan": {
+                "attempts": {
+                    "description": "how often the user has answered this question, and how their last answer was evaluated",
+                    "type": "integer",
+                    "example": 1
+                },
+                "choices": {
+                    "description": "the options to answer multiple_choice and most cloze questions with,\ntrue_false questions are answered with \"true\" or \"false\"",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Hello",
+                        "Goodbye",
+                        "Thank you",
+                        "Please"
+                    ]
+                },
+                "last_evaluation": {
                     "type": "string",
-                    "example": "CLASSROOM"
+                    "example": "FAIL"
                 },
-                "current_expiration": {
+                "question": {
+                    "description": "cloze questions have the missing word replaced by _____",
                     "type": "string",
-                    "example": "2025-03-24T12:00:00Z"
+                    "example": "What does 'bonjour' mean?"
                 },
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                "question_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "question_type": {
+                    "type": "string",
+                    "example": "vocab"
                 }
             }
         },
-        "models.ClassroomContentItem": {
+        "models.GetStoryPageResponse": {
             "type": "object",
             "required": [
-                "audiobook_tier",
                 "cefr_level",
+                "content",
                 "content_type",
-                "created_at",
                 "date_created",
-                "id",
                 "language",
                 "pages",
                 "preview_text",
@@ -1760,29 +3782,21 @@ const docTemplate = `{
                 "topic"
             ],
             "properties": {
-                "audiobook_tier": {
-                    "type": "string",
-                    "example": "NONE"
-                },
                 "cefr_level": {
                     "type": "string",
                     "example": "B1"
                 },
-                "content_type": {
+                "content": {
                     "type": "string",
-                    "example": "News"
+                    "example": "Le contenu complet de l'article..."
                 },
-                "created_at": {
+                "content_type": {
                     "type": "string",
-                    "example": "2025-02-26T13:01:13.390612Z"
+                    "example": "Story"
                 },
                 "date_created": {
                     "type": "string",
-                    "example": "2025-02-26"
-                },
-                "id": {
-                    "type": "string",
-                    "example": "2479"
+                    "example": "2024-02-26"
                 },
                 "language": {
                     "type": "string",
@@ -1794,11 +3808,11 @@ const docTemplate = `{
                 },
                 "preview_text": {
                     "type": "string",
-                    "example": "# L'actualité musicale en bref\n\n## Un flot de nouveautés..."
+                    "example": "Un résumé des nouvelles musicales..."
                 },
                 "title": {
                     "type": "string",
-                    "example": "# L'actualité musicale en bref\n\n## Un fl..."
+                    "example": "L'actualité musicale en bref"
                 },
                 "topic": {
                     "type": "string",
@@ -1806,61 +3820,63 @@ const docTemplate = `{
                 }
             }
         },
-        "models.ClassroomListItem": {
+        "models.GetStoryQNAContextResponse": {
             "type": "object",
             "required": [
-                "classroom_id",
-                "name"
+                "context"
             ],
             "properties": {
-                "classroom_id": {
-                    "type": "string",
-                    "example": "123"
-                },
-                "name": {
+                "context": {
                     "type": "string",
-                    "example": "Connor"
-                },
-                "students_count": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 10
+                    "example": "Le contexte de l'histoire..."
                 }
             }
         },
-        "models.CreateCheckoutSessionRequest": {
-            "type": "object"
-        },
-        "models.CreateCheckoutSessionResponse": {
+        "models.GetStudentClassroomResponse": {
             "type": "object",
             "required": [
-                "redirect_url"
+                "teacher_id"
             ],
             "properties": {
-                "redirect_url": {
+                "students_count": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 10
+                },
+                "teacher_id": {
                     "type": "string",
-                    "example": "https://checkout.stripe.com/c/pay/123"
+                    "example": "789"
                 }
             }
         },
-        "models.CreateClassroomRequest": {
+        "models.IncrementProgressResponse": {
             "type": "object",
             "required": [
-                "name"
+                "date",
+                "goal_met",
+                "user_id"
             ],
             "properties": {
-                "name": {
+                "date": {
                     "type": "string",
-                    "example": "Tuesday 9am"
+                    "example": "2025-02-26T00:00:00Z"
                 },
-                "students_count": {
+                "goal_met": {
+                    "type": "boolean",
+                    "example": true
+                },
+                "questions_completed": {
                     "type": "integer",
                     "minimum": 0,
-                    "example": 10
+                    "example": 5
+                },
+                "user_id": {
+                    "type": "string",
+                    "example": "123"
                 }
             }
         },
-        "models.CreateClassroomResponse": {
+        "models.JoinClassroomRequest": {
             "type": "object",
             "required": [
                 "classroom_id"
@@ -1872,200 +3888,171 @@ const docTemplate = `{
                 }
             }
         },
-        "models.CreateIndividualCheckoutSessionRequest": {
-            "type": "object"
-        },
-        "models.CreateIndividualCheckoutSessionResponse": {
+        "models.JoinClassroomResponse": {
             "type": "object",
             "required": [
-                "redirect_url"
+                "message"
             ],
             "properties": {
-                "redirect_url": {
+                "message": {
                     "type": "string",
-                    "example": "https://checkout.stripe.com/c/pay/123"
+                    "example": "Student added to classroom successfully"
                 }
             }
         },
-        "models.CreateOrganizationRequest": {
-            "type": "object"
-        },
-        "models.CreateOrganizationResponse": {
+        "models.JoinOrganizationRequest": {
             "type": "object",
             "required": [
-                "organization_id",
-                "teacher_id"
+                "organization_id"
             ],
             "properties": {
                 "organization_id": {
                     "type": "string",
                     "example": "123"
-                },
-                "teacher_id": {
-                    "type": "string",
-                    "example": "123"
                 }
             }
         },
-        "models.DeleteClassroomRequest": {
+        "models.JoinOrganizationResponse": {
             "type": "object",
             "required": [
-                "classroom_id"
+                "teacher_id"
             ],
             "properties": {
-                "classroom_id": {
+                "teacher_id": {
                     "type": "string",
                     "example": "123"
                 }
             }
         },
-        "models.DeleteClassroomResponse": {
-            "type": "object",
-            "required": [
-                "message"
-            ],
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Classroom deleted successfully"
-                }
-            }
-        },
-        "models.ERROR_CODE": {
-            "type": "string",
-            "enum": [
-                "PROFILE_NOT_FOUND",
-                "NO_TRANSCRIPT",
-                "AUTH_REQUIRED",
-                "USAGE_LIMIT_REACHED",
-                "USAGE_RESTRICTED"
-            ],
-            "x-enum-varnames": [
-                "PROFILE_NOT_FOUND",
-                "NO_TRANSCRIPT",
-                "AUTH_REQUIRED",
-                "USAGE_LIMIT_REACHED",
-                "USAGE_RESTRICTED"
-            ]
-        },
-        "models.ErrorResponse": {
+        "models.LevelEstimateResponse": {
             "type": "object",
             "required": [
-                "error"
+                "declared_level",
+                "estimated_level",
+                "evidence",
+                "language",
+                "recommendation",
+                "skill_level"
             ],
             "properties": {
-                "code": {
-                    "enum": [
-                        "PROFILE_NOT_FOUND",
-                        "NO_TRANSCRIPT",
-                        "AUTH_REQUIRED",
-                        "USAGE_LIMIT_REACHED",
-                        "USAGE_RESTRICTED"
-                    ],
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/models.ERROR_CODE"
-                        }
-                    ],
-                    "example": "PROFILE_NOT_FOUND"
+                "applied": {
+                    "description": "whether the estimate replaced the declared level",
+                    "type": "boolean",
+                    "example": true
                 },
-                "error": {
-                    "type": "string",
-                    "example": "Something went wrong"
-                }
-            }
-        },
-        "models.EvaluateAnswerRequest": {
-            "type": "object",
-            "required": [
-                "answer",
-                "cefr",
-                "content",
-                "question"
-            ],
-            "properties": {
-                "answer": {
-                    "type": "string",
-                    "example": "Hello"
+                "confidence": {
+                    "type": "number",
+                    "example": 0.8
                 },
-                "cefr": {
+                "declared_level": {
                     "type": "string",
                     "example": "B1"
                 },
-                "content": {
+                "estimated_level": {
                     "type": "string",
-                    "example": "Bonjour, comment ça va?"
+                    "example": "B2"
                 },
-                "question": {
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.LevelEvidence"
+                    }
+                },
+                "language": {
                     "type": "string",
-                    "example": "What does 'bonjour' mean?"
-                }
-            }
-        },
-        "models.EvaluateAnswerResponse": {
-            "type": "object",
-            "required": [
-                "evaluation",
-                "explanation"
-            ],
-            "properties": {
-                "evaluation": {
+                    "example": "French"
+                },
+                "recommendation": {
+                    "description": "how the declared level should change",
                     "type": "string",
-                    "example": "PASS"
+                    "enum": [
+                        "up",
+                        "down",
+                        "stay"
+                    ],
+                    "example": "up"
                 },
-                "explanation": {
+                "skill_level": {
+                    "description": "the level in the profile, after any automatic adjustment",
                     "type": "string",
-                    "example": "Perfect!"
+                    "example": "B2"
                 }
             }
         },
-        "models.GetClassroomListResponse": {
+        "models.LevelEvidence": {
             "type": "object",
             "required": [
-                "classrooms"
+                "level"
             ],
             "properties": {
-                "classrooms": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/models.ClassroomListItem"
-                    }
+                "attempts": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "completed": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "level": {
+                    "type": "string",
+                    "example": "B1"
+                },
+                "lookups": {
+                    "type": "integer",
+                    "example": 21
+                },
+                "mastery": {
+                    "description": "from 0 to 1, not set without answers or readings",
+                    "type": "number",
+                    "example": 0.78
+                },
+                "passed": {
+                    "type": "integer",
+                    "example": 9
+                },
+                "started": {
+                    "type": "integer",
+                    "example": 5
+                },
+                "words_read": {
+                    "type": "integer",
+                    "example": 1800
                 }
             }
         },
-        "models.GetNewsResponse": {
+        "models.NewsItem": {
             "type": "object",
             "required": [
+                "audiobook_tier",
                 "cefr_level",
-                "content",
-                "content_type",
+                "created_at",
                 "date_created",
-                "dictionary",
+                "id",
                 "language",
                 "preview_text",
-                "sources",
                 "title",
                 "topic"
             ],
             "properties": {
-                "cefr_level": {
+                "audiobook_tier": {
                     "type": "string",
-                    "example": "B1"
+                    "example": "NONE"
                 },
-                "content": {
+                "cefr_level": {
                     "type": "string",
-                    "example": "Le contenu complet de l'article..."
+                    "example": "B1"
                 },
-                "content_type": {
+                "created_at": {
                     "type": "string",
-                    "example": "News"
+                    "example": "2024-02-26T13:01:13.390612Z"
                 },
                 "date_created": {
                     "type": "string",
                     "example": "2024-02-26"
                 },
-                "dictionary": {
-                    "$ref": "#/definitions/storage.Dictionary"
+                "id": {
+                    "type": "string",
+                    "example": "123"
                 },
                 "language": {
                     "type": "string",
@@ -2075,11 +4062,15 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Un résumé des nouvelles musicales..."
                 },
-                "sources": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/storage.Source"
-                    }
+                "reading_status": {
+                    "description": "the user's progress, not set if it could not be read",
+                    "type": "string",
+                    "enum": [
+                        "unread",
+                        "in_progress",
+                        "read"
+                    ],
+                    "example": "unread"
                 },
                 "title": {
                     "type": "string",
@@ -2091,207 +4082,213 @@ const docTemplate = `{
                 }
             }
         },
-        "models.GetProfileResponse": {
+        "models.OrganizationResponse": {
             "type": "object",
             "required": [
-                "interested_topics",
-                "learning_language",
-                "skill_level",
-                "username"
+                "organization_id",
+                "plan",
+                "teacher_id"
             ],
             "properties": {
-                "daily_questions_goal": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 3
-                },
-                "interested_topics": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    },
-                    "example": [
-                        "[\"NBA\"]"
-                    ]
-                },
-                "learning_language": {
-                    "type": "string",
-                    "example": "French"
+                "canceled": {
+                    "type": "boolean",
+                    "example": false
                 },
-                "skill_level": {
+                "expiration_date": {
                     "type": "string",
-                    "example": "B1"
+                    "example": "2025-03-24T12:00:00Z"
                 },
-                "username": {
-                    "type": "string",
-                    "example": "connortbot"
-                }
-            }
-        },
-        "models.GetQuestionRequest": {
-            "type": "object",
-            "required": [
-                "cefr_level",
-                "content_type",
-                "id",
-                "question_type"
-            ],
-            "properties": {
-                "cefr_level": {
+                "organization_id": {
                     "type": "string",
-                    "example": "B1"
+                    "example": "123"
                 },
-                "content_type": {
+                "plan": {
                     "type": "string",
-                    "example": "News"
+                    "example": "FREE"
                 },
-                "id": {
+                "teacher_id": {
                     "type": "string",
                     "example": "123"
-                },
-                "question_type": {
-                    "type": "string",
-                    "enum": [
-                        "vocab",
-                        "understanding"
-                    ],
-                    "example": "vocab"
                 }
             }
         },
-        "models.GetQuestionResponse": {
+        "models.PaymentsResponse": {
             "type": "object",
             "required": [
-                "question"
+                "success"
             ],
             "properties": {
-                "question": {
-                    "type": "string",
-                    "example": "What does 'bonjour' mean?"
+                "success": {
+                    "type": "boolean",
+                    "example": true
                 }
             }
         },
-        "models.GetStoryPageResponse": {
+        "models.QNAAttempt": {
             "type": "object",
             "required": [
+                "answer",
+                "attempt_id",
                 "cefr_level",
-                "content",
+                "content_id",
                 "content_type",
-                "date_created",
-                "language",
-                "pages",
-                "preview_text",
-                "title",
-                "topic"
+                "created_at",
+                "evaluation",
+                "question",
+                "question_id",
+                "question_type"
             ],
             "properties": {
+                "answer": {
+                    "type": "string",
+                    "example": "Hello"
+                },
+                "attempt_id": {
+                    "type": "integer",
+                    "example": 7
+                },
                 "cefr_level": {
                     "type": "string",
                     "example": "B1"
                 },
-                "content": {
+                "content_id": {
                     "type": "string",
-                    "example": "Le contenu complet de l'article..."
+                    "example": "123"
                 },
                 "content_type": {
                     "type": "string",
-                    "example": "Story"
+                    "example": "News"
                 },
-                "date_created": {
+                "created_at": {
                     "type": "string",
-                    "example": "2024-02-26"
+                    "example": "2025-02-26T13:01:13Z"
                 },
-                "language": {
-                    "type": "string",
-                    "example": "French"
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/qna.AnswerError"
+                    }
                 },
-                "pages": {
-                    "type": "integer",
-                    "example": 10
+                "evaluation": {
+                    "type": "string",
+                    "example": "PASS"
                 },
-                "preview_text": {
+                "explanation": {
                     "type": "string",
-                    "example": "Un résumé des nouvelles musicales..."
+                    "example": "Perfect!"
                 },
-                "title": {
+                "question": {
                     "type": "string",
-                    "example": "L'actualité musicale en bref"
+                    "example": "What does 'bonjour' mean?"
                 },
-                "topic": {
+                "question_id": {
+                    "type": "integer",
+                    "example": 42
+                },
+                "question_type": {
                     "type": "string",
-                    "example": "Music"
+                    "example": "vocab"
+                },
+                "scores": {
+                    "description": "not set for answers evaluated before scores were given",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/qna.RubricScores"
+                        }
+                    ]
                 }
             }
         },
-        "models.GetStoryQNAContextResponse": {
+        "models.QNAHistoryResponse": {
             "type": "object",
             "required": [
-                "context"
+                "attempts"
             ],
             "properties": {
-                "context": {
-                    "type": "string",
-                    "example": "Le contexte de l'histoire..."
+                "attempts": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.QNAAttempt"
+                    }
                 }
             }
         },
-        "models.GetStudentClassroomResponse": {
+        "models.ReadingProgressResponse": {
             "type": "object",
             "required": [
-                "teacher_id"
+                "content_type",
+                "id",
+                "reading_status",
+                "started_at",
+                "updated_at"
             ],
             "properties": {
-                "students_count": {
-                    "type": "integer",
-                    "minimum": 0,
-                    "example": 10
+                "completed_at": {
+                    "description": "not set until the content is finished",
+                    "type": "string",
+                    "example": "2025-02-26T13:08:13Z"
                 },
-                "teacher_id": {
+                "content_type": {
                     "type": "string",
-                    "example": "789"
-                }
-            }
-        },
-        "models.IncrementProgressResponse": {
-            "type": "object",
-            "required": [
-                "date",
-                "goal_met",
-                "user_id"
-            ],
-            "properties": {
-                "date": {
+                    "example": "Story"
+                },
+                "id": {
                     "type": "string",
-                    "example": "2025-02-26T00:00:00Z"
+                    "example": "123"
                 },
-                "goal_met": {
-                    "type": "boolean",
-                    "example": true
+                "last_page": {
+                    "type": "integer",
+                    "example": 2
                 },
-                "questions_completed": {
+                "percent_complete": {
+                    "type": "number",
+                    "example": 60
+                },
+                "reading_status": {
+                    "type": "string",
+                    "enum": [
+                        "in_progress",
+                        "read"
+                    ],
+                    "example": "in_progress"
+                },
+                "started_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13Z"
+                },
+                "time_spent_seconds": {
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 5
+                    "example": 420
                 },
-                "user_id": {
+                "updated_at": {
                     "type": "string",
-                    "example": "123"
+                    "example": "2025-02-26T13:08:13Z"
                 }
             }
         },
-        "models.JoinClassroomRequest": {
+        "models.RejectContentRequest": {
             "type": "object",
             "required": [
-                "classroom_id"
+                "classroom_id",
+                "content_type"
             ],
             "properties": {
                 "classroom_id": {
                     "type": "string",
                     "example": "123"
+                },
+                "content_id": {
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 123
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
                 }
             }
         },
-        "models.JoinClassroomResponse": {
+        "models.RejectContentResponse": {
             "type": "object",
             "required": [
                 "message"
@@ -2299,59 +4296,89 @@ const docTemplate = `{
             "properties": {
                 "message": {
                     "type": "string",
-                    "example": "Student added to classroom successfully"
+                    "example": "Content rejected successfully"
                 }
             }
         },
-        "models.JoinOrganizationRequest": {
+        "models.SaveVocabRequest": {
             "type": "object",
             "required": [
-                "organization_id"
+                "language",
+                "word"
             ],
             "properties": {
-                "organization_id": {
+                "content_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "content_type": {
+                    "description": "the content the word was looked up in, if any",
+                    "type": "string",
+                    "enum": [
+                        "News",
+                        "Story"
+                    ],
+                    "example": "News"
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "sentence": {
+                    "description": "the sentence the word was looked up in",
+                    "type": "string",
+                    "example": "Le chat dort sur le lit."
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "cat"
+                },
+                "word": {
                     "type": "string",
-                    "example": "123"
+                    "example": "chat"
                 }
             }
         },
-        "models.JoinOrganizationResponse": {
+        "models.SearchResponse": {
             "type": "object",
             "required": [
-                "teacher_id"
+                "results"
             ],
             "properties": {
-                "teacher_id": {
-                    "type": "string",
-                    "example": "123"
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.SearchResult"
+                    }
                 }
             }
         },
-        "models.NewsItem": {
+        "models.SearchResult": {
             "type": "object",
             "required": [
-                "audiobook_tier",
                 "cefr_level",
+                "content_type",
                 "created_at",
                 "date_created",
                 "id",
                 "language",
                 "preview_text",
+                "snippet",
                 "title",
                 "topic"
             ],
             "properties": {
-                "audiobook_tier": {
-                    "type": "string",
-                    "example": "NONE"
-                },
                 "cefr_level": {
                     "type": "string",
                     "example": "B1"
                 },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
                 "created_at": {
                     "type": "string",
-                    "example": "2024-02-26T13:01:13.390612Z"
+                    "example": "2024-02-26T13:01:13Z"
                 },
                 "date_created": {
                     "type": "string",
@@ -2365,93 +4392,65 @@ const docTemplate = `{
                     "type": "string",
                     "example": "French"
                 },
-                "preview_text": {
-                    "type": "string",
-                    "example": "Un résumé des nouvelles musicales..."
-                },
-                "title": {
-                    "type": "string",
-                    "example": "L'actualité musicale en bref"
+                "pages": {
+                    "type": "integer",
+                    "example": 5
                 },
-                "topic": {
+                "preview_text": {
                     "type": "string",
-                    "example": "Music"
-                }
-            }
-        },
-        "models.OrganizationResponse": {
-            "type": "object",
-            "required": [
-                "organization_id",
-                "plan",
-                "teacher_id"
-            ],
-            "properties": {
-                "canceled": {
-                    "type": "boolean",
-                    "example": false
+                    "example": "Les Français votent dimanche..."
                 },
-                "expiration_date": {
-                    "type": "string",
-                    "example": "2025-03-24T12:00:00Z"
+                "rank": {
+                    "type": "number",
+                    "example": 0.42
                 },
-                "organization_id": {
+                "snippet": {
+                    "description": "plain text with the matches in \u003cmark\u003e tags",
                     "type": "string",
-                    "example": "123"
+                    "example": "Les \u003cmark\u003eélections\u003c/mark\u003e municipales ont lieu dimanche"
                 },
-                "plan": {
+                "title": {
                     "type": "string",
-                    "example": "FREE"
+                    "example": "Les élections municipales"
                 },
-                "teacher_id": {
+                "topic": {
                     "type": "string",
-                    "example": "123"
-                }
-            }
-        },
-        "models.PaymentsResponse": {
-            "type": "object",
-            "required": [
-                "success"
-            ],
-            "properties": {
-                "success": {
-                    "type": "boolean",
-                    "example": true
+                    "example": "Politics"
                 }
             }
         },
-        "models.RejectContentRequest": {
+        "models.SentenceSupport": {
             "type": "object",
             "required": [
-                "classroom_id",
-                "content_type"
+                "overlap",
+                "sentence",
+                "source",
+                "unsupported"
             ],
             "properties": {
-                "classroom_id": {
+                "overlap": {
+                    "type": "number",
+                    "example": 0.8
+                },
+                "sentence": {
                     "type": "string",
-                    "example": "123"
+                    "example": "Le Sénat a voté le budget mardi."
                 },
-                "content_id": {
+                "source": {
+                    "description": "index into sources of the source sharing the most words, -1 if none",
                     "type": "integer",
-                    "minimum": 0,
-                    "example": 123
+                    "example": 0
                 },
-                "content_type": {
-                    "type": "string",
-                    "example": "News"
-                }
-            }
-        },
-        "models.RejectContentResponse": {
-            "type": "object",
-            "required": [
-                "message"
-            ],
-            "properties": {
-                "message": {
-                    "type": "string",
-                    "example": "Content rejected successfully"
+                "unsupported": {
+                    "description": "names and numbers that are in none of the sources",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "Dupont",
+                        "40"
+                    ]
                 }
             }
         },
@@ -2530,6 +4529,16 @@ const docTemplate = `{
                     "type": "string",
                     "example": "Un résumé des nouvelles musicales..."
                 },
+                "reading_status": {
+                    "description": "the user's progress, not set if it could not be read",
+                    "type": "string",
+                    "enum": [
+                        "unread",
+                        "in_progress",
+                        "read"
+                    ],
+                    "example": "unread"
+                },
                 "title": {
                     "type": "string",
                     "example": "L'actualité musicale en bref"
@@ -2719,6 +4728,50 @@ const docTemplate = `{
                 }
             }
         },
+        "models.UpdateReadingProgressRequest": {
+            "type": "object",
+            "required": [
+                "content_type",
+                "id"
+            ],
+            "properties": {
+                "completed": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "content_type": {
+                    "type": "string",
+                    "enum": [
+                        "News",
+                        "Story"
+                    ],
+                    "example": "Story"
+                },
+                "id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "page": {
+                    "description": "the page the user is on, 0 for news",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                },
+                "percent_complete": {
+                    "description": "defaults to the share of pages read for stories",
+                    "type": "number",
+                    "maximum": 100,
+                    "minimum": 0,
+                    "example": 60
+                },
+                "seconds_spent": {
+                    "description": "time spent reading since the last update",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 95
+                }
+            }
+        },
         "models.UpsertProfileRequest": {
             "type": "object",
             "required": [
@@ -2773,6 +4826,133 @@ const docTemplate = `{
                 }
             }
         },
+        "models.VocabCard": {
+            "type": "object",
+            "required": [
+                "card_id",
+                "created_at",
+                "due_at",
+                "ease_factor",
+                "language",
+                "lookups",
+                "word"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "content_id": {
+                    "type": "string",
+                    "example": "123"
+                },
+                "content_type": {
+                    "type": "string",
+                    "example": "News"
+                },
+                "created_at": {
+                    "type": "string",
+                    "example": "2025-02-26T13:01:13Z"
+                },
+                "due_at": {
+                    "type": "string",
+                    "example": "2025-03-07T12:00:00Z"
+                },
+                "ease_factor": {
+                    "type": "number",
+                    "example": 2.5
+                },
+                "interval_days": {
+                    "type": "integer",
+                    "example": 6
+                },
+                "language": {
+                    "type": "string",
+                    "example": "French"
+                },
+                "last_reviewed_at": {
+                    "description": "not set for cards that were never reviewed",
+                    "type": "string",
+                    "example": "2025-03-01T12:00:00Z"
+                },
+                "lookups": {
+                    "description": "how many times the word was saved",
+                    "type": "integer",
+                    "example": 3
+                },
+                "repetitions": {
+                    "type": "integer",
+                    "example": 2
+                },
+                "sentence": {
+                    "type": "string",
+                    "example": "Le chat dort sur le lit."
+                },
+                "translation": {
+                    "type": "string",
+                    "example": "cat"
+                },
+                "word": {
+                    "type": "string",
+                    "example": "chat"
+                }
+            }
+        },
+        "models.VocabCardsResponse": {
+            "type": "object",
+            "required": [
+                "cards"
+            ],
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/models.VocabCard"
+                    }
+                }
+            }
+        },
+        "models.VocabReviewRequest": {
+            "type": "object",
+            "required": [
+                "card_id",
+                "grade"
+            ],
+            "properties": {
+                "card_id": {
+                    "type": "integer",
+                    "example": 12
+                },
+                "grade": {
+                    "description": "0 (no recollection) to 5 (perfect recall), 3 and above count as remembered",
+                    "type": "integer",
+                    "maximum": 5,
+                    "minimum": 0,
+                    "example": 4
+                }
+            }
+        },
+        "models.VocabReviewResponse": {
+            "type": "object",
+            "required": [
+                "card"
+            ],
+            "properties": {
+                "card": {
+                    "$ref": "#/definitions/models.VocabCard"
+                },
+                "goal_met": {
+                    "type": "boolean",
+                    "example": false
+                },
+                "questions_completed": {
+                    "description": "today's progress, which counts each review as a completed question",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 5
+                }
+            }
+        },
         "models.WebhookResponse": {
             "type": "object",
             "properties": {
@@ -2784,9 +4964,67 @@ const docTemplate = `{
                 }
             }
         },
+        "qna.AnswerError": {
+            "type": "object",
+            "properties": {
+                "correction": {
+                    "type": "string",
+                    "example": "ils sont"
+                },
+                "end": {
+                    "type": "integer",
+                    "example": 11
+                },
+                "explanation": {
+                    "type": "string",
+                    "example": "Ils is the plural of il."
+                },
+                "start": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "text": {
+                    "type": "string",
+                    "example": "il sont"
+                },
+                "type": {
+                    "type": "string",
+                    "example": "grammar"
+                }
+            }
+        },
+        "qna.RubricScores": {
+            "type": "object",
+            "properties": {
+                "comprehension": {
+                    "type": "integer",
+                    "example": 4
+                },
+                "grammar": {
+                    "type": "integer",
+                    "example": 3
+                },
+                "vocabulary": {
+                    "type": "integer",
+                    "example": 5
+                }
+            }
+        },
         "storage.Dictionary": {
             "type": "object",
             "properties": {
+                "lemmas": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/dictionary.Entry"
+                    }
+                },
+                "tokens": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dictionary.Token"
+                    }
+                },
                 "translations": {
                     "type": "object",
                     "properties": {
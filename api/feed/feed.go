@@ -0,0 +1,167 @@
+// Package feed ranks the content a learner is shown on their home feed.
+//
+// Each candidate gets a score from its topic, level, age and whether the
+// learner has started it, and the ranked list is then mixed so that news and
+// stories alternate unless one is clearly a better fit. The reasons an item
+// scored what it did are returned with it, so clients can explain the feed.
+package feed
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"squeak-shared/qnagen"
+)
+
+// Reasons an item is in the feed.
+const (
+	REASON_TOPIC       = "topic"       // on one of the learner's topics
+	REASON_LEVEL       = "level"       // at the learner's level
+	REASON_LEVEL_UP    = "level_up"    // one level above the learner's, to stretch them
+	REASON_RECENT      = "recent"      // published within RECENCY_HALF_LIFE
+	REASON_IN_PROGRESS = "in_progress" // started but not finished
+	REASON_MIX         = "mix"         // moved up so content types alternate
+)
+
+// RECENCY_HALF_LIFE is the age at which the recency weight has halved.
+const RECENCY_HALF_LIFE = 72 * time.Hour
+
+// Weights are what each reason adds to an item's score.
+type Weights struct {
+	Topic      float64
+	Level      float64
+	LevelUp    float64
+	Recency    float64 // for new content, halving every RECENCY_HALF_LIFE
+	InProgress float64
+	// RepeatType is taken off an item for each item of the same content type
+	// directly before it in the feed.
+	RepeatType float64
+}
+
+// DefaultWeights favour topic over level, so a learner sees what they are
+// interested in first, with content one level up mixed in below content at
+// their level.
+var DefaultWeights = Weights{
+	Topic:      3,
+	Level:      2,
+	LevelUp:    1,
+	Recency:    2,
+	InProgress: 1.5,
+	RepeatType: 2,
+}
+
+// Candidate is content that could go in a learner's feed.
+type Candidate struct {
+	ContentType string // News or Story
+	ID          string
+	Topic       string
+	CEFRLevel   string
+	CreatedAt   time.Time
+	InProgress  bool
+}
+
+// Ranked is a candidate's place in the feed.
+type Ranked struct {
+	Index   int // of the candidate passed to Rank
+	Score   float64
+	Reasons []string
+}
+
+// Levels returns the levels a learner at level is shown: their own and the
+// one above, if there is one. It returns nil for an unknown level.
+func Levels(level string) []string {
+	for i, l := range qnagen.Levels {
+		if l == level {
+			return qnagen.Levels[i:min(i+2, len(qnagen.Levels))]
+		}
+	}
+	return nil
+}
+
+// Score scores one candidate for a learner at level interested in topics.
+func Score(candidate Candidate, level string, topics []string, weights Weights, now time.Time) (float64, []string) {
+	score := 0.0
+	reasons := []string{}
+	for _, topic := range topics {
+		if topic == candidate.Topic {
+			score += weights.Topic
+			reasons = append(reasons, REASON_TOPIC)
+			break
+		}
+	}
+	if candidate.CEFRLevel == level {
+		score += weights.Level
+		reasons = append(reasons, REASON_LEVEL)
+	} else if levels := Levels(level); len(levels) == 2 && candidate.CEFRLevel == levels[1] {
+		score += weights.LevelUp
+		reasons = append(reasons, REASON_LEVEL_UP)
+	}
+	age := max(now.Sub(candidate.CreatedAt), 0)
+	score += weights.Recency * math.Pow(0.5, float64(age)/float64(RECENCY_HALF_LIFE))
+	if age < RECENCY_HALF_LIFE {
+		reasons = append(reasons, REASON_RECENT)
+	}
+	if candidate.InProgress {
+		score += weights.InProgress
+		reasons = append(reasons, REASON_IN_PROGRESS)
+	}
+	return score, reasons
+}
+
+// Rank orders candidates for a learner, best first. The order depends only on
+// the arguments, so ranking the same candidates at the same now always gives
+// the same feed and it can be paged through with an offset.
+func Rank(candidates []Candidate, level string, topics []string, weights Weights, now time.Time) []Ranked {
+	remaining := make([]Ranked, len(candidates))
+	for i, candidate := range candidates {
+		score, reasons := Score(candidate, level, topics, weights, now)
+		remaining[i] = Ranked{Index: i, Score: score, Reasons: reasons}
+	}
+	// ties go to the newest content, then the lowest ID for a stable order
+	sort.SliceStable(remaining, func(i, j int) bool {
+		a, b := remaining[i], remaining[j]
+		if a.Score != b.Score {
+			return a.Score > b.Score
+		}
+		ca, cb := candidates[a.Index], candidates[b.Index]
+		if !ca.CreatedAt.Equal(cb.CreatedAt) {
+			return ca.CreatedAt.After(cb.CreatedAt)
+		}
+		if ca.ContentType != cb.ContentType {
+			return ca.ContentType < cb.ContentType
+		}
+		return ca.ID < cb.ID
+	})
+
+	// Greedily take the best item after the repeat penalty. remaining stays
+	// sorted by score, so the first item of each type is the best of it.
+	ranked := make([]Ranked, 0, len(candidates))
+	lastType, run := "", 0
+	for len(remaining) > 0 {
+		best, bestScore := 0, math.Inf(-1)
+		for i, item := range remaining {
+			score := item.Score
+			if candidates[item.Index].ContentType == lastType {
+				score -= weights.RepeatType * float64(run)
+			}
+			if score > bestScore {
+				best, bestScore = i, score
+			}
+		}
+		item := remaining[best]
+		if best > 0 {
+			// something of another type scored higher before the penalty
+			item.Reasons = append(item.Reasons, REASON_MIX)
+		}
+		remaining = append(remaining[:best], remaining[best+1:]...)
+		ranked = append(ranked, item)
+
+		if contentType := candidates[item.Index].ContentType; contentType == lastType {
+			run++
+		} else {
+			lastType, run = contentType, 1
+		}
+	}
+	return ranked
+}
@@ -0,0 +1,96 @@
+package feed
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func order(candidates []Candidate, ranked []Ranked) []string {
+	ids := []string{}
+	for _, item := range ranked {
+		ids = append(ids, candidates[item.Index].ID)
+	}
+	return ids
+}
+
+func TestLevels(t *testing.T) {
+	if levels := Levels("B1"); !reflect.DeepEqual(levels, []string{"B1", "B2"}) {
+		t.Errorf("expected B1 and B2, got %v", levels)
+	}
+	if levels := Levels("C2"); !reflect.DeepEqual(levels, []string{"C2"}) {
+		t.Errorf("expected only C2, got %v", levels)
+	}
+	if levels := Levels("Z9"); levels != nil {
+		t.Errorf("expected no levels for an unknown level, got %v", levels)
+	}
+}
+
+func TestScoreReasons(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	weights := Weights{Topic: 3, Level: 2, LevelUp: 1, Recency: 2, InProgress: 1.5}
+
+	score, reasons := Score(Candidate{Topic: "NBA", CEFRLevel: "B1", CreatedAt: now}, "B1", []string{"NBA"}, weights, now)
+	if score != 7 || !reflect.DeepEqual(reasons, []string{REASON_TOPIC, REASON_LEVEL, REASON_RECENT}) {
+		t.Errorf("unexpected score %v with reasons %v", score, reasons)
+	}
+
+	old := now.Add(-2 * RECENCY_HALF_LIFE)
+	score, reasons = Score(Candidate{Topic: "Music", CEFRLevel: "B2", CreatedAt: old, InProgress: true}, "B1", []string{"NBA"}, weights, now)
+	if score != 3 || !reflect.DeepEqual(reasons, []string{REASON_LEVEL_UP, REASON_IN_PROGRESS}) {
+		t.Errorf("unexpected score %v with reasons %v", score, reasons)
+	}
+}
+
+func TestRankPrefersTopicThenLevel(t *testing.T) {
+	now := time.Now()
+	candidates := []Candidate{
+		{ContentType: "News", ID: "up", Topic: "Music", CEFRLevel: "B2", CreatedAt: now},
+		{ContentType: "News", ID: "level", Topic: "Music", CEFRLevel: "B1", CreatedAt: now},
+		{ContentType: "News", ID: "topic", Topic: "NBA", CEFRLevel: "B2", CreatedAt: now},
+	}
+	weights := DefaultWeights
+	weights.RepeatType = 0
+
+	ranked := Rank(candidates, "B1", []string{"NBA"}, weights, now)
+	if ids := order(candidates, ranked); !reflect.DeepEqual(ids, []string{"topic", "level", "up"}) {
+		t.Errorf("unexpected order %v", ids)
+	}
+}
+
+func TestRankMixesContentTypes(t *testing.T) {
+	now := time.Now()
+	candidates := []Candidate{
+		{ContentType: "News", ID: "1", Topic: "NBA", CEFRLevel: "B1", CreatedAt: now},
+		{ContentType: "News", ID: "2", Topic: "NBA", CEFRLevel: "B1", CreatedAt: now.Add(-time.Minute)},
+		{ContentType: "News", ID: "3", Topic: "NBA", CEFRLevel: "B1", CreatedAt: now.Add(-2 * time.Minute)},
+		{ContentType: "Story", ID: "4", Topic: "Music", CEFRLevel: "B1", CreatedAt: now},
+	}
+
+	ranked := Rank(candidates, "B1", []string{"NBA"}, DefaultWeights, now)
+	if ids := order(candidates, ranked); !reflect.DeepEqual(ids, []string{"1", "2", "4", "3"}) {
+		t.Fatalf("expected the story after two news, got %v", ids)
+	}
+	mixed := ranked[2].Reasons
+	if mixed[len(mixed)-1] != REASON_MIX {
+		t.Errorf("expected the story to be marked as mixed in, got %v", mixed)
+	}
+	if reasons := ranked[1].Reasons; reasons[len(reasons)-1] == REASON_MIX {
+		t.Errorf("expected the second news not to be marked as mixed in, got %v", reasons)
+	}
+}
+
+func TestRankIsStable(t *testing.T) {
+	now := time.Now()
+	candidates := []Candidate{
+		{ContentType: "Story", ID: "2", CEFRLevel: "A1", CreatedAt: now},
+		{ContentType: "News", ID: "2", CEFRLevel: "A1", CreatedAt: now},
+		{ContentType: "News", ID: "1", CEFRLevel: "A1", CreatedAt: now},
+	}
+	first := order(candidates, Rank(candidates, "A1", nil, DefaultWeights, now))
+	candidates[0], candidates[2] = candidates[2], candidates[0]
+	second := order(candidates, Rank(candidates, "A1", nil, DefaultWeights, now))
+	if !reflect.DeepEqual(first, second) {
+		t.Errorf("expected the same order for the same candidates, got %v and %v", first, second)
+	}
+}
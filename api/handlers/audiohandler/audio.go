@@ -19,12 +19,14 @@ import (
 type AudioHandler struct {
 	*handlers.Handler
 	AudioClient *audio.Client
+	Storage     *storage.Client
 }
 
-func New(dbClient *supabase.Client, audioClient *audio.Client) *AudioHandler {
+func New(dbClient supabase.Repository, audioClient *audio.Client, storageClient *storage.Client) *AudioHandler {
 	return &AudioHandler{
 		Handler:     handlers.New(dbClient),
 		AudioClient: audioClient,
+		Storage:     storageClient,
 	}
 }
 
@@ -253,8 +255,7 @@ func (h *AudioHandler) GetAudiobook(c *gin.Context) {
 	
 	keyContentType := "News"
 	if contentType == "story" { keyContentType = "Story" }
-	s3Key := storage.GetAudiobookKey(audiobookInfo.Language, audiobookInfo.CEFRLevel, audiobookInfo.Topic, audiobookInfo.Date.Format("2006-01-02"), pageInt, keyContentType)
-	presignedURL, err := storage.GetPresignedURL(s3Key, 5) // 5 minute exp
+	presignedURL, err := h.Storage.GetAudiobookURL(audiobookInfo.Language, audiobookInfo.CEFRLevel, audiobookInfo.Topic, audiobookInfo.Date.Format("2006-01-02"), pageInt, keyContentType, 5) // 5 minute exp
 	if err != nil {
 		log.Printf("Error generating pre-signed URL: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{
@@ -21,7 +21,7 @@ type BillingHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *BillingHandler {
+func New(dbClient supabase.Repository) *BillingHandler {
 	return &BillingHandler{
 		Handler: handlers.New(dbClient),
 	}
@@ -0,0 +1,126 @@
+package cataloghandler
+
+import (
+	"log"
+	"net/http"
+	"sort"
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/supabase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type CatalogHandler struct {
+	*handlers.Handler
+}
+
+func New(dbClient supabase.Repository) *CatalogHandler {
+	return &CatalogHandler{
+		Handler: handlers.New(dbClient),
+	}
+}
+
+// set collects unique values and returns them sorted.
+type set map[string]bool
+
+func (s set) sorted() []string {
+	values := make([]string, 0, len(s))
+	for value := range s {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+	return values
+}
+
+// @Summary		List supported languages
+// @Description	List the languages content is generated in, with their CEFR levels, content types and topics
+// @Tags			catalog
+// @Produce		json
+// @Success		200	{object}	models.CatalogLanguagesResponse
+// @Failure		500	{object}	models.ErrorResponse
+// @Router			/catalog/languages [get]
+func (h *CatalogHandler) GetLanguages(c *gin.Context) {
+	cells, err := h.DBClient.GetGenerationMatrix()
+	if err != nil {
+		log.Printf("Failed to get generation matrix: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to get languages"})
+		return
+	}
+
+	levels := map[string]set{}
+	contentTypes := map[string]set{}
+	topics := map[string]set{}
+	for _, cell := range cells {
+		if levels[cell.Language] == nil {
+			levels[cell.Language] = set{}
+			contentTypes[cell.Language] = set{}
+			topics[cell.Language] = set{}
+		}
+		levels[cell.Language][cell.CEFRLevel] = true
+		contentTypes[cell.Language][cell.ContentType] = true
+		topics[cell.Language][cell.Subject] = true
+	}
+
+	languages := []models.CatalogLanguage{}
+	for language := range levels {
+		languages = append(languages, models.CatalogLanguage{
+			Language:     language,
+			CEFRLevels:   levels[language].sorted(),
+			ContentTypes: contentTypes[language].sorted(),
+			Topics:       topics[language].sorted(),
+		})
+	}
+	sort.Slice(languages, func(i, j int) bool { return languages[i].Language < languages[j].Language })
+
+	c.JSON(http.StatusOK, models.CatalogLanguagesResponse{Languages: languages})
+}
+
+// @Summary		List supported topics
+// @Description	List the topics content is generated for, optionally only those available in one language
+// @Tags			catalog
+// @Produce		json
+// @Param			language	query		string	false	"Only topics available in this language"
+// @Success		200			{object}	models.CatalogTopicsResponse
+// @Failure		500			{object}	models.ErrorResponse
+// @Router			/catalog/topics [get]
+func (h *CatalogHandler) GetTopics(c *gin.Context) {
+	language := c.Query("language")
+
+	cells, err := h.DBClient.GetGenerationMatrix()
+	if err != nil {
+		log.Printf("Failed to get generation matrix: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to get topics"})
+		return
+	}
+
+	languages := map[string]set{}
+	levels := map[string]set{}
+	contentTypes := map[string]set{}
+	for _, cell := range cells {
+		if language != "" && cell.Language != language {
+			continue
+		}
+		if languages[cell.Subject] == nil {
+			languages[cell.Subject] = set{}
+			levels[cell.Subject] = set{}
+			contentTypes[cell.Subject] = set{}
+		}
+		languages[cell.Subject][cell.Language] = true
+		levels[cell.Subject][cell.CEFRLevel] = true
+		contentTypes[cell.Subject][cell.ContentType] = true
+	}
+
+	topics := []models.CatalogTopic{}
+	for topic := range languages {
+		topics = append(topics, models.CatalogTopic{
+			Topic:        topic,
+			Languages:    languages[topic].sorted(),
+			CEFRLevels:   levels[topic].sorted(),
+			ContentTypes: contentTypes[topic].sorted(),
+		})
+	}
+	sort.Slice(topics, func(i, j int) bool { return topics[i].Topic < topics[j].Topic })
+
+	c.JSON(http.StatusOK, models.CatalogTopicsResponse{Topics: topics})
+}
@@ -0,0 +1,64 @@
+package cataloghandler_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"story-api/handlers/cataloghandler"
+	"story-api/handlers/handlertest"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func seededDB() *fake.DB {
+	db := fake.New()
+	db.AddGenerationCell(supabase.GenerationCell{Language: "French", CEFRLevel: "B1", Subject: "Politics", ContentType: "News", FrequencyDays: 1})
+	db.AddGenerationCell(supabase.GenerationCell{Language: "French", CEFRLevel: "A1", Subject: "Travel", ContentType: "Story", FrequencyDays: 7})
+	db.AddGenerationCell(supabase.GenerationCell{Language: "Spanish", CEFRLevel: "B1", Subject: "Politics", ContentType: "News", FrequencyDays: 1})
+	return db
+}
+
+func TestGetLanguages(t *testing.T) {
+	h := cataloghandler.New(seededDB())
+
+	recorder := handlertest.Do(t, h.GetLanguages, http.MethodGet, "/catalog/languages", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.CatalogLanguagesResponse
+	handlertest.Decode(t, recorder, &response)
+
+	if len(response.Languages) != 2 || response.Languages[0].Language != "French" {
+		t.Fatalf("expected French and Spanish, got %+v", response.Languages)
+	}
+	french := response.Languages[0]
+	if len(french.CEFRLevels) != 2 || french.CEFRLevels[0] != "A1" || len(french.ContentTypes) != 2 || len(french.Topics) != 2 {
+		t.Errorf("unexpected French catalog: %+v", french)
+	}
+}
+
+func TestGetTopicsFiltersByLanguage(t *testing.T) {
+	h := cataloghandler.New(seededDB())
+
+	recorder := handlertest.Do(t, h.GetTopics, http.MethodGet, "/catalog/topics", "user", nil)
+	var response models.CatalogTopicsResponse
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Topics) != 2 || response.Topics[0].Topic != "Politics" || len(response.Topics[0].Languages) != 2 {
+		t.Fatalf("unexpected topics: %+v", response.Topics)
+	}
+
+	recorder = handlertest.Do(t, h.GetTopics, http.MethodGet, "/catalog/topics?language=Spanish", "user", nil)
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Topics) != 1 || response.Topics[0].Topic != "Politics" || response.Topics[0].Languages[0] != "Spanish" {
+		t.Errorf("expected only Spanish Politics, got %+v", response.Topics)
+	}
+}
+
+func TestGetLanguagesDatabaseError(t *testing.T) {
+	db := fake.New()
+	db.Fail("GetGenerationMatrix", errors.New("connection refused"))
+	h := cataloghandler.New(db)
+
+	recorder := handlertest.Do(t, h.GetLanguages, http.MethodGet, "/catalog/languages", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusInternalServerError)
+}
@@ -0,0 +1,97 @@
+package editorhandler
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"story-api/models"
+	"story-api/storage"
+
+	"squeak-shared/tokenize"
+)
+
+var numberPattern = regexp.MustCompile(`\d[\d.,]*\d|\d`)
+
+// numbers returns the numbers of at least two digits in text, as digits only
+// so that 1,5 and 1.5 compare equal.
+func numbers(text string) []string {
+	found := []string{}
+	for _, number := range numberPattern.FindAllString(text, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, number)
+		if len(digits) >= 2 {
+			found = append(found, digits)
+		}
+	}
+	return found
+}
+
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+// sourceDiff relates every sentence of a markdown article to the source it
+// shares the most words with, and lists the names (capitalized words not
+// starting a sentence) and numbers in it that no source mentions.
+func sourceDiff(language string, article string, sources []storage.Source) []models.SentenceSupport {
+	tokenizer := tokenize.For(language)
+
+	sourceWords := make([]map[string]bool, len(sources))
+	allWords := map[string]bool{}
+	for i, source := range sources {
+		sourceWords[i] = map[string]bool{}
+		text := source.Title + "\n" + source.Content
+		for _, word := range tokenizer.Words(text) {
+			sourceWords[i][strings.ToLower(word)] = true
+			allWords[strings.ToLower(word)] = true
+		}
+		for _, number := range numbers(text) {
+			allWords[number] = true
+		}
+	}
+
+	diff := []models.SentenceSupport{}
+	for _, sentence := range tokenizer.Sentences(article) {
+		sentence = strings.TrimSpace(strings.TrimLeft(sentence, "#"))
+		words := tokenizer.Words(sentence)
+		if len(words) == 0 {
+			continue
+		}
+
+		support := models.SentenceSupport{Sentence: sentence, Source: -1, Unsupported: []string{}}
+		for i := range sources {
+			shared := 0
+			for _, word := range words {
+				if sourceWords[i][strings.ToLower(word)] {
+					shared++
+				}
+			}
+			overlap := math.Round(float64(shared)/float64(len(words))*100) / 100
+			if shared > 0 && overlap > support.Overlap {
+				support.Source, support.Overlap = i, overlap
+			}
+		}
+
+		for i, word := range words {
+			if i > 0 && isCapitalized(word) && !allWords[strings.ToLower(word)] {
+				support.Unsupported = append(support.Unsupported, word)
+			}
+		}
+		for _, number := range numbers(sentence) {
+			if !allWords[number] {
+				support.Unsupported = append(support.Unsupported, number)
+			}
+		}
+		diff = append(diff, support)
+	}
+	return diff
+}
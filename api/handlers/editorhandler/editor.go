@@ -0,0 +1,354 @@
+package editorhandler
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/storage"
+	"story-api/supabase"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"squeak-shared/stripmd"
+)
+
+type EditorHandler struct {
+	*handlers.Handler
+	Storage *storage.Client
+}
+
+func New(dbClient supabase.Repository, storageClient *storage.Client) *EditorHandler {
+	return &EditorHandler{
+		Handler: handlers.New(dbClient),
+		Storage: storageClient,
+	}
+}
+
+var statuses = map[string]bool{
+	supabase.STATUS_DRAFT:     true,
+	supabase.STATUS_IN_REVIEW: true,
+	supabase.STATUS_PUBLISHED: true,
+	supabase.STATUS_RETRACTED: true,
+}
+
+// the statuses content can be published or retracted from
+var (
+	publishFrom = []string{supabase.STATUS_DRAFT, supabase.STATUS_IN_REVIEW, supabase.STATUS_RETRACTED}
+	retractFrom = []string{supabase.STATUS_DRAFT, supabase.STATUS_IN_REVIEW, supabase.STATUS_PUBLISHED}
+)
+
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(time.RFC3339)
+}
+
+func toItem(content supabase.EditorContent) models.EditorContentItem {
+	return models.EditorContentItem{
+		ID:          content.ID,
+		ContentType: content.ContentType,
+		Title:       content.Title,
+		Language:    content.Language,
+		Topic:       content.Topic,
+		CEFRLevel:   content.CEFRLevel,
+		PreviewText: content.PreviewText,
+		Pages:       content.Pages,
+		Status:      content.Status,
+		Moderation:  content.Moderation,
+		Readability: content.Readability,
+		CreatedAt:   content.CreatedAt.Format(time.RFC3339Nano),
+		DateCreated: content.DateCreated,
+		EditedAt:    formatTime(content.EditedAt),
+		ReviewedBy:  content.ReviewedBy,
+		ReviewedAt:  formatTime(content.ReviewedAt),
+		ReviewNote:  content.ReviewNote,
+	}
+}
+
+// getContent writes an error response and returns nil if the content cannot
+// be found.
+func (h *EditorHandler) getContent(c *gin.Context, contentType string, id string) *supabase.EditorContent {
+	if contentType != "Story" && contentType != "News" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid content type"})
+		return nil
+	}
+	content, err := h.DBClient.GetEditorContent(contentType, id)
+	if err != nil {
+		log.Printf("Failed to get editor content: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content"})
+		return nil
+	}
+	if content == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Content not found"})
+		return nil
+	}
+	return content
+}
+
+// @Summary		List content for review
+// @Description	List news and stories with a status, newest first. Editors only.
+// @Tags			editor
+// @Produce		json
+// @Param			status			query		string	false	"draft, in_review (default), published or retracted"
+// @Param			content_type	query		string	false	"News, Story or All (default)"
+// @Param			page			query		string	false	"Page"
+// @Param			pagesize		query		string	false	"Page size"
+// @Success		200				{object}	models.EditorContentListResponse
+// @Failure		400				{object}	models.ErrorResponse
+// @Failure		403				{object}	models.ErrorResponse
+// @Router			/editor/content [get]
+func (h *EditorHandler) GetContentList(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+	if !h.CheckIsCorrectRole(c, userID, "editor") {
+		return
+	}
+
+	status := c.DefaultQuery("status", supabase.STATUS_IN_REVIEW)
+	contentType := c.DefaultQuery("content_type", "All")
+	if !statuses[status] {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid status"})
+		return
+	}
+	if contentType != "Story" && contentType != "News" && contentType != "All" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid content type"})
+		return
+	}
+
+	pageNum, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || pageNum < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page number"})
+		return
+	}
+	pageSizeNum, err := strconv.Atoi(c.DefaultQuery("pagesize", "20"))
+	if err != nil || pageSizeNum < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page size"})
+		return
+	}
+
+	contents, err := h.DBClient.QueryEditorContent(contentType, status, pageNum, pageSizeNum)
+	if err != nil {
+		log.Printf("Failed to query editor content: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Query execution failed"})
+		return
+	}
+
+	items := []models.EditorContentItem{}
+	for _, content := range contents {
+		items = append(items, toItem(content))
+	}
+	c.JSON(http.StatusOK, models.EditorContentListResponse{Items: items})
+}
+
+// @Summary		Get content for review
+// @Description	Get news or a story whatever its status, with its body. News also has its sources and how each sentence relates to them. Editors only.
+// @Tags			editor
+// @Produce		json
+// @Param			content_type	query		string	true	"News or Story"
+// @Param			id				query		string	true	"Content ID"
+// @Success		200				{object}	models.EditorContentResponse
+// @Failure		403				{object}	models.ErrorResponse
+// @Failure		404				{object}	models.ErrorResponse
+// @Router			/editor/content/item [get]
+func (h *EditorHandler) GetContent(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+	if !h.CheckIsCorrectRole(c, userID, "editor") {
+		return
+	}
+
+	content := h.getContent(c, c.Query("content_type"), c.Query("id"))
+	if content == nil {
+		return
+	}
+
+	response := models.EditorContentResponse{EditorContentItem: toItem(*content)}
+	if content.ContentType == "News" {
+		news, err := h.Storage.PullContent(content.Language, content.CEFRLevel, content.Topic, "News", content.DateCreated)
+		if err != nil {
+			log.Printf("Failed to pull content from content store: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content data"})
+			return
+		}
+		response.Body = news.Content
+		response.Sources = news.Sources
+		response.SourceDiff = sourceDiff(content.Language, news.Content, news.Sources)
+	} else {
+		response.StoryPages = []string{}
+		for page := 0; page < content.Pages; page++ {
+			story, err := h.Storage.PullStoryByPage(content.Language, content.CEFRLevel, content.Topic, content.ID, page)
+			if err != nil {
+				log.Printf("Failed to pull story by page: %v", err)
+				c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content data"})
+				return
+			}
+			response.StoryPages = append(response.StoryPages, story.Content)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// @Summary		Edit content
+// @Description	Replace the title, preview text and body of news (body) or a story (story_pages). Fields that are not set are kept. Editing the body retires the questions and deletes the audiobook made from it; answers to retired questions are kept. Editors only.
+// @Tags			editor
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.EditorUpdateRequest	true	"Edit content request"
+// @Success		200		{object}	models.EditorContentItem
+// @Failure		400		{object}	models.ErrorResponse
+// @Failure		403		{object}	models.ErrorResponse
+// @Failure		404		{object}	models.ErrorResponse
+// @Router			/editor/content/update [post]
+func (h *EditorHandler) UpdateContent(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+	if !h.CheckIsCorrectRole(c, userID, "editor") {
+		return
+	}
+
+	var infoBody models.EditorUpdateRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	if infoBody.Body != nil && infoBody.ContentType != "News" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Only news has a body, set story_pages for stories"})
+		return
+	}
+	if infoBody.StoryPages != nil && (infoBody.ContentType != "Story" || len(infoBody.StoryPages) == 0) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "story_pages must be at least one page of a story"})
+		return
+	}
+
+	content := h.getContent(c, infoBody.ContentType, infoBody.ID)
+	if content == nil {
+		return
+	}
+
+	if infoBody.Body != nil {
+		news, err := h.Storage.PullContent(content.Language, content.CEFRLevel, content.Topic, "News", content.DateCreated)
+		if err != nil {
+			log.Printf("Failed to pull content from content store: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content data"})
+			return
+		}
+		// the dictionary is kept, words added by the edit have no translation
+		news.Content = *infoBody.Body
+		if err := h.Storage.PutContent(content.Language, content.CEFRLevel, content.Topic, "News", content.DateCreated, news); err != nil {
+			log.Printf("Failed to put content in content store: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save content data"})
+			return
+		}
+	}
+	if infoBody.StoryPages != nil {
+		if err := h.Storage.PutStoryPages(content.Language, content.CEFRLevel, content.Topic, content.ID, infoBody.StoryPages, content.Pages); err != nil {
+			log.Printf("Failed to put story pages in content store: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save content data"})
+			return
+		}
+		content.Pages = len(infoBody.StoryPages)
+	}
+	if infoBody.Body != nil || infoBody.StoryPages != nil {
+		// questions and audiobooks made from the old body would not match it
+		if err := h.DBClient.RetireDerivedContent(content.ContentType, content.ID); err != nil {
+			log.Printf("Failed to retire derived content: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update content"})
+			return
+		}
+	}
+
+	if infoBody.Title != nil {
+		content.Title = *infoBody.Title
+	}
+	if infoBody.PreviewText != nil {
+		content.PreviewText = *infoBody.PreviewText
+	}
+	if err := h.DBClient.UpdateContentText(content.ContentType, content.ID, content.Title, content.PreviewText, content.Pages); err != nil {
+		log.Printf("Failed to update content: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update content"})
+		return
+	}
+
+	var body string
+	if infoBody.Body != nil {
+		body = *infoBody.Body
+	} else if infoBody.StoryPages != nil {
+		body = strings.Join(infoBody.StoryPages, "\n\n")
+	}
+	if body != "" {
+		// search falls back to the old body text, the edit itself is saved
+		if err := h.DBClient.SetBodyText(content.ContentType, content.ID, strings.TrimSpace(stripmd.Strip(body))); err != nil {
+			log.Printf("Failed to update body text: %v", err)
+		}
+	}
+
+	now := time.Now()
+	content.EditedAt = &now
+	c.JSON(http.StatusOK, toItem(*content))
+}
+
+// setStatus moves content to status if it is in one of the from statuses.
+func (h *EditorHandler) setStatus(c *gin.Context, from []string, to string) {
+	userID := h.GetUserIDFromToken(c)
+	if !h.CheckIsCorrectRole(c, userID, "editor") {
+		return
+	}
+
+	var infoBody models.EditorStatusRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+
+	content := h.getContent(c, infoBody.ContentType, infoBody.ID)
+	if content == nil {
+		return
+	}
+
+	changed, err := h.DBClient.SetContentStatus(infoBody.ContentType, infoBody.ID, from, to, userID, infoBody.Note)
+	if err != nil {
+		log.Printf("Failed to set content status: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update content status"})
+		return
+	}
+	if !changed {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: fmt.Sprintf("Content in status %s cannot be moved to %s", content.Status, to)})
+		return
+	}
+
+	c.JSON(http.StatusOK, models.EditorStatusResponse{Status: to})
+}
+
+// @Summary		Publish content
+// @Description	Publish a draft, in review or retracted news article or story, making it visible to learners. Editors only.
+// @Tags			editor
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.EditorStatusRequest	true	"Publish request"
+// @Success		200		{object}	models.EditorStatusResponse
+// @Failure		403		{object}	models.ErrorResponse
+// @Failure		404		{object}	models.ErrorResponse
+// @Failure		409		{object}	models.ErrorResponse
+// @Router			/editor/content/publish [post]
+func (h *EditorHandler) PublishContent(c *gin.Context) {
+	h.setStatus(c, publishFrom, supabase.STATUS_PUBLISHED)
+}
+
+// @Summary		Retract content
+// @Description	Retract a news article or story, hiding it from learners. Editors only.
+// @Tags			editor
+// @Accept			json
+// @Produce		json
+// @Param			request	body		models.EditorStatusRequest	true	"Retract request"
+// @Success		200		{object}	models.EditorStatusResponse
+// @Failure		403		{object}	models.ErrorResponse
+// @Failure		404		{object}	models.ErrorResponse
+// @Failure		409		{object}	models.ErrorResponse
+// @Router			/editor/content/retract [post]
+func (h *EditorHandler) RetractContent(c *gin.Context) {
+	h.setStatus(c, retractFrom, supabase.STATUS_RETRACTED)
+}
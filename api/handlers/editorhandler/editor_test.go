@@ -0,0 +1,270 @@
+package editorhandler_test
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"squeak-shared/contentstore"
+
+	"story-api/handlers/editorhandler"
+	"story-api/handlers/handlertest"
+	"story-api/models"
+	"story-api/storage"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func newTestHandler(t *testing.T, db *fake.DB) (*editorhandler.EditorHandler, *storage.Client) {
+	t.Helper()
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("failed to create local store: %v", err)
+	}
+	client := storage.NewClient(store)
+	return editorhandler.New(db, client), client
+}
+
+const article = "# Le budget est voté\n\nLe Sénat a voté le budget mardi. Le ministre Pierre Martin a parlé pendant 45 minutes."
+
+func seedNews(t *testing.T, db *fake.DB, client *storage.Client, status string) string {
+	t.Helper()
+	id := db.AddNews(fake.Content{Title: "Budget", Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31", Status: status})
+	news := storage.News{
+		Content: article,
+		Sources: []storage.Source{{Title: "Senate passes budget", URL: "https://example.com/budget", Content: "The Sénat voted the budget on Tuesday after a long debate."}},
+	}
+	if err := client.PutContent("French", "B1", "Politics", "News", "2025-01-31", news); err != nil {
+		t.Fatalf("failed to seed content: %v", err)
+	}
+	return id
+}
+
+func TestEditorRoutesAreEditorOnly(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	recorder := handlertest.Do(t, h.GetContentList, http.MethodGet, "/editor/content", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+
+	recorder = handlertest.Do(t, h.PublishContent, http.MethodPost, "/editor/content/publish", "user", models.EditorStatusRequest{ContentType: "News", ID: "1"})
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+}
+
+func TestGetContentListDefaultsToInReview(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, _ := newTestHandler(t, db)
+
+	held := db.AddNews(fake.Content{Title: "held", Language: "French", Topic: "Politics", CEFRLevel: "B1", Status: supabase.STATUS_IN_REVIEW})
+	db.AddNews(fake.Content{Title: "live", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	draft := db.AddStory(fake.Content{Title: "draft", Language: "French", Topic: "Travel", CEFRLevel: "A1", Pages: 2, Status: supabase.STATUS_DRAFT})
+
+	recorder := handlertest.Do(t, h.GetContentList, http.MethodGet, "/editor/content", "editor", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.EditorContentListResponse
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Items) != 1 || response.Items[0].ID != held || response.Items[0].Status != supabase.STATUS_IN_REVIEW {
+		t.Fatalf("expected only the article in review, got %+v", response.Items)
+	}
+
+	recorder = handlertest.Do(t, h.GetContentList, http.MethodGet, "/editor/content?status=draft&content_type=Story", "editor", nil)
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Items) != 1 || response.Items[0].ID != draft || response.Items[0].Pages != 2 {
+		t.Fatalf("expected the draft story, got %+v", response.Items)
+	}
+
+	recorder = handlertest.Do(t, h.GetContentList, http.MethodGet, "/editor/content?status=deleted", "editor", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+}
+
+func TestGetContentShowsSourceDiff(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := seedNews(t, db, client, supabase.STATUS_IN_REVIEW)
+
+	recorder := handlertest.Do(t, h.GetContent, http.MethodGet, "/editor/content/item?content_type=News&id="+id, "editor", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.EditorContentResponse
+	handlertest.Decode(t, recorder, &response)
+
+	if response.Body != article || len(response.Sources) != 1 {
+		t.Fatalf("expected the body and sources, got %+v", response)
+	}
+	if len(response.SourceDiff) != 3 {
+		t.Fatalf("expected a heading and two sentences, got %+v", response.SourceDiff)
+	}
+	voted := response.SourceDiff[1]
+	if voted.Source != 0 || voted.Overlap == 0 || len(voted.Unsupported) != 0 {
+		t.Errorf("expected the vote to be supported by the source, got %+v", voted)
+	}
+	minister := response.SourceDiff[2]
+	if len(minister.Unsupported) != 3 || minister.Unsupported[0] != "Pierre" || minister.Unsupported[2] != "45" {
+		t.Errorf("expected the minister's name and the minutes to be unsupported, got %+v", minister)
+	}
+}
+
+func TestUpdateContentReplacesBodyAndTitle(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := seedNews(t, db, client, supabase.STATUS_IN_REVIEW)
+
+	title, body := "Le Sénat vote le budget", "# Le Sénat vote le budget\n\nLe Sénat a voté le budget mardi."
+	recorder := handlertest.Do(t, h.UpdateContent, http.MethodPost, "/editor/content/update", "editor",
+		models.EditorUpdateRequest{ContentType: "News", ID: id, Title: &title, Body: &body})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	content := db.Content("News", id)
+	if content.Title != title || content.PreviewText != "" || content.EditedAt == nil {
+		t.Errorf("expected only the title to change, got %+v", content)
+	}
+	if strings.Contains(content.Body, "#") || !strings.Contains(content.Body, "Le Sénat a voté le budget mardi.") {
+		t.Errorf("expected the plain body text to be searchable, got %q", content.Body)
+	}
+	news, err := client.PullContent("French", "B1", "Politics", "News", "2025-01-31")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if news.Content != body || len(news.Sources) != 1 {
+		t.Errorf("expected the body to be replaced and the sources kept, got %+v", news)
+	}
+
+	recorder = handlertest.Do(t, h.UpdateContent, http.MethodPost, "/editor/content/update", "editor",
+		models.EditorUpdateRequest{ContentType: "News", ID: id, StoryPages: []string{"page"}})
+	handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+}
+
+func TestUpdateContentKeepsAttempts(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := seedNews(t, db, client, supabase.STATUS_PUBLISHED)
+	question := db.AddQuestion("News", id, "comprehension", "B1", "Qui a voté le budget ?")
+	if _, err := db.InsertQuestionAttempt("user", question, "Le Sénat", "PASS", "", nil); err != nil {
+		t.Fatal(err)
+	}
+
+	body := "# Le Sénat vote le budget\n\nLe Sénat a voté le budget mardi."
+	recorder := handlertest.Do(t, h.UpdateContent, http.MethodPost, "/editor/content/update", "editor",
+		models.EditorUpdateRequest{ContentType: "News", ID: id, Body: &body})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	attempts, err := db.GetQuestionAttempts("user", "", "", 1, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(attempts) != 1 || attempts[0].Question.ID != question {
+		t.Errorf("expected the attempt to survive the edit, got %+v", attempts)
+	}
+	pool, err := db.GetQuestionPool("News", id, "comprehension", "B1", "user")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pool) != 0 {
+		t.Errorf("expected the question made from the old body to be retired, got %+v", pool)
+	}
+	stored, err := db.CreateContentQuestion("News", id, "comprehension", "B1", 0, "Quand le budget a-t-il été voté ?", nil, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.ID == question {
+		t.Errorf("expected a new question at the retired one's position, got %+v", stored)
+	}
+}
+
+func TestUpdateStoryPages(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := db.AddStory(fake.Content{Title: "Voyage", Language: "French", Topic: "Travel", CEFRLevel: "A1", Pages: 3, Status: supabase.STATUS_IN_REVIEW})
+
+	recorder := handlertest.Do(t, h.UpdateContent, http.MethodPost, "/editor/content/update", "editor",
+		models.EditorUpdateRequest{ContentType: "Story", ID: id, StoryPages: []string{"# Voyage\n\nPage un.", "Page deux."}})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	if pages := db.Content("Story", id).Pages; pages != 2 {
+		t.Errorf("expected 2 pages, got %d", pages)
+	}
+	if body := db.Content("Story", id).Body; !strings.HasSuffix(body, "Page un.\n\nPage deux.") {
+		t.Errorf("expected the pages in the body text, got %q", body)
+	}
+	page, err := client.PullStoryByPage("French", "A1", "Travel", id, 1)
+	if err != nil || page.Content != "Page deux." {
+		t.Errorf("expected the second page to be replaced, got %q (%v)", page.Content, err)
+	}
+	storyContext, err := client.PullStoryQNAContext("French", "A1", "Travel", id)
+	if err != nil || storyContext != contentstore.StoryContext("# Voyage\n\nPage un.\n\nPage deux.") || strings.Contains(storyContext, "#") {
+		t.Errorf("expected the context to be the whole story without markdown, got %q (%v)", storyContext, err)
+	}
+}
+
+func TestUpdateStoryToFewerPages(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := db.AddStory(fake.Content{Title: "Voyage", Language: "French", Topic: "Travel", CEFRLevel: "A1", Pages: 3})
+	if err := client.PutStoryPages("French", "A1", "Travel", id, []string{"Page un.", "Page deux.", "Page trois."}, 0); err != nil {
+		t.Fatal(err)
+	}
+	db.AddQuestion("Story", id, "vocab", "A1", "Que veut dire « trois » ?")
+	db.AddAudiobook("Story", id, "PREMIUM", 3)
+
+	recorder := handlertest.Do(t, h.UpdateContent, http.MethodPost, "/editor/content/update", "editor",
+		models.EditorUpdateRequest{ContentType: "Story", ID: id, StoryPages: []string{"Page un."}})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	if _, err := client.PullStoryByPage("French", "A1", "Travel", id, 0); err != nil {
+		t.Errorf("expected the first page to be kept: %v", err)
+	}
+	for page := 1; page < 3; page++ {
+		if _, err := client.PullStoryByPage("French", "A1", "Travel", id, page); !errors.Is(err, contentstore.ErrNotFound) {
+			t.Errorf("expected page %d to be deleted, got %v", page, err)
+		}
+	}
+	if pool, err := db.GetQuestionPool("Story", id, "vocab", "A1", "user"); err != nil || len(pool) != 0 {
+		t.Errorf("expected the questions on the old pages to be deleted, got %+v (%v)", pool, err)
+	}
+	if audiobook, err := db.GetAudiobook("story", id); err != nil || audiobook.Pages != 0 {
+		t.Errorf("expected the audiobook to be deleted, got %+v (%v)", audiobook, err)
+	}
+}
+
+func TestPublishAndRetract(t *testing.T) {
+	db := fake.New()
+	db.AddEditor("editor")
+	h, client := newTestHandler(t, db)
+	id := seedNews(t, db, client, supabase.STATUS_IN_REVIEW)
+
+	if record, _ := db.GetContentByID("News", id); record != nil {
+		t.Fatalf("expected content in review to be hidden")
+	}
+
+	recorder := handlertest.Do(t, h.PublishContent, http.MethodPost, "/editor/content/publish", "editor",
+		models.EditorStatusRequest{ContentType: "News", ID: id, Note: "checked"})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	if record, _ := db.GetContentByID("News", id); record == nil {
+		t.Fatalf("expected published content to be visible")
+	}
+	if content := db.Content("News", id); content.ReviewedBy != "editor" || content.ReviewNote != "checked" {
+		t.Errorf("expected the review to be recorded, got %+v", content)
+	}
+
+	recorder = handlertest.Do(t, h.PublishContent, http.MethodPost, "/editor/content/publish", "editor",
+		models.EditorStatusRequest{ContentType: "News", ID: id})
+	handlertest.ExpectStatus(t, recorder, http.StatusConflict)
+
+	recorder = handlertest.Do(t, h.RetractContent, http.MethodPost, "/editor/content/retract", "editor",
+		models.EditorStatusRequest{ContentType: "News", ID: id, Note: "wrong date"})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	results, err := db.QueryNews(supabase.QueryParams{Page: 1, PageSize: 10})
+	if err != nil || len(results) != 0 {
+		t.Errorf("expected retracted content to be hidden, got %v (%v)", results, err)
+	}
+
+	recorder = handlertest.Do(t, h.RetractContent, http.MethodPost, "/editor/content/retract", "editor",
+		models.EditorStatusRequest{ContentType: "News", ID: "404"})
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+}
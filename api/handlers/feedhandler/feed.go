@@ -0,0 +1,165 @@
+package feedhandler
+
+import (
+	"database/sql"
+	"encoding/base64"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/feed"
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/supabase"
+)
+
+const (
+	MAX_FEED_PAGE_SIZE = 50
+	// MAX_FEED_CANDIDATES is how many of the newest news and of the newest
+	// stories are ranked, so the feed ends after at most twice this many items.
+	MAX_FEED_CANDIDATES = 200
+)
+
+type FeedHandler struct {
+	*handlers.Handler
+}
+
+func New(dbClient supabase.Repository) *FeedHandler {
+	return &FeedHandler{
+		Handler: handlers.New(dbClient),
+	}
+}
+
+// A cursor is the time the first page was ranked at and the offset of the
+// next page. Ranking every page as of the same time keeps the order stable
+// while new content is published and the user reads.
+func encodeCursor(asOf time.Time, offset int) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("%d:%d", asOf.UnixMicro(), offset)))
+}
+
+func decodeCursor(cursor string) (time.Time, int, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+	var micros int64
+	var offset int
+	if _, err := fmt.Sscanf(string(raw), "%d:%d", &micros, &offset); err != nil {
+		return time.Time{}, 0, err
+	}
+	if offset < 0 {
+		return time.Time{}, 0, fmt.Errorf("negative offset: %d", offset)
+	}
+	return time.UnixMicro(micros).UTC(), offset, nil
+}
+
+//	@Summary		Get feed
+//	@Description	Get news and stories ranked for the user from their profile: content on their topics at their level first, with some content one level up, news and stories mixed and content they have read left out. Each item has its score and the reasons for it, and the weights used are returned with the page. Students only get content their teacher accepted.
+//	@Tags			feed
+//	@Produce		json
+//	@Param			pagesize	query		string	false	"Page size, 10 by default and at most 50"
+//	@Param			cursor		query		string	false	"next_cursor of the previous page"
+//	@Success		200			{object}	models.FeedResponse
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Failure		404			{object}	models.ErrorResponse
+//	@Router			/feed [get]
+func (h *FeedHandler) GetFeed(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pagesize", "10"))
+	if err != nil || pageSize < 1 || pageSize > MAX_FEED_PAGE_SIZE {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Page size must be between 1 and " + strconv.Itoa(MAX_FEED_PAGE_SIZE)})
+		return
+	}
+	asOf, offset := time.Now().UTC(), 0
+	if cursor := c.Query("cursor"); cursor != "" {
+		if asOf, offset, err = decodeCursor(cursor); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid cursor"})
+			return
+		}
+	}
+
+	profile, err := h.DBClient.GetProfile(userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Failed to retrieve profile",
+				Code:  "PROFILE_NOT_FOUND",
+			})
+			return
+		}
+		log.Printf("Failed to retrieve profile: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve profile"})
+		return
+	}
+	levels := feed.Levels(profile.SkillLevel)
+	if levels == nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Profile skill level must be a CEFR level"})
+		return
+	}
+
+	// students only see what their teacher accepted, as in /search
+	_, classroomID, err := h.DBClient.CheckStudentStatus(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to check student status"})
+		return
+	}
+
+	candidates, err := h.DBClient.GetFeedCandidates(userID, profile.LearningLanguage, levels, asOf, MAX_FEED_CANDIDATES, classroomID)
+	if err != nil {
+		log.Printf("Failed to retrieve feed candidates: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve feed"})
+		return
+	}
+	ranking := make([]feed.Candidate, len(candidates))
+	for i, candidate := range candidates {
+		ranking[i] = candidate.Candidate
+	}
+	weights := feed.DefaultWeights
+	ranked := feed.Rank(ranking, profile.SkillLevel, profile.InterestedTopics, weights, asOf)
+
+	response := models.FeedResponse{
+		Items: []models.FeedItem{},
+		Weights: models.FeedWeights{
+			Topic:      weights.Topic,
+			Level:      weights.Level,
+			LevelUp:    weights.LevelUp,
+			Recency:    weights.Recency,
+			InProgress: weights.InProgress,
+			RepeatType: weights.RepeatType,
+		},
+	}
+	end := min(offset+pageSize, len(ranked))
+	for _, item := range ranked[min(offset, end):end] {
+		candidate := candidates[item.Index]
+		status := supabase.READING_UNREAD
+		if candidate.InProgress {
+			status = supabase.READING_IN_PROGRESS
+		}
+		response.Items = append(response.Items, models.FeedItem{
+			ContentType:   candidate.ContentType,
+			ID:            candidate.ID,
+			Title:         candidate.Title,
+			Language:      candidate.Language,
+			Topic:         candidate.Topic,
+			CEFRLevel:     candidate.CEFRLevel,
+			PreviewText:   candidate.PreviewText,
+			DateCreated:   candidate.DateCreated,
+			CreatedAt:     candidate.CreatedAt.Format(time.RFC3339),
+			Pages:         candidate.Pages,
+			ReadingStatus: status,
+			Score:         math.Round(item.Score*100) / 100,
+			Reasons:       item.Reasons,
+		})
+	}
+	if end < len(ranked) {
+		response.NextCursor = encodeCursor(asOf, end)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,158 @@
+package feedhandler_test
+
+import (
+	"net/http"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"story-api/feed"
+	"story-api/handlers/feedhandler"
+	"story-api/handlers/handlertest"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func newTestDB(t *testing.T) *fake.DB {
+	t.Helper()
+	db := fake.New()
+	if _, err := db.UpsertProfile("user", &supabase.Profile{
+		Username:         "learner",
+		LearningLanguage: "French",
+		SkillLevel:       "B1",
+		InterestedTopics: []string{"NBA"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func hoursAgo(hours int) time.Time {
+	return time.Now().Add(-time.Duration(hours) * time.Hour)
+}
+
+func getFeed(t *testing.T, h *feedhandler.FeedHandler, query string) models.FeedResponse {
+	t.Helper()
+	recorder := handlertest.Do(t, h.GetFeed, http.MethodGet, "/feed"+query, "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.FeedResponse
+	handlertest.Decode(t, recorder, &response)
+	return response
+}
+
+func TestGetFeed(t *testing.T) {
+	db := newTestDB(t)
+	nba := db.AddNews(fake.Content{Title: "NBA", Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(1)})
+	music := db.AddNews(fake.Content{Title: "Musique", Language: "French", Topic: "Music", CEFRLevel: "B1", CreatedAt: hoursAgo(2)})
+	stretch := db.AddStory(fake.Content{Title: "Concert", Language: "French", Topic: "Music", CEFRLevel: "B2", CreatedAt: hoursAgo(3), Pages: 3})
+	started := db.AddStory(fake.Content{Title: "Voyage", Language: "French", Topic: "Travel", CEFRLevel: "B1", CreatedAt: hoursAgo(4), Pages: 2})
+	read := db.AddNews(fake.Content{Title: "Lu", Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(1)})
+	db.AddNews(fake.Content{Title: "Trop dur", Language: "French", Topic: "NBA", CEFRLevel: "C1", CreatedAt: hoursAgo(1)})
+	db.AddNews(fake.Content{Title: "Trop facile", Language: "French", Topic: "NBA", CEFRLevel: "A2", CreatedAt: hoursAgo(1)})
+	db.AddNews(fake.Content{Title: "Noticias", Language: "Spanish", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(1)})
+	db.AddNews(fake.Content{Title: "Brouillon", Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(1), Status: supabase.STATUS_DRAFT})
+	db.UpdateReadingSession("user", "Story", started, 0, 50, 60, false)
+	db.UpdateReadingSession("user", "News", read, 0, 100, 60, true)
+	h := feedhandler.New(db)
+
+	response := getFeed(t, h, "")
+
+	ids := []string{}
+	for _, item := range response.Items {
+		ids = append(ids, item.ContentType+"/"+item.ID)
+	}
+	if want := []string{"News/" + nba, "Story/" + started, "News/" + music, "Story/" + stretch}; !reflect.DeepEqual(ids, want) {
+		t.Fatalf("expected %v, got %v", want, ids)
+	}
+	if reasons := response.Items[0].Reasons; !reflect.DeepEqual(reasons, []string{feed.REASON_TOPIC, feed.REASON_LEVEL, feed.REASON_RECENT}) {
+		t.Errorf("unexpected reasons for the topic match: %v", reasons)
+	}
+	if item := response.Items[1]; item.ReadingStatus != supabase.READING_IN_PROGRESS || item.Reasons[len(item.Reasons)-1] != feed.REASON_IN_PROGRESS {
+		t.Errorf("expected the started story to be in progress, got %+v", item)
+	}
+	if item := response.Items[3]; item.Reasons[0] != feed.REASON_LEVEL_UP || item.Pages != 3 || item.ReadingStatus != supabase.READING_UNREAD {
+		t.Errorf("expected the B2 story to be one level up, got %+v", item)
+	}
+	if response.Weights.Topic != feed.DefaultWeights.Topic || response.Weights.RepeatType != feed.DefaultWeights.RepeatType {
+		t.Errorf("expected the default weights, got %+v", response.Weights)
+	}
+	if response.NextCursor != "" {
+		t.Errorf("expected a single page, got cursor %q", response.NextCursor)
+	}
+}
+
+func TestGetFeedCursor(t *testing.T) {
+	db := newTestDB(t)
+	for i := 0; i < 5; i++ {
+		db.AddNews(fake.Content{Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(i + 1)})
+	}
+	h := feedhandler.New(db)
+
+	first := getFeed(t, h, "?pagesize=2")
+	if len(first.Items) != 2 || first.NextCursor == "" {
+		t.Fatalf("expected a first page of 2, got %+v", first)
+	}
+
+	// neither new content nor reading moves items between pages
+	db.AddNews(fake.Content{Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: time.Now()})
+	second := getFeed(t, h, "?pagesize=2&cursor="+first.NextCursor)
+	db.UpdateReadingSession("user", "News", second.Items[0].ID, 0, 100, 60, true)
+	third := getFeed(t, h, "?pagesize=2&cursor="+second.NextCursor)
+
+	seen := map[string]bool{}
+	for _, page := range []models.FeedResponse{first, second, third} {
+		for _, item := range page.Items {
+			if seen[item.ID] {
+				t.Errorf("expected %s on one page only", item.ID)
+			}
+			seen[item.ID] = true
+		}
+	}
+	if len(seen) != 5 || len(third.Items) != 1 || third.NextCursor != "" {
+		t.Errorf("expected the 5 items in 3 pages, got %v and last page %+v", seen, third)
+	}
+
+	if fresh := getFeed(t, h, "?pagesize=10"); len(fresh.Items) != 5 {
+		t.Errorf("expected a new feed to have the new content and not the read news, got %+v", fresh.Items)
+	}
+}
+
+func TestGetFeedStudentSeesOnlyAccepted(t *testing.T) {
+	db := newTestDB(t)
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	db.AddStudent("user", classroomID)
+	accepted := db.AddStory(fake.Content{Title: "Voyage", Language: "French", Topic: "Travel", CEFRLevel: "B1", CreatedAt: hoursAgo(2), Pages: 2})
+	db.AddNews(fake.Content{Title: "NBA", Language: "French", Topic: "NBA", CEFRLevel: "B1", CreatedAt: hoursAgo(1)})
+	classroom, _ := strconv.Atoi(classroomID)
+	story, _ := strconv.Atoi(accepted)
+	if err := db.AcceptContent(classroom, "Story", story); err != nil {
+		t.Fatal(err)
+	}
+	h := feedhandler.New(db)
+
+	response := getFeed(t, h, "")
+
+	if len(response.Items) != 1 || response.Items[0].ContentType != "Story" || response.Items[0].ID != accepted {
+		t.Errorf("expected only the accepted story, got %+v", response.Items)
+	}
+}
+
+func TestGetFeedErrors(t *testing.T) {
+	db := newTestDB(t)
+	h := feedhandler.New(db)
+
+	for _, query := range []string{"?pagesize=0", "?pagesize=51", "?cursor=not-a-cursor"} {
+		recorder := handlertest.Do(t, h.GetFeed, http.MethodGet, "/feed"+query, "user", nil)
+		handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+	}
+
+	recorder := handlertest.Do(t, h.GetFeed, http.MethodGet, "/feed", "stranger", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+
+	if response := getFeed(t, h, ""); len(response.Items) != 0 {
+		t.Errorf("expected an empty feed, got %+v", response.Items)
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"fmt"
+	"log"
 	"net/http"
 	"story-api/models"
 	"story-api/plans"
@@ -11,10 +12,10 @@ import (
 )
 
 type Handler struct {
-	DBClient *supabase.Client
+	DBClient supabase.Repository
 }
 
-func New(dbClient *supabase.Client) *Handler {
+func New(dbClient supabase.Repository) *Handler {
 	return &Handler{
 		DBClient: dbClient,
 	}
@@ -133,3 +134,14 @@ func (h *Handler) CheckUsageLimit(c *gin.Context, userID string, featureID strin
 	}
 	return true
 }
+
+// ReadingStatuses returns the user's reading status of each content ID, or
+// nil if it cannot be read; query results are still worth returning without.
+func (h *Handler) ReadingStatuses(userID string, contentType string, contentIDs []string) map[string]string {
+	statuses, err := h.DBClient.GetReadingStatuses(userID, contentType, contentIDs)
+	if err != nil {
+		log.Printf("Failed to get reading statuses: %v", err)
+		return nil
+	}
+	return statuses
+}
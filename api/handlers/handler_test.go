@@ -0,0 +1,154 @@
+package handlers_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"story-api/handlers"
+	"story-api/handlers/handlertest"
+	"story-api/models"
+	"story-api/plans"
+	"story-api/supabase/fake"
+)
+
+func TestCheckUserPlan(t *testing.T) {
+	db := fake.New()
+
+	db.SetBillingPlan("premium-user", "PREMIUM")
+
+	orgID := db.AddOrganization("admin-user", "CLASSROOM")
+	teacherID := db.AddTeacher("teacher-user", orgID)
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	db.AddStudent("student-user", classroomID)
+
+	freeOrgID := db.AddOrganization("other-admin", "FREE")
+	db.AddTeacher("free-teacher", freeOrgID)
+
+	tests := []struct {
+		userID string
+		want   string
+	}{
+		{"new-user", "FREE"},
+		{"premium-user", "PREMIUM"},
+		{"teacher-user", "CLASSROOM"},
+		{"student-user", "CLASSROOM"},
+		{"free-teacher", "FREE"},
+	}
+
+	h := handlers.New(db)
+	for _, tt := range tests {
+		c, _ := handlertest.Context()
+		plan, err := h.CheckUserPlan(c, tt.userID)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tt.userID, err)
+		}
+		if plan != tt.want {
+			t.Errorf("%s: expected plan %s, got %s", tt.userID, tt.want, plan)
+		}
+	}
+}
+
+func TestCheckUserPlanBillingFailure(t *testing.T) {
+	db := fake.New()
+	db.Fail("GetBillingAccount", errors.New("connection reset"))
+
+	c, recorder := handlertest.Context()
+	if _, err := handlers.New(db).CheckUserPlan(c, "user"); err == nil {
+		t.Fatal("expected an error")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusInternalServerError)
+}
+
+func TestCheckUsageLimit(t *testing.T) {
+	db := fake.New()
+	h := handlers.New(db)
+
+	// FREE allows 20 natural TTS calls per period
+	for i := 0; i < 19; i++ {
+		db.InsertUsage("free-user", plans.NATURAL_TTS_FEATURE, 1)
+	}
+	c, _ := handlertest.Context()
+	if !h.CheckUsageLimit(c, "free-user", plans.NATURAL_TTS_FEATURE) {
+		t.Fatal("expected usage below the limit to be allowed")
+	}
+
+	db.InsertUsage("free-user", plans.NATURAL_TTS_FEATURE, 1)
+	c, recorder := handlertest.Context()
+	if h.CheckUsageLimit(c, "free-user", plans.NATURAL_TTS_FEATURE) {
+		t.Fatal("expected usage at the limit to be rejected")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+	var errResp models.ErrorResponse
+	handlertest.Decode(t, recorder, &errResp)
+	if errResp.Code != models.USAGE_LIMIT_REACHED {
+		t.Errorf("expected code %s, got %s", models.USAGE_LIMIT_REACHED, errResp.Code)
+	}
+}
+
+func TestCheckUsageLimitRestrictedFeature(t *testing.T) {
+	db := fake.New()
+
+	c, recorder := handlertest.Context()
+	if handlers.New(db).CheckUsageLimit(c, "free-user", plans.BASIC_AUDIOBOOKS_FEATURE) {
+		t.Fatal("expected FREE plan to be restricted from basic audiobooks")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+	var errResp models.ErrorResponse
+	handlertest.Decode(t, recorder, &errResp)
+	if errResp.Code != models.USAGE_RESTRICTED {
+		t.Errorf("expected code %s, got %s", models.USAGE_RESTRICTED, errResp.Code)
+	}
+}
+
+func TestCheckUsageLimitPaidPlansSkipMetering(t *testing.T) {
+	db := fake.New()
+	db.SetBillingPlan("premium-user", "PREMIUM")
+	db.Fail("GetUsage", errors.New("should not be called"))
+
+	c, _ := handlertest.Context()
+	if !handlers.New(db).CheckUsageLimit(c, "premium-user", plans.PREMIUM_AUDIOBOOKS_FEATURE) {
+		t.Fatal("expected paid plan to be allowed")
+	}
+}
+
+func TestCheckUsageLimitUsageFailure(t *testing.T) {
+	db := fake.New()
+	db.Fail("GetUsage", errors.New("connection reset"))
+
+	c, recorder := handlertest.Context()
+	if handlers.New(db).CheckUsageLimit(c, "free-user", plans.NATURAL_TTS_FEATURE) {
+		t.Fatal("expected failure to deny access")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusInternalServerError)
+}
+
+func TestRoleChecks(t *testing.T) {
+	db := fake.New()
+	orgID := db.AddOrganization("admin-user", "FREE")
+	teacherID := db.AddTeacher("teacher-user", orgID)
+	db.AddStudent("student-user", db.AddClassroom(teacherID, "Period 1"))
+	h := handlers.New(db)
+
+	c, _ := handlertest.Context()
+	if !h.CheckIsCorrectRole(c, "teacher-user", "teacher") {
+		t.Error("expected teacher to pass teacher check")
+	}
+
+	c, recorder := handlertest.Context()
+	if h.CheckIsCorrectRole(c, "student-user", "teacher") {
+		t.Error("expected student to fail teacher check")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+
+	c, _ = handlertest.Context()
+	if !h.CheckNotForbiddenRole(c, "regular-user", "student") {
+		t.Error("expected regular user to pass not-student check")
+	}
+
+	c, recorder = handlertest.Context()
+	if h.CheckNotForbiddenRole(c, "student-user", "student") {
+		t.Error("expected student to fail not-student check")
+	}
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+}
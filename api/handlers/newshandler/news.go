@@ -14,11 +14,13 @@ import (
 
 type NewsHandler struct {
 	*handlers.Handler
+	Storage *storage.Client
 }
 
-func New(dbClient *supabase.Client) *NewsHandler {
+func New(dbClient supabase.Repository, storageClient *storage.Client) *NewsHandler {
 	return &NewsHandler{
 		Handler: handlers.New(dbClient),
+		Storage: storageClient,
 	}
 }
 
@@ -71,8 +73,8 @@ func (h *NewsHandler) GetNews(c *gin.Context) {
 		return
 	}
 
-	// Get the content from s3
-	content, err := storage.PullContent(
+	// Get the content from the content store
+	content, err := h.Storage.PullContent(
 		contentRecord["language"].(string),
 		contentRecord["cefr_level"].(string),
 		contentRecord["topic"].(string),
@@ -80,7 +82,7 @@ func (h *NewsHandler) GetNews(c *gin.Context) {
 		contentRecord["date_created"].(string),
 	)
 	if err != nil {
-		log.Printf("Failed to pull content from S3 bucket: %v", err)
+		log.Printf("Failed to pull content from content store: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content data"})
 		return
 	}
@@ -179,5 +181,14 @@ func (h *NewsHandler) GetNewsQuery(c *gin.Context) {
 		return
 	}
 
+	ids := make([]string, len(response))
+	for i, item := range response {
+		ids[i] = item.ID
+	}
+	statuses := h.ReadingStatuses(userID, "News", ids)
+	for i := range response {
+		response[i].ReadingStatus = statuses[response[i].ID]
+	}
+
 	c.JSON(http.StatusOK, response)
 }
\ No newline at end of file
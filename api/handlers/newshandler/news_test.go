@@ -0,0 +1,194 @@
+package newshandler_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+
+	"squeak-shared/contentstore"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/newshandler"
+	"story-api/models"
+	"story-api/storage"
+	"story-api/supabase/fake"
+)
+
+func newTestHandler(t *testing.T, db *fake.DB) (*newshandler.NewsHandler, contentstore.ContentStore) {
+	t.Helper()
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("failed to create local store: %v", err)
+	}
+	return newshandler.New(db, storage.NewClient(store)), store
+}
+
+func TestGetNews(t *testing.T) {
+	db := fake.New()
+	h, store := newTestHandler(t, db)
+
+	id := db.AddNews(fake.Content{Title: "Titre", Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	key := contentstore.NewsKey("French", "B1", "Politics", "News", "2025-01-31")
+	body := `{"article":"Le contenu","dictionary":{"translations":{"words":{},"sentences":{}}},"sources":[]}`
+	if err := store.Put(context.Background(), key, []byte(body)); err != nil {
+		t.Fatalf("failed to seed content: %v", err)
+	}
+
+	recorder := handlertest.Do(t, h.GetNews, http.MethodGet, "/news?id="+id, "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	var response models.GetNewsResponse
+	handlertest.Decode(t, recorder, &response)
+	if response.Content != "Le contenu" || response.Title != "Titre" {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if len(response.Dictionary.Tokens) != 0 {
+		t.Errorf("expected no tokens for a legacy dictionary, got %+v", response.Dictionary.Tokens)
+	}
+}
+
+func TestGetNewsAnnotatedDictionary(t *testing.T) {
+	db := fake.New()
+	h, store := newTestHandler(t, db)
+
+	id := db.AddNews(fake.Content{Title: "Titre", Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	key := contentstore.NewsKey("French", "B1", "Politics", "News", "2025-01-31")
+	body := `{"article":"Nous mangeons.","dictionary":{"translations":{"words":{},"sentences":{}},` +
+		`"lemmas":{"manger":{"lemma":"manger","pos":"VERB","translation":"to eat"}},` +
+		`"tokens":[{"text":"mangeons","lemma":"manger","sentence":0,"gloss":"(we) eat"}]},"sources":[]}`
+	if err := store.Put(context.Background(), key, []byte(body)); err != nil {
+		t.Fatalf("failed to seed content: %v", err)
+	}
+
+	recorder := handlertest.Do(t, h.GetNews, http.MethodGet, "/news?id="+id, "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	var response models.GetNewsResponse
+	handlertest.Decode(t, recorder, &response)
+	entry, ok := response.Dictionary.Lookup("mangeons")
+	if !ok || entry.PartOfSpeech != "VERB" || entry.Translation != "to eat" {
+		t.Errorf("unexpected dictionary: %+v", response.Dictionary)
+	}
+	if response.Dictionary.Tokens[0].Gloss != "(we) eat" {
+		t.Errorf("unexpected token: %+v", response.Dictionary.Tokens[0])
+	}
+}
+
+func TestGetNewsNotFound(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	recorder := handlertest.Do(t, h.GetNews, http.MethodGet, "/news?id=404", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+}
+
+func TestNewsHeldForReviewIsHidden(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	published := db.AddNews(fake.Content{Title: "published", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	held := db.AddNews(fake.Content{Title: "held", Language: "French", Topic: "Politics", CEFRLevel: "B1", Status: "in_review"})
+
+	recorder := handlertest.Do(t, h.GetNewsQuery, http.MethodGet, "/news/query?language=French", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.GetNewsQueryResponse
+	handlertest.Decode(t, recorder, &response)
+	if len(response) != 1 || response[0].ID != published {
+		t.Fatalf("expected only the published article, got %+v", response)
+	}
+
+	recorder = handlertest.Do(t, h.GetNews, http.MethodGet, "/news?id="+held, "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+}
+
+func TestGetNewsClassroomWhitelist(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	db.AddStudent("student-user", db.AddClassroom(teacherID, "Period 1"))
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+
+	recorder := handlertest.Do(t, h.GetNews, http.MethodGet, "/news?id="+id, "student-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+}
+
+func TestGetNewsQueryStudentSeesOnlyAccepted(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	db.AddStudent("student-user", classroomID)
+
+	accepted := db.AddNews(fake.Content{Title: "accepted", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	db.AddNews(fake.Content{Title: "not accepted", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+
+	recorder := handlertest.Do(t, h.GetNewsQuery, http.MethodGet, "/news/query?language=French", "student-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.GetNewsQueryResponse
+	handlertest.Decode(t, recorder, &response)
+	if len(response) != 0 {
+		t.Fatalf("expected no content before acceptance, got %d items", len(response))
+	}
+
+	if err := db.AcceptContent(atoi(t, classroomID), "News", atoi(t, accepted)); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder = handlertest.Do(t, h.GetNewsQuery, http.MethodGet, "/news/query?language=French", "student-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	handlertest.Decode(t, recorder, &response)
+	if len(response) != 1 || response[0].ID != accepted {
+		t.Fatalf("expected only the accepted article, got %+v", response)
+	}
+
+	// regular users are not filtered
+	recorder = handlertest.Do(t, h.GetNewsQuery, http.MethodGet, "/news/query?language=French", "regular-user", nil)
+	handlertest.Decode(t, recorder, &response)
+	if len(response) != 2 {
+		t.Fatalf("expected both articles for a regular user, got %d", len(response))
+	}
+}
+
+func atoi(t *testing.T, s string) int {
+	t.Helper()
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		t.Fatalf("invalid id %q: %v", s, err)
+	}
+	return n
+}
+
+func TestGetNewsQueryReadingStatus(t *testing.T) {
+	db := fake.New()
+	h, _ := newTestHandler(t, db)
+
+	unread := db.AddNews(fake.Content{Title: "unread", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	started := db.AddNews(fake.Content{Title: "started", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	finished := db.AddNews(fake.Content{Title: "finished", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	db.UpdateReadingSession("user", "News", started, 0, 40, 60, false)
+	db.UpdateReadingSession("user", "News", finished, 0, 100, 60, true)
+
+	statuses := func(userID string) map[string]string {
+		t.Helper()
+		recorder := handlertest.Do(t, h.GetNewsQuery, http.MethodGet, "/news/query?language=French", userID, nil)
+		handlertest.ExpectStatus(t, recorder, http.StatusOK)
+		var response models.GetNewsQueryResponse
+		handlertest.Decode(t, recorder, &response)
+		statuses := map[string]string{}
+		for _, item := range response {
+			statuses[item.ID] = item.ReadingStatus
+		}
+		return statuses
+	}
+
+	got := statuses("user")
+	if got[unread] != "unread" || got[started] != "in_progress" || got[finished] != "read" {
+		t.Errorf("unexpected reading statuses %v", got)
+	}
+	if got := statuses("someone else"); got[finished] != "unread" {
+		t.Errorf("expected reading statuses to be per user, got %v", got)
+	}
+}
@@ -26,7 +26,7 @@ type OrganizationHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *OrganizationHandler {
+func New(dbClient supabase.Repository) *OrganizationHandler {
 	return &OrganizationHandler{
 		Handler: handlers.New(dbClient),
 	}
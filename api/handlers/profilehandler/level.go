@@ -0,0 +1,120 @@
+package profilehandler
+
+import (
+	"database/sql"
+	"log"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/cefr"
+	"story-api/models"
+	"story-api/supabase"
+)
+
+//	@Summary		Get estimated level
+//	@Description	Estimate the user's CEFR level in their learning language from the questions they pass, the content they finish and the words they look up at each level, and recommend moving their skill level up or down. Nothing is stored, see /profile/level/apply.
+//	@Tags			profile
+//	@Produce		json
+//	@Success		200	{object}	models.LevelEstimateResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Router			/profile/level [get]
+func (h *ProfileHandler) GetLevel(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+
+	profile, evidence, ok := h.getLevelEvidence(c, userID)
+	if !ok {
+		return
+	}
+	estimate := cefr.EstimateLevel(profile.SkillLevel, evidence)
+
+	c.JSON(http.StatusOK, levelResponse(profile, estimate, evidence, false))
+}
+
+//	@Summary		Apply estimated level
+//	@Description	Estimate the user's level as GET /profile/level does and store the estimate. With AUTO_LEVEL_ADJUST set, a confident estimate also replaces the skill level in their profile.
+//	@Tags			profile
+//	@Produce		json
+//	@Success		200	{object}	models.LevelEstimateResponse
+//	@Failure		404	{object}	models.ErrorResponse
+//	@Router			/profile/level/apply [post]
+func (h *ProfileHandler) ApplyLevel(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+
+	profile, evidence, ok := h.getLevelEvidence(c, userID)
+	if !ok {
+		return
+	}
+	estimate := cefr.EstimateLevel(profile.SkillLevel, evidence)
+
+	apply := h.autoLevelAdjust && estimate.Recommendation != cefr.RECOMMEND_STAY &&
+		estimate.Confidence >= cefr.AUTO_ADJUST_CONFIDENCE
+	applied, err := h.DBClient.SaveLevelEstimate(userID, profile.LearningLanguage, profile.SkillLevel, estimate, evidence, apply)
+	if err != nil {
+		log.Printf("Failed to save level estimate: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to estimate level"})
+		return
+	}
+
+	c.JSON(http.StatusOK, levelResponse(profile, estimate, evidence, applied))
+}
+
+// getLevelEvidence returns the profile of a user and their evidence in their
+// learning language, or writes the error response and returns false.
+func (h *ProfileHandler) getLevelEvidence(c *gin.Context, userID string) (*supabase.Profile, []cefr.Evidence, bool) {
+	profile, err := h.DBClient.GetProfile(userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{
+				Error: "Failed to retrieve profile",
+				Code:  "PROFILE_NOT_FOUND",
+			})
+			return nil, nil, false
+		}
+		log.Printf("Failed to retrieve profile: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve profile"})
+		return nil, nil, false
+	}
+
+	evidence, err := h.DBClient.GetLevelEvidence(userID, profile.LearningLanguage)
+	if err != nil {
+		log.Printf("Failed to retrieve level evidence: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to estimate level"})
+		return nil, nil, false
+	}
+	return profile, evidence, true
+}
+
+func levelResponse(profile *supabase.Profile, estimate cefr.Estimate, evidence []cefr.Evidence, applied bool) models.LevelEstimateResponse {
+	response := models.LevelEstimateResponse{
+		Language:       profile.LearningLanguage,
+		SkillLevel:     profile.SkillLevel,
+		DeclaredLevel:  profile.SkillLevel,
+		EstimatedLevel: estimate.Level,
+		Confidence:     math.Round(estimate.Confidence*100) / 100,
+		Recommendation: estimate.Recommendation,
+		Applied:        applied,
+		Evidence:       make([]models.LevelEvidence, 0, len(evidence)),
+	}
+	if applied {
+		response.SkillLevel = estimate.Level
+	}
+	for _, e := range evidence {
+		item := models.LevelEvidence{
+			Level:     e.Level,
+			Attempts:  e.Attempts,
+			Passed:    e.Passed,
+			Started:   e.Started,
+			Completed: e.Completed,
+			WordsRead: e.WordsRead,
+			Lookups:   e.Lookups,
+		}
+		if mastery, ok := e.Mastery(); ok {
+			mastery = math.Round(mastery*100) / 100
+			item.Mastery = &mastery
+		}
+		response.Evidence = append(response.Evidence, item)
+	}
+	return response
+}
@@ -0,0 +1,177 @@
+package profilehandler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/cefr"
+	"story-api/handlers/handlertest"
+	"story-api/handlers/profilehandler"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+// newLevelDB seeds a B1 learner of French who passed passed of attempts
+// questions on a B2 article.
+func newLevelDB(t *testing.T, attempts int, passed int) *fake.DB {
+	t.Helper()
+	db := fake.New()
+	if _, err := db.UpsertProfile("user", &supabase.Profile{Username: "lecteur", LearningLanguage: "French", SkillLevel: "B1"}); err != nil {
+		t.Fatal(err)
+	}
+	article := db.AddNews(fake.Content{Language: "French", Topic: "NBA", CEFRLevel: "B2"})
+	question := db.AddQuestion("News", article, "vocab", "B2", "Que veut dire « panier » ?")
+	for i := 0; i < attempts; i++ {
+		evaluation := "FAIL"
+		if i < passed {
+			evaluation = "PASS"
+		}
+		if _, err := db.InsertQuestionAttempt("user", question, "answer", evaluation, "", nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return db
+}
+
+func getLevel(t *testing.T, h *profilehandler.ProfileHandler) models.LevelEstimateResponse {
+	t.Helper()
+	return levelRequest(t, h.GetLevel, http.MethodGet, "/profile/level")
+}
+
+func applyLevel(t *testing.T, h *profilehandler.ProfileHandler) models.LevelEstimateResponse {
+	t.Helper()
+	return levelRequest(t, h.ApplyLevel, http.MethodPost, "/profile/level/apply")
+}
+
+func levelRequest(t *testing.T, handler gin.HandlerFunc, method string, path string) models.LevelEstimateResponse {
+	t.Helper()
+	recorder := handlertest.Do(t, handler, method, path, "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.LevelEstimateResponse
+	handlertest.Decode(t, recorder, &response)
+	return response
+}
+
+func TestGetLevelRecommendsMovingUp(t *testing.T) {
+	db := newLevelDB(t, 30, 27)
+	h := profilehandler.New(db, true)
+
+	response := getLevel(t, h)
+	if response.EstimatedLevel != "B2" || response.Recommendation != cefr.RECOMMEND_UP || response.Confidence != 0.75 {
+		t.Fatalf("expected a confident B2 estimate, got %+v", response)
+	}
+	if len(response.Evidence) != 1 || response.Evidence[0].Attempts != 30 || *response.Evidence[0].Mastery != 0.9 {
+		t.Errorf("unexpected evidence %+v", response.Evidence)
+	}
+	if response.Applied || response.SkillLevel != "B1" {
+		t.Errorf("expected GET to keep the profile, got %+v", response)
+	}
+	if profile, _ := db.GetProfile("user"); profile.SkillLevel != "B1" {
+		t.Errorf("expected the profile to stay B1, got %s", profile.SkillLevel)
+	}
+	if stored := db.StoredLevelEstimate("user", "French"); stored != nil {
+		t.Errorf("expected GET not to store the estimate, got %+v", stored)
+	}
+}
+
+func TestApplyLevelStoresEstimate(t *testing.T) {
+	db := newLevelDB(t, 30, 27)
+	h := profilehandler.New(db, false)
+
+	response := applyLevel(t, h)
+	if response.EstimatedLevel != "B2" || response.Applied || response.SkillLevel != "B1" {
+		t.Errorf("expected the profile to be kept without AUTO_LEVEL_ADJUST, got %+v", response)
+	}
+	if stored := db.StoredLevelEstimate("user", "French"); stored == nil || stored.Level != "B2" || stored.DeclaredLevel != "B1" || stored.Applied {
+		t.Errorf("expected the estimate to be stored, got %+v", stored)
+	}
+}
+
+func TestApplyLevelAutoAdjust(t *testing.T) {
+	db := newLevelDB(t, 30, 27)
+	h := profilehandler.New(db, true)
+
+	response := applyLevel(t, h)
+	if !response.Applied || response.SkillLevel != "B2" || response.DeclaredLevel != "B1" {
+		t.Fatalf("expected the estimate to replace the skill level, got %+v", response)
+	}
+	if profile, _ := db.GetProfile("user"); profile.SkillLevel != "B2" {
+		t.Errorf("expected the profile to be B2, got %s", profile.SkillLevel)
+	}
+	if stored := db.StoredLevelEstimate("user", "French"); stored == nil || !stored.Applied {
+		t.Errorf("expected the applied estimate to be stored, got %+v", stored)
+	}
+
+	// the estimate now matches the profile
+	if response := applyLevel(t, h); response.Recommendation != cefr.RECOMMEND_STAY || response.Applied {
+		t.Errorf("expected no further change, got %+v", response)
+	}
+}
+
+func TestApplyLevelAutoAdjustNeedsConfidence(t *testing.T) {
+	db := newLevelDB(t, 10, 10)
+	h := profilehandler.New(db, true)
+
+	response := applyLevel(t, h)
+	if response.Recommendation != cefr.RECOMMEND_UP || response.Applied || response.SkillLevel != "B1" {
+		t.Errorf("expected a recommendation without a change, got %+v", response)
+	}
+}
+
+func TestGetLevelReadingEvidence(t *testing.T) {
+	db := newLevelDB(t, 0, 0)
+	article := db.AddNews(fake.Content{Language: "French", Topic: "Music", CEFRLevel: "B1", Words: 200})
+	db.AddNews(fake.Content{Language: "French", Topic: "Music", CEFRLevel: "B1"})
+	spanish := db.AddNews(fake.Content{Language: "Spanish", Topic: "Music", CEFRLevel: "B1", Words: 200})
+	db.UpdateReadingSession("user", "News", article, 0, 100, 60, true)
+	db.UpdateReadingSession("user", "News", spanish, 0, 100, 60, true)
+	for _, word := range []string{"chanson", "scène"} {
+		if _, err := db.SaveVocabCard("user", supabase.VocabCard{Word: word, Language: "French", ContentType: "News", ContentID: article}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := profilehandler.New(db, false)
+
+	response := getLevel(t, h)
+	if len(response.Evidence) != 1 {
+		t.Fatalf("expected evidence at B1 only, got %+v", response.Evidence)
+	}
+	evidence := response.Evidence[0]
+	if evidence.Level != "B1" || evidence.Started != 1 || evidence.Completed != 1 || evidence.WordsRead != 200 || evidence.Lookups != 2 {
+		t.Errorf("unexpected evidence %+v", evidence)
+	}
+	if response.Recommendation != cefr.RECOMMEND_STAY || response.Confidence != 0 {
+		t.Errorf("expected too little evidence to estimate, got %+v", response)
+	}
+}
+
+func TestGetLevelCountsLookupsPerContent(t *testing.T) {
+	db := newLevelDB(t, 0, 0)
+	easy := db.AddNews(fake.Content{Language: "French", Topic: "Music", CEFRLevel: "A2", Words: 100})
+	hard := db.AddNews(fake.Content{Language: "French", Topic: "Music", CEFRLevel: "B1", Words: 200})
+	for _, article := range []string{easy, hard, hard} {
+		db.UpdateReadingSession("user", "News", article, 0, 100, 60, true)
+		if _, err := db.SaveVocabCard("user", supabase.VocabCard{Word: "chanson", Language: "French", ContentType: "News", ContentID: article}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := profilehandler.New(db, false)
+
+	response := getLevel(t, h)
+	lookups := map[string]int{}
+	for _, e := range response.Evidence {
+		lookups[e.Level] = e.Lookups
+	}
+	if lookups["A2"] != 1 || lookups["B1"] != 2 {
+		t.Errorf("expected each lookup to count against the article it was made in, got %+v", response.Evidence)
+	}
+}
+
+func TestGetLevelNotFound(t *testing.T) {
+	h := profilehandler.New(fake.New(), true)
+	handlertest.ExpectStatus(t, handlertest.Do(t, h.GetLevel, http.MethodGet, "/profile/level", "user", nil), http.StatusNotFound)
+	handlertest.ExpectStatus(t, handlertest.Do(t, h.ApplyLevel, http.MethodPost, "/profile/level/apply", "user", nil), http.StatusNotFound)
+}
@@ -14,11 +14,14 @@ import (
 
 type ProfileHandler struct {
 	*handlers.Handler
+	// replace the skill level with confident estimates, see ApplyLevel
+	autoLevelAdjust bool
 }
 
-func New(dbClient *supabase.Client) *ProfileHandler {
+func New(dbClient supabase.Repository, autoLevelAdjust bool) *ProfileHandler {
 	return &ProfileHandler{
-		Handler: handlers.New(dbClient),
+		Handler:         handlers.New(dbClient),
+		autoLevelAdjust: autoLevelAdjust,
 	}
 }
 
@@ -0,0 +1,64 @@
+package profilehandler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/profilehandler"
+	"story-api/models"
+	"story-api/supabase/fake"
+)
+
+func TestGetProfileNotFound(t *testing.T) {
+	h := profilehandler.New(fake.New(), false)
+
+	recorder := handlertest.Do(t, h.GetProfile, http.MethodGet, "/profile", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+	var errResp models.ErrorResponse
+	handlertest.Decode(t, recorder, &errResp)
+	if errResp.Code != models.PROFILE_NOT_FOUND {
+		t.Errorf("expected code %s, got %s", models.PROFILE_NOT_FOUND, errResp.Code)
+	}
+}
+
+func TestUpsertThenGetProfile(t *testing.T) {
+	h := profilehandler.New(fake.New(), false)
+
+	request := models.UpsertProfileRequest{
+		Username:           "lecteur",
+		LearningLanguage:   "French",
+		SkillLevel:         "B1",
+		InterestedTopics:   []string{"NBA"},
+		DailyQuestionsGoal: 3,
+	}
+	recorder := handlertest.Do(t, h.UpsertProfile, http.MethodPost, "/profile/upsert", "user", request)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	request.SkillLevel = "B2"
+	recorder = handlertest.Do(t, h.UpsertProfile, http.MethodPost, "/profile/upsert", "user", request)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	recorder = handlertest.Do(t, h.GetProfile, http.MethodGet, "/profile", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var profile models.GetProfileResponse
+	handlertest.Decode(t, recorder, &profile)
+	if profile.Username != "lecteur" || profile.SkillLevel != "B2" || len(profile.InterestedTopics) != 1 {
+		t.Errorf("unexpected profile: %+v", profile)
+	}
+}
+
+func TestUpsertProfileUsernameTaken(t *testing.T) {
+	h := profilehandler.New(fake.New(), false)
+
+	request := models.UpsertProfileRequest{
+		Username:         "lecteur",
+		LearningLanguage: "French",
+		SkillLevel:       "B1",
+		InterestedTopics: []string{},
+	}
+	handlertest.ExpectStatus(t, handlertest.Do(t, h.UpsertProfile, http.MethodPost, "/profile/upsert", "first-user", request), http.StatusOK)
+
+	recorder := handlertest.Do(t, h.UpsertProfile, http.MethodPost, "/profile/upsert", "second-user", request)
+	handlertest.ExpectStatus(t, recorder, http.StatusConflict)
+}
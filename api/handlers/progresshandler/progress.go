@@ -16,7 +16,7 @@ type ProgressHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *ProgressHandler {
+func New(dbClient supabase.Repository) *ProgressHandler {
 	return &ProgressHandler{
 		Handler: handlers.New(dbClient),
 	}
@@ -0,0 +1,59 @@
+package progresshandler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/progresshandler"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func TestIncrementProgressMeetsGoal(t *testing.T) {
+	db := fake.New()
+	db.UpsertProfile("user", &supabase.Profile{Username: "lecteur", DailyQuestionsGoal: 3})
+	h := progresshandler.New(db)
+
+	recorder := handlertest.Do(t, h.IncrementProgress, http.MethodGet, "/progress/increment?amount=2", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var progress models.IncrementProgressResponse
+	handlertest.Decode(t, recorder, &progress)
+	if progress.QuestionsCompleted != 2 || progress.GoalMet {
+		t.Fatalf("unexpected progress after 2 questions: %+v", progress)
+	}
+
+	recorder = handlertest.Do(t, h.IncrementProgress, http.MethodGet, "/progress/increment?amount=1", "user", nil)
+	handlertest.Decode(t, recorder, &progress)
+	if progress.QuestionsCompleted != 3 || !progress.GoalMet {
+		t.Fatalf("expected goal to be met after 3 questions: %+v", progress)
+	}
+}
+
+func TestIncrementProgressRejectsBadAmount(t *testing.T) {
+	h := progresshandler.New(fake.New())
+
+	for _, amount := range []string{"", "abc", "-1"} {
+		recorder := handlertest.Do(t, h.IncrementProgress, http.MethodGet, "/progress/increment?amount="+amount, "user", nil)
+		handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+	}
+}
+
+func TestGetStreak(t *testing.T) {
+	db := fake.New()
+	now := time.Now()
+	db.SetProgress("user", now.AddDate(0, 0, -3), 3, true)
+	db.SetProgress("user", now.AddDate(0, 0, -2), 3, true)
+	db.SetProgress("user", now.AddDate(0, 0, -1), 3, true)
+	h := progresshandler.New(db)
+
+	recorder := handlertest.Do(t, h.GetStreak, http.MethodGet, "/progress/streak", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var streak models.StreakResponse
+	handlertest.Decode(t, recorder, &streak)
+	if streak.Streak != 3 || streak.CompletedToday {
+		t.Errorf("expected a 3 day streak not yet extended today, got %+v", streak)
+	}
+}
@@ -0,0 +1,40 @@
+package qnahandler
+
+import (
+	"story-api/qna"
+	"story-api/supabase"
+)
+
+// pickQuestion returns the first question of a pool the user has not
+// answered, or else the failed question they answered longest ago. It
+// returns false if the user has passed every question.
+func pickQuestion(pool []supabase.PooledQuestion) (supabase.PooledQuestion, bool) {
+	for _, question := range pool {
+		if question.Attempts == 0 {
+			return question, true
+		}
+	}
+
+	var failed []supabase.PooledQuestion
+	for _, question := range pool {
+		if question.LastEvaluation == qna.EVALUATION_FAIL {
+			failed = append(failed, question)
+		}
+	}
+	if len(failed) == 0 {
+		return supabase.PooledQuestion{}, false
+	}
+	return rotateQuestion(failed), true
+}
+
+// rotateQuestion returns the question of a non-empty pool the user answered
+// longest ago.
+func rotateQuestion(pool []supabase.PooledQuestion) supabase.PooledQuestion {
+	oldest := pool[0]
+	for _, question := range pool[1:] {
+		if question.LastAttemptAt != nil && (oldest.LastAttemptAt == nil || question.LastAttemptAt.Before(*oldest.LastAttemptAt)) {
+			oldest = question
+		}
+	}
+	return oldest
+}
@@ -1,31 +1,43 @@
 package qnahandler
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
-	"os"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
 
-	"story-api/gemini"
+	"squeak-shared/qnagen"
+
+	"story-api/handlers"
 	"story-api/models"
+	"story-api/qna"
 	"story-api/storage"
 	"story-api/supabase"
 )
 
 type QNAHandler struct {
-	dbClient *supabase.Client
+	*handlers.Handler
+	Storage   *storage.Client
+	QNAClient *qna.Client
 }
 
-func New(dbClient *supabase.Client) *QNAHandler {
+func New(dbClient supabase.Repository, storageClient *storage.Client, qnaClient *qna.Client) *QNAHandler {
 	return &QNAHandler{
-		dbClient: dbClient,
+		Handler:   handlers.New(dbClient),
+		Storage:   storageClient,
+		QNAClient: qnaClient,
 	}
 }
 
 //	@Summary		Get or generate a question
-//	@Description	Get an existing question or generate a new one for the given content
+//	@Description	Get a question of the content the user has not answered or has failed, generating one if the pool needs more
 //	@Tags			qna
 //	@Accept			json
 //	@Produce		json
@@ -52,140 +64,295 @@ func (h *QNAHandler) GetQuestion(c *gin.Context) {
 		return
 	}
 
-	if infoBody.QuestionType != "vocab" && infoBody.QuestionType != "understanding" {
-		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Question type must be either 'vocab' or 'understanding'"})
+	if !isQuestionType(infoBody.QuestionType) {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Question type must be one of " + strings.Join(qnagen.QuestionTypes, ", ")})
 		return
 	}
 
-	questionData, err := h.dbClient.GetContentQuestion(infoBody.ContentType, infoBody.ID, infoBody.QuestionType, infoBody.CEFRLevel)
+	userID := h.GetUserIDFromToken(c)
+	pool, err := h.DBClient.GetQuestionPool(infoBody.ContentType, infoBody.ID, infoBody.QuestionType, infoBody.CEFRLevel, userID)
 	if err != nil {
-		log.Printf("Failed to retrieve question: %v", err)
+		log.Printf("Failed to retrieve question pool: %v", err)
 		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve question"})
 		return
 	}
 
-	if questionData == nil {
-		if infoBody.ContentType == "Story" {
-			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "No questions found for this story!"})
-			return
-		}
-
-		// Step 1: Get the record from supabase db
-		contentRecord, err := h.dbClient.GetContentByID(infoBody.ContentType, infoBody.ID)
-		if err != nil {
-			log.Printf("Failed to retrieve content record in DB: %v", err)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content"})
-			return
-		}
-		if contentRecord == nil {
+	question, ok := pickQuestion(pool)
+	if !ok && len(pool) < qnagen.POOL_SIZE {
+		// The generation lambda writes the pools when content is created,
+		// so this only runs for content it missed or once a user has passed
+		// every question of a pool.
+		generated, err := h.generateQuestion(infoBody, pool)
+		switch {
+		case err == nil:
+			question, ok = supabase.PooledQuestion{Question: generated}, true
+		case len(pool) > 0:
+			log.Printf("Failed to add to question pool, asking a question again: %v", err)
+		case err == errContentNotFound:
 			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Content not found"})
 			return
-		}
-
-		// Step 2: Get the content from s3
-		contentData, err := storage.PullContent(
-			contentRecord["language"].(string),
-			contentRecord["cefr_level"].(string),
-			contentRecord["topic"].(string),
-			infoBody.ContentType,
-			contentRecord["date_created"].(string),
-		)
-		if err != nil {
-			log.Printf("Failed to pull content from S3 bucket: %v", err)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content data during new question generation"})
-			return
-		}
-		contentString := contentData.Content
-
-		// Step 3: generate the question
-		apiKey := os.Getenv("GEMINI_API_KEY")
-		geminiClient, err := gemini.NewGeminiClient(apiKey)
-		if err != nil {
-			log.Printf("Failed to create Gemini client: %v", err)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to initialize gemini client"})
-			return
-		}
-		defer geminiClient.Client.Close()
-
-		var generatedQuestion string
-		var genErr error
-		if infoBody.QuestionType == "vocab" {
-			generatedQuestion, genErr = geminiClient.CreateVocabQuestion(infoBody.CEFRLevel, contentString)
-		} else {
-			generatedQuestion, genErr = geminiClient.CreateUnderstandingQuestion(infoBody.CEFRLevel, contentString)
-		}
-		if genErr != nil {
-			log.Printf("Failed to generate question: %v", genErr)
+		default:
+			log.Printf("Failed to generate question: %v", err)
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to generate question"})
 			return
 		}
+	}
+	if !ok {
+		question = rotateQuestion(pool)
+	}
 
-		// Step 4: save question to db
-		err = h.dbClient.CreateContentQuestion(infoBody.ContentType, infoBody.ID, infoBody.QuestionType, infoBody.CEFRLevel, generatedQuestion)
-		if err != nil {
-			log.Printf("Failed to save question to database: %v", err)
-			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save question to database"})
-			return
+	c.JSON(http.StatusOK, models.GetQuestionResponse{
+		QuestionID:     question.ID,
+		QuestionType:   question.QuestionType,
+		Question:       question.Question.Question,
+		Choices:        question.Choices,
+		Attempts:       question.Attempts,
+		LastEvaluation: question.LastEvaluation,
+	})
+}
+
+func isQuestionType(questionType string) bool {
+	for _, t := range qnagen.QuestionTypes {
+		if t == questionType {
+			return true
 		}
+	}
+	return false
+}
 
-		c.JSON(http.StatusOK, models.GetQuestionResponse{
-			Question: generatedQuestion,
-		})
-		return
+var errContentNotFound = errors.New("content not found")
+
+// generateQuestion writes a question that is not in pool yet and stores it at
+// the next position, keeping the question of a concurrent request if it
+// stored one there first.
+func (h *QNAHandler) generateQuestion(infoBody models.GetQuestionRequest, pool []supabase.PooledQuestion) (supabase.Question, error) {
+	contentRecord, err := h.DBClient.GetContentByID(infoBody.ContentType, infoBody.ID)
+	if err != nil {
+		return supabase.Question{}, fmt.Errorf("failed to retrieve content record in DB: %v", err)
+	}
+	if contentRecord == nil {
+		return supabase.Question{}, errContentNotFound
 	}
 
-	c.JSON(http.StatusOK, models.GetQuestionResponse{
-		Question: questionData["question"].(string),
-	})
+	language := contentRecord["language"].(string)
+	cefrLevel := contentRecord["cefr_level"].(string)
+	topic := contentRecord["topic"].(string)
+	var contentString string
+	if infoBody.ContentType == "Story" {
+		contentString, err = h.Storage.PullStoryQNAContext(language, cefrLevel, topic, infoBody.ID)
+	} else {
+		var contentData storage.News
+		contentData, err = h.Storage.PullContent(language, cefrLevel, topic, infoBody.ContentType, contentRecord["date_created"].(string))
+		contentString = contentData.Content
+	}
+	if err != nil {
+		return supabase.Question{}, fmt.Errorf("failed to pull content from content store: %v", err)
+	}
+
+	avoid := make([]string, 0, len(pool))
+	position := 0
+	for _, pooled := range pool {
+		avoid = append(avoid, pooled.Question.Question)
+		if pooled.Position >= position {
+			position = pooled.Position + 1
+		}
+	}
+	exercise, err := h.QNAClient.CreateQuestion(infoBody.QuestionType, infoBody.CEFRLevel, language, contentString, avoid)
+	if err != nil {
+		return supabase.Question{}, err
+	}
+
+	return h.DBClient.CreateContentQuestion(infoBody.ContentType, infoBody.ID, infoBody.QuestionType, infoBody.CEFRLevel, position, exercise.Question, exercise.Choices, exercise.Answer)
 }
 
 //	@Summary		Evaluate an answer
-//	@Description	Evaluate a user's answer to a question
+//	@Description	Evaluate a user's answer to a question. Answers to multiple_choice, cloze and true_false questions are sent as a models.ObjectiveAnswerRequest, graded against the question's answer key and answered with a models.ObjectiveAnswerResponse.
 //	@Tags			qna
 //	@Accept			json
 //	@Produce		json
 //	@Param			request	body		models.EvaluateAnswerRequest	true	"Answer evaluation request"
 //	@Success		200		{object}	models.EvaluateAnswerResponse
 //	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
 //	@Router			/qna/evaluate [post]
 func (h *QNAHandler) EvaluateAnswer(c *gin.Context) {
-	var infoBody models.EvaluateAnswerRequest
+	// the question decides which request body this is
+	var target struct {
+		QuestionID int `json:"question_id"`
+	}
+	if err := c.ShouldBindBodyWith(&target, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
 
-	if err := c.ShouldBindJSON(&infoBody); err != nil {
+	var question *supabase.Question
+	if target.QuestionID != 0 {
+		var err error
+		question, err = h.DBClient.GetQuestion(target.QuestionID)
+		if err != nil {
+			log.Printf("Failed to retrieve question: %v", err)
+			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve question"})
+			return
+		}
+		if question == nil {
+			c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Question not found"})
+			return
+		}
+		if question.AnswerKey != "" {
+			h.gradeAnswer(c, *question)
+			return
+		}
+	}
+
+	var infoBody models.EvaluateAnswerRequest
+	if err := c.ShouldBindBodyWith(&infoBody, binding.JSON); err != nil {
 		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
 		return
 	}
+	if question != nil {
+		infoBody.Question = question.Question
+	}
 
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	geminiClient, err := gemini.NewGeminiClient(apiKey)
+	evaluation, err := h.QNAClient.EvaluateAnswer(infoBody.CEFR, infoBody.Content, infoBody.Question, infoBody.Answer)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to initialize gemini client"})
+		log.Printf("Failed to evaluate answer: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Answer evaluation failed"})
 		return
 	}
-	defer geminiClient.Client.Close()
 
-	evaluation, err := geminiClient.EvaluateQNA(infoBody.CEFR, infoBody.Content, infoBody.Question, infoBody.Answer)
+	response := models.EvaluateAnswerResponse{
+		Evaluation:  evaluation.Evaluation,
+		Explanation: evaluation.Explanation,
+		Scores:      evaluation.Scores,
+		Errors:      evaluation.Errors,
+	}
+	if infoBody.QuestionID != 0 {
+		// the evaluation is still returned if it cannot be recorded
+		feedback, err := json.Marshal(evaluation.Feedback)
+		if err == nil {
+			response.AttemptID, err = h.DBClient.InsertQuestionAttempt(h.GetUserIDFromToken(c), infoBody.QuestionID, infoBody.Answer, evaluation.Evaluation, evaluation.Explanation, feedback)
+		}
+		if err != nil {
+			log.Printf("Failed to record question attempt: %v", err)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// gradeAnswer grades an answer to a question with an answer key and records
+// it, which costs no LLM call.
+func (h *QNAHandler) gradeAnswer(c *gin.Context, question supabase.Question) {
+	var infoBody models.ObjectiveAnswerRequest
+	if err := c.ShouldBindBodyWith(&infoBody, binding.JSON); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+
+	switch question.QuestionType {
+	case qnagen.TRUE_FALSE:
+		if !qnagen.Grade(qnagen.TRUE, infoBody.Answer) && !qnagen.Grade(qnagen.FALSE, infoBody.Answer) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Answer must be either 'true' or 'false'"})
+			return
+		}
+	case qnagen.MULTIPLE_CHOICE:
+		if !isChoice(question.Choices, infoBody.Answer) {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Answer must be one of the question's choices"})
+			return
+		}
+	}
+
+	evaluation, explanation := qna.GradeAnswer(question.AnswerKey, infoBody.Answer)
+	response := models.ObjectiveAnswerResponse{
+		Evaluation:    evaluation,
+		Correct:       evaluation == qna.EVALUATION_PASS,
+		CorrectAnswer: question.AnswerKey,
+		Explanation:   explanation,
+	}
+	// the grade is still returned if it cannot be recorded
+	attemptID, err := h.DBClient.InsertQuestionAttempt(h.GetUserIDFromToken(c), question.ID, infoBody.Answer, evaluation, explanation, nil)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Evaluation with Gemini failed"})
+		log.Printf("Failed to record question attempt: %v", err)
+	}
+	response.AttemptID = attemptID
+
+	c.JSON(http.StatusOK, response)
+}
+
+func isChoice(choices []string, answer string) bool {
+	for _, choice := range choices {
+		if qnagen.Grade(choice, answer) {
+			return true
+		}
+	}
+	return false
+}
+
+//	@Summary		Get answer history
+//	@Description	Get the user's answers to questions, newest first, optionally only for one piece of content
+//	@Tags			qna
+//	@Produce		json
+//	@Param			content_type	query		string	false	"News or Story, requires id"
+//	@Param			id				query		string	false	"Content ID"
+//	@Param			page			query		string	false	"Page"
+//	@Param			pagesize		query		string	false	"Page size"
+//	@Success		200				{object}	models.QNAHistoryResponse
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Router			/qna/history [get]
+func (h *QNAHandler) GetHistory(c *gin.Context) {
+	contentType := c.Query("content_type")
+	contentID := c.Query("id")
+	if contentType != "" && contentType != "News" && contentType != "Story" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Content type must be either 'News' or 'Story'"})
+		return
+	}
+	if (contentType == "") != (contentID == "") {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "content_type and id must be given together"})
 		return
 	}
 
-	// take only first 4 characters of evaluation, as its either PASS or FAIL
-	evaluationScore := ""
-	if len(evaluation) >= 4 {
-		evaluationScore = evaluation[:4]
-	} else {
-		evaluationScore = evaluation
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pagesize", "20"))
+	if err != nil || pageSize < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page size"})
+		return
 	}
 
-	explanation, err := geminiClient.GenerateQNAExplanation(infoBody.CEFR, infoBody.Content, infoBody.Question, infoBody.Answer, evaluationScore)
+	attempts, err := h.DBClient.GetQuestionAttempts(h.GetUserIDFromToken(c), contentType, contentID, page, pageSize)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Explanation with Gemini failed"})
+		log.Printf("Failed to retrieve question attempts: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve history"})
 		return
 	}
 
-	c.JSON(http.StatusOK, models.EvaluateAnswerResponse{
-		Evaluation:  evaluationScore,
-		Explanation: explanation,
-	})
+	response := models.QNAHistoryResponse{Attempts: make([]models.QNAAttempt, 0, len(attempts))}
+	for _, attempt := range attempts {
+		item := models.QNAAttempt{
+			AttemptID:    attempt.ID,
+			QuestionID:   attempt.Question.ID,
+			ContentType:  attempt.Question.ContentType,
+			ContentID:    attempt.Question.ContentID,
+			QuestionType: attempt.Question.QuestionType,
+			CEFRLevel:    attempt.Question.CEFRLevel,
+			Question:     attempt.Question.Question,
+			Answer:       attempt.Answer,
+			Evaluation:   attempt.Evaluation,
+			Explanation:  attempt.Explanation,
+			CreatedAt:    attempt.CreatedAt.Format(time.RFC3339),
+		}
+		if attempt.Feedback != nil {
+			var feedback qna.Feedback
+			if err := json.Unmarshal(attempt.Feedback, &feedback); err != nil {
+				log.Printf("Failed to decode feedback of attempt %d: %v", attempt.ID, err)
+			} else {
+				item.Scores, item.Errors = &feedback.Scores, feedback.Errors
+			}
+		}
+		response.Attempts = append(response.Attempts, item)
+	}
+	c.JSON(http.StatusOK, response)
 }
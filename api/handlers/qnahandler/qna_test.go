@@ -0,0 +1,394 @@
+package qnahandler_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"squeak-shared/contentstore"
+	"squeak-shared/textgen"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/qnahandler"
+	"story-api/models"
+	"story-api/qna"
+	"story-api/storage"
+	"story-api/supabase/fake"
+)
+
+func newTestHandler(t *testing.T, db *fake.DB, generator textgen.TextGenerator) *qnahandler.QNAHandler {
+	t.Helper()
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatalf("failed to create local store: %v", err)
+	}
+	key := contentstore.NewsKey("French", "B1", "Politics", "News", "2025-01-31")
+	if err := store.Put(context.Background(), key, []byte(`{"article":"Le président a parlé."}`)); err != nil {
+		t.Fatalf("failed to seed content: %v", err)
+	}
+	return qnahandler.New(db, storage.NewClient(store), qna.NewClient(generator))
+}
+
+func TestGetQuestionGeneratesAndStoresNewsQuestion(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "Qui a parlé ?", nil
+	}
+	h := newTestHandler(t, db, generator)
+
+	request := models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "understanding"}
+	for i := 0; i < 2; i++ {
+		recorder := handlertest.Do(t, h.GetQuestion, http.MethodPost, "/qna", "user", request)
+		handlertest.ExpectStatus(t, recorder, http.StatusOK)
+		var response models.GetQuestionResponse
+		handlertest.Decode(t, recorder, &response)
+		if response.Question != "Qui a parlé ?" {
+			t.Fatalf("unexpected question: %q", response.Question)
+		}
+	}
+
+	calls := generator.Calls()
+	if len(calls) != 1 {
+		t.Fatalf("expected the question to be generated once and then served from the db, got %d calls", len(calls))
+	}
+	if !strings.Contains(calls[0].Prompt, "Le président a parlé.") {
+		t.Errorf("expected the article in the prompt, got %q", calls[0].Prompt)
+	}
+}
+
+func TestGetQuestionGeneratesMissingStoryQuestion(t *testing.T) {
+	db := fake.New()
+	id := db.AddStory(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", Pages: 2})
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "What does voyage mean?\n", nil
+	}
+	h := newTestHandler(t, db, generator)
+	if err := h.Storage.PutStoryPages("French", "B1", "Politics", id, []string{"Le voyage commence."}, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	recorder := handlertest.Do(t, h.GetQuestion, http.MethodPost, "/qna", "user",
+		models.GetQuestionRequest{ContentType: "Story", ID: id, CEFRLevel: "B1", QuestionType: "vocab"})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.GetQuestionResponse
+	handlertest.Decode(t, recorder, &response)
+	if response.Question != "What does voyage mean?" {
+		t.Fatalf("unexpected question: %q", response.Question)
+	}
+	if calls := generator.Calls(); len(calls) != 1 || !strings.Contains(calls[0].Prompt, "Le voyage commence.") {
+		t.Errorf("expected the story context in the prompt, got %+v", calls)
+	}
+}
+
+func TestGetQuestionKeepsConcurrentlyStoredQuestion(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		// another request stores its question while this one is generating
+		if _, err := db.CreateContentQuestion("News", id, "understanding", "B1", 0, "Qui a voté ?", nil, ""); err != nil {
+			t.Fatal(err)
+		}
+		return "Qui a parlé ?", nil
+	}
+	h := newTestHandler(t, db, generator)
+
+	recorder := handlertest.Do(t, h.GetQuestion, http.MethodPost, "/qna", "user",
+		models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "understanding"})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.GetQuestionResponse
+	handlertest.Decode(t, recorder, &response)
+	if response.Question != "Qui a voté ?" {
+		t.Errorf("expected the first stored question to win, got %q", response.Question)
+	}
+}
+
+// evaluation is a well formed evaluation with a verdict.
+func evaluation(verdict string) string {
+	return `{"evaluation":"` + verdict + `","scores":{"comprehension":3,"grammar":3,"vocabulary":3},"errors":[],"explanation":"Explanation."}`
+}
+
+func getQuestion(t *testing.T, h *qnahandler.QNAHandler, userID string, request models.GetQuestionRequest) models.GetQuestionResponse {
+	t.Helper()
+	recorder := handlertest.Do(t, h.GetQuestion, http.MethodPost, "/qna", userID, request)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.GetQuestionResponse
+	handlertest.Decode(t, recorder, &response)
+	return response
+}
+
+func answer(t *testing.T, h *qnahandler.QNAHandler, userID string, questionID int, answer string) models.EvaluateAnswerResponse {
+	t.Helper()
+	recorder := handlertest.Do(t, h.EvaluateAnswer, http.MethodPost, "/qna/evaluate", userID, models.EvaluateAnswerRequest{
+		CEFR:       "B1",
+		Content:    "Le président a parlé.",
+		Question:   "ignored when question_id is set",
+		Answer:     answer,
+		QuestionID: questionID,
+	})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.EvaluateAnswerResponse
+	handlertest.Decode(t, recorder, &response)
+	return response
+}
+
+func TestGetQuestionRotatesThroughThePool(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	first := db.AddQuestion("News", id, "understanding", "B1", "Qui a parlé ?")
+	second := db.AddQuestion("News", id, "understanding", "B1", "Où ?")
+	third := db.AddQuestion("News", id, "understanding", "B1", "Quand ?")
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if strings.Contains(prompt, "Quand ?") {
+			return evaluation("FAIL"), nil
+		}
+		return evaluation("PASS"), nil
+	}
+	h := newTestHandler(t, db, generator)
+	request := models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "understanding"}
+
+	served := []int{}
+	for i := 0; i < 3; i++ {
+		question := getQuestion(t, h, "user", request)
+		if question.Attempts != 0 {
+			t.Fatalf("expected an unseen question, got %+v", question)
+		}
+		served = append(served, question.QuestionID)
+		if response := answer(t, h, "user", question.QuestionID, "réponse"); response.AttemptID == 0 {
+			t.Fatalf("expected the attempt to be recorded, got %+v", response)
+		}
+	}
+	if served[0] != first || served[1] != second || served[2] != third {
+		t.Fatalf("expected the pool in order, got %v", served)
+	}
+
+	// the failed question comes back, another user starts from the beginning
+	question := getQuestion(t, h, "user", request)
+	if question.QuestionID != third || question.Attempts != 1 || question.LastEvaluation != "FAIL" {
+		t.Errorf("expected the failed question again, got %+v", question)
+	}
+	if question := getQuestion(t, h, "other", request); question.QuestionID != first {
+		t.Errorf("expected another user to get the first question, got %+v", question)
+	}
+
+	// once everything is passed the full pool rotates, oldest first
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) { return evaluation("PASS"), nil }
+	answer(t, h, "user", third, "réponse")
+	if question := getQuestion(t, h, "user", request); question.QuestionID != first {
+		t.Errorf("expected the question answered longest ago, got %+v", question)
+	}
+}
+
+func TestGetQuestionGrowsThePoolOncePassed(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	first := db.AddQuestion("News", id, "vocab", "B1", "What does président mean?")
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if strings.Contains(prompt, "PASS or FAIL") {
+			return evaluation("PASS"), nil
+		}
+		return "What does parlé mean?", nil
+	}
+	h := newTestHandler(t, db, generator)
+	request := models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "vocab"}
+
+	answer(t, h, "user", first, "president")
+	question := getQuestion(t, h, "user", request)
+	if question.QuestionID == first || question.Question != "What does parlé mean?" {
+		t.Fatalf("expected a new question, got %+v", question)
+	}
+	prompt := generator.Calls()[len(generator.Calls())-1].Prompt
+	if !strings.Contains(prompt, "- What does président mean?") {
+		t.Errorf("expected the pool to be avoided, got %q", prompt)
+	}
+
+	// a repeated question falls back to the pool
+	answer(t, h, "user", question.QuestionID, "spoke")
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) { return "What does parlé mean?", nil }
+	if question := getQuestion(t, h, "user", request); question.QuestionID != first {
+		t.Errorf("expected the question answered longest ago, got %+v", question)
+	}
+}
+
+func TestGetHistory(t *testing.T) {
+	db := fake.New()
+	news := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	story := db.AddStory(fake.Content{Language: "French", Topic: "Travel", CEFRLevel: "B1", Pages: 1})
+	newsQuestion := db.AddQuestion("News", news, "understanding", "B1", "Qui a parlé ?")
+	storyQuestion := db.AddQuestion("Story", story, "vocab", "B1", "What does voyage mean?")
+	h := newTestHandler(t, db, textgen.NewFake())
+
+	answer(t, h, "user", newsQuestion, "Le président")
+	answer(t, h, "user", storyQuestion, "trip")
+	answer(t, h, "other", storyQuestion, "journey")
+
+	recorder := handlertest.Do(t, h.GetHistory, http.MethodGet, "/qna/history", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.QNAHistoryResponse
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Attempts) != 2 || response.Attempts[0].Answer != "trip" || response.Attempts[1].Question != "Qui a parlé ?" {
+		t.Fatalf("expected the user's attempts newest first, got %+v", response.Attempts)
+	}
+	if attempt := response.Attempts[1]; attempt.ContentType != "News" || attempt.ContentID != news || attempt.Evaluation != "PASS" {
+		t.Errorf("unexpected attempt: %+v", attempt)
+	}
+
+	recorder = handlertest.Do(t, h.GetHistory, http.MethodGet, "/qna/history?content_type=News&id="+news, "user", nil)
+	handlertest.Decode(t, recorder, &response)
+	if len(response.Attempts) != 1 || response.Attempts[0].QuestionID != newsQuestion {
+		t.Errorf("expected only the news attempt, got %+v", response.Attempts)
+	}
+
+	recorder = handlertest.Do(t, h.GetHistory, http.MethodGet, "/qna/history?content_type=News", "user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+}
+
+func TestEvaluateAnswer(t *testing.T) {
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return `{"evaluation":"FAIL","scores":{"comprehension":1,"grammar":4,"vocabulary":2},
+			"errors":[{"text":"Goodbye","correction":"Hello","type":"vocabulary","explanation":"Bonjour is a greeting."}],
+			"explanation":"Bonjour means hello."}`, nil
+	}
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	questionID := db.AddQuestion("News", id, "vocab", "B1", "What does 'bonjour' mean?")
+	h := newTestHandler(t, db, generator)
+
+	recorder := handlertest.Do(t, h.EvaluateAnswer, http.MethodPost, "/qna/evaluate", "user", models.EvaluateAnswerRequest{
+		CEFR:       "B1",
+		Content:    "Bonjour, comment ça va?",
+		Question:   "What does 'bonjour' mean?",
+		Answer:     "Oh, Goodbye",
+		QuestionID: questionID,
+	})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.EvaluateAnswerResponse
+	handlertest.Decode(t, recorder, &response)
+	if response.Evaluation != "FAIL" || response.Explanation != "Bonjour means hello." || response.Scores.Vocabulary != 2 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if len(response.Errors) != 1 || response.Errors[0].Start != 4 || response.Errors[0].End != 11 || response.Errors[0].Correction != "Hello" {
+		t.Errorf("expected the error to be located in the answer, got %+v", response.Errors)
+	}
+	if calls := generator.Calls(); len(calls) != 1 {
+		t.Errorf("expected a single evaluation call, got %d", len(calls))
+	}
+
+	recorder = handlertest.Do(t, h.GetHistory, http.MethodGet, "/qna/history", "user", nil)
+	var history models.QNAHistoryResponse
+	handlertest.Decode(t, recorder, &history)
+	if len(history.Attempts) != 1 || history.Attempts[0].Scores == nil || history.Attempts[0].Scores.Comprehension != 1 || len(history.Attempts[0].Errors) != 1 {
+		t.Errorf("expected the feedback in the history, got %+v", history.Attempts)
+	}
+}
+
+func TestEvaluateAnswerRejectsMalformedEvaluations(t *testing.T) {
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "PASS", nil
+	}
+	h := newTestHandler(t, fake.New(), generator)
+
+	recorder := handlertest.Do(t, h.EvaluateAnswer, http.MethodPost, "/qna/evaluate", "user", models.EvaluateAnswerRequest{
+		CEFR:     "B1",
+		Content:  "Bonjour, comment ça va?",
+		Question: "What does 'bonjour' mean?",
+		Answer:   "Hello",
+	})
+	handlertest.ExpectStatus(t, recorder, http.StatusInternalServerError)
+	if calls := generator.Calls(); len(calls) != qna.EVALUATION_ATTEMPTS {
+		t.Errorf("expected %d attempts, got %d", qna.EVALUATION_ATTEMPTS, len(calls))
+	}
+}
+
+func TestGetQuestionGeneratesMultipleChoice(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return `{"question": "Qui a parlé ?", "choices": ["Le président", "Le ministre", "Le maire", "Le juge"], "answer": 0}`, nil
+	}
+	h := newTestHandler(t, db, generator)
+
+	response := getQuestion(t, h, "user", models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "multiple_choice"})
+	if response.QuestionType != "multiple_choice" || response.Question != "Qui a parlé ?" || len(response.Choices) != 4 {
+		t.Fatalf("unexpected question %+v", response)
+	}
+	if strings.Contains(handlertest.Do(t, h.GetQuestion, http.MethodPost, "/qna", "user",
+		models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "multiple_choice"}).Body.String(), "answer_key") {
+		t.Error("expected the answer key not to be sent")
+	}
+	stored, _ := db.GetQuestion(response.QuestionID)
+	if stored == nil || stored.AnswerKey != "Le président" {
+		t.Errorf("expected the answer key to be stored, got %+v", stored)
+	}
+}
+
+func TestGetQuestionBuildsClozeWithoutLLM(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	generator := textgen.NewFake()
+	h := newTestHandler(t, db, generator)
+
+	response := getQuestion(t, h, "user", models.GetQuestionRequest{ContentType: "News", ID: id, CEFRLevel: "B1", QuestionType: "cloze"})
+	if response.Question != "Le _____ a parlé." {
+		t.Fatalf("unexpected question %+v", response)
+	}
+	if len(generator.Calls()) != 0 {
+		t.Error("expected cloze questions to need no LLM call")
+	}
+}
+
+func TestEvaluateObjectiveAnswer(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1", DateCreated: "2025-01-31"})
+	choiceID := db.AddExercise("News", id, "multiple_choice", "B1", "Qui a parlé ?", []string{"Le ministre", "Le président", "Le maire", "Le juge"}, "Le président")
+	statementID := db.AddExercise("News", id, "true_false", "B1", "Le président a parlé.", nil, "true")
+	generator := textgen.NewFake()
+	h := newTestHandler(t, db, generator)
+
+	grade := func(questionID int, answer string, status int) models.ObjectiveAnswerResponse {
+		t.Helper()
+		recorder := handlertest.Do(t, h.EvaluateAnswer, http.MethodPost, "/qna/evaluate", "user",
+			models.ObjectiveAnswerRequest{QuestionID: questionID, Answer: answer})
+		handlertest.ExpectStatus(t, recorder, status)
+		var response models.ObjectiveAnswerResponse
+		if status == http.StatusOK {
+			handlertest.Decode(t, recorder, &response)
+		}
+		return response
+	}
+
+	if response := grade(choiceID, "le président", http.StatusOK); !response.Correct || response.Evaluation != qna.EVALUATION_PASS || response.AttemptID == 0 {
+		t.Errorf("expected a correct answer, got %+v", response)
+	}
+	if response := grade(choiceID, "Le maire", http.StatusOK); response.Correct || response.Evaluation != qna.EVALUATION_FAIL || response.CorrectAnswer != "Le président" {
+		t.Errorf("expected a wrong answer with the correct one, got %+v", response)
+	}
+	grade(choiceID, "Le roi", http.StatusBadRequest)
+	if response := grade(statementID, "False", http.StatusOK); response.Correct {
+		t.Errorf("expected a wrong answer, got %+v", response)
+	}
+	grade(statementID, "maybe", http.StatusBadRequest)
+
+	if len(generator.Calls()) != 0 {
+		t.Error("expected answers with an answer key to be graded without an LLM call")
+	}
+	attempts, _ := db.GetQuestionAttempts("user", "", "", 1, 10)
+	if len(attempts) != 3 || attempts[0].Feedback != nil {
+		t.Errorf("expected the graded answers to be recorded without feedback, got %+v", attempts)
+	}
+
+	// free text questions still need the full request
+	freeID := db.AddQuestion("News", id, "understanding", "B1", "Qui a parlé ?")
+	recorder := handlertest.Do(t, h.EvaluateAnswer, http.MethodPost, "/qna/evaluate", "user",
+		models.ObjectiveAnswerRequest{QuestionID: freeID, Answer: "Le président"})
+	handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+}
@@ -0,0 +1,149 @@
+package readinghandler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/supabase"
+)
+
+const (
+	// MAX_SECONDS_PER_UPDATE caps the reading time one update can add, so a
+	// tab left open overnight does not count as hours of reading.
+	MAX_SECONDS_PER_UPDATE = 30 * 60
+	MAX_CONTINUE_ITEMS     = 50
+)
+
+type ReadingHandler struct {
+	*handlers.Handler
+}
+
+func New(dbClient supabase.Repository) *ReadingHandler {
+	return &ReadingHandler{
+		Handler: handlers.New(dbClient),
+	}
+}
+
+func readingProgressResponse(session supabase.ReadingSession) models.ReadingProgressResponse {
+	response := models.ReadingProgressResponse{
+		ContentType:      session.ContentType,
+		ID:               session.ContentID,
+		LastPage:         session.LastPage,
+		PercentComplete:  session.PercentComplete,
+		TimeSpentSeconds: session.TimeSpentSeconds,
+		ReadingStatus:    supabase.READING_IN_PROGRESS,
+		StartedAt:        session.StartedAt.Format(time.RFC3339),
+		UpdatedAt:        session.UpdatedAt.Format(time.RFC3339),
+	}
+	if session.CompletedAt != nil {
+		response.ReadingStatus = supabase.READING_READ
+		response.CompletedAt = session.CompletedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+//	@Summary		Update reading progress
+//	@Description	Record the page the user is on in a news article or story and the time they spent reading since the last update. Stories are complete on their last page, news when completed is set or percent_complete reaches 100.
+//	@Tags			reading
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.UpdateReadingProgressRequest	true	"Reading position"
+//	@Success		200		{object}	models.ReadingProgressResponse
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Router			/reading/progress [post]
+func (h *ReadingHandler) UpdateProgress(c *gin.Context) {
+	var infoBody models.UpdateReadingProgressRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+	if _, err := strconv.Atoi(infoBody.ID); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "ID must be a valid number"})
+		return
+	}
+
+	contentRecord, err := h.DBClient.GetContentByID(infoBody.ContentType, infoBody.ID)
+	if err != nil {
+		log.Printf("Failed to retrieve content record in DB: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve content"})
+		return
+	}
+	if contentRecord == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Content not found"})
+		return
+	}
+
+	percent := 0.0
+	if infoBody.ContentType == "Story" {
+		pages := contentRecord["pages"].(int)
+		if infoBody.Page >= pages {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Page must be less than " + strconv.Itoa(pages)})
+			return
+		}
+		percent = float64(infoBody.Page+1) * 100 / float64(pages)
+	} else if infoBody.Page != 0 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "News have a single page"})
+		return
+	}
+	if infoBody.PercentComplete != nil {
+		percent = *infoBody.PercentComplete
+	}
+	completed := infoBody.Completed || percent >= 100
+	if completed {
+		percent = 100
+	}
+
+	session, err := h.DBClient.UpdateReadingSession(h.GetUserIDFromToken(c), infoBody.ContentType, infoBody.ID,
+		infoBody.Page, percent, min(infoBody.SecondsSpent, MAX_SECONDS_PER_UPDATE), completed)
+	if err != nil {
+		log.Printf("Failed to update reading session: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to update reading progress"})
+		return
+	}
+
+	c.JSON(http.StatusOK, readingProgressResponse(session))
+}
+
+//	@Summary		Continue reading
+//	@Description	Get the news and stories the user has started but not finished, the most recently read first, with the page to resume from
+//	@Tags			reading
+//	@Produce		json
+//	@Param			limit	query		string	false	"Maximum number of items, 10 by default and at most 50"
+//	@Success		200		{object}	models.ContinueReadingResponse
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Router			/reading/continue [get]
+func (h *ReadingHandler) ContinueReading(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "10"))
+	if err != nil || limit < 1 || limit > MAX_CONTINUE_ITEMS {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Limit must be between 1 and " + strconv.Itoa(MAX_CONTINUE_ITEMS)})
+		return
+	}
+
+	entries, err := h.DBClient.GetReadingSessions(h.GetUserIDFromToken(c), true, limit)
+	if err != nil {
+		log.Printf("Failed to retrieve reading sessions: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve reading sessions"})
+		return
+	}
+
+	response := models.ContinueReadingResponse{Items: make([]models.ContinueReadingItem, 0, len(entries))}
+	for _, entry := range entries {
+		response.Items = append(response.Items, models.ContinueReadingItem{
+			ReadingProgressResponse: readingProgressResponse(entry.ReadingSession),
+			Title:                   entry.Title,
+			Language:                entry.Language,
+			Topic:                   entry.Topic,
+			CEFRLevel:               entry.CEFRLevel,
+			PreviewText:             entry.PreviewText,
+			Pages:                   entry.Pages,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,105 @@
+package readinghandler_test
+
+import (
+	"net/http"
+	"testing"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/readinghandler"
+	"story-api/models"
+	"story-api/supabase/fake"
+)
+
+func update(t *testing.T, h *readinghandler.ReadingHandler, request models.UpdateReadingProgressRequest, status int) models.ReadingProgressResponse {
+	t.Helper()
+	recorder := handlertest.Do(t, h.UpdateProgress, http.MethodPost, "/reading/progress", "user", request)
+	handlertest.ExpectStatus(t, recorder, status)
+	var response models.ReadingProgressResponse
+	if status == http.StatusOK {
+		handlertest.Decode(t, recorder, &response)
+	}
+	return response
+}
+
+func continueReading(t *testing.T, h *readinghandler.ReadingHandler, userID string) []models.ContinueReadingItem {
+	t.Helper()
+	recorder := handlertest.Do(t, h.ContinueReading, http.MethodGet, "/reading/continue", userID, nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.ContinueReadingResponse
+	handlertest.Decode(t, recorder, &response)
+	return response.Items
+}
+
+func TestStoryProgress(t *testing.T) {
+	db := fake.New()
+	id := db.AddStory(fake.Content{Title: "Le voyage", Language: "French", Topic: "Travel", CEFRLevel: "A2", Pages: 4})
+	h := readinghandler.New(db)
+
+	first := update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, Page: 1, SecondsSpent: 90}, http.StatusOK)
+	if first.LastPage != 1 || first.PercentComplete != 50 || first.TimeSpentSeconds != 90 || first.ReadingStatus != "in_progress" {
+		t.Fatalf("unexpected progress %+v", first)
+	}
+
+	// going back a page keeps the progress, idle time is capped
+	back := update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, Page: 0, SecondsSpent: 10 * 60 * 60}, http.StatusOK)
+	if back.LastPage != 0 || back.PercentComplete != 50 || back.TimeSpentSeconds != 90+readinghandler.MAX_SECONDS_PER_UPDATE {
+		t.Errorf("unexpected progress after going back %+v", back)
+	}
+
+	items := continueReading(t, h, "user")
+	if len(items) != 1 || items[0].ID != id || items[0].Title != "Le voyage" || items[0].Pages != 4 || items[0].LastPage != 0 {
+		t.Fatalf("expected the story to resume at page 0, got %+v", items)
+	}
+
+	last := update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, Page: 3}, http.StatusOK)
+	if last.ReadingStatus != "read" || last.PercentComplete != 100 || last.CompletedAt == "" {
+		t.Errorf("expected the last page to complete the story, got %+v", last)
+	}
+	if items := continueReading(t, h, "user"); len(items) != 0 {
+		t.Errorf("expected finished content not to be listed, got %+v", items)
+	}
+
+	// reading it again keeps it finished
+	if again := update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, Page: 0}, http.StatusOK); again.CompletedAt != last.CompletedAt {
+		t.Errorf("expected the completion time to be kept, got %+v", again)
+	}
+}
+
+func TestNewsProgress(t *testing.T) {
+	db := fake.New()
+	older := db.AddNews(fake.Content{Title: "older", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	newer := db.AddNews(fake.Content{Title: "newer", Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	held := db.AddNews(fake.Content{Title: "held", Language: "French", Topic: "Politics", CEFRLevel: "B1", Status: "in_review"})
+	h := readinghandler.New(db)
+
+	percent := 30.0
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: older, PercentComplete: &percent}, http.StatusOK)
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: newer, PercentComplete: &percent}, http.StatusOK)
+
+	items := continueReading(t, h, "user")
+	if len(items) != 2 || items[0].ID != newer || items[1].ID != older || items[0].PercentComplete != 30 {
+		t.Errorf("expected the most recently read first, got %+v", items)
+	}
+	if items := continueReading(t, h, "someone else"); len(items) != 0 {
+		t.Errorf("expected reading sessions to be per user, got %+v", items)
+	}
+
+	if done := update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: older, Completed: true}, http.StatusOK); done.ReadingStatus != "read" {
+		t.Errorf("expected the article to be read, got %+v", done)
+	}
+
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: held}, http.StatusNotFound)
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: newer, Page: 1}, http.StatusBadRequest)
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: "abc"}, http.StatusBadRequest)
+	over := 120.0
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "News", ID: newer, PercentComplete: &over}, http.StatusBadRequest)
+}
+
+func TestStoryProgressRejectsMissingPage(t *testing.T) {
+	db := fake.New()
+	id := db.AddStory(fake.Content{Language: "French", Topic: "Travel", CEFRLevel: "A2", Pages: 2})
+	h := readinghandler.New(db)
+
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, Page: 2}, http.StatusBadRequest)
+	update(t, h, models.UpdateReadingProgressRequest{ContentType: "Story", ID: id, SecondsSpent: -1}, http.StatusBadRequest)
+}
@@ -0,0 +1,120 @@
+package searchhandler
+
+import (
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/supabase"
+)
+
+const (
+	MAX_SEARCH_PAGE_SIZE    = 50
+	MAX_SEARCH_QUERY_LENGTH = 200
+)
+
+type SearchHandler struct {
+	*handlers.Handler
+}
+
+func New(dbClient supabase.Repository) *SearchHandler {
+	return &SearchHandler{
+		Handler: handlers.New(dbClient),
+	}
+}
+
+//	@Summary		Search content
+//	@Description	Full-text search over the title, preview and body of published news and stories in a language, best match first. Words are matched by their stem in French, Spanish and English. The query takes words, "quoted phrases", or and -excluded words. Students in a classroom only find content accepted in it.
+//	@Tags			search
+//	@Produce		json
+//	@Param			q				query		string	true	"Search query"
+//	@Param			language		query		string	true	"Language"
+//	@Param			cefr			query		string	false	"CEFR"
+//	@Param			subject			query		string	false	"Subject"
+//	@Param			content_type	query		string	false	"News or Story, both by default"
+//	@Param			page			query		string	false	"Page, 1 by default"
+//	@Param			pagesize		query		string	false	"Page size, 10 by default and at most 50"
+//	@Success		200				{object}	models.SearchResponse
+//	@Failure		400				{object}	models.ErrorResponse
+//	@Router			/search [get]
+func (h *SearchHandler) Search(c *gin.Context) {
+	userID := h.GetUserIDFromToken(c)
+
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" || utf8.RuneCountInString(query) > MAX_SEARCH_QUERY_LENGTH {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Query must be between 1 and " + strconv.Itoa(MAX_SEARCH_QUERY_LENGTH) + " characters"})
+		return
+	}
+	language := c.Query("language")
+	if language == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Language is required"})
+		return
+	}
+	contentType := c.Query("content_type")
+	if contentType != "" && contentType != "News" && contentType != "Story" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Content type must be News or Story"})
+		return
+	}
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pagesize", "10"))
+	if err != nil || pageSize < 1 || pageSize > MAX_SEARCH_PAGE_SIZE {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Page size must be between 1 and " + strconv.Itoa(MAX_SEARCH_PAGE_SIZE)})
+		return
+	}
+
+	params := supabase.SearchParams{
+		Query:       query,
+		Language:    language,
+		CEFR:        c.Query("cefr"),
+		Subject:     c.Query("subject"),
+		ContentType: contentType,
+		Page:        page,
+		PageSize:    pageSize,
+	}
+
+	// students only find what their teacher accepted, as in /news/query
+	_, classroomID, err := h.DBClient.CheckStudentStatus(userID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to check student status"})
+		return
+	}
+	params.ClassroomID = classroomID
+
+	results, err := h.DBClient.SearchContent(params)
+	if err != nil {
+		log.Printf("Failed to search content: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Search failed"})
+		return
+	}
+
+	response := models.SearchResponse{Results: make([]models.SearchResult, 0, len(results))}
+	for _, result := range results {
+		response.Results = append(response.Results, models.SearchResult{
+			ContentType: result.ContentType,
+			ID:          result.ID,
+			Title:       result.Title,
+			Language:    result.Language,
+			Topic:       result.Topic,
+			CEFRLevel:   result.CEFRLevel,
+			PreviewText: result.PreviewText,
+			DateCreated: result.DateCreated,
+			CreatedAt:   result.CreatedAt.Format(time.RFC3339),
+			Pages:       result.Pages,
+			Rank:        math.Round(result.Rank*1000) / 1000,
+			Snippet:     result.Snippet,
+		})
+	}
+	c.JSON(http.StatusOK, response)
+}
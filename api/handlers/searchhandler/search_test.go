@@ -0,0 +1,90 @@
+package searchhandler_test
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"testing"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/searchhandler"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func search(t *testing.T, h *searchhandler.SearchHandler, userID string, query url.Values) []models.SearchResult {
+	t.Helper()
+	recorder := handlertest.Do(t, h.Search, http.MethodGet, "/search?"+query.Encode(), userID, nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.SearchResponse
+	handlertest.Decode(t, recorder, &response)
+	return response.Results
+}
+
+func TestSearch(t *testing.T) {
+	db := fake.New()
+	title := db.AddNews(fake.Content{Title: "Les élections municipales", Language: "French", Topic: "Politics", CEFRLevel: "B1",
+		Body: "Les Français votent dimanche pour les élections municipales."})
+	body := db.AddStory(fake.Content{Title: "Le maire", Language: "French", Topic: "Politics", CEFRLevel: "A2", Pages: 2,
+		Body: "Le maire attend les élections."})
+	db.AddNews(fake.Content{Title: "Les élections", Language: "Spanish", Topic: "Politics", CEFRLevel: "B1"})
+	db.AddNews(fake.Content{Title: "Les élections", Language: "French", Topic: "Politics", CEFRLevel: "B1", Status: supabase.STATUS_DRAFT})
+	db.AddNews(fake.Content{Title: "Le match", Language: "French", Topic: "NBA", CEFRLevel: "B1", Body: "Les Lakers gagnent."})
+	h := searchhandler.New(db)
+
+	results := search(t, h, "user", url.Values{"q": {"élections"}, "language": {"French"}})
+	if len(results) != 2 || results[0].ID != title || results[1].ID != body {
+		t.Fatalf("expected the title match before the body match, got %+v", results)
+	}
+	if results[1].ContentType != "Story" || results[1].Pages != 2 || results[1].Snippet != "Le maire attend les <mark>élections</mark>." {
+		t.Errorf("unexpected story result %+v", results[1])
+	}
+
+	results = search(t, h, "user", url.Values{"q": {"élections"}, "language": {"French"}, "content_type": {"Story"}})
+	if len(results) != 1 || results[0].ID != body {
+		t.Errorf("expected only the story, got %+v", results)
+	}
+	results = search(t, h, "user", url.Values{"q": {"élections"}, "language": {"French"}, "cefr": {"B1"}, "pagesize": {"1"}, "page": {"2"}})
+	if len(results) != 0 {
+		t.Errorf("expected one B1 result on the first page only, got %+v", results)
+	}
+}
+
+func TestSearchStudentSeesOnlyAccepted(t *testing.T) {
+	db := fake.New()
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	db.AddStudent("student-user", classroomID)
+	accepted := db.AddNews(fake.Content{Title: "Lakers", Language: "French", Topic: "NBA", CEFRLevel: "B1"})
+	db.AddNews(fake.Content{Title: "Lakers encore", Language: "French", Topic: "NBA", CEFRLevel: "B1"})
+	classroom, _ := strconv.Atoi(classroomID)
+	news, _ := strconv.Atoi(accepted)
+	if err := db.AcceptContent(classroom, "News", news); err != nil {
+		t.Fatal(err)
+	}
+	h := searchhandler.New(db)
+
+	query := url.Values{"q": {"lakers"}, "language": {"French"}}
+	if results := search(t, h, "student-user", query); len(results) != 1 || results[0].ID != accepted {
+		t.Errorf("expected only the accepted article, got %+v", results)
+	}
+	if results := search(t, h, "regular-user", query); len(results) != 2 {
+		t.Errorf("expected regular users not to be filtered, got %+v", results)
+	}
+}
+
+func TestSearchValidation(t *testing.T) {
+	h := searchhandler.New(fake.New())
+
+	for _, query := range []url.Values{
+		{"language": {"French"}},
+		{"q": {"élections"}},
+		{"q": {"élections"}, "language": {"French"}, "content_type": {"Podcast"}},
+		{"q": {"élections"}, "language": {"French"}, "pagesize": {"51"}},
+		{"q": {"élections"}, "language": {"French"}, "page": {"0"}},
+	} {
+		recorder := handlertest.Do(t, h.Search, http.MethodGet, "/search?"+query.Encode(), "user", nil)
+		handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+	}
+}
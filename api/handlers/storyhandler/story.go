@@ -15,11 +15,13 @@ import (
 
 type StoryHandler struct {
 	*handlers.Handler
+	Storage *storage.Client
 }
 
-func New(dbClient *supabase.Client) *StoryHandler {
+func New(dbClient supabase.Repository, storageClient *storage.Client) *StoryHandler {
 	return &StoryHandler{
 		Handler: handlers.New(dbClient),
+		Storage: storageClient,
 	}
 }
 
@@ -56,7 +58,7 @@ func (h *StoryHandler) GetStoryPage(c *gin.Context) {
 		return
 	}
 	if classroomID != "" {
-		accepted, err := h.DBClient.CheckAcceptedContent(classroomID, "News", id)
+		accepted, err := h.DBClient.CheckAcceptedContent(classroomID, "Story", id)
 		if err != nil {
 			c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to check accepted content"})
 			return
@@ -79,8 +81,8 @@ func (h *StoryHandler) GetStoryPage(c *gin.Context) {
 		return
 	}
 
-	// Get the content from s3
-	content, err := storage.PullStoryByPage(
+	// Get the content from the content store
+	content, err := h.Storage.PullStoryByPage(
 		contentRecord["language"].(string),
 		contentRecord["cefr_level"].(string),
 		contentRecord["topic"].(string),
@@ -137,8 +139,8 @@ func (h *StoryHandler) GetStoryQNAContext(c *gin.Context) {
 		return
 	}
 
-	// Get the context from s3
-	context, err := storage.PullStoryQNAContext(
+	// Get the context from the content store
+	context, err := h.Storage.PullStoryQNAContext(
 		contentRecord["language"].(string),
 		contentRecord["cefr_level"].(string),
 		contentRecord["topic"].(string),
@@ -235,5 +237,14 @@ func (h *StoryHandler) GetStoryQuery(c *gin.Context) {
 		return
 	}
 
+	ids := make([]string, len(response))
+	for i, item := range response {
+		ids[i] = item.ID
+	}
+	statuses := h.ReadingStatuses(userID, "Story", ids)
+	for i := range response {
+		response[i].ReadingStatus = statuses[response[i].ID]
+	}
+
 	c.JSON(http.StatusOK, response)
 }
@@ -13,7 +13,7 @@ import (
 
 
 // We need to handle INDIVIDUAL vs ORGANIZATION by checking "Premium" vs "Classroom"
-func HandleCheckoutSessionCompleted(checkout stripe.CheckoutSession, dbClient *supabase.Client) {
+func HandleCheckoutSessionCompleted(checkout stripe.CheckoutSession, dbClient supabase.Repository) {
 	stripe.Key = os.Getenv("STRIPE_KEY")
 	userID := checkout.ClientReferenceID
 
@@ -11,7 +11,7 @@ import (
 	product "github.com/stripe/stripe-go/v81/product"
 )
 
-func HandleInvoicePaymentSucceeded(invoice stripe.Invoice, dbClient *supabase.Client) {
+func HandleInvoicePaymentSucceeded(invoice stripe.Invoice, dbClient supabase.Repository) {
 	stripe.Key = os.Getenv("STRIPE_KEY")
 	customerRef := invoice.Customer
 	subscriptionRef := invoice.Subscription
@@ -60,7 +60,7 @@ func HandleInvoicePaymentSucceeded(invoice stripe.Invoice, dbClient *supabase.Cl
 	log.Printf("HandleInvoicePaymentSucceeded: Neither Organization nor Individual mode!")
 }
 
-func HandleInvoicePaymentFailed(invoice stripe.Invoice, dbClient *supabase.Client) {
+func HandleInvoicePaymentFailed(invoice stripe.Invoice, dbClient supabase.Repository) {
 	stripe.Key = os.Getenv("STRIPE_KEY")
 	customerRef := invoice.Customer
 	subscriptionRef := invoice.Subscription
@@ -9,7 +9,7 @@ import (
 	product "github.com/stripe/stripe-go/v81/product"
 )
 
-func HandleSubscriptionUpdated(subscription stripe.Subscription, dbClient *supabase.Client) {
+func HandleSubscriptionUpdated(subscription stripe.Subscription, dbClient supabase.Repository) {
 	productRef := subscription.Items.Data[0].Plan.Product
 	prodParams:= &stripe.ProductParams{}
 	expandedProduct, _ := product.Get(productRef.ID, prodParams)
@@ -69,7 +69,7 @@ func HandleSubscriptionUpdated(subscription stripe.Subscription, dbClient *supab
 	log.Printf("HandleSubscriptionUpdated: Neither Organization nor Individual mode!")
 }
 
-func HandleSubscriptionDeleted(subscription stripe.Subscription, dbClient *supabase.Client) {
+func HandleSubscriptionDeleted(subscription stripe.Subscription, dbClient supabase.Repository) {
 	productRef := subscription.Items.Data[0].Plan.Product
 	prodParams:= &stripe.ProductParams{}
 	expandedProduct, _ := product.Get(productRef.ID, prodParams)
@@ -19,7 +19,7 @@ type StripeHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *StripeHandler {
+func New(dbClient supabase.Repository) *StripeHandler {
 	stripe.Key = os.Getenv("STRIPE_KEY")
 
 	return &StripeHandler{
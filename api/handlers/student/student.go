@@ -14,7 +14,7 @@ type StudentHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *StudentHandler {
+func New(dbClient supabase.Repository) *StudentHandler {
 	return &StudentHandler{
 		Handler: handlers.New(dbClient),
 	}
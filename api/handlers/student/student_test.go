@@ -0,0 +1,48 @@
+package student_test
+
+import (
+	"net/http"
+	"testing"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/student"
+	"story-api/models"
+	"story-api/supabase/fake"
+)
+
+func TestJoinClassroom(t *testing.T) {
+	db := fake.New()
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	h := student.New(db)
+
+	recorder := handlertest.Do(t, h.JoinClassroom, http.MethodPost, "/student/classroom/join", "student-user",
+		models.JoinClassroomRequest{ClassroomID: classroomID})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	recorder = handlertest.Do(t, h.CheckStudentStatus, http.MethodGet, "/student", "student-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var status models.StudentStatusResponse
+	handlertest.Decode(t, recorder, &status)
+	if status.ClassroomID != classroomID || status.Plan != "CLASSROOM" {
+		t.Errorf("unexpected student status: %+v", status)
+	}
+
+	recorder = handlertest.Do(t, h.GetClassroomInfo, http.MethodGet, "/student/classroom", "student-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var info models.GetStudentClassroomResponse
+	handlertest.Decode(t, recorder, &info)
+	if info.TeacherID != teacherID || info.StudentsCount != 1 {
+		t.Errorf("unexpected classroom info: %+v", info)
+	}
+}
+
+func TestTeacherCannotJoinClassroom(t *testing.T) {
+	db := fake.New()
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+
+	recorder := handlertest.Do(t, student.New(db).JoinClassroom, http.MethodPost, "/student/classroom/join", "teacher-user",
+		models.JoinClassroomRequest{ClassroomID: classroomID})
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+}
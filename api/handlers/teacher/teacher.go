@@ -16,7 +16,7 @@ type TeacherHandler struct {
 	*handlers.Handler
 }
 
-func New(dbClient *supabase.Client) *TeacherHandler {
+func New(dbClient supabase.Repository) *TeacherHandler {
 	return &TeacherHandler{
 		Handler: handlers.New(dbClient),
 	}
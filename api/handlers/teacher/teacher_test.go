@@ -0,0 +1,127 @@
+package teacher_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/teacher"
+	"story-api/models"
+	"story-api/supabase/fake"
+)
+
+type classroomFixture struct {
+	db          *fake.DB
+	handler     *teacher.TeacherHandler
+	classroomID string
+	newsID      string
+	storyID     string
+}
+
+func newClassroomFixture() classroomFixture {
+	db := fake.New()
+	teacherID := db.AddTeacher("teacher-user", db.AddOrganization("admin", "CLASSROOM"))
+	classroomID := db.AddClassroom(teacherID, "Period 1")
+	db.AddTeacher("other-teacher", db.AddOrganization("other-admin", "CLASSROOM"))
+	return classroomFixture{
+		db:          db,
+		handler:     teacher.New(db),
+		classroomID: classroomID,
+		newsID:      db.AddNews(fake.Content{Title: "news", Language: "French", Topic: "Politics", CEFRLevel: "B1"}),
+		storyID:     db.AddStory(fake.Content{Title: "story", Language: "French", Topic: "Politics", CEFRLevel: "B1", Pages: 3}),
+	}
+}
+
+func (f classroomFixture) query(t *testing.T, userID string, whitelist string) (int, models.QueryClassroomContentResponse) {
+	t.Helper()
+	path := "/teacher/classroom/content?content_type=All&whitelist=" + whitelist + "&classroom_id=" + f.classroomID
+	recorder := handlertest.Do(t, f.handler.QueryClassroomContent, http.MethodGet, path, userID, nil)
+	var response models.QueryClassroomContentResponse
+	if recorder.Code == http.StatusOK {
+		handlertest.Decode(t, recorder, &response)
+	}
+	return recorder.Code, response
+}
+
+func TestAcceptAndRejectContent(t *testing.T) {
+	f := newClassroomFixture()
+
+	_, accepted := f.query(t, "teacher-user", "accepted")
+	_, rejected := f.query(t, "teacher-user", "rejected")
+	if len(accepted) != 0 || len(rejected) != 2 {
+		t.Fatalf("expected everything to start rejected, got %d accepted and %d rejected", len(accepted), len(rejected))
+	}
+
+	recorder := handlertest.Do(t, f.handler.AcceptContent, http.MethodPost, "/teacher/classroom/accept", "teacher-user",
+		models.AcceptContentRequest{ClassroomID: f.classroomID, ContentType: "Story", ContentID: atoi(t, f.storyID)})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	_, accepted = f.query(t, "teacher-user", "accepted")
+	if len(accepted) != 1 || accepted[0].ID != f.storyID || accepted[0].ContentType != "Story" {
+		t.Fatalf("expected the story to be accepted, got %+v", accepted)
+	}
+
+	recorder = handlertest.Do(t, f.handler.RejectContent, http.MethodPost, "/teacher/classroom/reject", "teacher-user",
+		models.RejectContentRequest{ClassroomID: f.classroomID, ContentType: "Story", ContentID: atoi(t, f.storyID)})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+
+	_, accepted = f.query(t, "teacher-user", "accepted")
+	if len(accepted) != 0 {
+		t.Fatalf("expected nothing accepted after rejecting, got %+v", accepted)
+	}
+}
+
+func TestQueryClassroomContentRequiresOwnership(t *testing.T) {
+	f := newClassroomFixture()
+
+	if status, _ := f.query(t, "other-teacher", "accepted"); status != http.StatusForbidden {
+		t.Errorf("expected another teacher to be forbidden, got %d", status)
+	}
+	if status, _ := f.query(t, "regular-user", "accepted"); status != http.StatusForbidden {
+		t.Errorf("expected a non-teacher to be forbidden, got %d", status)
+	}
+}
+
+func TestAcceptContentRequiresTeacher(t *testing.T) {
+	f := newClassroomFixture()
+
+	recorder := handlertest.Do(t, f.handler.AcceptContent, http.MethodPost, "/teacher/classroom/accept", "regular-user",
+		models.AcceptContentRequest{ClassroomID: f.classroomID, ContentType: "News", ContentID: atoi(t, f.newsID)})
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+}
+
+func TestClassroomLifecycle(t *testing.T) {
+	f := newClassroomFixture()
+
+	recorder := handlertest.Do(t, f.handler.CreateClassroom, http.MethodPost, "/teacher/classroom/create", "teacher-user",
+		models.CreateClassroomRequest{Name: "Period 2"})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var created models.CreateClassroomResponse
+	handlertest.Decode(t, recorder, &created)
+
+	recorder = handlertest.Do(t, f.handler.GetClassroomList, http.MethodGet, "/teacher/classroom", "teacher-user", nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var list models.GetClassroomListResponse
+	handlertest.Decode(t, recorder, &list)
+	if len(list.Classrooms) != 2 {
+		t.Fatalf("expected 2 classrooms, got %+v", list.Classrooms)
+	}
+
+	recorder = handlertest.Do(t, f.handler.DeleteClassroom, http.MethodPost, "/teacher/classroom/delete", "other-teacher",
+		models.DeleteClassroomRequest{ClassroomID: created.ClassroomID})
+	handlertest.ExpectStatus(t, recorder, http.StatusForbidden)
+
+	recorder = handlertest.Do(t, f.handler.DeleteClassroom, http.MethodPost, "/teacher/classroom/delete", "teacher-user",
+		models.DeleteClassroomRequest{ClassroomID: created.ClassroomID})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+}
+
+func atoi(t *testing.T, s string) int {
+	t.Helper()
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		t.Fatalf("invalid id %q: %v", s, err)
+	}
+	return n
+}
@@ -0,0 +1,269 @@
+package vocabhandler
+
+import (
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/handlers"
+	"story-api/models"
+	"story-api/srs"
+	"story-api/supabase"
+)
+
+const MAX_DUE_CARDS = 100
+
+type VocabHandler struct {
+	*handlers.Handler
+}
+
+func New(dbClient supabase.Repository) *VocabHandler {
+	return &VocabHandler{
+		Handler: handlers.New(dbClient),
+	}
+}
+
+func vocabCardResponse(card supabase.VocabCard) models.VocabCard {
+	response := models.VocabCard{
+		CardID:       card.ID,
+		Word:         card.Word,
+		Language:     card.Language,
+		Sentence:     card.Sentence,
+		Translation:  card.Translation,
+		ContentType:  card.ContentType,
+		ContentID:    card.ContentID,
+		Lookups:      card.Lookups,
+		EaseFactor:   card.EaseFactor,
+		IntervalDays: card.IntervalDays,
+		Repetitions:  card.Repetitions,
+		DueAt:        card.DueAt.Format(time.RFC3339),
+		CreatedAt:    card.CreatedAt.Format(time.RFC3339),
+	}
+	if card.LastReviewedAt != nil {
+		response.LastReviewedAt = card.LastReviewedAt.Format(time.RFC3339)
+	}
+	return response
+}
+
+func vocabCardsResponse(cards []supabase.VocabCard) models.VocabCardsResponse {
+	response := models.VocabCardsResponse{Cards: make([]models.VocabCard, 0, len(cards))}
+	for _, card := range cards {
+		response.Cards = append(response.Cards, vocabCardResponse(card))
+	}
+	return response
+}
+
+// normalizeWord makes the words saved from "Chat," and "chat" the same card.
+func normalizeWord(word string) string {
+	return strings.ToLower(strings.TrimFunc(word, func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}))
+}
+
+//	@Summary		Save a word
+//	@Description	Add a word the user looked up to their vocabulary deck, due for review straight away. Saving a word already in the deck counts another lookup and keeps its schedule.
+//	@Tags			vocab
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.SaveVocabRequest	true	"Word to save"
+//	@Success		200		{object}	models.VocabCard
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Router			/vocab/save [post]
+func (h *VocabHandler) SaveWord(c *gin.Context) {
+	var infoBody models.SaveVocabRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+
+	word := normalizeWord(infoBody.Word)
+	if word == "" {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Word must contain letters"})
+		return
+	}
+	if (infoBody.ContentType == "") != (infoBody.ContentID == "") {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "content_type and content_id must be given together"})
+		return
+	}
+	if infoBody.ContentID != "" {
+		if _, err := strconv.Atoi(infoBody.ContentID); err != nil {
+			c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Content ID must be a valid number"})
+			return
+		}
+	}
+
+	card, err := h.DBClient.SaveVocabCard(h.GetUserIDFromToken(c), supabase.VocabCard{
+		Word:        word,
+		Language:    infoBody.Language,
+		Sentence:    strings.TrimSpace(infoBody.Sentence),
+		Translation: strings.TrimSpace(infoBody.Translation),
+		ContentType: infoBody.ContentType,
+		ContentID:   infoBody.ContentID,
+	})
+	if err != nil {
+		log.Printf("Failed to save vocab card: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to save word"})
+		return
+	}
+
+	c.JSON(http.StatusOK, vocabCardResponse(card))
+}
+
+//	@Summary		Get vocabulary deck
+//	@Description	Get the words the user saved, newest first
+//	@Tags			vocab
+//	@Produce		json
+//	@Param			language	query		string	false	"Only words of this language"
+//	@Param			page		query		string	false	"Page"
+//	@Param			pagesize	query		string	false	"Page size"
+//	@Success		200			{object}	models.VocabCardsResponse
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Router			/vocab [get]
+func (h *VocabHandler) GetCards(c *gin.Context) {
+	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if err != nil || page < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page number"})
+		return
+	}
+	pageSize, err := strconv.Atoi(c.DefaultQuery("pagesize", "20"))
+	if err != nil || pageSize < 1 {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid page size"})
+		return
+	}
+
+	cards, err := h.DBClient.GetVocabCards(h.GetUserIDFromToken(c), c.Query("language"), nil, page, pageSize)
+	if err != nil {
+		log.Printf("Failed to retrieve vocab cards: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve vocabulary"})
+		return
+	}
+	c.JSON(http.StatusOK, vocabCardsResponse(cards))
+}
+
+//	@Summary		Get due cards
+//	@Description	Get the user's cards that are due for review, the longest overdue first
+//	@Tags			vocab
+//	@Produce		json
+//	@Param			language	query		string	false	"Only words of this language"
+//	@Param			limit		query		string	false	"Maximum number of cards, 20 by default and at most 100"
+//	@Success		200			{object}	models.VocabCardsResponse
+//	@Failure		400			{object}	models.ErrorResponse
+//	@Router			/vocab/due [get]
+func (h *VocabHandler) GetDueCards(c *gin.Context) {
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "20"))
+	if err != nil || limit < 1 || limit > MAX_DUE_CARDS {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Limit must be between 1 and " + strconv.Itoa(MAX_DUE_CARDS)})
+		return
+	}
+
+	now := time.Now().UTC()
+	cards, err := h.DBClient.GetVocabCards(h.GetUserIDFromToken(c), c.Query("language"), &now, 1, limit)
+	if err != nil {
+		log.Printf("Failed to retrieve due vocab cards: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve vocabulary"})
+		return
+	}
+	c.JSON(http.StatusOK, vocabCardsResponse(cards))
+}
+
+//	@Summary		Review a card
+//	@Description	Grade a review of a card from 0 to 5 and schedule its next review. Only cards that are due can be reviewed. Each review counts as a completed question towards the daily goal.
+//	@Tags			vocab
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.VocabReviewRequest	true	"Review grade"
+//	@Success		200		{object}	models.VocabReviewResponse
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Failure		409		{object}	models.ErrorResponse
+//	@Router			/vocab/review [post]
+func (h *VocabHandler) ReviewCard(c *gin.Context) {
+	var infoBody models.VocabReviewRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body, grade must be between 0 and 5"})
+		return
+	}
+
+	userID := h.GetUserIDFromToken(c)
+	card, err := h.DBClient.GetVocabCard(userID, infoBody.CardID)
+	if err != nil {
+		log.Printf("Failed to retrieve vocab card: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to retrieve card"})
+		return
+	}
+	if card == nil {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Card not found"})
+		return
+	}
+
+	now := time.Now().UTC()
+	if card.DueAt.After(now) {
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Card is not due for review until " + card.DueAt.Format(time.RFC3339)})
+		return
+	}
+	card.Card, err = srs.Review(card.Card, *infoBody.Grade, now)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: err.Error()})
+		return
+	}
+	found, err := h.DBClient.ReviewVocabCard(userID, card.ID, *infoBody.Grade, card.Card, now)
+	if err != nil {
+		log.Printf("Failed to record vocab review: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to record review"})
+		return
+	}
+	if !found {
+		// reviewed or deleted since it was read
+		c.JSON(http.StatusConflict, models.ErrorResponse{Error: "Card is no longer due for review"})
+		return
+	}
+	card.LastReviewedAt = &now
+
+	response := models.VocabReviewResponse{Card: vocabCardResponse(*card)}
+	// the review is recorded even if the daily progress cannot be updated
+	if err := h.DBClient.IncrementQuestionsCompleted(userID, 1); err != nil {
+		log.Printf("Failed to count vocab review towards daily progress: %v", err)
+	} else if progress, err := h.DBClient.GetTodayProgress(userID); err != nil {
+		log.Printf("Failed to get updated progress: %v", err)
+	} else {
+		response.QuestionsCompleted = progress.QuestionsCompleted
+		response.GoalMet = progress.GoalMet
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+//	@Summary		Delete a card
+//	@Description	Remove a word and its reviews from the user's deck
+//	@Tags			vocab
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		models.DeleteVocabRequest	true	"Card to delete"
+//	@Success		200		{object}	models.DeleteVocabResponse
+//	@Failure		400		{object}	models.ErrorResponse
+//	@Failure		404		{object}	models.ErrorResponse
+//	@Router			/vocab/delete [post]
+func (h *VocabHandler) DeleteCard(c *gin.Context) {
+	var infoBody models.DeleteVocabRequest
+	if err := c.ShouldBindJSON(&infoBody); err != nil {
+		c.JSON(http.StatusBadRequest, models.ErrorResponse{Error: "Invalid request body"})
+		return
+	}
+
+	deleted, err := h.DBClient.DeleteVocabCard(h.GetUserIDFromToken(c), infoBody.CardID)
+	if err != nil {
+		log.Printf("Failed to delete vocab card: %v", err)
+		c.JSON(http.StatusInternalServerError, models.ErrorResponse{Error: "Failed to delete card"})
+		return
+	}
+	if !deleted {
+		c.JSON(http.StatusNotFound, models.ErrorResponse{Error: "Card not found"})
+		return
+	}
+	c.JSON(http.StatusOK, models.DeleteVocabResponse{Message: "Card deleted successfully"})
+}
@@ -0,0 +1,160 @@
+package vocabhandler_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/handlers/handlertest"
+	"story-api/handlers/vocabhandler"
+	"story-api/models"
+	"story-api/supabase"
+	"story-api/supabase/fake"
+)
+
+func save(t *testing.T, h *vocabhandler.VocabHandler, userID string, request models.SaveVocabRequest) models.VocabCard {
+	t.Helper()
+	recorder := handlertest.Do(t, h.SaveWord, http.MethodPost, "/vocab/save", userID, request)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var card models.VocabCard
+	handlertest.Decode(t, recorder, &card)
+	return card
+}
+
+func cards(t *testing.T, h *vocabhandler.VocabHandler, handler func(c *gin.Context), path string, userID string) []models.VocabCard {
+	t.Helper()
+	recorder := handlertest.Do(t, handler, http.MethodGet, path, userID, nil)
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	var response models.VocabCardsResponse
+	handlertest.Decode(t, recorder, &response)
+	return response.Cards
+}
+
+func TestSaveWordKeepsOneCardPerWord(t *testing.T) {
+	db := fake.New()
+	id := db.AddNews(fake.Content{Language: "French", Topic: "Politics", CEFRLevel: "B1"})
+	h := vocabhandler.New(db)
+
+	first := save(t, h, "user", models.SaveVocabRequest{Word: "Chat,", Language: "French", Sentence: "Le chat dort.", ContentType: "News", ContentID: id})
+	if first.Word != "chat" || first.Lookups != 1 || first.ContentID != id || first.Repetitions != 0 {
+		t.Fatalf("unexpected card %+v", first)
+	}
+	again := save(t, h, "user", models.SaveVocabRequest{Word: "chat", Language: "French", Sentence: "Un autre chat.", Translation: "cat"})
+	if again.CardID != first.CardID || again.Lookups != 2 || again.Sentence != "Le chat dort." || again.Translation != "cat" {
+		t.Errorf("expected the lookup to be counted on the same card, got %+v", again)
+	}
+	if other := save(t, h, "user", models.SaveVocabRequest{Word: "chat", Language: "English"}); other.CardID == first.CardID {
+		t.Error("expected a card per language")
+	}
+
+	if deck := cards(t, h, h.GetCards, "/vocab?language=French", "user"); len(deck) != 1 || deck[0].CardID != first.CardID {
+		t.Errorf("unexpected deck %+v", deck)
+	}
+	if deck := cards(t, h, h.GetCards, "/vocab", "someone else"); len(deck) != 0 {
+		t.Errorf("expected decks to be per user, got %+v", deck)
+	}
+
+	for _, request := range []models.SaveVocabRequest{
+		{Word: "...", Language: "French"},
+		{Word: "chat", Language: "French", ContentType: "News"},
+		{Word: "chat", Language: "French", ContentType: "Video", ContentID: "1"},
+		{Word: "chat", Language: "French", ContentType: "News", ContentID: "abc"},
+	} {
+		recorder := handlertest.Do(t, h.SaveWord, http.MethodPost, "/vocab/save", "user", request)
+		handlertest.ExpectStatus(t, recorder, http.StatusBadRequest)
+	}
+}
+
+func TestReviewSchedulesTheCardAndCountsTowardsTheGoal(t *testing.T) {
+	db := fake.New()
+	db.UpsertProfile("user", &supabase.Profile{Username: "lecteur", DailyQuestionsGoal: 2})
+	h := vocabhandler.New(db)
+	card := save(t, h, "user", models.SaveVocabRequest{Word: "chat", Language: "French"})
+
+	if due := cards(t, h, h.GetDueCards, "/vocab/due", "user"); len(due) != 1 {
+		t.Fatalf("expected a new card to be due, got %+v", due)
+	}
+
+	review := func(grade int) models.VocabReviewResponse {
+		t.Helper()
+		recorder := handlertest.Do(t, h.ReviewCard, http.MethodPost, "/vocab/review", "user", models.VocabReviewRequest{CardID: card.CardID, Grade: &grade})
+		handlertest.ExpectStatus(t, recorder, http.StatusOK)
+		var response models.VocabReviewResponse
+		handlertest.Decode(t, recorder, &response)
+		return response
+	}
+
+	perfect := 5
+	first := review(4)
+	if first.Card.IntervalDays != 1 || first.Card.Repetitions != 1 || first.Card.LastReviewedAt == "" {
+		t.Errorf("unexpected schedule after the first review: %+v", first.Card)
+	}
+	if first.QuestionsCompleted != 1 || first.GoalMet {
+		t.Errorf("expected the review to count as a question, got %+v", first)
+	}
+	if due := cards(t, h, h.GetDueCards, "/vocab/due", "user"); len(due) != 0 {
+		t.Errorf("expected the reviewed card not to be due, got %+v", due)
+	}
+
+	// a card that is not due is not reviewed again or counted
+	recorder := handlertest.Do(t, h.ReviewCard, http.MethodPost, "/vocab/review", "user", models.VocabReviewRequest{CardID: card.CardID, Grade: &perfect})
+	handlertest.ExpectStatus(t, recorder, http.StatusConflict)
+	if db.VocabReviews(card.CardID) != 1 {
+		t.Errorf("expected the early review not to be recorded, got %d reviews", db.VocabReviews(card.CardID))
+	}
+	if progress, _ := db.GetTodayProgress("user"); progress.QuestionsCompleted != 1 {
+		t.Errorf("expected the early review not to count, got %+v", progress)
+	}
+
+	db.SetVocabDue(card.CardID, time.Now())
+	second := review(1)
+	if second.Card.IntervalDays != 1 || second.Card.Repetitions != 0 || !second.GoalMet {
+		t.Errorf("expected a forgotten card to start over and the goal to be met, got %+v", second)
+	}
+	if db.VocabReviews(card.CardID) != 2 {
+		t.Errorf("expected 2 reviews to be recorded, got %d", db.VocabReviews(card.CardID))
+	}
+}
+
+func TestReviewRejectsBadRequests(t *testing.T) {
+	db := fake.New()
+	h := vocabhandler.New(db)
+	card := save(t, h, "user", models.SaveVocabRequest{Word: "chat", Language: "French"})
+
+	six, zero := 6, 0
+	for _, tt := range []struct {
+		userID  string
+		request models.VocabReviewRequest
+		status  int
+	}{
+		{"user", models.VocabReviewRequest{CardID: card.CardID, Grade: &six}, http.StatusBadRequest},
+		{"user", models.VocabReviewRequest{CardID: card.CardID}, http.StatusBadRequest},
+		{"someone else", models.VocabReviewRequest{CardID: card.CardID, Grade: &zero}, http.StatusNotFound},
+	} {
+		recorder := handlertest.Do(t, h.ReviewCard, http.MethodPost, "/vocab/review", tt.userID, tt.request)
+		handlertest.ExpectStatus(t, recorder, tt.status)
+	}
+
+	// without a profile there is no daily goal, but the review still counts
+	recorder := handlertest.Do(t, h.ReviewCard, http.MethodPost, "/vocab/review", "user", models.VocabReviewRequest{CardID: card.CardID, Grade: &zero})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	if db.VocabReviews(card.CardID) != 1 {
+		t.Error("expected the review to be recorded")
+	}
+}
+
+func TestDeleteCard(t *testing.T) {
+	db := fake.New()
+	h := vocabhandler.New(db)
+	card := save(t, h, "user", models.SaveVocabRequest{Word: "chat", Language: "French"})
+
+	recorder := handlertest.Do(t, h.DeleteCard, http.MethodPost, "/vocab/delete", "someone else", models.DeleteVocabRequest{CardID: card.CardID})
+	handlertest.ExpectStatus(t, recorder, http.StatusNotFound)
+	recorder = handlertest.Do(t, h.DeleteCard, http.MethodPost, "/vocab/delete", "user", models.DeleteVocabRequest{CardID: card.CardID})
+	handlertest.ExpectStatus(t, recorder, http.StatusOK)
+	if deck := cards(t, h, h.GetCards, "/vocab", "user"); len(deck) != 0 {
+		t.Errorf("expected the card to be deleted, got %+v", deck)
+	}
+}
@@ -13,10 +13,15 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	"net/http"
 
@@ -28,26 +33,20 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	_ "github.com/lib/pq"
 
+	"squeak-shared/contentstore"
+	"squeak-shared/textgen"
+
 	"story-api/audio"
+	"story-api/qna"
+	"story-api/storage"
 	"story-api/supabase"
-
-	"story-api/handlers/audiohandler"
-	"story-api/handlers/newshandler"
-	"story-api/handlers/profilehandler"
-	"story-api/handlers/progresshandler"
-	"story-api/handlers/qnahandler"
-	"story-api/handlers/storyhandler"
-	"story-api/handlers/stripehandler"
-	"story-api/handlers/student"
-	"story-api/handlers/teacher"
-	"story-api/handlers/orghandler"
-	"story-api/handlers/billinghandler"
 )
 
 type Profile = supabase.Profile
 
+const shutdownTimeout = 10 * time.Second
+
 var ginLambda *ginadapter.GinLambda
-var dbClient *supabase.Client
 
 func authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -99,154 +98,100 @@ func authMiddleware() gin.HandlerFunc {
 	}
 }
 
-func init() {
-	log.Println("Gin cold start")
-
-	var err error
-	dbClient, err = supabase.NewClient()
-	audioClient := audio.NewClient(os.Getenv("GOOGLE_API_KEY"), os.Getenv("ELEVENLABS_API_KEY"))
+func newDependencies(ctx context.Context) (Dependencies, error) {
+	dbClient, err := supabase.NewClient()
 	if err != nil {
-		log.Fatalf("Failed to initialize database connection: %v", err)
+		return Dependencies{}, fmt.Errorf("failed to initialize database connection: %v", err)
+	}
+	contentStore, err := contentstore.NewFromEnv(ctx)
+	if err != nil {
+		dbClient.Close()
+		return Dependencies{}, fmt.Errorf("failed to initialize content store: %v", err)
+	}
+	generator, err := textgen.NewFromEnv(ctx)
+	if err != nil {
+		dbClient.Close()
+		return Dependencies{}, fmt.Errorf("failed to initialize LLM provider: %v", err)
 	}
 
-	router := gin.Default()
-
-	AllowOrigin := "*"
-
-	router.Use(func(c *gin.Context) {
-		c.Writer.Header().Set("Access-Control-Allow-Origin", AllowOrigin)
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,Stripe-Signature")
-		c.Writer.Header().Set("Access-Control-Max-Age", "3600")
-
-		if c.Request.Method == http.MethodOptions {
-			c.AbortWithStatus(http.StatusOK)
-			return
-		}
+	return Dependencies{
+		DBClient:    dbClient,
+		AudioClient: audio.NewClient(os.Getenv("GOOGLE_API_KEY"), os.Getenv("ELEVENLABS_API_KEY")),
+		Storage:     storage.NewClient(contentStore),
+		QNAClient:   qna.NewClient(generator),
 
-		c.Next()
-	})
+		AutoLevelAdjust: os.Getenv("AUTO_LEVEL_ADJUST") == "true",
+	}, nil
+}
 
-	router.Use(func(c *gin.Context) {
-		if c.Request.Method != http.MethodOptions && !strings.HasSuffix(c.Request.URL.Path, "/webhook") {
-			authMiddleware()(c)
-		}
-	})
+// serve runs the router on a plain net/http server until SIGINT or SIGTERM,
+// then gives in-flight requests shutdownTimeout to finish.
+func serve(router http.Handler, addr string) error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// unprotected webhook route
-	webhookGroup := router.Group("/webhook")
-	{
-		stripeHandler := stripehandler.New(dbClient)
-		webhookGroup.POST("", stripeHandler.HandleWebhook)
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           router,
+		ReadHeaderTimeout: 10 * time.Second,
 	}
 
-	billingHandler := billing.New(dbClient)
-	billingGroup := router.Group("/billing")
-	{
-		billingGroup.GET("", billingHandler.GetBillingAccount)
-		billingGroup.GET("/usage", billingHandler.GetBillingAccountUsage)
-		billingGroup.POST("/create-checkout-session", billingHandler.CreateCheckoutSession)
-		billingGroup.POST("/cancel-subscription-eop", billingHandler.CancelSubscriptionAtEndOfPeriod)
-	}
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("API listening on %s", addr)
+		serverErr <- server.ListenAndServe()
+	}()
 
-	orgHandler := org.New(dbClient)
-	orgGroup := router.Group("/organization")
-	{
-		orgGroup.GET("", orgHandler.CheckOrganization)
-		orgGroup.POST("/create", orgHandler.CreateOrganization)
-		orgGroup.POST("/join", orgHandler.JoinOrganization)
-
-		paymentsGroup := orgGroup.Group("/payments")
-		{
-			paymentsGroup.GET("", orgHandler.GetOrganizationPayments)
-			paymentsGroup.POST("/create-checkout-session", orgHandler.CreateCheckoutSession)
-			paymentsGroup.POST("/cancel-subscription-eop", orgHandler.CancelSubscriptionAtEndOfPeriod)
-		}
+	select {
+	case err := <-serverErr:
+		return err
+	case <-ctx.Done():
 	}
 
-	teacherHandler := teacher.New(dbClient)
-	teacherGroup := router.Group("/teacher")
-	{
-		teacherGroup.GET("", teacherHandler.CheckTeacherStatus)
-
-		classroomGroup := teacherGroup.Group("/classroom")
-		{
-			classroomGroup.GET("", teacherHandler.GetClassroomList)
-			classroomGroup.POST("/update", teacherHandler.UpdateClassroom)
-			classroomGroup.GET("/content", teacherHandler.QueryClassroomContent)
-			classroomGroup.POST("/create", teacherHandler.CreateClassroom)
-			classroomGroup.POST("/delete", teacherHandler.DeleteClassroom)
-			classroomGroup.POST("/accept", teacherHandler.AcceptContent)
-			classroomGroup.POST("/reject", teacherHandler.RejectContent)
-		}
-	}
+	log.Println("Shutting down API server")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
 
-	studentHandler := student.New(dbClient)
-	studentGroup := router.Group("/student")
-	{
-		studentGroup.GET("", studentHandler.CheckStudentStatus)
+func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return ginLambda.ProxyWithContext(ctx, req)
+}
 
-		classroomGroup := studentGroup.Group("/classroom")
-		{
-			classroomGroup.GET("", studentHandler.GetClassroomInfo)
-			classroomGroup.POST("/join", studentHandler.JoinClassroom)
-		}
+func listenAddr(addr string) string {
+	if addr != "" {
+		return addr
 	}
-
-	audioHandler := audiohandler.New(dbClient, audioClient)
-	audioGroup := router.Group("/audio")
-	{
-		audioGroup.GET("", audioHandler.CheckHealth)
-		audioGroup.POST("/translate", audioHandler.Translate)
-		audioGroup.POST("/tts", audioHandler.TextToSpeech)
-		audioGroup.POST("/stt", audioHandler.SpeechToText)
-		audioGroup.GET("/audiobook", audioHandler.GetAudiobook)
+	if port := os.Getenv("PORT"); port != "" {
+		return ":" + port
 	}
+	return ":8080"
+}
 
-	progressHandler := progresshandler.New(dbClient)
-	progressGroup := router.Group("/progress")
-	{
-		progressGroup.GET("", progressHandler.GetTodayProgress)
-		progressGroup.GET("/streak", progressHandler.GetStreak)
-		progressGroup.GET("/increment", progressHandler.IncrementProgress)
-	}
+// By default the API runs as a Lambda behind API Gateway. Pass -serve or set
+// API_MODE=serve to run it as a standalone HTTP server instead.
+func main() {
+	serveMode := flag.Bool("serve", false, "run as a standalone HTTP server instead of a Lambda handler")
+	addr := flag.String("addr", "", "listen address for -serve (default :$PORT or :8080)")
+	flag.Parse()
 
-	profileHandler := profilehandler.New(dbClient)
-	profileGroup := router.Group("/profile")
-	{
-		profileGroup.GET("", profileHandler.GetProfile)
-		profileGroup.POST("/upsert", profileHandler.UpsertProfile)
-	}
+	log.Println("Gin cold start")
 
-	newsHandler := newshandler.New(dbClient)
-	newsGroup := router.Group("/news")
-	{
-		newsGroup.GET("", newsHandler.GetNews)
-		newsGroup.GET("/query", newsHandler.GetNewsQuery)
+	deps, err := newDependencies(context.Background())
+	if err != nil {
+		log.Fatalf("Failed to initialize dependencies: %v", err)
 	}
+	defer deps.DBClient.Close()
 
-	storyHandler := storyhandler.New(dbClient)
-	storyGroup := router.Group("/story")
-	{
-		storyGroup.GET("", storyHandler.GetStoryPage)
-		storyGroup.GET("/context", storyHandler.GetStoryQNAContext)
-		storyGroup.GET("/query", storyHandler.GetStoryQuery)
-	}
+	router := newRouter(deps)
 
-	qnaHandler := qnahandler.New(dbClient)
-	qnaGroup := router.Group("/qna")
-	{
-		qnaGroup.POST("", qnaHandler.GetQuestion)
-		qnaGroup.POST("/evaluate", qnaHandler.EvaluateAnswer)
+	if *serveMode || os.Getenv("API_MODE") == "serve" {
+		if err := serve(router, listenAddr(*addr)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("API server failed: %v", err)
+		}
+		return
 	}
 
 	ginLambda = ginadapter.New(router)
-}
-
-func Handler(ctx context.Context, req events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
-	return ginLambda.ProxyWithContext(ctx, req)
-}
-
-func main() {
 	lambda.Start(Handler)
 }
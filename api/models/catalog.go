@@ -0,0 +1,23 @@
+package models
+
+type CatalogLanguage struct {
+	Language     string   `json:"language" binding:"required" example:"French"`
+	CEFRLevels   []string `json:"cefr_levels" binding:"required" example:"A1,A2,B1"`
+	ContentTypes []string `json:"content_types" binding:"required" example:"News,Story"`
+	Topics       []string `json:"topics" binding:"required" example:"Politics,Travel"`
+}
+
+type CatalogLanguagesResponse struct {
+	Languages []CatalogLanguage `json:"languages" binding:"required"`
+}
+
+type CatalogTopic struct {
+	Topic        string   `json:"topic" binding:"required" example:"Politics"`
+	Languages    []string `json:"languages" binding:"required" example:"French,Spanish"`
+	CEFRLevels   []string `json:"cefr_levels" binding:"required" example:"A1,A2,B1"`
+	ContentTypes []string `json:"content_types" binding:"required" example:"News"`
+}
+
+type CatalogTopicsResponse struct {
+	Topics []CatalogTopic `json:"topics" binding:"required"`
+}
@@ -0,0 +1,69 @@
+package models
+
+import (
+	"encoding/json"
+	"story-api/storage"
+)
+
+type EditorContentItem struct {
+	ID          string          `json:"id" binding:"required" example:"2479"`
+	ContentType string          `json:"content_type" binding:"required" example:"News"`
+	Title       string          `json:"title" binding:"required" example:"Le budget est voté"`
+	Language    string          `json:"language" binding:"required" example:"French"`
+	Topic       string          `json:"topic" binding:"required" example:"Politics"`
+	CEFRLevel   string          `json:"cefr_level" binding:"required" example:"B1"`
+	PreviewText string          `json:"preview_text" binding:"required" example:"Le Sénat a voté le budget..."`
+	Pages       int             `json:"pages" example:"3"`
+	Status      string          `json:"status" binding:"required" example:"in_review"`
+	Moderation  json.RawMessage `json:"moderation,omitempty" swaggertype:"object"`
+	Readability json.RawMessage `json:"readability,omitempty" swaggertype:"object"`
+	CreatedAt   string          `json:"created_at" binding:"required" example:"2025-02-26T13:01:13.390612Z"`
+	DateCreated string          `json:"date_created" binding:"required" example:"2025-02-26"`
+	EditedAt    string          `json:"edited_at,omitempty" example:"2025-02-26T15:20:00Z"`
+	ReviewedBy  string          `json:"reviewed_by,omitempty" example:"a1b2c3d4-..."`
+	ReviewedAt  string          `json:"reviewed_at,omitempty" example:"2025-02-26T15:30:00Z"`
+	ReviewNote  string          `json:"review_note,omitempty" example:"Fixed a wrong date"`
+}
+
+type EditorContentListResponse struct {
+	Items []EditorContentItem `json:"items" binding:"required"`
+}
+
+// SentenceSupport relates one sentence of an article to its sources.
+type SentenceSupport struct {
+	Sentence string `json:"sentence" binding:"required" example:"Le Sénat a voté le budget mardi."`
+	// index into sources of the source sharing the most words, -1 if none
+	Source  int     `json:"source" binding:"required" example:"0"`
+	Overlap float64 `json:"overlap" binding:"required" example:"0.8"`
+	// names and numbers that are in none of the sources
+	Unsupported []string `json:"unsupported" binding:"required" example:"Dupont,40"`
+}
+
+type EditorContentResponse struct {
+	EditorContentItem
+	Body       string            `json:"body,omitempty" example:"# Le budget est voté\n\nLe Sénat..."`
+	StoryPages []string          `json:"story_pages,omitempty"`
+	Sources    []storage.Source  `json:"sources,omitempty"`
+	SourceDiff []SentenceSupport `json:"source_diff,omitempty"`
+}
+
+// EditorUpdateRequest replaces the fields that are set. Body is the markdown
+// of a news article, StoryPages the markdown of every page of a story.
+type EditorUpdateRequest struct {
+	ContentType string   `json:"content_type" binding:"required" example:"News"`
+	ID          string   `json:"id" binding:"required" example:"2479"`
+	Title       *string  `json:"title" example:"Le budget est voté"`
+	PreviewText *string  `json:"preview_text" example:"Le Sénat a voté le budget..."`
+	Body        *string  `json:"body" example:"# Le budget est voté\n\nLe Sénat..."`
+	StoryPages  []string `json:"story_pages"`
+}
+
+type EditorStatusRequest struct {
+	ContentType string `json:"content_type" binding:"required" example:"News"`
+	ID          string `json:"id" binding:"required" example:"2479"`
+	Note        string `json:"note" example:"Checked against the sources"`
+}
+
+type EditorStatusResponse struct {
+	Status string `json:"status" binding:"required" example:"published"`
+}
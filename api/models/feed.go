@@ -0,0 +1,35 @@
+package models
+
+type FeedWeights struct {
+	Topic      float64 `json:"topic" example:"3"`
+	Level      float64 `json:"level" example:"2"`
+	LevelUp    float64 `json:"level_up" example:"1"`
+	Recency    float64 `json:"recency" example:"2"`
+	InProgress float64 `json:"in_progress" example:"1.5"`
+	// taken off for each item of the same content type directly before
+	RepeatType float64 `json:"repeat_type" example:"2"`
+}
+
+type FeedItem struct {
+	ContentType   string  `json:"content_type" binding:"required" example:"News"`
+	ID            string  `json:"id" binding:"required" example:"123"`
+	Title         string  `json:"title" binding:"required" example:"L'actualité musicale en bref"`
+	Language      string  `json:"language" binding:"required" example:"French"`
+	Topic         string  `json:"topic" binding:"required" example:"Music"`
+	CEFRLevel     string  `json:"cefr_level" binding:"required" example:"B1"`
+	PreviewText   string  `json:"preview_text" binding:"required" example:"Un résumé des nouvelles musicales..."`
+	DateCreated   string  `json:"date_created" binding:"required" example:"2024-02-26"`
+	CreatedAt     string  `json:"created_at" binding:"required" example:"2024-02-26T13:01:13Z"`
+	Pages         int     `json:"pages,omitempty" example:"5"`
+	ReadingStatus string  `json:"reading_status" binding:"required" enums:"unread,in_progress" example:"unread"`
+	Score         float64 `json:"score" example:"6.4"`
+	// why the item scored what it did, in the order the weights were applied
+	Reasons []string `json:"reasons" binding:"required" example:"topic,level,recent"`
+}
+
+type FeedResponse struct {
+	Items   []FeedItem  `json:"items" binding:"required"`
+	Weights FeedWeights `json:"weights" binding:"required"`
+	// pass as cursor to get the next page, not set on the last page
+	NextCursor string `json:"next_cursor,omitempty" example:"MTc0MDU3NDg3MzAwMDAwMDoxMA"`
+}
@@ -0,0 +1,27 @@
+package models
+
+type LevelEvidence struct {
+	Level     string `json:"level" binding:"required" example:"B1"`
+	Attempts  int    `json:"attempts" example:"12"`
+	Passed    int    `json:"passed" example:"9"`
+	Started   int    `json:"started" example:"5"`
+	Completed int    `json:"completed" example:"4"`
+	WordsRead int    `json:"words_read" example:"1800"`
+	Lookups   int    `json:"lookups" example:"21"`
+	// from 0 to 1, not set without answers or readings
+	Mastery *float64 `json:"mastery,omitempty" example:"0.78"`
+}
+
+type LevelEstimateResponse struct {
+	Language string `json:"language" binding:"required" example:"French"`
+	// the level in the profile, after any automatic adjustment
+	SkillLevel     string  `json:"skill_level" binding:"required" example:"B2"`
+	DeclaredLevel  string  `json:"declared_level" binding:"required" example:"B1"`
+	EstimatedLevel string  `json:"estimated_level" binding:"required" example:"B2"`
+	Confidence     float64 `json:"confidence" example:"0.8"`
+	// how the declared level should change
+	Recommendation string `json:"recommendation" binding:"required" enums:"up,down,stay" example:"up"`
+	// whether the estimate replaced the declared level
+	Applied  bool            `json:"applied" example:"true"`
+	Evidence []LevelEvidence `json:"evidence" binding:"required"`
+}
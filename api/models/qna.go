@@ -1,14 +1,25 @@
 package models
 
+import "story-api/qna"
+
 type GetQuestionRequest struct {
 	ContentType string `json:"content_type" binding:"required" example:"News"`
 	ID          string `json:"id" binding:"required" example:"123"`
 	CEFRLevel   string `json:"cefr_level" binding:"required" example:"B1"`
-	QuestionType string `json:"question_type" binding:"required,oneof=vocab understanding" example:"vocab"`
+	QuestionType string `json:"question_type" binding:"required,oneof=vocab understanding multiple_choice cloze true_false" example:"vocab"`
 }
 
 type GetQuestionResponse struct {
+	QuestionID   int    `json:"question_id" binding:"required" example:"42"`
+	QuestionType string `json:"question_type" binding:"required" example:"vocab"`
+	// cloze questions have the missing word replaced by _____
 	Question string `json:"question" binding:"required" example:"What does 'bonjour' mean?"`
+	// the options to answer multiple_choice and most cloze questions with,
+	// true_false questions are answered with "true" or "false"
+	Choices []string `json:"choices,omitempty" example:"Hello,Goodbye,Thank you,Please"`
+	// how often the user has answered this question, and how their last answer was evaluated
+	Attempts       int    `json:"attempts" example:"1"`
+	LastEvaluation string `json:"last_evaluation,omitempty" example:"FAIL"`
 }
 
 type EvaluateAnswerRequest struct {
@@ -16,9 +27,53 @@ type EvaluateAnswerRequest struct {
 	Content  string `json:"content" binding:"required" example:"Bonjour, comment ça va?"`
 	Question string `json:"question" binding:"required" example:"What does 'bonjour' mean?"`
 	Answer   string `json:"answer" binding:"required" example:"Hello"`
+	// set to record the answer in the user's history
+	QuestionID int `json:"question_id" example:"42"`
 }
 
 type EvaluateAnswerResponse struct {
 	Evaluation  string `json:"evaluation" binding:"required" example:"PASS"`
 	Explanation string `json:"explanation" binding:"required" example:"Perfect!"`
+	// scores from 1 to 5
+	Scores qna.RubricScores `json:"scores" binding:"required"`
+	// mistakes in the answer, with their character offsets so they can be highlighted
+	Errors    []qna.AnswerError `json:"errors" binding:"required"`
+	AttemptID int               `json:"attempt_id,omitempty" example:"7"`
+}
+
+// ObjectiveAnswerRequest answers a multiple_choice, cloze or true_false
+// question, graded against its answer key without an LLM call.
+type ObjectiveAnswerRequest struct {
+	QuestionID int `json:"question_id" binding:"required" example:"42"`
+	// the chosen choice, the missing word, or "true" or "false"
+	Answer string `json:"answer" binding:"required" example:"Hello"`
+}
+
+type ObjectiveAnswerResponse struct {
+	Evaluation    string `json:"evaluation" binding:"required" example:"PASS"`
+	Correct       bool   `json:"correct" binding:"required" example:"true"`
+	CorrectAnswer string `json:"correct_answer" binding:"required" example:"Hello"`
+	Explanation   string `json:"explanation" binding:"required" example:"Correct!"`
+	AttemptID     int    `json:"attempt_id,omitempty" example:"7"`
+}
+
+type QNAAttempt struct {
+	AttemptID    int    `json:"attempt_id" binding:"required" example:"7"`
+	QuestionID   int    `json:"question_id" binding:"required" example:"42"`
+	ContentType  string `json:"content_type" binding:"required" example:"News"`
+	ContentID    string `json:"content_id" binding:"required" example:"123"`
+	QuestionType string `json:"question_type" binding:"required" example:"vocab"`
+	CEFRLevel    string `json:"cefr_level" binding:"required" example:"B1"`
+	Question     string `json:"question" binding:"required" example:"What does 'bonjour' mean?"`
+	Answer       string `json:"answer" binding:"required" example:"Hello"`
+	Evaluation   string `json:"evaluation" binding:"required" example:"PASS"`
+	Explanation  string `json:"explanation" example:"Perfect!"`
+	// not set for answers evaluated before scores were given
+	Scores    *qna.RubricScores `json:"scores,omitempty"`
+	Errors    []qna.AnswerError `json:"errors,omitempty"`
+	CreatedAt string            `json:"created_at" binding:"required" example:"2025-02-26T13:01:13Z"`
+}
+
+type QNAHistoryResponse struct {
+	Attempts []QNAAttempt `json:"attempts" binding:"required"`
 }
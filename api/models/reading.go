@@ -0,0 +1,40 @@
+package models
+
+type UpdateReadingProgressRequest struct {
+	ContentType string `json:"content_type" binding:"required,oneof=News Story" example:"Story"`
+	ID          string `json:"id" binding:"required" example:"123"`
+	// the page the user is on, 0 for news
+	Page int `json:"page" binding:"gte=0" example:"2"`
+	// defaults to the share of pages read for stories
+	PercentComplete *float64 `json:"percent_complete" binding:"omitempty,gte=0,lte=100" example:"60"`
+	// time spent reading since the last update
+	SecondsSpent int  `json:"seconds_spent" binding:"gte=0" example:"95"`
+	Completed    bool `json:"completed" example:"false"`
+}
+
+type ReadingProgressResponse struct {
+	ContentType      string  `json:"content_type" binding:"required" example:"Story"`
+	ID               string  `json:"id" binding:"required" example:"123"`
+	LastPage         int     `json:"last_page" example:"2"`
+	PercentComplete  float64 `json:"percent_complete" example:"60"`
+	TimeSpentSeconds int     `json:"time_spent_seconds" example:"420"`
+	ReadingStatus    string  `json:"reading_status" binding:"required" enums:"in_progress,read" example:"in_progress"`
+	StartedAt        string  `json:"started_at" binding:"required" example:"2025-02-26T13:01:13Z"`
+	UpdatedAt        string  `json:"updated_at" binding:"required" example:"2025-02-26T13:08:13Z"`
+	// not set until the content is finished
+	CompletedAt string `json:"completed_at,omitempty" example:"2025-02-26T13:08:13Z"`
+}
+
+type ContinueReadingItem struct {
+	ReadingProgressResponse
+	Title       string `json:"title" binding:"required" example:"L'actualité musicale en bref"`
+	Language    string `json:"language" binding:"required" example:"French"`
+	Topic       string `json:"topic" binding:"required" example:"Music"`
+	CEFRLevel   string `json:"cefr_level" binding:"required" example:"B1"`
+	PreviewText string `json:"preview_text" binding:"required" example:"Un résumé des nouvelles musicales..."`
+	Pages       int    `json:"pages,omitempty" example:"5"`
+}
+
+type ContinueReadingResponse struct {
+	Items []ContinueReadingItem `json:"items" binding:"required"`
+}
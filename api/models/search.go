@@ -0,0 +1,21 @@
+package models
+
+type SearchResult struct {
+	ContentType string  `json:"content_type" binding:"required" example:"News"`
+	ID          string  `json:"id" binding:"required" example:"123"`
+	Title       string  `json:"title" binding:"required" example:"Les élections municipales"`
+	Language    string  `json:"language" binding:"required" example:"French"`
+	Topic       string  `json:"topic" binding:"required" example:"Politics"`
+	CEFRLevel   string  `json:"cefr_level" binding:"required" example:"B1"`
+	PreviewText string  `json:"preview_text" binding:"required" example:"Les Français votent dimanche..."`
+	DateCreated string  `json:"date_created" binding:"required" example:"2024-02-26"`
+	CreatedAt   string  `json:"created_at" binding:"required" example:"2024-02-26T13:01:13Z"`
+	Pages       int     `json:"pages,omitempty" example:"5"`
+	Rank        float64 `json:"rank" example:"0.42"`
+	// plain text with the matches in <mark> tags
+	Snippet string `json:"snippet" binding:"required" example:"Les <mark>élections</mark> municipales ont lieu dimanche"`
+}
+
+type SearchResponse struct {
+	Results []SearchResult `json:"results" binding:"required"`
+}
@@ -26,6 +26,8 @@ type StoryItem struct {
 	Title string `json:"title" binding:"required" example:"L'actualité musicale en bref"`
 	Topic string `json:"topic" binding:"required" example:"Music"`
 	AudiobookTier string `json:"audiobook_tier" binding:"required" example:"NONE"`
+	// the user's progress, not set if it could not be read
+	ReadingStatus string `json:"reading_status,omitempty" enums:"unread,in_progress,read" example:"unread"`
 }
 
 type GetStoryQueryResponse []StoryItem
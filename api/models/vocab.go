@@ -0,0 +1,56 @@
+package models
+
+type SaveVocabRequest struct {
+	Word     string `json:"word" binding:"required" example:"chat"`
+	Language string `json:"language" binding:"required" example:"French"`
+	// the sentence the word was looked up in
+	Sentence    string `json:"sentence" example:"Le chat dort sur le lit."`
+	Translation string `json:"translation" example:"cat"`
+	// the content the word was looked up in, if any
+	ContentType string `json:"content_type" binding:"omitempty,oneof=News Story" example:"News"`
+	ContentID   string `json:"content_id" example:"123"`
+}
+
+type VocabCard struct {
+	CardID      int    `json:"card_id" binding:"required" example:"12"`
+	Word        string `json:"word" binding:"required" example:"chat"`
+	Language    string `json:"language" binding:"required" example:"French"`
+	Sentence    string `json:"sentence" example:"Le chat dort sur le lit."`
+	Translation string `json:"translation" example:"cat"`
+	ContentType string `json:"content_type,omitempty" example:"News"`
+	ContentID   string `json:"content_id,omitempty" example:"123"`
+	// how many times the word was saved
+	Lookups      int     `json:"lookups" binding:"required" example:"3"`
+	EaseFactor   float64 `json:"ease_factor" binding:"required" example:"2.5"`
+	IntervalDays int     `json:"interval_days" example:"6"`
+	Repetitions  int     `json:"repetitions" example:"2"`
+	DueAt        string  `json:"due_at" binding:"required" example:"2025-03-07T12:00:00Z"`
+	// not set for cards that were never reviewed
+	LastReviewedAt string `json:"last_reviewed_at,omitempty" example:"2025-03-01T12:00:00Z"`
+	CreatedAt      string `json:"created_at" binding:"required" example:"2025-02-26T13:01:13Z"`
+}
+
+type VocabCardsResponse struct {
+	Cards []VocabCard `json:"cards" binding:"required"`
+}
+
+type VocabReviewRequest struct {
+	CardID int `json:"card_id" binding:"required" example:"12"`
+	// 0 (no recollection) to 5 (perfect recall), 3 and above count as remembered
+	Grade *int `json:"grade" binding:"required,min=0,max=5" example:"4"`
+}
+
+type VocabReviewResponse struct {
+	Card VocabCard `json:"card" binding:"required"`
+	// today's progress, which counts each review as a completed question
+	QuestionsCompleted int  `json:"questions_completed" binding:"gte=0" example:"5"`
+	GoalMet            bool `json:"goal_met" example:"false"`
+}
+
+type DeleteVocabRequest struct {
+	CardID int `json:"card_id" binding:"required" example:"12"`
+}
+
+type DeleteVocabResponse struct {
+	Message string `json:"message" binding:"required" example:"Card deleted successfully"`
+}
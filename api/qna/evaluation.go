@@ -0,0 +1,177 @@
+package qna
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"strings"
+	"unicode/utf8"
+
+	"squeak-shared/textgen"
+
+	"story-api/prompts"
+)
+
+// EVALUATION_ATTEMPTS is how often a malformed evaluation is asked for again.
+const EVALUATION_ATTEMPTS = 3
+
+// Error types.
+const (
+	ERROR_GRAMMAR       = "grammar"
+	ERROR_SPELLING      = "spelling"
+	ERROR_VOCABULARY    = "vocabulary"
+	ERROR_COMPREHENSION = "comprehension"
+)
+
+const (
+	MIN_SCORE = 1
+	MAX_SCORE = 5
+)
+
+var minScore, maxScore = float64(MIN_SCORE), float64(MAX_SCORE)
+
+type RubricScores struct {
+	Comprehension int `json:"comprehension" example:"4"`
+	Grammar       int `json:"grammar" example:"3"`
+	Vocabulary    int `json:"vocabulary" example:"5"`
+}
+
+// AnswerError is a mistake in an answer. Start and End are the offsets of
+// Text in the answer, counted in characters (Unicode code points).
+type AnswerError struct {
+	Text        string `json:"text" example:"il sont"`
+	Start       int    `json:"start" example:"4"`
+	End         int    `json:"end" example:"11"`
+	Correction  string `json:"correction" example:"ils sont"`
+	Type        string `json:"type" example:"grammar"`
+	Explanation string `json:"explanation" example:"Ils is the plural of il."`
+}
+
+// Feedback is what an evaluation says about an answer besides the verdict.
+type Feedback struct {
+	Scores RubricScores  `json:"scores"`
+	Errors []AnswerError `json:"errors"`
+}
+
+type Evaluation struct {
+	Evaluation  string `json:"evaluation"` // EVALUATION_PASS or EVALUATION_FAIL
+	Explanation string `json:"explanation"`
+	Feedback
+}
+
+var scoreSchema = &textgen.Schema{Type: textgen.TypeInteger, Description: "1 (poor) to 5 (excellent)", Minimum: &minScore, Maximum: &maxScore}
+
+var evaluationSchema = &textgen.Schema{
+	Type:     textgen.TypeObject,
+	Required: []string{"evaluation", "scores", "errors", "explanation"},
+	Properties: map[string]*textgen.Schema{
+		"evaluation": {Type: textgen.TypeString, Enum: []string{EVALUATION_PASS, EVALUATION_FAIL}},
+		"scores": {
+			Type:     textgen.TypeObject,
+			Required: []string{"comprehension", "grammar", "vocabulary"},
+			Properties: map[string]*textgen.Schema{
+				"comprehension": scoreSchema,
+				"grammar":       scoreSchema,
+				"vocabulary":    scoreSchema,
+			},
+		},
+		"errors": {
+			Type: textgen.TypeArray,
+			Items: &textgen.Schema{
+				Type:     textgen.TypeObject,
+				Required: []string{"text", "correction", "type", "explanation"},
+				Properties: map[string]*textgen.Schema{
+					"text":        {Type: textgen.TypeString, Description: "copied exactly from the answer"},
+					"correction":  {Type: textgen.TypeString},
+					"type":        {Type: textgen.TypeString, Enum: []string{ERROR_GRAMMAR, ERROR_SPELLING, ERROR_VOCABULARY, ERROR_COMPREHENSION}},
+					"explanation": {Type: textgen.TypeString},
+				},
+			},
+		},
+		"explanation": {Type: textgen.TypeString},
+	},
+}
+
+// EvaluateAnswer evaluates an answer in a single structured call. Evaluations
+// that do not match the schema are asked for again, up to EVALUATION_ATTEMPTS
+// times.
+func (c *Client) EvaluateAnswer(cefr string, content string, question string, answer string) (Evaluation, error) {
+	prompt := prompts.CreateEvaluateQNAPrompt(cefr, content, question, answer)
+	if IsVocabQuestion(question) {
+		prompt = prompts.CreateEvaluateVocabQNAPrompt(cefr, content, question, answer)
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= EVALUATION_ATTEMPTS; attempt++ {
+		response, err := c.generator.Generate(context.Background(), prompt, textgen.Options{
+			Model:       EVALUATE_QNA_MODEL,
+			Temperature: EVALUATE_QNA_TEMPERATURE,
+			Schema:      evaluationSchema,
+		})
+		if err != nil {
+			return Evaluation{}, err
+		}
+		evaluation, err := parseEvaluation(response, answer)
+		if err == nil {
+			return evaluation, nil
+		}
+		log.Printf("Malformed evaluation on attempt %d: %v", attempt, err)
+		lastErr = err
+	}
+	return Evaluation{}, fmt.Errorf("malformed evaluation after %d attempts: %v", EVALUATION_ATTEMPTS, lastErr)
+}
+
+// parseEvaluation reads and checks an evaluation, and locates its errors in
+// the answer.
+func parseEvaluation(response string, answer string) (Evaluation, error) {
+	var evaluation Evaluation
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return evaluation, fmt.Errorf("no JSON object in response")
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), &evaluation); err != nil {
+		return evaluation, fmt.Errorf("invalid JSON: %v", err)
+	}
+
+	if evaluation.Evaluation != EVALUATION_PASS && evaluation.Evaluation != EVALUATION_FAIL {
+		return evaluation, fmt.Errorf("invalid evaluation: %q", evaluation.Evaluation)
+	}
+	scores := evaluation.Scores
+	for name, score := range map[string]int{"comprehension": scores.Comprehension, "grammar": scores.Grammar, "vocabulary": scores.Vocabulary} {
+		if score < MIN_SCORE || score > MAX_SCORE {
+			return evaluation, fmt.Errorf("%s score %d is not between %d and %d", name, score, MIN_SCORE, MAX_SCORE)
+		}
+	}
+	evaluation.Explanation = strings.TrimSpace(evaluation.Explanation)
+	if evaluation.Explanation == "" {
+		return evaluation, fmt.Errorf("empty explanation")
+	}
+
+	if evaluation.Errors == nil {
+		evaluation.Errors = []AnswerError{}
+	}
+	// the same text can be wrong more than once, each error takes the next occurrence
+	searchFrom := map[string]int{}
+	for i := range evaluation.Errors {
+		answerError := &evaluation.Errors[i]
+		switch answerError.Type {
+		case ERROR_GRAMMAR, ERROR_SPELLING, ERROR_VOCABULARY, ERROR_COMPREHENSION:
+		default:
+			return evaluation, fmt.Errorf("invalid error type: %q", answerError.Type)
+		}
+		if answerError.Text == "" {
+			return evaluation, fmt.Errorf("error without text")
+		}
+		from := searchFrom[answerError.Text]
+		index := strings.Index(answer[from:], answerError.Text)
+		if index < 0 {
+			return evaluation, fmt.Errorf("error text %q is not in the answer", answerError.Text)
+		}
+		index += from
+		searchFrom[answerError.Text] = index + len(answerError.Text)
+		answerError.Start = utf8.RuneCountInString(answer[:index])
+		answerError.End = answerError.Start + utf8.RuneCountInString(answerError.Text)
+	}
+	return evaluation, nil
+}
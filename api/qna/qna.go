@@ -0,0 +1,60 @@
+package qna
+
+import (
+	"context"
+	"strings"
+
+	"squeak-shared/qnagen"
+	"squeak-shared/textgen"
+)
+
+const (
+	EVALUATE_QNA_MODEL       = textgen.ModelFast
+	EVALUATE_QNA_TEMPERATURE = 0.3
+)
+
+// Evaluations, the verdict of EvaluateAnswer.
+const (
+	EVALUATION_PASS = "PASS"
+	EVALUATION_FAIL = "FAIL"
+)
+
+// Client builds the QNA prompts and runs them on whichever LLM provider it
+// was given.
+//
+// USAGE
+// generator, err := textgen.NewFromEnv(ctx)
+// qnaClient := qna.NewClient(generator)
+type Client struct {
+	generator textgen.TextGenerator
+	questions *qnagen.Generator
+}
+
+func NewClient(generator textgen.TextGenerator) *Client {
+	return &Client{
+		generator: generator,
+		questions: qnagen.New(generator),
+	}
+}
+
+func IsVocabQuestion(question string) bool {
+	cleanQuestion := strings.ToLower(strings.TrimSpace(question))
+	return strings.HasPrefix(cleanQuestion, "what does") && strings.HasSuffix(cleanQuestion, "mean?")
+}
+
+// CreateQuestion writes a question of one of qnagen.QuestionTypes that is not
+// one of avoid, with its choices and answer key when it has them. The
+// generation lambda writes these ahead of time, so this only runs for content
+// it missed or for pools a user has worked through.
+func (c *Client) CreateQuestion(questionType string, cefr string, language string, content string, avoid []string) (qnagen.Exercise, error) {
+	return c.questions.Exercise(context.Background(), questionType, cefr, language, content, avoid)
+}
+
+// GradeAnswer grades an answer to a multiple choice, cloze or true/false
+// question against its answer key, without an LLM call.
+func GradeAnswer(answerKey string, answer string) (string, string) {
+	if qnagen.Grade(answerKey, answer) {
+		return EVALUATION_PASS, "Correct!"
+	}
+	return EVALUATION_FAIL, "The correct answer is: " + answerKey
+}
@@ -0,0 +1,82 @@
+package qna
+
+import (
+	"strings"
+	"testing"
+
+	"squeak-shared/textgen"
+)
+
+func TestIsVocabQuestion(t *testing.T) {
+	tests := map[string]bool{
+		"What does 'bonjour' mean?":     true,
+		"  what does président MEAN?  ": true,
+		"Who is the president?":         false,
+		"What does the author think?":   false,
+	}
+	for question, want := range tests {
+		if got := IsVocabQuestion(question); got != want {
+			t.Errorf("IsVocabQuestion(%q) = %v, want %v", question, got, want)
+		}
+	}
+}
+
+func TestEvaluateAnswer(t *testing.T) {
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return `{"evaluation":"FAIL","scores":{"comprehension":2,"grammar":3,"vocabulary":4},
+			"errors":[{"text":"il sont","correction":"ils sont","type":"grammar","explanation":"Plural."},
+				{"text":"é","correction":"è","type":"spelling","explanation":"Accent."},
+				{"text":"é","correction":"è","type":"spelling","explanation":"Accent."}],
+			"explanation":"Try explaining who left."}`, nil
+	}
+	client := NewClient(generator)
+
+	evaluation, err := client.EvaluateAnswer("B1", "Ils sont partis.", "Who left?", "Oui, il sont partis à l'école, très fâché.")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if evaluation.Evaluation != EVALUATION_FAIL || evaluation.Scores.Comprehension != 2 || evaluation.Explanation != "Try explaining who left." {
+		t.Errorf("unexpected evaluation: %+v", evaluation)
+	}
+	spans := [][2]int{{5, 12}, {24, 25}, {40, 41}}
+	for i, span := range spans {
+		if got := evaluation.Errors[i]; got.Start != span[0] || got.End != span[1] {
+			t.Errorf("error %d: expected %v, got %d-%d", i, span, got.Start, got.End)
+		}
+	}
+
+	calls := generator.Calls()
+	if len(calls) != 1 || calls[0].Options.Schema != evaluationSchema || calls[0].Options.Model != EVALUATE_QNA_MODEL {
+		t.Errorf("expected a single structured call, got %+v", calls)
+	}
+}
+
+func TestEvaluateAnswerRetriesMalformedOutput(t *testing.T) {
+	responses := []string{
+		"PASS",
+		`{"evaluation":"PASS","scores":{"comprehension":9,"grammar":3,"vocabulary":4},"errors":[],"explanation":"Good."}`,
+		`{"evaluation":"PASS","scores":{"comprehension":5,"grammar":3,"vocabulary":4},"errors":[{"text":"nope","correction":"x","type":"grammar","explanation":"x"}],"explanation":"Good."}`,
+		`{"evaluation":"PASS","scores":{"comprehension":5,"grammar":5,"vocabulary":5},"errors":[],"explanation":"Perfect!"}`,
+	}
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		response := responses[0]
+		responses = responses[1:]
+		return response, nil
+	}
+	client := NewClient(generator)
+
+	if _, err := client.EvaluateAnswer("B1", "Bonjour.", "What does bonjour mean?", "Hello"); err == nil {
+		t.Fatal("expected three malformed evaluations to be an error")
+	}
+
+	responses = responses[len(responses)-1:]
+	evaluation, err := client.EvaluateAnswer("B1", "Bonjour.", "What does bonjour mean?", "Hello")
+	if err != nil || evaluation.Evaluation != EVALUATION_PASS || len(evaluation.Errors) != 0 {
+		t.Errorf("unexpected evaluation %+v (%v)", evaluation, err)
+	}
+	if prompt := generator.Calls()[0].Prompt; !strings.Contains(prompt, "word memory") {
+		t.Errorf("expected the vocab prompt, got %q", prompt)
+	}
+}
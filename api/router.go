@@ -0,0 +1,223 @@
+package main
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"story-api/audio"
+	"story-api/qna"
+	"story-api/storage"
+	"story-api/supabase"
+
+	"story-api/handlers/audiohandler"
+	"story-api/handlers/billinghandler"
+	"story-api/handlers/cataloghandler"
+	"story-api/handlers/editorhandler"
+	"story-api/handlers/feedhandler"
+	"story-api/handlers/newshandler"
+	"story-api/handlers/orghandler"
+	"story-api/handlers/profilehandler"
+	"story-api/handlers/progresshandler"
+	"story-api/handlers/qnahandler"
+	"story-api/handlers/readinghandler"
+	"story-api/handlers/searchhandler"
+	"story-api/handlers/storyhandler"
+	"story-api/handlers/stripehandler"
+	"story-api/handlers/student"
+	"story-api/handlers/teacher"
+	"story-api/handlers/vocabhandler"
+)
+
+// Dependencies are the clients shared by all handlers. They are built once in
+// main and passed in, so the same router can be served through the Lambda
+// proxy or a plain HTTP server.
+type Dependencies struct {
+	DBClient    *supabase.Client
+	AudioClient *audio.Client
+	Storage     *storage.Client
+	QNAClient   *qna.Client
+
+	// AutoLevelAdjust lets /profile/level/apply replace a user's skill level
+	// with a confident estimate (AUTO_LEVEL_ADJUST=true).
+	AutoLevelAdjust bool
+}
+
+func newRouter(deps Dependencies) *gin.Engine {
+	router := gin.Default()
+
+	AllowOrigin := "*"
+
+	router.Use(func(c *gin.Context) {
+		c.Writer.Header().Set("Access-Control-Allow-Origin", AllowOrigin)
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type,Authorization,Stripe-Signature")
+		c.Writer.Header().Set("Access-Control-Max-Age", "3600")
+
+		if c.Request.Method == http.MethodOptions {
+			c.AbortWithStatus(http.StatusOK)
+			return
+		}
+
+		c.Next()
+	})
+
+	router.Use(func(c *gin.Context) {
+		if c.Request.Method != http.MethodOptions && !strings.HasSuffix(c.Request.URL.Path, "/webhook") {
+			authMiddleware()(c)
+		}
+	})
+
+	// unprotected webhook route
+	webhookGroup := router.Group("/webhook")
+	{
+		stripeHandler := stripehandler.New(deps.DBClient)
+		webhookGroup.POST("", stripeHandler.HandleWebhook)
+	}
+
+	billingHandler := billing.New(deps.DBClient)
+	billingGroup := router.Group("/billing")
+	{
+		billingGroup.GET("", billingHandler.GetBillingAccount)
+		billingGroup.GET("/usage", billingHandler.GetBillingAccountUsage)
+		billingGroup.POST("/create-checkout-session", billingHandler.CreateCheckoutSession)
+		billingGroup.POST("/cancel-subscription-eop", billingHandler.CancelSubscriptionAtEndOfPeriod)
+	}
+
+	orgHandler := org.New(deps.DBClient)
+	orgGroup := router.Group("/organization")
+	{
+		orgGroup.GET("", orgHandler.CheckOrganization)
+		orgGroup.POST("/create", orgHandler.CreateOrganization)
+		orgGroup.POST("/join", orgHandler.JoinOrganization)
+
+		paymentsGroup := orgGroup.Group("/payments")
+		{
+			paymentsGroup.GET("", orgHandler.GetOrganizationPayments)
+			paymentsGroup.POST("/create-checkout-session", orgHandler.CreateCheckoutSession)
+			paymentsGroup.POST("/cancel-subscription-eop", orgHandler.CancelSubscriptionAtEndOfPeriod)
+		}
+	}
+
+	teacherHandler := teacher.New(deps.DBClient)
+	teacherGroup := router.Group("/teacher")
+	{
+		teacherGroup.GET("", teacherHandler.CheckTeacherStatus)
+
+		classroomGroup := teacherGroup.Group("/classroom")
+		{
+			classroomGroup.GET("", teacherHandler.GetClassroomList)
+			classroomGroup.POST("/update", teacherHandler.UpdateClassroom)
+			classroomGroup.GET("/content", teacherHandler.QueryClassroomContent)
+			classroomGroup.POST("/create", teacherHandler.CreateClassroom)
+			classroomGroup.POST("/delete", teacherHandler.DeleteClassroom)
+			classroomGroup.POST("/accept", teacherHandler.AcceptContent)
+			classroomGroup.POST("/reject", teacherHandler.RejectContent)
+		}
+	}
+
+	studentHandler := student.New(deps.DBClient)
+	studentGroup := router.Group("/student")
+	{
+		studentGroup.GET("", studentHandler.CheckStudentStatus)
+
+		classroomGroup := studentGroup.Group("/classroom")
+		{
+			classroomGroup.GET("", studentHandler.GetClassroomInfo)
+			classroomGroup.POST("/join", studentHandler.JoinClassroom)
+		}
+	}
+
+	audioHandler := audiohandler.New(deps.DBClient, deps.AudioClient, deps.Storage)
+	audioGroup := router.Group("/audio")
+	{
+		audioGroup.GET("", audioHandler.CheckHealth)
+		audioGroup.POST("/translate", audioHandler.Translate)
+		audioGroup.POST("/tts", audioHandler.TextToSpeech)
+		audioGroup.POST("/stt", audioHandler.SpeechToText)
+		audioGroup.GET("/audiobook", audioHandler.GetAudiobook)
+	}
+
+	progressHandler := progresshandler.New(deps.DBClient)
+	progressGroup := router.Group("/progress")
+	{
+		progressGroup.GET("", progressHandler.GetTodayProgress)
+		progressGroup.GET("/streak", progressHandler.GetStreak)
+		progressGroup.GET("/increment", progressHandler.IncrementProgress)
+	}
+
+	profileHandler := profilehandler.New(deps.DBClient, deps.AutoLevelAdjust)
+	profileGroup := router.Group("/profile")
+	{
+		profileGroup.GET("", profileHandler.GetProfile)
+		profileGroup.POST("/upsert", profileHandler.UpsertProfile)
+		profileGroup.GET("/level", profileHandler.GetLevel)
+		profileGroup.POST("/level/apply", profileHandler.ApplyLevel)
+	}
+
+	newsHandler := newshandler.New(deps.DBClient, deps.Storage)
+	newsGroup := router.Group("/news")
+	{
+		newsGroup.GET("", newsHandler.GetNews)
+		newsGroup.GET("/query", newsHandler.GetNewsQuery)
+	}
+
+	storyHandler := storyhandler.New(deps.DBClient, deps.Storage)
+	storyGroup := router.Group("/story")
+	{
+		storyGroup.GET("", storyHandler.GetStoryPage)
+		storyGroup.GET("/context", storyHandler.GetStoryQNAContext)
+		storyGroup.GET("/query", storyHandler.GetStoryQuery)
+	}
+
+	catalogHandler := cataloghandler.New(deps.DBClient)
+	catalogGroup := router.Group("/catalog")
+	{
+		catalogGroup.GET("/languages", catalogHandler.GetLanguages)
+		catalogGroup.GET("/topics", catalogHandler.GetTopics)
+	}
+
+	editorHandler := editorhandler.New(deps.DBClient, deps.Storage)
+	editorGroup := router.Group("/editor")
+	{
+		editorGroup.GET("/content", editorHandler.GetContentList)
+		editorGroup.GET("/content/item", editorHandler.GetContent)
+		editorGroup.POST("/content/update", editorHandler.UpdateContent)
+		editorGroup.POST("/content/publish", editorHandler.PublishContent)
+		editorGroup.POST("/content/retract", editorHandler.RetractContent)
+	}
+
+	qnaHandler := qnahandler.New(deps.DBClient, deps.Storage, deps.QNAClient)
+	qnaGroup := router.Group("/qna")
+	{
+		qnaGroup.POST("", qnaHandler.GetQuestion)
+		qnaGroup.POST("/evaluate", qnaHandler.EvaluateAnswer)
+		qnaGroup.GET("/history", qnaHandler.GetHistory)
+	}
+
+	readingHandler := readinghandler.New(deps.DBClient)
+	readingGroup := router.Group("/reading")
+	{
+		readingGroup.POST("/progress", readingHandler.UpdateProgress)
+		readingGroup.GET("/continue", readingHandler.ContinueReading)
+	}
+
+	feedHandler := feedhandler.New(deps.DBClient)
+	router.GET("/feed", feedHandler.GetFeed)
+
+	searchHandler := searchhandler.New(deps.DBClient)
+	router.GET("/search", searchHandler.Search)
+
+	vocabHandler := vocabhandler.New(deps.DBClient)
+	vocabGroup := router.Group("/vocab")
+	{
+		vocabGroup.GET("", vocabHandler.GetCards)
+		vocabGroup.GET("/due", vocabHandler.GetDueCards)
+		vocabGroup.POST("/save", vocabHandler.SaveWord)
+		vocabGroup.POST("/review", vocabHandler.ReviewCard)
+		vocabGroup.POST("/delete", vocabHandler.DeleteCard)
+	}
+
+	return router
+}
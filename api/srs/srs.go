@@ -0,0 +1,80 @@
+// Package srs schedules the reviews of vocabulary cards with SM-2, the
+// algorithm of SuperMemo 2 (and, with tweaks, Anki). Each review is graded
+// from 0 to 5; cards graded 3 or more come back after a growing interval,
+// the others start over.
+package srs
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Grades, as in SM-2.
+const (
+	GRADE_BLACKOUT  = 0 // no recollection
+	GRADE_WRONG     = 1 // wrong, but recognised the answer
+	GRADE_HARD_MISS = 2 // wrong, but the answer seemed easy to recall
+	GRADE_HARD      = 3 // correct with serious difficulty
+	GRADE_GOOD      = 4 // correct after hesitation
+	GRADE_EASY      = 5 // perfect recall
+
+	MIN_GRADE  = GRADE_BLACKOUT
+	MAX_GRADE  = GRADE_EASY
+	PASS_GRADE = GRADE_HARD
+)
+
+const (
+	DEFAULT_EASE_FACTOR = 2.5
+	MIN_EASE_FACTOR     = 1.3
+)
+
+// Card is the schedule of a card. A new card has no repetitions and is due
+// straight away.
+type Card struct {
+	EaseFactor   float64
+	IntervalDays int
+	Repetitions  int // correct reviews in a row
+	DueAt        time.Time
+}
+
+// NewCard is a card that has never been reviewed, due at now.
+func NewCard(now time.Time) Card {
+	return Card{EaseFactor: DEFAULT_EASE_FACTOR, DueAt: now}
+}
+
+// ValidGrade reports whether grade is one of the SM-2 grades.
+func ValidGrade(grade int) bool {
+	return grade >= MIN_GRADE && grade <= MAX_GRADE
+}
+
+// Review returns the schedule of card after a review at now.
+func Review(card Card, grade int, now time.Time) (Card, error) {
+	if !ValidGrade(grade) {
+		return card, fmt.Errorf("grade must be between %d and %d: %d", MIN_GRADE, MAX_GRADE, grade)
+	}
+	if card.EaseFactor == 0 {
+		card.EaseFactor = DEFAULT_EASE_FACTOR
+	}
+
+	if grade < PASS_GRADE {
+		// forgotten cards start over but keep their ease factor
+		card.Repetitions = 0
+		card.IntervalDays = 1
+	} else {
+		switch card.Repetitions {
+		case 0:
+			card.IntervalDays = 1
+		case 1:
+			card.IntervalDays = 6
+		default:
+			card.IntervalDays = int(math.Round(float64(card.IntervalDays) * card.EaseFactor))
+		}
+		card.Repetitions++
+
+		q := float64(MAX_GRADE - grade)
+		card.EaseFactor = math.Max(MIN_EASE_FACTOR, card.EaseFactor+0.1-q*(0.08+q*0.02))
+	}
+	card.DueAt = now.AddDate(0, 0, card.IntervalDays)
+	return card, nil
+}
@@ -0,0 +1,62 @@
+package srs
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReviewGrowsTheInterval(t *testing.T) {
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	card := NewCard(now)
+
+	var err error
+	for i, want := range []int{1, 6, 15, 38} {
+		card, err = Review(card, GRADE_GOOD, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if card.IntervalDays != want || card.Repetitions != i+1 {
+			t.Fatalf("review %d: expected an interval of %d days, got %+v", i+1, want, card)
+		}
+	}
+	if !card.DueAt.Equal(now.AddDate(0, 0, 38)) {
+		t.Errorf("unexpected due date %v", card.DueAt)
+	}
+	if card.EaseFactor != DEFAULT_EASE_FACTOR {
+		t.Errorf("expected grade 4 to keep the ease factor, got %v", card.EaseFactor)
+	}
+}
+
+func TestReviewEaseFactor(t *testing.T) {
+	now := time.Now()
+
+	easy, _ := Review(NewCard(now), GRADE_EASY, now)
+	if easy.EaseFactor != 2.6 {
+		t.Errorf("expected grade 5 to raise the ease factor to 2.6, got %v", easy.EaseFactor)
+	}
+
+	card := NewCard(now)
+	for i := 0; i < 10; i++ {
+		card, _ = Review(card, GRADE_HARD, now)
+	}
+	if card.EaseFactor != MIN_EASE_FACTOR {
+		t.Errorf("expected the ease factor to stop at %v, got %v", MIN_EASE_FACTOR, card.EaseFactor)
+	}
+}
+
+func TestForgottenCardsStartOver(t *testing.T) {
+	now := time.Now()
+	card := Card{EaseFactor: 2.2, IntervalDays: 30, Repetitions: 5, DueAt: now}
+
+	card, err := Review(card, GRADE_WRONG, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if card.Repetitions != 0 || card.IntervalDays != 1 || card.EaseFactor != 2.2 {
+		t.Errorf("expected the card to start over with its ease factor, got %+v", card)
+	}
+
+	if _, err := Review(card, 6, now); err == nil {
+		t.Error("expected an invalid grade to be rejected")
+	}
+}
@@ -4,16 +4,13 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log"
-	"os"
 	"strings"
 
-	"github.com/aws/aws-sdk-go-v2/aws"
-	"github.com/aws/aws-sdk-go-v2/config"
-	"github.com/aws/aws-sdk-go-v2/service/s3"
-
 	"github.com/gin-gonic/gin"
+
+	"squeak-shared/contentstore"
+	"squeak-shared/dictionary"
 )
 
 type Content interface {
@@ -34,12 +31,9 @@ func (n News) ToMap() gin.H {
 	}
 }
 
-type Dictionary struct {
-	Translations struct {
-		Words     map[string]string `json:"words"`
-		Sentences map[string]string `json:"sentences"`
-	} `json:"translations"`
-}
+// Dictionary has the original surface-form translations plus, for newer
+// articles, lemma entries and per-occurrence tokens.
+type Dictionary = dictionary.Dictionary
 
 // since stories are mostly stored as pages, this type is mostly
 // used to represent a single page of a story
@@ -67,49 +61,29 @@ const (
 	ArticleType ContentType = "Article"
 )
 
-func buildS3Key(language string, cefr string, subject string, contentType string, date string) string {
-	return fmt.Sprintf("%s/%s/%s/%s/%s_%s_%s_%s.json",
-		strings.ToLower(language),
-		strings.ToUpper(cefr),
-		strings.Title(subject),
-		strings.Title(contentType),
-		strings.ToUpper(cefr),
-		strings.Title(contentType),
-		strings.Title(subject),
-		date,
-	)
+// Client reads content through whichever ContentStore it was given, so the
+// API can be pointed at S3 or at a local directory.
+type Client struct {
+	store contentstore.ContentStore
 }
 
-func PullContent(language string, cefrLevel string, subject string, contentType string, dateCreated string) (News, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion("us-east-2"))
-	if err != nil {
-		log.Printf("unable to load SDK config, %v", err)
-		return News{}, err
+func NewClient(store contentstore.ContentStore) *Client {
+	return &Client{
+		store: store,
 	}
+}
 
-	client := s3.NewFromConfig(cfg)
-	key := buildS3Key(language, cefrLevel, subject, contentType, dateCreated)
-
-	resp, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
-		Bucket: aws.String(os.Getenv("STORY_BUCKET_NAME")),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		log.Println(err)
-		return News{}, err
-	}
-	defer resp.Body.Close()
+func (c *Client) PullContent(language string, cefrLevel string, subject string, contentType string, dateCreated string) (News, error) {
+	key := contentstore.NewsKey(language, cefrLevel, subject, contentType, dateCreated)
 
-	var builder strings.Builder
-	_, err = io.Copy(&builder, resp.Body)
+	data, err := c.store.Get(context.TODO(), key)
 	if err != nil {
-		log.Println(err)
+		log.Printf("failed to get %s: %v", key, err)
 		return News{}, err
 	}
 
-	jsonData := builder.String()
 	var news News
-	err = json.Unmarshal([]byte(jsonData), &news)
+	err = json.Unmarshal(data, &news)
 	if err != nil {
 		log.Printf("failed to unmarshal News JSON: %v", err)
 		return News{}, err
@@ -117,81 +91,58 @@ func PullContent(language string, cefrLevel string, subject string, contentType
 	return news, nil
 }
 
-func buildS3PageKey(language string, cefr string, subject string, id string, page int) string {
-	return fmt.Sprintf("%s/%s/%s/Story/%s/page%d.mdx",
-		strings.ToLower(language),
-		strings.ToUpper(cefr),
-		strings.Title(subject),
-		id,
-		page,
-	)
-}
+func (c *Client) PullStoryByPage(language string, cefrLevel string, subject string, id string, page int) (Story, error) {
+	key := contentstore.StoryPageKey(language, cefrLevel, subject, id, page)
 
-func PullStoryByPage(language string, cefrLevel string, subject string, id string, page int) (Story, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion("us-east-2"))
+	data, err := c.store.Get(context.TODO(), key)
 	if err != nil {
-		log.Printf("unable to load SDK config, %v", err)
+		log.Printf("failed to get story page %s: %v", key, err)
 		return Story{}, err
 	}
 
-	client := s3.NewFromConfig(cfg)
-	key := buildS3PageKey(language, cefrLevel, subject, id, page)
+	return Story{Content: string(data)}, nil
+}
 
-	resp, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
-		Bucket: aws.String(os.Getenv("STORY_BUCKET_NAME")),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		log.Printf("failed to get object from S3: %v", err)
-		return Story{}, err
-	}
-	defer resp.Body.Close()
+func (c *Client) PullStoryQNAContext(language string, cefrLevel string, subject string, id string) (string, error) {
+	key := contentstore.StoryContextKey(language, cefrLevel, subject, id)
 
-	var builder strings.Builder
-	_, err = io.Copy(&builder, resp.Body)
+	data, err := c.store.Get(context.TODO(), key)
 	if err != nil {
-		log.Printf("failed to read object body: %v", err)
-		return Story{}, err
+		log.Printf("failed to get context.txt %s: %v", key, err)
+		return "", err
 	}
 
-	return Story{Content: builder.String()}, nil
+	return string(data), nil
 }
 
-func buildS3QNAContextKey(language string, cefr string, subject string, id string) string {
-	return fmt.Sprintf("%s/%s/%s/Story/%s/context.txt",
-		strings.ToLower(language),
-		strings.ToUpper(cefr),
-		strings.Title(subject),
-		id,
-	)
-}
+// PutContent replaces the stored body of a news article.
+func (c *Client) PutContent(language string, cefrLevel string, subject string, contentType string, dateCreated string, news News) error {
+	key := contentstore.NewsKey(language, cefrLevel, subject, contentType, dateCreated)
 
-func PullStoryQNAContext(language string, cefrLevel string, subject string, id string) (string, error) {
-	cfg, err := config.LoadDefaultConfig(context.TODO(), config.WithRegion("us-east-2"))
+	data, err := json.Marshal(news)
 	if err != nil {
-		log.Printf("unable to load SDK config, %v", err)
-		return "", err
+		return fmt.Errorf("failed to marshal News JSON: %v", err)
 	}
+	return c.store.Put(context.TODO(), key, data)
+}
 
-	client := s3.NewFromConfig(cfg)
-	key := buildS3QNAContextKey(language, cefrLevel, subject, id)
-
-	resp, err := client.GetObject(context.TODO(), &s3.GetObjectInput{
-		Bucket: aws.String(os.Getenv("STORY_BUCKET_NAME")),
-		Key:    aws.String(key),
-	})
-	if err != nil {
-		log.Printf("failed to get context.txt from S3: %v", err)
-		return "", err
+// PutStoryPages replaces the pages of a story and the QNA context, which is
+// the whole story as the generation lambda writes it. Pages from
+// len(pages) up to previousPages are deleted.
+func (c *Client) PutStoryPages(language string, cefrLevel string, subject string, id string, pages []string, previousPages int) error {
+	for i, page := range pages {
+		if err := c.store.Put(context.TODO(), contentstore.StoryPageKey(language, cefrLevel, subject, id, i), []byte(page)); err != nil {
+			return fmt.Errorf("failed to put story page %d: %v", i, err)
+		}
 	}
-	defer resp.Body.Close()
-
-	var builder strings.Builder
-	_, err = io.Copy(&builder, resp.Body)
-	if err != nil {
-		log.Printf("failed to read context.txt body: %v", err)
-		return "", err
+	storyContext := contentstore.StoryContext(strings.Join(pages, "\n\n"))
+	if err := c.store.Put(context.TODO(), contentstore.StoryContextKey(language, cefrLevel, subject, id), []byte(storyContext)); err != nil {
+		return fmt.Errorf("failed to put story context: %v", err)
 	}
-
-	return builder.String(), nil
+	for i := len(pages); i < previousPages; i++ {
+		if err := c.store.Delete(context.TODO(), contentstore.StoryPageKey(language, cefrLevel, subject, id, i)); err != nil {
+			return fmt.Errorf("failed to delete story page %d: %v", i, err)
+		}
+	}
+	return nil
 }
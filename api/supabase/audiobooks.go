@@ -27,7 +27,7 @@ func (c *Client) GetAudiobook(contentType string, id string) (AudiobookInfo, err
 	tableName := contentType
 	if contentType == "story" { tableName = "stories" }
 
-	err := c.db.QueryRow(fmt.Sprintf("SELECT language, topic, cefr_level, date_created FROM %s WHERE id = $1", tableName),
+	err := c.db.QueryRow(fmt.Sprintf("SELECT language, topic, cefr_level, date_created FROM %s WHERE id = $1 AND status = 'published'", tableName),
 		id).Scan(&language, &topic, &cefr_level, &date)
 	if err != nil {
 		return AudiobookInfo{}, err
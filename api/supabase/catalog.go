@@ -0,0 +1,35 @@
+package supabase
+
+import "fmt"
+
+// GenerationCell is one enabled row of generation_matrix.
+type GenerationCell struct {
+	Language      string
+	CEFRLevel     string
+	Subject       string
+	ContentType   string
+	AudiobookTier string
+	FrequencyDays int
+}
+
+func (c *Client) GetGenerationMatrix() ([]GenerationCell, error) {
+	rows, err := c.db.Query(`
+		SELECT language, cefr_level, subject, content_type, COALESCE(audiobook_tier, ''), frequency_days
+		FROM generation_matrix
+		WHERE enabled
+		ORDER BY language, cefr_level, subject, content_type`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get generation matrix: %v", err)
+	}
+	defer rows.Close()
+
+	cells := []GenerationCell{}
+	for rows.Next() {
+		var cell GenerationCell
+		if err := rows.Scan(&cell.Language, &cell.CEFRLevel, &cell.Subject, &cell.ContentType, &cell.AudiobookTier, &cell.FrequencyDays); err != nil {
+			return nil, fmt.Errorf("failed to scan generation matrix: %v", err)
+		}
+		cells = append(cells, cell)
+	}
+	return cells, rows.Err()
+}
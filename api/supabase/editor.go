@@ -0,0 +1,252 @@
+package supabase
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Content statuses. Only published content is returned by the content
+// queries, the others are only seen by editors.
+const (
+	STATUS_DRAFT     = "draft"
+	STATUS_IN_REVIEW = "in_review"
+	STATUS_PUBLISHED = "published"
+	STATUS_RETRACTED = "retracted"
+)
+
+// EditorContent is a news or stories row as editors see it, whatever its
+// status.
+type EditorContent struct {
+	ID          string
+	ContentType string // News or Story
+	Title       string
+	Language    string
+	Topic       string
+	CEFRLevel   string
+	PreviewText string
+	Pages       int // stories only
+	Status      string
+	Moderation  json.RawMessage // nil when the content was not moderated
+	Readability json.RawMessage // nil when readability was not measured
+	CreatedAt   time.Time
+	DateCreated string
+	EditedAt    *time.Time
+	ReviewedBy  string
+	ReviewedAt  *time.Time
+	ReviewNote  string
+}
+
+func contentTableName(contentType string) (string, error) {
+	switch contentType {
+	case "News":
+		return "news", nil
+	case "Story":
+		return "stories", nil
+	}
+	return "", fmt.Errorf("invalid content type: %s", contentType)
+}
+
+func (c *Client) CheckEditorStatus(userID string) (bool, error) {
+	var exists bool
+	err := c.db.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM editors
+			WHERE user_id = $1
+		)
+	`, userID).Scan(&exists)
+
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+// editorSelect selects the EditorContent columns of a table, see scanEditorContent.
+func editorSelect(contentType string, table string) string {
+	pages := "NULL::int"
+	if table == "stories" {
+		pages = "pages"
+	}
+	return fmt.Sprintf(`
+		SELECT '%s'::text AS content_type, id::text, title, language, topic, cefr_level, preview_text, %s AS pages,
+			status, moderation, readability, created_at, date_created, edited_at,
+			COALESCE(reviewed_by::text, ''), reviewed_at, COALESCE(review_note, '')
+		FROM %s`, contentType, pages, table)
+}
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanEditorContent(row rowScanner) (EditorContent, error) {
+	var content EditorContent
+	var pages sql.NullInt64
+	var moderation, readability []byte
+	var dateCreated sql.NullTime
+	var editedAt, reviewedAt sql.NullTime
+	err := row.Scan(
+		&content.ContentType, &content.ID, &content.Title, &content.Language, &content.Topic, &content.CEFRLevel, &content.PreviewText, &pages,
+		&content.Status, &moderation, &readability, &content.CreatedAt, &dateCreated, &editedAt,
+		&content.ReviewedBy, &reviewedAt, &content.ReviewNote,
+	)
+	if err != nil {
+		return content, err
+	}
+	content.Pages = int(pages.Int64)
+	if moderation != nil {
+		content.Moderation = json.RawMessage(moderation)
+	}
+	if readability != nil {
+		content.Readability = json.RawMessage(readability)
+	}
+	content.DateCreated = dateCreated.Time.Format("2006-01-02")
+	if editedAt.Valid {
+		content.EditedAt = &editedAt.Time
+	}
+	if reviewedAt.Valid {
+		content.ReviewedAt = &reviewedAt.Time
+	}
+	return content, nil
+}
+
+// QueryEditorContent lists News, Story or All content with a status, newest first.
+func (c *Client) QueryEditorContent(contentType string, status string, page int, pageSize int) ([]EditorContent, error) {
+	parts := []string{}
+	if contentType == "News" || contentType == "All" {
+		parts = append(parts, editorSelect("News", "news")+" WHERE status = $1")
+	}
+	if contentType == "Story" || contentType == "All" {
+		parts = append(parts, editorSelect("Story", "stories")+" WHERE status = $1")
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("invalid content type: %s", contentType)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s) editor_content
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3`, strings.Join(parts, " UNION ALL "))
+
+	rows, err := c.db.Query(query, status, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query editor content: %v", err)
+	}
+	defer rows.Close()
+
+	results := []EditorContent{}
+	for rows.Next() {
+		content, err := scanEditorContent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan editor content: %v", err)
+		}
+		results = append(results, content)
+	}
+	return results, rows.Err()
+}
+
+// GetEditorContent returns a news or stories row whatever its status, or nil
+// if there is none.
+func (c *Client) GetEditorContent(contentType string, contentID string) (*EditorContent, error) {
+	table, err := contentTableName(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := scanEditorContent(c.db.QueryRow(editorSelect(contentType, table)+" WHERE id = $1", contentID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get editor content: %v", err)
+	}
+	return &content, nil
+}
+
+// UpdateContentText replaces the title and preview text of a news or stories
+// row, and for stories the number of pages.
+func (c *Client) UpdateContentText(contentType string, contentID string, title string, previewText string, pages int) error {
+	table, err := contentTableName(contentType)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf(`UPDATE %s SET title = $1, preview_text = $2, edited_at = NOW() WHERE id = $3`, table)
+	args := []interface{}{title, previewText, contentID}
+	if contentType == "Story" {
+		query = `UPDATE stories SET title = $1, preview_text = $2, pages = $4, edited_at = NOW() WHERE id = $3`
+		args = append(args, pages)
+	}
+
+	result, err := c.db.Exec(query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update content: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("no content was updated")
+	}
+	return nil
+}
+
+// RetireDerivedContent retires the questions and deletes the audiobook of a
+// news or stories row whose body was edited, as they were made from the old
+// body. Retired questions are no longer served but keep their attempts, and
+// new ones are generated on request; the audiobook objects are left in the
+// content store but no longer listed.
+func (c *Client) RetireDerivedContent(contentType string, contentID string) error {
+	column, err := questionColumn(contentType)
+	if err != nil {
+		return err
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`UPDATE questions SET retired_at = NOW() WHERE %s = $1 AND retired_at IS NULL`, column), contentID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to retire questions: %v", err)
+	}
+	if _, err := tx.Exec(fmt.Sprintf(`DELETE FROM audiobooks WHERE %s = $1`, column), contentID); err != nil {
+		tx.Rollback()
+		return fmt.Errorf("failed to delete audiobook: %v", err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return nil
+}
+
+// SetContentStatus moves content to a status if it currently has one of the
+// from statuses, recording the editor and their note. It returns false if the
+// content did not have one of the from statuses.
+func (c *Client) SetContentStatus(contentType string, contentID string, from []string, to string, editorID string, note string) (bool, error) {
+	table, err := contentTableName(contentType)
+	if err != nil {
+		return false, err
+	}
+
+	result, err := c.db.Exec(fmt.Sprintf(`
+		UPDATE %s
+		SET status = $1, reviewed_by = $2, reviewed_at = NOW(), review_note = NULLIF($3, '')
+		WHERE id = $4 AND status = ANY($5)`, table),
+		to, editorID, note, contentID, pq.Array(from))
+	if err != nil {
+		return false, fmt.Errorf("failed to set content status: %v", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %v", err)
+	}
+	return rowsAffected > 0, nil
+}
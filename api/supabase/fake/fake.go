@@ -0,0 +1,1872 @@
+// Package fake is an in-memory implementation of supabase.Repository for
+// handler tests. It mirrors the behaviour of the SQL queries closely enough
+// for role, plan, usage and whitelist checks, but makes no attempt to be a
+// general database.
+package fake
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"story-api/cefr"
+	"story-api/feed"
+	"story-api/models"
+	"story-api/plans"
+	"story-api/srs"
+	"story-api/supabase"
+)
+
+type Content struct {
+	ID          string
+	Title       string
+	Language    string
+	Topic       string
+	CEFRLevel   string
+	PreviewText string
+	DateCreated string // YYYY-MM-DD, defaults to today
+	CreatedAt   time.Time
+	Pages       int    // stories only
+	Words       int    // the readability word count, 0 if not measured
+	Body        string // the body text searched by SearchContent
+	Status      string // defaults to published
+	Moderation  json.RawMessage
+
+	// set by the editor methods
+	EditedAt   *time.Time
+	ReviewedBy string
+	ReviewNote string
+}
+
+func (c *Content) published() bool {
+	return c.Status == "" || c.Status == supabase.STATUS_PUBLISHED
+}
+
+func (c *Content) status() string {
+	if c.Status == "" {
+		return supabase.STATUS_PUBLISHED
+	}
+	return c.Status
+}
+
+type audiobook struct {
+	tier  string
+	pages int
+}
+
+type teacher struct {
+	id             string
+	organizationID string
+}
+
+type student struct {
+	id          string
+	classroomID string
+}
+
+type classroom struct {
+	id           string
+	teacherID    string
+	name         string
+	studentCount int
+}
+
+type organization struct {
+	id             string
+	adminID        string
+	plan           string
+	customerID     string
+	subscriptionID string
+	expiration     time.Time
+	canceled       bool
+}
+
+type billingAccount struct {
+	plan           string
+	expiration     time.Time
+	canceled       bool
+	customerID     string
+	subscriptionID string
+}
+
+type usageRow struct {
+	userID    string
+	featureID string
+	plan      string
+	amount    int
+	periodEnd string
+}
+
+type attemptRow struct {
+	userID  string
+	attempt supabase.QuestionAttempt
+}
+
+type vocabRow struct {
+	userID  string
+	card    supabase.VocabCard
+	reviews int
+}
+
+type lookupRow struct {
+	userID      string
+	contentType string
+	contentID   string
+}
+
+type readingRow struct {
+	userID  string
+	session supabase.ReadingSession
+	updated int // nextID when last updated, to order sessions within a clock tick
+}
+
+// LevelEstimate is a stored level estimate.
+type LevelEstimate struct {
+	cefr.Estimate
+	DeclaredLevel string
+	Evidence      []cefr.Evidence
+	Applied       bool
+}
+
+type profileRow struct {
+	id      int
+	profile supabase.Profile
+}
+
+// DB is safe for concurrent use. The zero value is not usable, call New.
+type DB struct {
+	mu sync.Mutex
+
+	nextID int
+
+	news       map[string]*Content
+	stories    map[string]*Content
+	audiobooks map[string]audiobook // "<contentType>:<id>"
+	questions  []*supabase.Question
+	retired    map[int]bool // question IDs
+	attempts   []attemptRow
+	vocab      []*vocabRow
+	lookups    []lookupRow
+	reading    []*readingRow
+	estimates  map[string]*LevelEstimate // "<userID>:<language>"
+	accepted   map[string]bool           // "<classroomID>:<contentType>:<id>"
+
+	profiles      map[string]*profileRow
+	progress      map[string]map[string]*supabase.DailyProgress // user -> date -> progress
+	teachers      map[string]*teacher                           // by user ID
+	students      map[string]*student                           // by user ID
+	editors       map[string]bool                               // by user ID
+	classrooms    map[string]*classroom
+	organizations map[string]*organization
+	billing       map[string]*billingAccount
+	usage         []usageRow
+	matrix        []supabase.GenerationCell
+
+	failures map[string]error
+}
+
+var _ supabase.Repository = (*DB)(nil)
+
+func New() *DB {
+	return &DB{
+		news:          make(map[string]*Content),
+		stories:       make(map[string]*Content),
+		audiobooks:    make(map[string]audiobook),
+		retired:       make(map[int]bool),
+		accepted:      make(map[string]bool),
+		estimates:     make(map[string]*LevelEstimate),
+		profiles:      make(map[string]*profileRow),
+		progress:      make(map[string]map[string]*supabase.DailyProgress),
+		teachers:      make(map[string]*teacher),
+		students:      make(map[string]*student),
+		editors:       make(map[string]bool),
+		classrooms:    make(map[string]*classroom),
+		organizations: make(map[string]*organization),
+		billing:       make(map[string]*billingAccount),
+		failures:      make(map[string]error),
+	}
+}
+
+// Fail makes every later call to the named method (e.g. "GetUsage") return err.
+func (f *DB) Fail(method string, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failures[method] = err
+}
+
+func (f *DB) fail(method string) error {
+	return f.failures[method]
+}
+
+func (f *DB) newID() string {
+	f.nextID++
+	return strconv.Itoa(f.nextID)
+}
+
+func today() string {
+	return time.Now().Format("2006-01-02")
+}
+
+// Seeding helpers
+
+func (f *DB) AddNews(c Content) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addContent(f.news, c)
+}
+
+func (f *DB) AddStory(c Content) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.addContent(f.stories, c)
+}
+
+func (f *DB) addContent(table map[string]*Content, c Content) string {
+	if c.ID == "" {
+		c.ID = f.newID()
+	}
+	if c.DateCreated == "" {
+		c.DateCreated = today()
+	}
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = time.Now().Add(time.Duration(f.nextID) * time.Millisecond)
+	}
+	table[c.ID] = &c
+	return c.ID
+}
+
+// Content returns a copy of seeded content, or nil.
+func (f *DB) Content(contentType string, id string) *Content {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	table, err := f.contentTable(contentType)
+	if err != nil || table[id] == nil {
+		return nil
+	}
+	c := *table[id]
+	return &c
+}
+
+// AddEditor makes userID an editor.
+func (f *DB) AddEditor(userID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.editors[userID] = true
+}
+
+// AddAudiobook registers an audiobook for News or Story content.
+func (f *DB) AddAudiobook(contentType string, id string, tier string, pages int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.audiobooks[contentType+":"+id] = audiobook{tier: tier, pages: pages}
+}
+
+// AddGenerationCell adds an enabled generation_matrix row.
+func (f *DB) AddGenerationCell(cell supabase.GenerationCell) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.matrix = append(f.matrix, cell)
+}
+
+// AddOrganization creates an organization on the given plan and returns its ID.
+func (f *DB) AddOrganization(adminID string, plan string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := "org-" + f.newID()
+	f.organizations[id] = &organization{id: id, adminID: adminID, plan: plan}
+	return id
+}
+
+// AddTeacher makes userID a teacher in the organization and returns the teacher ID.
+func (f *DB) AddTeacher(userID string, organizationID string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := "teacher-" + f.newID()
+	f.teachers[userID] = &teacher{id: id, organizationID: organizationID}
+	return id
+}
+
+// AddClassroom creates a classroom owned by teacherID (a teacher ID, not a user ID).
+func (f *DB) AddClassroom(teacherID string, name string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	id := f.newID()
+	f.classrooms[id] = &classroom{id: id, teacherID: teacherID, name: name}
+	return id
+}
+
+// AddStudent puts userID in the classroom and returns the student ID.
+func (f *DB) AddStudent(userID string, classroomID string) string {
+	if err := f.AddStudentToClassroom(classroomID, userID); err != nil {
+		panic(err)
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.students[userID].id
+}
+
+func (f *DB) SetBillingPlan(userID string, plan string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.billingAccount(userID).plan = plan
+}
+
+// SetProgress records progress for a given day, e.g. to build up a streak.
+func (f *DB) SetProgress(userID string, date time.Time, questionsCompleted int, goalMet bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	day := date.Format("2006-01-02")
+	if f.progress[userID] == nil {
+		f.progress[userID] = make(map[string]*supabase.DailyProgress)
+	}
+	parsed, _ := time.Parse("2006-01-02", day)
+	f.progress[userID][day] = &supabase.DailyProgress{
+		UserID:             userID,
+		Date:               parsed,
+		QuestionsCompleted: questionsCompleted,
+		GoalMet:            goalMet,
+	}
+}
+
+// TotalUsage sums recorded usage for a feature across all periods and plans.
+func (f *DB) TotalUsage(userID string, featureID string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	total := 0
+	for _, row := range f.usage {
+		if row.userID == userID && row.featureID == featureID {
+			total += row.amount
+		}
+	}
+	return total
+}
+
+// ContentRepository
+
+func (f *DB) contentTable(contentType string) (map[string]*Content, error) {
+	switch contentType {
+	case "News":
+		return f.news, nil
+	case "Story":
+		return f.stories, nil
+	}
+	return nil, fmt.Errorf("invalid content type: %s", contentType)
+}
+
+func (f *DB) QueryNews(params supabase.QueryParams) ([]map[string]interface{}, error) {
+	return f.queryContent(params, "News")
+}
+
+func (f *DB) QueryStories(params supabase.QueryParams) ([]map[string]interface{}, error) {
+	return f.queryContent(params, "Story")
+}
+
+func (f *DB) QueryAllContent(params supabase.QueryParams) ([]map[string]interface{}, error) {
+	return f.queryContent(params, "All")
+}
+
+func matches(filter string, value string) bool {
+	return filter == "" || filter == "any" || filter == value
+}
+
+func (f *DB) queryContent(params supabase.QueryParams, contentType string) ([]map[string]interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("queryContent"); err != nil {
+		return nil, err
+	}
+
+	type row struct {
+		contentType string
+		content     *Content
+	}
+	var rows []row
+	collect := func(ct string, table map[string]*Content) {
+		for _, c := range table {
+			if !c.published() || !matches(params.Language, c.Language) || !matches(params.CEFR, c.CEFRLevel) || !matches(params.Subject, c.Topic) {
+				continue
+			}
+			if params.ClassroomID != "" {
+				accepted := f.accepted[params.ClassroomID+":"+ct+":"+c.ID]
+				if params.WhitelistStatus == "accepted" && !accepted {
+					continue
+				}
+				if params.WhitelistStatus == "rejected" && accepted {
+					continue
+				}
+			}
+			rows = append(rows, row{contentType: ct, content: c})
+		}
+	}
+	if contentType == "News" || contentType == "All" {
+		collect("News", f.news)
+	}
+	if contentType == "Story" || contentType == "All" {
+		collect("Story", f.stories)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].content.CreatedAt.After(rows[j].content.CreatedAt)
+	})
+
+	offset := (params.Page - 1) * params.PageSize
+	results := make([]map[string]interface{}, 0)
+	for i := offset; i >= 0 && i < len(rows) && i < offset+params.PageSize; i++ {
+		c := rows[i].content
+		result := map[string]interface{}{
+			"id":           c.ID,
+			"title":        c.Title,
+			"language":     c.Language,
+			"topic":        c.Topic,
+			"cefr_level":   c.CEFRLevel,
+			"preview_text": c.PreviewText,
+			"created_at":   c.CreatedAt,
+			"date_created": c.DateCreated,
+		}
+		if contentType == "Story" || contentType == "All" {
+			if rows[i].contentType == "Story" {
+				result["pages"] = int32(c.Pages)
+			} else {
+				result["pages"] = nil
+			}
+		}
+		if contentType == "All" {
+			result["content_type"] = rows[i].contentType
+		}
+		tier := "NONE"
+		if book, ok := f.audiobooks[rows[i].contentType+":"+c.ID]; ok {
+			tier = book.tier
+		}
+		result["audiobook_tier"] = tier
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+func (f *DB) GetContentByID(contentType string, contentID string) (map[string]interface{}, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetContentByID"); err != nil {
+		return nil, err
+	}
+	table, err := f.contentTable(contentType)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := table[contentID]
+	if !ok || !c.published() {
+		return nil, nil
+	}
+	result := map[string]interface{}{
+		"id":           c.ID,
+		"title":        c.Title,
+		"language":     c.Language,
+		"topic":        c.Topic,
+		"cefr_level":   c.CEFRLevel,
+		"preview_text": c.PreviewText,
+		"content":      "",
+		"created_at":   c.CreatedAt,
+		"date_created": c.DateCreated,
+	}
+	if contentType == "Story" {
+		result["pages"] = c.Pages
+	}
+	return result, nil
+}
+
+// AddQuestion seeds a question at the next position of its pool.
+func (f *DB) AddQuestion(contentType string, contentID string, questionType string, cefrLevel string, question string) int {
+	return f.AddExercise(contentType, contentID, questionType, cefrLevel, question, nil, "")
+}
+
+// AddExercise is AddQuestion for question types with choices and an answer key.
+func (f *DB) AddExercise(contentType string, contentID string, questionType string, cefrLevel string, question string, choices []string, answerKey string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	position := 0
+	for _, q := range f.questions {
+		if !f.retired[q.ID] && q.ContentType == contentType && q.ContentID == contentID && q.QuestionType == questionType && q.CEFRLevel == cefrLevel && q.Position >= position {
+			position = q.Position + 1
+		}
+	}
+	return f.insertQuestion(contentType, contentID, questionType, cefrLevel, position, question, choices, answerKey).ID
+}
+
+func (f *DB) insertQuestion(contentType string, contentID string, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) *supabase.Question {
+	f.nextID++
+	q := &supabase.Question{
+		ID:           f.nextID,
+		ContentType:  contentType,
+		ContentID:    contentID,
+		QuestionType: questionType,
+		CEFRLevel:    cefrLevel,
+		Position:     position,
+		Question:     question,
+		Choices:      choices,
+		AnswerKey:    answerKey,
+		CreatedAt:    time.Now(),
+	}
+	f.questions = append(f.questions, q)
+	return q
+}
+
+func (f *DB) GetQuestionPool(contentType string, contentID string, questionType string, cefrLevel string, userID string) ([]supabase.PooledQuestion, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetQuestionPool"); err != nil {
+		return nil, err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return nil, err
+	}
+	pool := []supabase.PooledQuestion{}
+	for _, q := range f.questions {
+		if f.retired[q.ID] || q.ContentType != contentType || q.ContentID != contentID || q.QuestionType != questionType || q.CEFRLevel != cefrLevel {
+			continue
+		}
+		pooled := supabase.PooledQuestion{Question: *q}
+		for _, a := range f.attempts {
+			if a.userID == userID && a.attempt.Question.ID == q.ID {
+				createdAt := a.attempt.CreatedAt
+				pooled.Attempts++
+				pooled.LastEvaluation = a.attempt.Evaluation
+				pooled.LastAttemptAt = &createdAt
+			}
+		}
+		pool = append(pool, pooled)
+	}
+	sort.Slice(pool, func(i, j int) bool { return pool[i].Position < pool[j].Position })
+	return pool, nil
+}
+
+func (f *DB) GetQuestion(questionID int) (*supabase.Question, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetQuestion"); err != nil {
+		return nil, err
+	}
+	for _, q := range f.questions {
+		if q.ID == questionID {
+			question := *q
+			return &question, nil
+		}
+	}
+	return nil, nil
+}
+
+func (f *DB) CreateContentQuestion(contentType string, contentID string, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) (supabase.Question, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CreateContentQuestion"); err != nil {
+		return supabase.Question{}, err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return supabase.Question{}, err
+	}
+	for _, q := range f.questions {
+		if !f.retired[q.ID] && q.ContentType == contentType && q.ContentID == contentID && q.QuestionType == questionType && q.CEFRLevel == cefrLevel && q.Position == position {
+			return *q, nil
+		}
+	}
+	return *f.insertQuestion(contentType, contentID, questionType, cefrLevel, position, question, choices, answerKey), nil
+}
+
+func (f *DB) InsertQuestionAttempt(userID string, questionID int, answer string, evaluation string, explanation string, feedback json.RawMessage) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("InsertQuestionAttempt"); err != nil {
+		return 0, err
+	}
+	var question *supabase.Question
+	for _, q := range f.questions {
+		if q.ID == questionID {
+			question = q
+		}
+	}
+	if question == nil {
+		return 0, fmt.Errorf("failed to insert question attempt: question %d does not exist", questionID)
+	}
+	f.nextID++
+	f.attempts = append(f.attempts, attemptRow{userID: userID, attempt: supabase.QuestionAttempt{
+		ID:          f.nextID,
+		Question:    *question,
+		Answer:      answer,
+		Evaluation:  evaluation,
+		Explanation: explanation,
+		Feedback:    feedback,
+		// attempts in the same test are ordered even within a clock tick
+		CreatedAt: time.Now().Add(time.Duration(f.nextID) * time.Millisecond),
+	}})
+	return f.nextID, nil
+}
+
+func (f *DB) GetQuestionAttempts(userID string, contentType string, contentID string, page int, pageSize int) ([]supabase.QuestionAttempt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetQuestionAttempts"); err != nil {
+		return nil, err
+	}
+	attempts := []supabase.QuestionAttempt{}
+	for i := len(f.attempts) - 1; i >= 0; i-- {
+		a := f.attempts[i]
+		if a.userID != userID {
+			continue
+		}
+		if contentType != "" && (a.attempt.Question.ContentType != contentType || a.attempt.Question.ContentID != contentID) {
+			continue
+		}
+		attempts = append(attempts, a.attempt)
+	}
+	start := (page - 1) * pageSize
+	if start >= len(attempts) {
+		return []supabase.QuestionAttempt{}, nil
+	}
+	end := start + pageSize
+	if end > len(attempts) {
+		end = len(attempts)
+	}
+	return attempts[start:end], nil
+}
+
+func (f *DB) GetAudiobook(contentType string, id string) (supabase.AudiobookInfo, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetAudiobook"); err != nil {
+		return supabase.AudiobookInfo{}, err
+	}
+	var c *Content
+	var key string
+	switch contentType {
+	case "news":
+		c, key = f.news[id], "News:"+id
+	case "story":
+		c, key = f.stories[id], "Story:"+id
+	default:
+		return supabase.AudiobookInfo{}, fmt.Errorf("GetAudiobook: Invalid contentType story|news")
+	}
+	if c == nil || !c.published() {
+		return supabase.AudiobookInfo{}, sql.ErrNoRows
+	}
+	date, _ := time.Parse("2006-01-02", c.DateCreated)
+	info := supabase.AudiobookInfo{Language: c.Language, Topic: c.Topic, CEFRLevel: c.CEFRLevel, Date: date}
+	if book, ok := f.audiobooks[key]; ok {
+		info.Tier = book.tier
+		info.Pages = book.pages
+	}
+	return info, nil
+}
+
+// ProfileRepository
+
+func (f *DB) GetProfile(userID string) (*supabase.Profile, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetProfile"); err != nil {
+		return nil, err
+	}
+	p, ok := f.profiles[userID]
+	if !ok {
+		return nil, sql.ErrNoRows
+	}
+	profile := p.profile
+	return &profile, nil
+}
+
+func (f *DB) UpsertProfile(userID string, profile *supabase.Profile) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpsertProfile"); err != nil {
+		return 0, err
+	}
+	for otherID, p := range f.profiles {
+		if otherID != userID && p.profile.Username == profile.Username {
+			return 0, fmt.Errorf("failed to upsert profile: duplicate key value violates unique constraint \"profiles_username_key\"")
+		}
+	}
+	if p, ok := f.profiles[userID]; ok {
+		p.profile = *profile
+		return p.id, nil
+	}
+	f.nextID++
+	f.profiles[userID] = &profileRow{id: f.nextID, profile: *profile}
+	return f.nextID, nil
+}
+
+// ProgressRepository
+
+func (f *DB) todayProgress(userID string) *supabase.DailyProgress {
+	day := today()
+	if f.progress[userID] == nil {
+		f.progress[userID] = make(map[string]*supabase.DailyProgress)
+	}
+	p, ok := f.progress[userID][day]
+	if !ok {
+		date, _ := time.Parse("2006-01-02", day)
+		p = &supabase.DailyProgress{UserID: userID, Date: date}
+		f.progress[userID][day] = p
+	}
+	return p
+}
+
+func (f *DB) GetTodayProgress(userID string) (*supabase.DailyProgress, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetTodayProgress"); err != nil {
+		return nil, err
+	}
+	progress := *f.todayProgress(userID)
+	return &progress, nil
+}
+
+func (f *DB) IncrementQuestionsCompleted(userID string, amount int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("IncrementQuestionsCompleted"); err != nil {
+		return err
+	}
+	p, ok := f.profiles[userID]
+	if !ok {
+		return fmt.Errorf("failed to get daily goal: %v", sql.ErrNoRows)
+	}
+	progress := f.todayProgress(userID)
+	progress.QuestionsCompleted += amount
+	progress.GoalMet = progress.QuestionsCompleted >= p.profile.DailyQuestionsGoal
+	return nil
+}
+
+// GetProgressStreak counts consecutive goal-met days ending today or yesterday.
+func (f *DB) GetProgressStreak(userID string) (int, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetProgressStreak"); err != nil {
+		return 0, false, err
+	}
+	goalMet := func(day time.Time) bool {
+		p, ok := f.progress[userID][day.Format("2006-01-02")]
+		return ok && p.GoalMet
+	}
+
+	day := time.Now()
+	completedToday := goalMet(day)
+	if !completedToday {
+		day = day.AddDate(0, 0, -1)
+	}
+	streak := 0
+	for goalMet(day) {
+		streak++
+		day = day.AddDate(0, 0, -1)
+	}
+	return streak, completedToday, nil
+}
+
+// ClassroomRepository
+
+func (f *DB) GetClassroomById(classroomID string) (string, int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetClassroomById"); err != nil {
+		return "", 0, err
+	}
+	c, ok := f.classrooms[classroomID]
+	if !ok {
+		return "", 0, sql.ErrNoRows
+	}
+	return c.teacherID, c.studentCount, nil
+}
+
+func (f *DB) GetClassroomList(teacherID string) ([]models.ClassroomListItem, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetClassroomList"); err != nil {
+		return nil, err
+	}
+	var classrooms []models.ClassroomListItem
+	for _, c := range f.classrooms {
+		if c.teacherID == teacherID {
+			classrooms = append(classrooms, models.ClassroomListItem{
+				ClassroomID:   c.id,
+				Name:          c.name,
+				StudentsCount: c.studentCount,
+			})
+		}
+	}
+	sort.Slice(classrooms, func(i, j int) bool {
+		return classrooms[i].ClassroomID < classrooms[j].ClassroomID
+	})
+	return classrooms, nil
+}
+
+func (f *DB) CreateClassroom(teacherID string, name string, student_count int) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CreateClassroom"); err != nil {
+		return "", err
+	}
+	id := f.newID()
+	f.classrooms[id] = &classroom{id: id, teacherID: teacherID, name: name, studentCount: student_count}
+	return id, nil
+}
+
+func (f *DB) UpdateClassroom(classroomID int, name string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpdateClassroom"); err != nil {
+		return err
+	}
+	if c, ok := f.classrooms[strconv.Itoa(classroomID)]; ok {
+		c.name = name
+	}
+	return nil
+}
+
+func (f *DB) DeleteClassroom(classroomID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("DeleteClassroom"); err != nil {
+		return err
+	}
+	delete(f.classrooms, classroomID)
+	for userID, s := range f.students {
+		if s.classroomID == classroomID {
+			delete(f.students, userID)
+		}
+	}
+	return nil
+}
+
+func (f *DB) VerifyClassroomOwnership(teacherID string, classroomID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("VerifyClassroomOwnership"); err != nil {
+		return false, err
+	}
+	c, ok := f.classrooms[classroomID]
+	return ok && c.teacherID == teacherID, nil
+}
+
+func (f *DB) AddStudentToClassroom(classroomID string, studentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("AddStudentToClassroom"); err != nil {
+		return err
+	}
+	c, ok := f.classrooms[classroomID]
+	if !ok {
+		return fmt.Errorf("failed to add student to classroom: classroom %s does not exist", classroomID)
+	}
+	if _, exists := f.students[studentID]; exists {
+		return fmt.Errorf("failed to add student to classroom: student already in a classroom")
+	}
+	f.students[studentID] = &student{id: "student-" + f.newID(), classroomID: classroomID}
+	c.studentCount++
+	return nil
+}
+
+func (f *DB) CheckAcceptedContent(classroomID string, contentType string, contentID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckAcceptedContent"); err != nil {
+		return false, err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return false, err
+	}
+	return f.accepted[classroomID+":"+contentType+":"+contentID], nil
+}
+
+func (f *DB) AcceptContent(classroomID int, contentType string, contentID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("AcceptContent"); err != nil {
+		return err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return err
+	}
+	f.accepted[strconv.Itoa(classroomID)+":"+contentType+":"+strconv.Itoa(contentID)] = true
+	return nil
+}
+
+func (f *DB) RejectContent(classroomID int, contentType string, contentID int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("RejectContent"); err != nil {
+		return err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return err
+	}
+	key := strconv.Itoa(classroomID) + ":" + contentType + ":" + strconv.Itoa(contentID)
+	if !f.accepted[key] {
+		return fmt.Errorf("content was not accepted in classroom")
+	}
+	delete(f.accepted, key)
+	return nil
+}
+
+// AccountRepository
+
+func (f *DB) CheckAccountType(userID string, accountType string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckAccountType"); err != nil {
+		return false, err
+	}
+	return f.isAccountType(userID, accountType), nil
+}
+
+func (f *DB) isAdmin(userID string) bool {
+	for _, org := range f.organizations {
+		if org.adminID == userID {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *DB) isAccountType(userID string, accountType string) bool {
+	_, isTeacher := f.teachers[userID]
+	_, isStudent := f.students[userID]
+	isAdmin := f.isAdmin(userID)
+	switch accountType {
+	case "teacher":
+		return isTeacher
+	case "student":
+		return isStudent
+	case "admin":
+		return isAdmin
+	case "editor":
+		return f.editors[userID]
+	}
+	return !isTeacher && !isStudent && !isAdmin
+}
+
+func (f *DB) CheckStudentStatus(userID string) (string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckStudentStatus"); err != nil {
+		return "", "", err
+	}
+	s, ok := f.students[userID]
+	if !ok {
+		return "", "", nil
+	}
+	return s.id, s.classroomID, nil
+}
+
+func (f *DB) CheckTeacherStatus(userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckTeacherStatus"); err != nil {
+		return false, err
+	}
+	_, ok := f.teachers[userID]
+	return ok, nil
+}
+
+func (f *DB) CheckAdminStatus(userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckAdminStatus"); err != nil {
+		return false, err
+	}
+	return f.isAdmin(userID), nil
+}
+
+func (f *DB) CheckEditorStatus(userID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckEditorStatus"); err != nil {
+		return false, err
+	}
+	return f.editors[userID], nil
+}
+
+func (f *DB) GetTeacherInfo(teacherID string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetTeacherInfo"); err != nil {
+		return false, err
+	}
+	for _, c := range f.classrooms {
+		if c.teacherID == teacherID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// OrganizationRepository
+
+func (f *DB) GetTeacherUUID(userID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetTeacherUUID"); err != nil {
+		return "", err
+	}
+	t, ok := f.teachers[userID]
+	if !ok {
+		return "", fmt.Errorf("teacher doesn't exist with that user id")
+	}
+	return t.id, nil
+}
+
+func (f *DB) CheckTeacherOrganization(teacherID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckTeacherOrganization"); err != nil {
+		return "", err
+	}
+	for _, t := range f.teachers {
+		if t.id == teacherID {
+			return t.organizationID, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (f *DB) CheckTeacherOrganizationByUserID(userID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckTeacherOrganizationByUserID"); err != nil {
+		return "", err
+	}
+	t, ok := f.teachers[userID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return t.organizationID, nil
+}
+
+func (f *DB) CheckOrganizationByUserID(userID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CheckOrganizationByUserID"); err != nil {
+		return "", err
+	}
+	if s, ok := f.students[userID]; ok {
+		c, ok := f.classrooms[s.classroomID]
+		if !ok {
+			return "", fmt.Errorf("failed to get teacher ID from classroom: %w", sql.ErrNoRows)
+		}
+		for _, t := range f.teachers {
+			if t.id == c.teacherID {
+				return t.organizationID, nil
+			}
+		}
+		return "", fmt.Errorf("failed to get organization ID from teacher: %w", sql.ErrNoRows)
+	}
+	if t, ok := f.teachers[userID]; ok {
+		return t.organizationID, nil
+	}
+	return "", nil
+}
+
+func (f *DB) GetOrganizationPlan(organizationID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetOrganizationPlan"); err != nil {
+		return "", err
+	}
+	org, ok := f.organizations[organizationID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return org.plan, nil
+}
+
+func (f *DB) GetOrganizationSubscriptionID(organizationID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetOrganizationSubscriptionID"); err != nil {
+		return "", err
+	}
+	org, ok := f.organizations[organizationID]
+	if !ok {
+		return "", sql.ErrNoRows
+	}
+	return org.subscriptionID, nil
+}
+
+func (f *DB) GetOrganizationInfo(organizationID string) (string, string, string, time.Time, bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetOrganizationInfo"); err != nil {
+		return "", "", "", time.Time{}, false, err
+	}
+	org, ok := f.organizations[organizationID]
+	if !ok {
+		return "", "", "", time.Time{}, false, sql.ErrNoRows
+	}
+	return org.plan, org.customerID, org.subscriptionID, org.expiration, org.canceled, nil
+}
+
+func (f *DB) GetOrganizationByCustomerID(customerID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetOrganizationByCustomerID"); err != nil {
+		return "", err
+	}
+	for _, org := range f.organizations {
+		if customerID != "" && org.customerID == customerID {
+			return org.id, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (f *DB) CreateOrganization(adminID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("CreateOrganization"); err != nil {
+		return "", err
+	}
+	id := "org-" + f.newID()
+	f.organizations[id] = &organization{id: id, adminID: adminID, plan: "FREE"}
+	return id, nil
+}
+
+func (f *DB) JoinOrganization(userID string, organizationID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("JoinOrganization"); err != nil {
+		return "", err
+	}
+	if _, ok := f.organizations[organizationID]; !ok {
+		return "", fmt.Errorf("organization %s does not exist", organizationID)
+	}
+	if _, exists := f.teachers[userID]; exists {
+		return "", fmt.Errorf("duplicate key value violates unique constraint \"teachers_user_id_key\"")
+	}
+	id := "teacher-" + f.newID()
+	f.teachers[userID] = &teacher{id: id, organizationID: organizationID}
+	return id, nil
+}
+
+func (f *DB) UpdateOrganization(plan, organizationID, customerID, subscriptionID string, expiration time.Time, canceled bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpdateOrganization"); err != nil {
+		return err
+	}
+	org, ok := f.organizations[organizationID]
+	if !ok {
+		return fmt.Errorf("no organization found with ID: %s", organizationID)
+	}
+	org.plan = plan
+	org.customerID = customerID
+	org.subscriptionID = subscriptionID
+	org.expiration = expiration
+	org.canceled = canceled
+	return nil
+}
+
+// BillingRepository
+
+// billingAccount mirrors GetBillingAccount creating a FREE account on first access.
+func (f *DB) billingAccount(userID string) *billingAccount {
+	account, ok := f.billing[userID]
+	if !ok {
+		account = &billingAccount{plan: "FREE"}
+		f.billing[userID] = account
+	}
+	return account
+}
+
+func (f *DB) GetUserIDByCustomerID(customerID string) (string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetUserIDByCustomerID"); err != nil {
+		return "", err
+	}
+	for userID, account := range f.billing {
+		if customerID != "" && account.customerID == customerID {
+			return userID, nil
+		}
+	}
+	return "", sql.ErrNoRows
+}
+
+func (f *DB) GetBillingAccount(userID string) (string, time.Time, bool, string, string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetBillingAccount"); err != nil {
+		return "", time.Time{}, false, "", "", err
+	}
+	a := f.billingAccount(userID)
+	return a.plan, a.expiration, a.canceled, a.customerID, a.subscriptionID, nil
+}
+
+func (f *DB) UpdateBillingAccount(userID, plan, customerID, subscriptionID string, expiration time.Time, canceled bool) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpdateBillingAccount"); err != nil {
+		return err
+	}
+	a, ok := f.billing[userID]
+	if !ok {
+		return fmt.Errorf("no billing account found with ID: %s", userID)
+	}
+	a.plan = plan
+	a.customerID = customerID
+	a.subscriptionID = subscriptionID
+	a.expiration = expiration
+	a.canceled = canceled
+	return nil
+}
+
+// UsageRepository
+
+func endOfMonth() time.Time {
+	now := time.Now()
+	year, month, _ := now.Date()
+	return time.Date(year, month+1, 0, 23, 59, 59, 999999999, now.Location())
+}
+
+func (f *DB) periodEnd(userID string) (string, string) {
+	a := f.billingAccount(userID)
+	end := a.expiration
+	if end.IsZero() {
+		end = endOfMonth()
+	}
+	return a.plan, end.Format("2006-01-02")
+}
+
+func (f *DB) InsertUsage(userID string, featureID string, amount int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("InsertUsage"); err != nil {
+		return err
+	}
+	if !plans.IsValidFeatureID(featureID) {
+		return fmt.Errorf("invalid feature ID: %s", featureID)
+	}
+	plan, periodEnd := f.periodEnd(userID)
+	f.usage = append(f.usage, usageRow{userID: userID, featureID: featureID, plan: plan, amount: amount, periodEnd: periodEnd})
+	return nil
+}
+
+func (f *DB) GetUsage(userID string, featureID string, plan string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetUsage"); err != nil {
+		return 0, err
+	}
+	if !plans.IsValidFeatureID(featureID) {
+		return 0, fmt.Errorf("invalid feature ID: %s", featureID)
+	}
+	accountPlan, periodEnd := f.periodEnd(userID)
+	if plan == "" {
+		plan = accountPlan
+	}
+	total := 0
+	for _, row := range f.usage {
+		if row.userID == userID && row.featureID == featureID && row.plan == plan && row.periodEnd == periodEnd {
+			total += row.amount
+		}
+	}
+	return total, nil
+}
+
+// CatalogRepository
+
+func (f *DB) GetGenerationMatrix() ([]supabase.GenerationCell, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetGenerationMatrix"); err != nil {
+		return nil, err
+	}
+	return append([]supabase.GenerationCell{}, f.matrix...), nil
+}
+
+// EditorRepository
+
+func editorContent(contentType string, c *Content) supabase.EditorContent {
+	return supabase.EditorContent{
+		ID:          c.ID,
+		ContentType: contentType,
+		Title:       c.Title,
+		Language:    c.Language,
+		Topic:       c.Topic,
+		CEFRLevel:   c.CEFRLevel,
+		PreviewText: c.PreviewText,
+		Pages:       c.Pages,
+		Status:      c.status(),
+		Moderation:  c.Moderation,
+		CreatedAt:   c.CreatedAt,
+		DateCreated: c.DateCreated,
+		EditedAt:    c.EditedAt,
+		ReviewedBy:  c.ReviewedBy,
+		ReviewNote:  c.ReviewNote,
+	}
+}
+
+func (f *DB) QueryEditorContent(contentType string, status string, page int, pageSize int) ([]supabase.EditorContent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("QueryEditorContent"); err != nil {
+		return nil, err
+	}
+	if contentType != "News" && contentType != "Story" && contentType != "All" {
+		return nil, fmt.Errorf("invalid content type: %s", contentType)
+	}
+
+	rows := []supabase.EditorContent{}
+	for _, ct := range []string{"News", "Story"} {
+		if contentType != ct && contentType != "All" {
+			continue
+		}
+		table, _ := f.contentTable(ct)
+		for _, c := range table {
+			if c.status() == status {
+				rows = append(rows, editorContent(ct, c))
+			}
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].CreatedAt.After(rows[j].CreatedAt) })
+
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(rows) {
+		return []supabase.EditorContent{}, nil
+	}
+	return rows[offset:min(offset+pageSize, len(rows))], nil
+}
+
+func (f *DB) GetEditorContent(contentType string, contentID string) (*supabase.EditorContent, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetEditorContent"); err != nil {
+		return nil, err
+	}
+	table, err := f.contentTable(contentType)
+	if err != nil {
+		return nil, err
+	}
+	c, ok := table[contentID]
+	if !ok {
+		return nil, nil
+	}
+	content := editorContent(contentType, c)
+	return &content, nil
+}
+
+func (f *DB) UpdateContentText(contentType string, contentID string, title string, previewText string, pages int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpdateContentText"); err != nil {
+		return err
+	}
+	table, err := f.contentTable(contentType)
+	if err != nil {
+		return err
+	}
+	c, ok := table[contentID]
+	if !ok {
+		return fmt.Errorf("no content was updated")
+	}
+	now := time.Now()
+	c.Title, c.PreviewText, c.EditedAt = title, previewText, &now
+	if contentType == "Story" {
+		c.Pages = pages
+	}
+	return nil
+}
+
+func (f *DB) RetireDerivedContent(contentType string, contentID string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("RetireDerivedContent"); err != nil {
+		return err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return err
+	}
+	for _, q := range f.questions {
+		if q.ContentType == contentType && q.ContentID == contentID {
+			f.retired[q.ID] = true
+		}
+	}
+	delete(f.audiobooks, contentType+":"+contentID)
+	return nil
+}
+
+func (f *DB) SetContentStatus(contentType string, contentID string, from []string, to string, editorID string, note string) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("SetContentStatus"); err != nil {
+		return false, err
+	}
+	table, err := f.contentTable(contentType)
+	if err != nil {
+		return false, err
+	}
+	c, ok := table[contentID]
+	if !ok {
+		return false, nil
+	}
+	for _, status := range from {
+		if c.status() == status {
+			c.Status, c.ReviewedBy, c.ReviewNote = to, editorID, note
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *DB) findVocab(userID string, cardID int) *vocabRow {
+	for _, row := range f.vocab {
+		if row.userID == userID && row.card.ID == cardID {
+			return row
+		}
+	}
+	return nil
+}
+
+func (f *DB) SaveVocabCard(userID string, card supabase.VocabCard) (supabase.VocabCard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("SaveVocabCard"); err != nil {
+		return supabase.VocabCard{}, err
+	}
+	if card.ContentType != "" {
+		if _, err := f.contentTable(card.ContentType); err != nil {
+			return supabase.VocabCard{}, err
+		}
+		f.lookups = append(f.lookups, lookupRow{userID: userID, contentType: card.ContentType, contentID: card.ContentID})
+	}
+	for _, row := range f.vocab {
+		if row.userID != userID || row.card.Language != card.Language || row.card.Word != card.Word {
+			continue
+		}
+		row.card.Lookups++
+		if row.card.Sentence == "" {
+			row.card.Sentence = card.Sentence
+		}
+		if row.card.Translation == "" {
+			row.card.Translation = card.Translation
+		}
+		if row.card.ContentType == "" {
+			row.card.ContentType, row.card.ContentID = card.ContentType, card.ContentID
+		}
+		return row.card, nil
+	}
+
+	f.nextID++
+	card.ID = f.nextID
+	card.Lookups = 1
+	card.Card = srs.NewCard(time.Now())
+	card.LastReviewedAt = nil
+	// cards saved in the same test are ordered even within a clock tick
+	card.CreatedAt = time.Now().Add(time.Duration(f.nextID) * time.Millisecond)
+	f.vocab = append(f.vocab, &vocabRow{userID: userID, card: card})
+	return card, nil
+}
+
+func (f *DB) GetVocabCard(userID string, cardID int) (*supabase.VocabCard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetVocabCard"); err != nil {
+		return nil, err
+	}
+	row := f.findVocab(userID, cardID)
+	if row == nil {
+		return nil, nil
+	}
+	card := row.card
+	return &card, nil
+}
+
+func (f *DB) GetVocabCards(userID string, language string, dueBefore *time.Time, page int, pageSize int) ([]supabase.VocabCard, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetVocabCards"); err != nil {
+		return nil, err
+	}
+	cards := []supabase.VocabCard{}
+	for _, row := range f.vocab {
+		if row.userID != userID || (language != "" && row.card.Language != language) {
+			continue
+		}
+		if dueBefore != nil && row.card.DueAt.After(*dueBefore) {
+			continue
+		}
+		cards = append(cards, row.card)
+	}
+	if dueBefore != nil {
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].DueAt.Before(cards[j].DueAt) })
+	} else {
+		sort.SliceStable(cards, func(i, j int) bool { return cards[i].CreatedAt.After(cards[j].CreatedAt) })
+	}
+	offset := (page - 1) * pageSize
+	if offset < 0 || offset >= len(cards) {
+		return []supabase.VocabCard{}, nil
+	}
+	return cards[offset:min(offset+pageSize, len(cards))], nil
+}
+
+func (f *DB) ReviewVocabCard(userID string, cardID int, grade int, schedule srs.Card, reviewedAt time.Time) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("ReviewVocabCard"); err != nil {
+		return false, err
+	}
+	row := f.findVocab(userID, cardID)
+	if row == nil || row.card.DueAt.After(reviewedAt) {
+		return false, nil
+	}
+	row.card.Card = schedule
+	row.card.LastReviewedAt = &reviewedAt
+	row.reviews++
+	return true, nil
+}
+
+// SetVocabDue moves the next review of a card to dueAt.
+func (f *DB) SetVocabDue(cardID int, dueAt time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, row := range f.vocab {
+		if row.card.ID == cardID {
+			row.card.DueAt = dueAt
+		}
+	}
+}
+
+// VocabReviews is how many times a card has been reviewed.
+func (f *DB) VocabReviews(cardID int) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, row := range f.vocab {
+		if row.card.ID == cardID {
+			return row.reviews
+		}
+	}
+	return 0
+}
+
+func (f *DB) DeleteVocabCard(userID string, cardID int) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("DeleteVocabCard"); err != nil {
+		return false, err
+	}
+	for i, row := range f.vocab {
+		if row.userID == userID && row.card.ID == cardID {
+			f.vocab = append(f.vocab[:i], f.vocab[i+1:]...)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *DB) UpdateReadingSession(userID string, contentType string, contentID string, page int, percentComplete float64, secondsSpent int, completed bool) (supabase.ReadingSession, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("UpdateReadingSession"); err != nil {
+		return supabase.ReadingSession{}, err
+	}
+	if _, err := f.contentTable(contentType); err != nil {
+		return supabase.ReadingSession{}, err
+	}
+	f.nextID++
+	now := time.Now()
+
+	var row *readingRow
+	for _, r := range f.reading {
+		if r.userID == userID && r.session.ContentType == contentType && r.session.ContentID == contentID {
+			row = r
+		}
+	}
+	if row == nil {
+		row = &readingRow{userID: userID, session: supabase.ReadingSession{ContentType: contentType, ContentID: contentID, StartedAt: now}}
+		f.reading = append(f.reading, row)
+	}
+	row.session.LastPage = page
+	row.session.PercentComplete = max(row.session.PercentComplete, percentComplete)
+	row.session.TimeSpentSeconds += secondsSpent
+	row.session.UpdatedAt = now
+	row.updated = f.nextID
+	if completed && row.session.CompletedAt == nil {
+		row.session.CompletedAt = &now
+	}
+	return row.session, nil
+}
+
+func (f *DB) GetReadingSessions(userID string, inProgress bool, limit int) ([]supabase.ReadingEntry, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetReadingSessions"); err != nil {
+		return nil, err
+	}
+	rows := append([]*readingRow{}, f.reading...)
+	sort.Slice(rows, func(i, j int) bool { return rows[i].updated > rows[j].updated })
+	entries := []supabase.ReadingEntry{}
+	for _, r := range rows {
+		if r.userID != userID || (inProgress && r.session.CompletedAt != nil) {
+			continue
+		}
+		table, _ := f.contentTable(r.session.ContentType)
+		c, ok := table[r.session.ContentID]
+		if !ok || !c.published() {
+			continue
+		}
+		entries = append(entries, supabase.ReadingEntry{
+			ReadingSession: r.session,
+			Title:          c.Title,
+			Language:       c.Language,
+			Topic:          c.Topic,
+			CEFRLevel:      c.CEFRLevel,
+			PreviewText:    c.PreviewText,
+			Pages:          c.Pages,
+		})
+	}
+	return entries[:min(limit, len(entries))], nil
+}
+
+func (f *DB) GetReadingStatuses(userID string, contentType string, contentIDs []string) (map[string]string, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetReadingStatuses"); err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]string, len(contentIDs))
+	for _, id := range contentIDs {
+		statuses[id] = supabase.READING_UNREAD
+		for _, r := range f.reading {
+			if r.userID == userID && r.session.ContentType == contentType && r.session.ContentID == id {
+				statuses[id] = supabase.READING_IN_PROGRESS
+				if r.session.CompletedAt != nil {
+					statuses[id] = supabase.READING_READ
+				}
+			}
+		}
+	}
+	return statuses, nil
+}
+
+func (f *DB) GetFeedCandidates(userID string, language string, cefrLevels []string, asOf time.Time, limit int, classroomID string) ([]supabase.FeedCandidate, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetFeedCandidates"); err != nil {
+		return nil, err
+	}
+	candidates := []supabase.FeedCandidate{}
+	for _, ct := range []string{"News", "Story"} {
+		table, _ := f.contentTable(ct)
+		found := []supabase.FeedCandidate{}
+		for _, c := range table {
+			if !c.published() || c.Language != language || !slices.Contains(cefrLevels, c.CEFRLevel) || c.CreatedAt.After(asOf) ||
+				(classroomID != "" && !f.accepted[classroomID+":"+ct+":"+c.ID]) {
+				continue
+			}
+			inProgress := false
+			finished := false
+			for _, r := range f.reading {
+				if r.userID == userID && r.session.ContentType == ct && r.session.ContentID == c.ID && !r.session.StartedAt.After(asOf) {
+					inProgress = true
+					finished = r.session.CompletedAt != nil && !r.session.CompletedAt.After(asOf)
+				}
+			}
+			if finished {
+				continue
+			}
+			found = append(found, supabase.FeedCandidate{
+				Candidate: feed.Candidate{
+					ContentType: ct,
+					ID:          c.ID,
+					Topic:       c.Topic,
+					CEFRLevel:   c.CEFRLevel,
+					CreatedAt:   c.CreatedAt,
+					InProgress:  inProgress,
+				},
+				Title:       c.Title,
+				Language:    c.Language,
+				PreviewText: c.PreviewText,
+				DateCreated: c.DateCreated,
+				Pages:       c.Pages,
+			})
+		}
+		sort.Slice(found, func(i, j int) bool { return found[i].CreatedAt.After(found[j].CreatedAt) })
+		candidates = append(candidates, found[:min(limit, len(found))]...)
+	}
+	return candidates, nil
+}
+
+func (f *DB) GetLevelEvidence(userID string, language string) ([]cefr.Evidence, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("GetLevelEvidence"); err != nil {
+		return nil, err
+	}
+	byLevel := map[string]*cefr.Evidence{}
+	evidence := func(level string) *cefr.Evidence {
+		if byLevel[level] == nil {
+			byLevel[level] = &cefr.Evidence{Level: level}
+		}
+		return byLevel[level]
+	}
+	for _, row := range f.attempts {
+		question := row.attempt.Question
+		table, _ := f.contentTable(question.ContentType)
+		if c := table[question.ContentID]; row.userID != userID || c == nil || c.Language != language {
+			continue
+		}
+		e := evidence(question.CEFRLevel)
+		e.Attempts++
+		if row.attempt.Evaluation == "PASS" {
+			e.Passed++
+		}
+	}
+	for _, r := range f.reading {
+		table, _ := f.contentTable(r.session.ContentType)
+		c := table[r.session.ContentID]
+		if r.userID != userID || c == nil || c.Language != language {
+			continue
+		}
+		e := evidence(c.CEFRLevel)
+		e.Started++
+		if r.session.CompletedAt != nil {
+			e.Completed++
+		}
+		if c.Words == 0 {
+			continue
+		}
+		e.WordsRead += c.Words
+		for _, l := range f.lookups {
+			if l.userID == userID && l.contentType == r.session.ContentType && l.contentID == c.ID {
+				e.Lookups++
+			}
+		}
+	}
+	result := []cefr.Evidence{}
+	for _, e := range byLevel {
+		result = append(result, *e)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Level < result[j].Level })
+	return result, nil
+}
+
+func (f *DB) SaveLevelEstimate(userID string, language string, declaredLevel string, estimate cefr.Estimate, evidence []cefr.Evidence, apply bool) (bool, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("SaveLevelEstimate"); err != nil {
+		return false, err
+	}
+	applied := false
+	if row := f.profiles[userID]; apply && row != nil && row.profile.LearningLanguage == language && row.profile.SkillLevel == declaredLevel {
+		row.profile.SkillLevel = estimate.Level
+		applied = true
+	}
+	f.estimates[userID+":"+language] = &LevelEstimate{
+		Estimate:      estimate,
+		DeclaredLevel: declaredLevel,
+		Evidence:      append([]cefr.Evidence{}, evidence...),
+		Applied:       applied,
+	}
+	return applied, nil
+}
+
+// StoredLevelEstimate returns a copy of a stored level estimate, or nil.
+func (f *DB) StoredLevelEstimate(userID string, language string) *LevelEstimate {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	estimate := f.estimates[userID+":"+language]
+	if estimate == nil {
+		return nil
+	}
+	copied := *estimate
+	return &copied
+}
+
+// SearchContent matches content that has every word of the query in its
+// title, preview or body, ignoring case. It does not stem words or support
+// the web search operators.
+func (f *DB) SearchContent(params supabase.SearchParams) ([]supabase.SearchResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("SearchContent"); err != nil {
+		return nil, err
+	}
+	words := strings.Fields(strings.ToLower(params.Query))
+	results := []supabase.SearchResult{}
+	for _, ct := range []string{"News", "Story"} {
+		if params.ContentType != "" && params.ContentType != ct {
+			continue
+		}
+		table, _ := f.contentTable(ct)
+		for _, c := range table {
+			if !c.published() || c.Language != params.Language ||
+				(params.CEFR != "" && c.CEFRLevel != params.CEFR) || (params.Subject != "" && c.Topic != params.Subject) ||
+				(params.ClassroomID != "" && !f.accepted[params.ClassroomID+":"+ct+":"+c.ID]) {
+				continue
+			}
+			body := c.Body
+			if body == "" {
+				body = c.PreviewText
+			}
+			rank, matched := 0.0, len(words) > 0
+			for _, word := range words {
+				title := strings.Count(strings.ToLower(c.Title), word)
+				preview := strings.Count(strings.ToLower(c.PreviewText), word)
+				inBody := strings.Count(strings.ToLower(c.Body), word)
+				if title+preview+inBody == 0 {
+					matched = false
+				}
+				rank += float64(title) + 0.4*float64(preview) + 0.1*float64(inBody)
+			}
+			if !matched {
+				continue
+			}
+			snippet := body
+			if i := strings.Index(strings.ToLower(body), words[0]); i >= 0 {
+				snippet = body[:i] + "<mark>" + body[i:i+len(words[0])] + "</mark>" + body[i+len(words[0]):]
+			}
+			results = append(results, supabase.SearchResult{
+				ContentType: ct,
+				ID:          c.ID,
+				Title:       c.Title,
+				Language:    c.Language,
+				Topic:       c.Topic,
+				CEFRLevel:   c.CEFRLevel,
+				PreviewText: c.PreviewText,
+				CreatedAt:   c.CreatedAt,
+				DateCreated: c.DateCreated,
+				Pages:       c.Pages,
+				Rank:        rank,
+				Snippet:     snippet,
+			})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool {
+		if results[i].Rank != results[j].Rank {
+			return results[i].Rank > results[j].Rank
+		}
+		return results[i].CreatedAt.After(results[j].CreatedAt)
+	})
+	offset := (params.Page - 1) * params.PageSize
+	if offset < 0 || offset >= len(results) {
+		return []supabase.SearchResult{}, nil
+	}
+	return results[offset:min(offset+params.PageSize, len(results))], nil
+}
+
+func (f *DB) SetBodyText(contentType string, contentID string, text string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if err := f.fail("SetBodyText"); err != nil {
+		return err
+	}
+	table, err := f.contentTable(contentType)
+	if err != nil {
+		return err
+	}
+	if c := table[contentID]; c != nil {
+		c.Body = text
+	}
+	return nil
+}
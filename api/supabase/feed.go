@@ -0,0 +1,81 @@
+package supabase
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+
+	"story-api/feed"
+)
+
+// FeedCandidate is published content that could go in a user's feed.
+type FeedCandidate struct {
+	feed.Candidate
+	Title       string
+	Language    string
+	PreviewText string
+	DateCreated string
+	Pages       int // stories only
+}
+
+// GetFeedCandidates returns the newest published news and stories in language
+// at any of cefrLevels, at most limit of each. Only content created by asOf
+// is returned, and reading sessions are read as they were at asOf: content
+// the user had finished is left out, and content they had started is marked
+// InProgress. This keeps the candidates the same while a feed is paged
+// through. With classroomID set only content accepted in the classroom is
+// returned.
+func (c *Client) GetFeedCandidates(userID string, language string, cefrLevels []string, asOf time.Time, limit int, classroomID string) ([]FeedCandidate, error) {
+	args := []interface{}{userID, language, pq.Array(cefrLevels), asOf, limit}
+	whitelist := func(column string) string { return "" }
+	if classroomID != "" {
+		args = append(args, classroomID)
+		whitelist = func(column string) string {
+			return fmt.Sprintf(`
+			AND EXISTS (
+				SELECT 1 FROM accepted_content ac
+				WHERE ac.classroom_id = $%d AND ac.%s = c.id
+			)`, len(args), column)
+		}
+	}
+
+	selectContent := func(table string, column string, pages string) string {
+		return fmt.Sprintf(`
+		SELECT c.id::text, c.title, c.language, c.topic, c.cefr_level, c.preview_text,
+			c.created_at, c.date_created::text, %[3]s, r.id IS NOT NULL
+		FROM %[1]s c
+		LEFT JOIN reading_sessions r ON r.%[2]s = c.id AND r.user_id = $1 AND r.started_at <= $4
+		WHERE c.status = 'published' AND c.language = $2 AND c.cefr_level = ANY($3) AND c.created_at <= $4
+			AND (r.completed_at IS NULL OR r.completed_at > $4)%[4]s
+		ORDER BY c.created_at DESC, c.id DESC
+		LIMIT $5`, table, column, pages, whitelist(column))
+	}
+
+	candidates := []FeedCandidate{}
+	for _, source := range []struct{ contentType, query string }{
+		{"News", selectContent("news", "news_id", "0")},
+		{"Story", selectContent("stories", "story_id", "c.pages")},
+	} {
+		rows, err := c.db.Query(source.query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query feed candidates: %v", err)
+		}
+		for rows.Next() {
+			candidate := FeedCandidate{Candidate: feed.Candidate{ContentType: source.contentType}}
+			if err := rows.Scan(&candidate.ID, &candidate.Title, &candidate.Language, &candidate.Topic,
+				&candidate.CEFRLevel, &candidate.PreviewText, &candidate.CreatedAt, &candidate.DateCreated,
+				&candidate.Pages, &candidate.InProgress); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("failed to scan feed candidate: %v", err)
+			}
+			candidates = append(candidates, candidate)
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to query feed candidates: %v", err)
+		}
+	}
+	return candidates, nil
+}
@@ -0,0 +1,121 @@
+package supabase
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"story-api/cefr"
+)
+
+// GetLevelEvidence returns what a user did with content in language at each
+// CEFR level: the questions they answered and passed, the news and stories
+// they opened and finished, and the words they looked up in the content they
+// read against its length. Levels the user has done nothing at are left out.
+func (c *Client) GetLevelEvidence(userID string, language string) ([]cefr.Evidence, error) {
+	query := `
+		WITH attempts AS (
+			SELECT q.cefr_level AS level, COUNT(*) AS attempts,
+				COUNT(*) FILTER (WHERE a.evaluation = 'PASS') AS passed
+			FROM question_attempts a
+			JOIN questions q ON q.id = a.question_id
+			LEFT JOIN news n ON n.id = q.news_id
+			LEFT JOIN stories s ON s.id = q.story_id
+			WHERE a.user_id = $1 AND COALESCE(n.language, s.language) = $2
+			GROUP BY q.cefr_level
+		), reading AS (
+			SELECT COALESCE(n.cefr_level, s.cefr_level) AS level, COUNT(*) AS started,
+				COUNT(r.completed_at) AS completed,
+				COALESCE(SUM(w.words), 0) AS words_read,
+				COALESCE(SUM(l.lookups) FILTER (WHERE w.words IS NOT NULL), 0) AS lookups
+			FROM reading_sessions r
+			LEFT JOIN news n ON n.id = r.news_id
+			LEFT JOIN stories s ON s.id = r.story_id
+			CROSS JOIN LATERAL (
+				SELECT (COALESCE(n.readability, s.readability)->>'words')::int AS words
+			) w
+			LEFT JOIN LATERAL (
+				SELECT COUNT(*) AS lookups
+				FROM vocab_lookups v
+				WHERE v.user_id = r.user_id AND (v.news_id = r.news_id OR v.story_id = r.story_id)
+			) l ON true
+			WHERE r.user_id = $1 AND COALESCE(n.language, s.language) = $2
+			GROUP BY 1
+		)
+		SELECT level, COALESCE(a.attempts, 0), COALESCE(a.passed, 0), COALESCE(r.started, 0),
+			COALESCE(r.completed, 0), COALESCE(r.words_read, 0), COALESCE(r.lookups, 0)
+		FROM attempts a
+		FULL JOIN reading r USING (level)
+		ORDER BY level`
+
+	rows, err := c.db.Query(query, userID, language)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query level evidence: %v", err)
+	}
+	defer rows.Close()
+
+	evidence := []cefr.Evidence{}
+	for rows.Next() {
+		var e cefr.Evidence
+		if err := rows.Scan(&e.Level, &e.Attempts, &e.Passed, &e.Started, &e.Completed, &e.WordsRead, &e.Lookups); err != nil {
+			return nil, fmt.Errorf("failed to scan level evidence: %v", err)
+		}
+		evidence = append(evidence, e)
+	}
+	return evidence, rows.Err()
+}
+
+// SaveLevelEstimate stores a user's estimated level in language, replacing
+// their previous estimate. With apply set the estimate also replaces the
+// skill level in their profile, as long as it is still declaredLevel. It
+// returns whether the profile was changed.
+func (c *Client) SaveLevelEstimate(userID string, language string, declaredLevel string, estimate cefr.Estimate, evidence []cefr.Evidence, apply bool) (bool, error) {
+	evidenceJSON, err := json.Marshal(evidence)
+	if err != nil {
+		return false, fmt.Errorf("failed to marshal level evidence: %v", err)
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	applied := false
+	if apply {
+		result, err := tx.Exec(`
+			UPDATE profiles SET skill_level = $3
+			WHERE user_id = $1 AND learning_language = $2 AND skill_level = $4
+		`, userID, language, estimate.Level, declaredLevel)
+		if err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to update skill level: %v", err)
+		}
+		updated, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return false, fmt.Errorf("failed to update skill level: %v", err)
+		}
+		applied = updated > 0
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO level_estimates (user_id, language, declared_level, estimated_level, confidence, recommendation, evidence, applied)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		ON CONFLICT ON CONSTRAINT unique_level_estimate
+		DO UPDATE SET
+			declared_level = EXCLUDED.declared_level,
+			estimated_level = EXCLUDED.estimated_level,
+			confidence = EXCLUDED.confidence,
+			recommendation = EXCLUDED.recommendation,
+			evidence = EXCLUDED.evidence,
+			applied = EXCLUDED.applied,
+			estimated_at = CURRENT_TIMESTAMP
+	`, userID, language, declaredLevel, estimate.Level, estimate.Confidence, estimate.Recommendation, evidenceJSON, applied)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to save level estimate: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return applied, nil
+}
@@ -0,0 +1,228 @@
+package supabase
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Question is one question of the pool of a content, question type and level.
+type Question struct {
+	ID           int
+	ContentType  string // News or Story
+	ContentID    string
+	QuestionType string
+	CEFRLevel    string
+	Position     int
+	Question     string
+	Choices      []string // nil for free text questions
+	AnswerKey    string   // empty for questions evaluated by an LLM
+	CreatedAt    time.Time
+}
+
+// PooledQuestion is a question of a pool with how a user has done on it.
+type PooledQuestion struct {
+	Question
+	Attempts       int
+	LastEvaluation string     // empty when never attempted
+	LastAttemptAt  *time.Time // nil when never attempted
+}
+
+// QuestionAttempt is an answer of a user, with the question it answered.
+type QuestionAttempt struct {
+	ID          int
+	Question    Question
+	Answer      string
+	Evaluation  string
+	Explanation string
+	Feedback    json.RawMessage // the scores and errors, nil for older attempts
+	CreatedAt   time.Time
+}
+
+func questionColumn(contentType string) (string, error) {
+	switch contentType {
+	case "News":
+		return "news_id", nil
+	case "Story":
+		return "story_id", nil
+	}
+	return "", fmt.Errorf("invalid content type: %s", contentType)
+}
+
+const questionSelect = `
+	SELECT q.id, CASE WHEN q.story_id IS NULL THEN 'News' ELSE 'Story' END,
+		COALESCE(q.news_id, q.story_id)::text, q.question_type, q.cefr_level, q.position, q.question,
+		q.choices, COALESCE(q.answer_key, ''), q.created_at`
+
+func scanQuestion(row rowScanner, extra ...interface{}) (Question, error) {
+	var question Question
+	var choices []byte
+	dest := append([]interface{}{
+		&question.ID, &question.ContentType, &question.ContentID, &question.QuestionType,
+		&question.CEFRLevel, &question.Position, &question.Question,
+		&choices, &question.AnswerKey, &question.CreatedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return question, err
+	}
+	if choices != nil {
+		if err := json.Unmarshal(choices, &question.Choices); err != nil {
+			return question, fmt.Errorf("invalid choices: %v", err)
+		}
+	}
+	return question, nil
+}
+
+// GetQuestionPool returns the questions of a content, type and level that are
+// not retired in position order, with the attempts of a user on each.
+func (c *Client) GetQuestionPool(contentType string, contentID string, questionType string, cefrLevel string, userID string) ([]PooledQuestion, error) {
+	column, err := questionColumn(contentType)
+	if err != nil {
+		return nil, err
+	}
+
+	query := questionSelect + fmt.Sprintf(`,
+			COUNT(a.id), COALESCE((ARRAY_AGG(a.evaluation ORDER BY a.created_at DESC))[1], ''), MAX(a.created_at)
+		FROM questions q
+		LEFT JOIN question_attempts a ON a.question_id = q.id AND a.user_id = $4
+		WHERE q.%s = $1 AND q.question_type = $2 AND q.cefr_level = $3 AND q.retired_at IS NULL
+		GROUP BY q.id
+		ORDER BY q.position`, column)
+
+	rows, err := c.db.Query(query, contentID, questionType, cefrLevel, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query question pool: %v", err)
+	}
+	defer rows.Close()
+
+	pool := []PooledQuestion{}
+	for rows.Next() {
+		var pooled PooledQuestion
+		var lastAttemptAt sql.NullTime
+		pooled.Question, err = scanQuestion(rows, &pooled.Attempts, &pooled.LastEvaluation, &lastAttemptAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan question: %v", err)
+		}
+		if lastAttemptAt.Valid {
+			pooled.LastAttemptAt = &lastAttemptAt.Time
+		}
+		pool = append(pool, pooled)
+	}
+	return pool, rows.Err()
+}
+
+// GetQuestion returns a question by ID, or nil if there is none.
+func (c *Client) GetQuestion(questionID int) (*Question, error) {
+	question, err := scanQuestion(c.db.QueryRow(questionSelect+` FROM questions q WHERE q.id = $1`, questionID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get question: %v", err)
+	}
+	return &question, nil
+}
+
+// CreateContentQuestion stores a question at a position of a pool unless one
+// is already there, and returns the stored question. The generation lambda and
+// concurrent requests may write the same position, in which case the first
+// one written wins. choices and answerKey are only set for question types
+// graded against an answer key.
+func (c *Client) CreateContentQuestion(contentType string, contentID string, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) (Question, error) {
+	column, err := questionColumn(contentType)
+	if err != nil {
+		return Question{}, err
+	}
+	var choicesJSON, answerKeyValue interface{}
+	if choices != nil {
+		encoded, err := json.Marshal(choices)
+		if err != nil {
+			return Question{}, fmt.Errorf("failed to encode choices: %v", err)
+		}
+		choicesJSON = string(encoded)
+	}
+	if answerKey != "" {
+		answerKeyValue = answerKey
+	}
+
+	query := fmt.Sprintf(`
+		WITH q AS (
+			INSERT INTO questions (%s, question_type, cefr_level, position, question, choices, answer_key)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (%s, question_type, cefr_level, position) WHERE retired_at IS NULL
+			DO UPDATE SET question = questions.question
+			RETURNING *
+		)`, column, column) + questionSelect + ` FROM q`
+
+	stored, err := scanQuestion(c.db.QueryRow(query, contentID, questionType, cefrLevel, position, question, choicesJSON, answerKeyValue))
+	if err != nil {
+		return Question{}, fmt.Errorf("failed to insert question: %v", err)
+	}
+	return stored, nil
+}
+
+// InsertQuestionAttempt records an answer of a user. feedback is nil for
+// answers graded against an answer key.
+func (c *Client) InsertQuestionAttempt(userID string, questionID int, answer string, evaluation string, explanation string, feedback json.RawMessage) (int, error) {
+	var feedbackValue interface{}
+	if feedback != nil {
+		feedbackValue = string(feedback)
+	}
+	var id int
+	err := c.db.QueryRow(`
+		INSERT INTO question_attempts (user_id, question_id, answer, evaluation, explanation, feedback)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id`,
+		userID, questionID, answer, evaluation, explanation, feedbackValue,
+	).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert question attempt: %v", err)
+	}
+	return id, nil
+}
+
+// GetQuestionAttempts returns the attempts of a user, newest first. When
+// contentType and contentID are set only the attempts on that content are
+// returned.
+func (c *Client) GetQuestionAttempts(userID string, contentType string, contentID string, page int, pageSize int) ([]QuestionAttempt, error) {
+	filter := ""
+	args := []interface{}{userID, pageSize, (page - 1) * pageSize}
+	if contentType != "" {
+		column, err := questionColumn(contentType)
+		if err != nil {
+			return nil, err
+		}
+		filter = fmt.Sprintf(" AND q.%s = $4", column)
+		args = append(args, contentID)
+	}
+
+	query := questionSelect + `,
+			a.id, a.answer, a.evaluation, COALESCE(a.explanation, ''), a.feedback, a.created_at
+		FROM question_attempts a
+		JOIN questions q ON q.id = a.question_id
+		WHERE a.user_id = $1` + filter + `
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query question attempts: %v", err)
+	}
+	defer rows.Close()
+
+	attempts := []QuestionAttempt{}
+	for rows.Next() {
+		var attempt QuestionAttempt
+		var feedback []byte
+		attempt.Question, err = scanQuestion(rows, &attempt.ID, &attempt.Answer, &attempt.Evaluation, &attempt.Explanation, &feedback, &attempt.CreatedAt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan question attempt: %v", err)
+		}
+		if feedback != nil {
+			attempt.Feedback = json.RawMessage(feedback)
+		}
+		attempts = append(attempts, attempt)
+	}
+	return attempts, rows.Err()
+}
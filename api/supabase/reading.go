@@ -0,0 +1,174 @@
+package supabase
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Reading statuses of content for a user, as returned with query results.
+const (
+	READING_UNREAD      = "unread"
+	READING_IN_PROGRESS = "in_progress"
+	READING_READ        = "read"
+)
+
+// ReadingSession is where a user is in a piece of content.
+type ReadingSession struct {
+	ContentType      string // News or Story
+	ContentID        string
+	LastPage         int
+	PercentComplete  float64 // the furthest the user has got, 0 to 100
+	TimeSpentSeconds int
+	StartedAt        time.Time
+	UpdatedAt        time.Time
+	CompletedAt      *time.Time // nil until the user finishes the content
+}
+
+// ReadingEntry is a reading session with the content it is for.
+type ReadingEntry struct {
+	ReadingSession
+	Title       string
+	Language    string
+	Topic       string
+	CEFRLevel   string
+	PreviewText string
+	Pages       int // stories only
+}
+
+func readingColumn(contentType string) (string, string, error) {
+	switch contentType {
+	case "News":
+		return "news_id", "unique_news_reading", nil
+	case "Story":
+		return "story_id", "unique_story_reading", nil
+	}
+	return "", "", fmt.Errorf("invalid content type: %s", contentType)
+}
+
+const readingSelect = `
+	SELECT CASE WHEN r.story_id IS NULL THEN 'News' ELSE 'Story' END, COALESCE(r.news_id, r.story_id)::text,
+		r.last_page, r.percent_complete, r.time_spent_seconds, r.started_at, r.updated_at, r.completed_at`
+
+func scanReadingSession(row rowScanner, extra ...interface{}) (ReadingSession, error) {
+	var session ReadingSession
+	var completedAt sql.NullTime
+	dest := append([]interface{}{
+		&session.ContentType, &session.ContentID, &session.LastPage, &session.PercentComplete,
+		&session.TimeSpentSeconds, &session.StartedAt, &session.UpdatedAt, &completedAt,
+	}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return session, err
+	}
+	if completedAt.Valid {
+		session.CompletedAt = &completedAt.Time
+	}
+	return session, nil
+}
+
+// UpdateReadingSession moves a user to a page of a piece of content, adding
+// secondsSpent to their reading time. The percentage only ever grows, so
+// going back a page does not lose progress, and the completion time is kept
+// from the first time the user finishes.
+func (c *Client) UpdateReadingSession(userID string, contentType string, contentID string, page int, percentComplete float64, secondsSpent int, completed bool) (ReadingSession, error) {
+	column, constraint, err := readingColumn(contentType)
+	if err != nil {
+		return ReadingSession{}, err
+	}
+
+	query := fmt.Sprintf(`
+		WITH r AS (
+			INSERT INTO reading_sessions (user_id, %s, last_page, percent_complete, time_spent_seconds, completed_at)
+			VALUES ($1, $2, $3, $4, $5, CASE WHEN $6 THEN CURRENT_TIMESTAMP END)
+			ON CONFLICT ON CONSTRAINT %s
+			DO UPDATE SET
+				last_page = EXCLUDED.last_page,
+				percent_complete = GREATEST(reading_sessions.percent_complete, EXCLUDED.percent_complete),
+				time_spent_seconds = reading_sessions.time_spent_seconds + EXCLUDED.time_spent_seconds,
+				completed_at = COALESCE(reading_sessions.completed_at, EXCLUDED.completed_at),
+				updated_at = CURRENT_TIMESTAMP
+			RETURNING *
+		)`, column, constraint) + readingSelect + ` FROM r`
+
+	session, err := scanReadingSession(c.db.QueryRow(query, userID, contentID, page, percentComplete, secondsSpent, completed))
+	if err != nil {
+		return ReadingSession{}, fmt.Errorf("failed to update reading session: %v", err)
+	}
+	return session, nil
+}
+
+// GetReadingSessions returns a user's reading sessions of published content,
+// the most recently read first. With inProgress set only the content they
+// have not finished is returned.
+func (c *Client) GetReadingSessions(userID string, inProgress bool, limit int) ([]ReadingEntry, error) {
+	query := readingSelect + `,
+			COALESCE(n.title, s.title), COALESCE(n.language, s.language), COALESCE(n.topic, s.topic),
+			COALESCE(n.cefr_level, s.cefr_level), COALESCE(n.preview_text, s.preview_text), COALESCE(s.pages, 0)
+		FROM reading_sessions r
+		LEFT JOIN news n ON n.id = r.news_id AND n.status = 'published'
+		LEFT JOIN stories s ON s.id = r.story_id AND s.status = 'published'
+		WHERE r.user_id = $1 AND (n.id IS NOT NULL OR s.id IS NOT NULL)
+			AND (NOT $2 OR r.completed_at IS NULL)
+		ORDER BY r.updated_at DESC, r.id DESC
+		LIMIT $3`
+
+	rows, err := c.db.Query(query, userID, inProgress, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading sessions: %v", err)
+	}
+	defer rows.Close()
+
+	entries := []ReadingEntry{}
+	for rows.Next() {
+		var entry ReadingEntry
+		entry.ReadingSession, err = scanReadingSession(rows,
+			&entry.Title, &entry.Language, &entry.Topic, &entry.CEFRLevel, &entry.PreviewText, &entry.Pages)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reading session: %v", err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
+
+// GetReadingStatuses returns the reading status of each of contentIDs for a
+// user: READING_READ, READING_IN_PROGRESS or, for content they have not
+// opened, READING_UNREAD.
+func (c *Client) GetReadingStatuses(userID string, contentType string, contentIDs []string) (map[string]string, error) {
+	column, _, err := readingColumn(contentType)
+	if err != nil {
+		return nil, err
+	}
+	statuses := make(map[string]string, len(contentIDs))
+	for _, id := range contentIDs {
+		statuses[id] = READING_UNREAD
+	}
+	if len(contentIDs) == 0 {
+		return statuses, nil
+	}
+
+	query := fmt.Sprintf(`
+		SELECT %[1]s::text, completed_at IS NOT NULL
+		FROM reading_sessions
+		WHERE user_id = $1 AND %[1]s = ANY($2::int[])`, column)
+	rows, err := c.db.Query(query, userID, pq.Array(contentIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reading statuses: %v", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var id string
+		var completed bool
+		if err := rows.Scan(&id, &completed); err != nil {
+			return nil, fmt.Errorf("failed to scan reading status: %v", err)
+		}
+		statuses[id] = READING_IN_PROGRESS
+		if completed {
+			statuses[id] = READING_READ
+		}
+	}
+	return statuses, rows.Err()
+}
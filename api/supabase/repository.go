@@ -0,0 +1,162 @@
+package supabase
+
+import (
+	"encoding/json"
+	"story-api/cefr"
+	"story-api/models"
+	"story-api/srs"
+	"time"
+)
+
+// The interfaces below split the database client by area so handlers can be
+// given an in-memory implementation (see supabase/fake) in tests.
+// *Client implements all of them.
+
+type ContentRepository interface {
+	QueryNews(params QueryParams) ([]map[string]interface{}, error)
+	QueryStories(params QueryParams) ([]map[string]interface{}, error)
+	QueryAllContent(params QueryParams) ([]map[string]interface{}, error)
+	GetContentByID(contentType string, contentID string) (map[string]interface{}, error)
+	GetAudiobook(contentType string, id string) (AudiobookInfo, error)
+}
+
+// QuestionRepository reads and writes the QNA question pools and the answers
+// users give.
+type QuestionRepository interface {
+	GetQuestionPool(contentType string, contentID string, questionType string, cefrLevel string, userID string) ([]PooledQuestion, error)
+	GetQuestion(questionID int) (*Question, error)
+	CreateContentQuestion(contentType string, contentID string, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) (Question, error)
+	InsertQuestionAttempt(userID string, questionID int, answer string, evaluation string, explanation string, feedback json.RawMessage) (int, error)
+	GetQuestionAttempts(userID string, contentType string, contentID string, page int, pageSize int) ([]QuestionAttempt, error)
+}
+
+type ProfileRepository interface {
+	GetProfile(userID string) (*Profile, error)
+	UpsertProfile(userID string, profile *Profile) (int, error)
+}
+
+type ProgressRepository interface {
+	GetTodayProgress(userID string) (*DailyProgress, error)
+	IncrementQuestionsCompleted(userID string, amount int) error
+	GetProgressStreak(userID string) (int, bool, error)
+}
+
+type ClassroomRepository interface {
+	GetClassroomById(classroomID string) (string, int, error)
+	GetClassroomList(teacherID string) ([]models.ClassroomListItem, error)
+	CreateClassroom(teacherID string, name string, student_count int) (string, error)
+	UpdateClassroom(classroomID int, name string) error
+	DeleteClassroom(classroomID string) error
+	VerifyClassroomOwnership(teacherID string, classroomID string) (bool, error)
+	AddStudentToClassroom(classroomID string, studentID string) error
+	CheckAcceptedContent(classroomID string, contentType string, contentID string) (bool, error)
+	AcceptContent(classroomID int, contentType string, contentID int) error
+	RejectContent(classroomID int, contentType string, contentID int) error
+}
+
+// AccountRepository answers which kind of account (teacher, student, admin,
+// editor or regular user) a user has.
+type AccountRepository interface {
+	CheckAccountType(userID string, accountType string) (bool, error)
+	CheckStudentStatus(userID string) (string, string, error)
+	CheckTeacherStatus(userID string) (bool, error)
+	CheckAdminStatus(userID string) (bool, error)
+	CheckEditorStatus(userID string) (bool, error)
+	GetTeacherInfo(teacherID string) (bool, error)
+}
+
+type OrganizationRepository interface {
+	GetTeacherUUID(userID string) (string, error)
+	CheckTeacherOrganization(teacherID string) (string, error)
+	CheckTeacherOrganizationByUserID(userID string) (string, error)
+	CheckOrganizationByUserID(userID string) (string, error)
+	GetOrganizationPlan(organizationID string) (string, error)
+	GetOrganizationSubscriptionID(organizationID string) (string, error)
+	GetOrganizationInfo(organizationID string) (string, string, string, time.Time, bool, error)
+	GetOrganizationByCustomerID(customerID string) (string, error)
+	CreateOrganization(adminID string) (string, error)
+	JoinOrganization(userID string, organizationID string) (string, error)
+	UpdateOrganization(plan, organizationID, customerID, subscriptionID string, expiration time.Time, canceled bool) error
+}
+
+type BillingRepository interface {
+	GetUserIDByCustomerID(customerID string) (string, error)
+	GetBillingAccount(userID string) (string, time.Time, bool, string, string, error)
+	UpdateBillingAccount(userID, plan, customerID, subscriptionID string, expiration time.Time, canceled bool) error
+}
+
+type UsageRepository interface {
+	InsertUsage(userID string, featureID string, amount int) error
+	GetUsage(userID string, featureID string, plan string) (int, error)
+}
+
+// CatalogRepository reads the generation matrix, which decides which
+// languages, levels and topics have content.
+type CatalogRepository interface {
+	GetGenerationMatrix() ([]GenerationCell, error)
+}
+
+// EditorRepository reads and changes content whatever its status, for the
+// editorial review routes.
+type EditorRepository interface {
+	QueryEditorContent(contentType string, status string, page int, pageSize int) ([]EditorContent, error)
+	GetEditorContent(contentType string, contentID string) (*EditorContent, error)
+	UpdateContentText(contentType string, contentID string, title string, previewText string, pages int) error
+	RetireDerivedContent(contentType string, contentID string) error
+	SetContentStatus(contentType string, contentID string, from []string, to string, editorID string, note string) (bool, error)
+}
+
+// VocabRepository reads and schedules the words users save while reading.
+type VocabRepository interface {
+	SaveVocabCard(userID string, card VocabCard) (VocabCard, error)
+	GetVocabCard(userID string, cardID int) (*VocabCard, error)
+	GetVocabCards(userID string, language string, dueBefore *time.Time, page int, pageSize int) ([]VocabCard, error)
+	ReviewVocabCard(userID string, cardID int, grade int, schedule srs.Card, reviewedAt time.Time) (bool, error)
+	DeleteVocabCard(userID string, cardID int) (bool, error)
+}
+
+// ReadingRepository records how far users have got in the content they read.
+type ReadingRepository interface {
+	UpdateReadingSession(userID string, contentType string, contentID string, page int, percentComplete float64, secondsSpent int, completed bool) (ReadingSession, error)
+	GetReadingSessions(userID string, inProgress bool, limit int) ([]ReadingEntry, error)
+	GetReadingStatuses(userID string, contentType string, contentIDs []string) (map[string]string, error)
+}
+
+// FeedRepository finds the content a user's feed is ranked from.
+type FeedRepository interface {
+	GetFeedCandidates(userID string, language string, cefrLevels []string, asOf time.Time, limit int, classroomID string) ([]FeedCandidate, error)
+}
+
+// LevelRepository estimates users' CEFR levels from what they have done.
+type LevelRepository interface {
+	GetLevelEvidence(userID string, language string) ([]cefr.Evidence, error)
+	SaveLevelEstimate(userID string, language string, declaredLevel string, estimate cefr.Estimate, evidence []cefr.Evidence, apply bool) (bool, error)
+}
+
+// SearchRepository searches the text of published content.
+type SearchRepository interface {
+	SearchContent(params SearchParams) ([]SearchResult, error)
+	SetBodyText(contentType string, contentID string, text string) error
+}
+
+// Repository is everything the API handlers need from the database.
+type Repository interface {
+	ContentRepository
+	QuestionRepository
+	ProfileRepository
+	ProgressRepository
+	ClassroomRepository
+	AccountRepository
+	OrganizationRepository
+	BillingRepository
+	UsageRepository
+	CatalogRepository
+	EditorRepository
+	VocabRepository
+	ReadingRepository
+	FeedRepository
+	LevelRepository
+	SearchRepository
+}
+
+var _ Repository = (*Client)(nil)
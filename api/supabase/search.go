@@ -0,0 +1,126 @@
+package supabase
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// SEARCH_HEADLINE_OPTIONS are the ts_headline options of search snippets:
+// up to two fragments of the body with the matches in <mark> tags.
+const SEARCH_HEADLINE_OPTIONS = "StartSel=<mark>, StopSel=</mark>, MaxFragments=2, MaxWords=20, MinWords=8, FragmentDelimiter=\" … \""
+
+// SearchParams are the filters of a full-text search. Query is in web search
+// syntax: words, "quoted phrases", or and -excluded words.
+type SearchParams struct {
+	Query       string
+	Language    string
+	CEFR        string // optional
+	Subject     string // optional
+	ContentType string // News, Story or empty for both
+	// with ClassroomID set only content accepted in the classroom is found
+	ClassroomID string
+	Page        int
+	PageSize    int
+}
+
+// SearchResult is published content matching a search, best match first.
+type SearchResult struct {
+	ContentType string
+	ID          string
+	Title       string
+	Language    string
+	Topic       string
+	CEFRLevel   string
+	PreviewText string
+	CreatedAt   time.Time
+	DateCreated string
+	Pages       int // stories only
+	Rank        float64
+	Snippet     string // from the body, or the preview for content without one
+}
+
+// SearchContent searches the title, preview and body of published news and
+// stories in a language, stemming words with the language's text search
+// configuration (see search_config in the migrations).
+func (c *Client) SearchContent(params SearchParams) ([]SearchResult, error) {
+	args := []interface{}{params.Query, params.Language, params.CEFR, params.Subject}
+	whitelist := func(column string) string { return "" }
+	if params.ClassroomID != "" {
+		args = append(args, params.ClassroomID)
+		whitelist = func(column string) string {
+			return fmt.Sprintf(`
+				AND EXISTS (
+					SELECT 1 FROM accepted_content ac
+					WHERE ac.classroom_id = $%d AND ac.%s = c.id
+				)`, len(args), column)
+		}
+	}
+
+	selectContent := func(contentType string, table string, column string, pages string) string {
+		return fmt.Sprintf(`
+			SELECT '%[1]s' AS content_type, c.id, c.title, c.language, c.topic, c.cefr_level, c.preview_text,
+				c.created_at, c.date_created::text AS date_created, %[4]s AS pages,
+				COALESCE(NULLIF(c.body_text, ''), c.preview_text) AS body,
+				ts_rank_cd(c.search_vector, q.query) AS rank, q.query
+			FROM %[2]s c, q
+			WHERE c.status = 'published' AND c.language = $2 AND c.search_vector @@ q.query
+				AND ($3 = '' OR c.cefr_level = $3) AND ($4 = '' OR c.topic = $4)%[5]s`,
+			contentType, table, column, pages, whitelist(column))
+	}
+	selects := []string{}
+	if params.ContentType == "" || params.ContentType == "News" {
+		selects = append(selects, selectContent("News", "news", "news_id", "0"))
+	}
+	if params.ContentType == "" || params.ContentType == "Story" {
+		selects = append(selects, selectContent("Story", "stories", "story_id", "c.pages"))
+	}
+	if len(selects) == 0 {
+		return nil, fmt.Errorf("invalid content type: %s", params.ContentType)
+	}
+
+	args = append(args, params.PageSize, (params.Page-1)*params.PageSize)
+	// snippets are only made for the page, ts_headline reads the whole body
+	query := fmt.Sprintf(`
+		WITH q AS (SELECT websearch_to_tsquery(search_config($2), $1) AS query)
+		SELECT content_type, id::text, title, language, topic, cefr_level, preview_text, created_at,
+			date_created, pages, rank, ts_headline(search_config(language), body, query, '%s')
+		FROM (
+			%s
+			ORDER BY rank DESC, created_at DESC, content_type, id
+			LIMIT $%d OFFSET $%d
+		) results
+		ORDER BY rank DESC, created_at DESC, content_type, id`,
+		SEARCH_HEADLINE_OPTIONS, strings.Join(selects, "\n\t\t\tUNION ALL"), len(args)-1, len(args))
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search content: %v", err)
+	}
+	defer rows.Close()
+
+	results := []SearchResult{}
+	for rows.Next() {
+		var result SearchResult
+		if err := rows.Scan(&result.ContentType, &result.ID, &result.Title, &result.Language, &result.Topic,
+			&result.CEFRLevel, &result.PreviewText, &result.CreatedAt, &result.DateCreated, &result.Pages,
+			&result.Rank, &result.Snippet); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %v", err)
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// SetBodyText stores the plain text of a news or stories row for full-text
+// search.
+func (c *Client) SetBodyText(contentType string, contentID string, text string) error {
+	table, err := contentTableName(contentType)
+	if err != nil {
+		return err
+	}
+	if _, err := c.db.Exec(fmt.Sprintf("UPDATE %s SET body_text = $1 WHERE id = $2", table), text, contentID); err != nil {
+		return fmt.Errorf("failed to set body text: %v", err)
+	}
+	return nil
+}
@@ -112,6 +112,7 @@ func (c *Client) QueryAllContent(params QueryParams) ([]map[string]interface{},
 
 // helper function called in the QueryNews and QueryStories functions
 // builds the SQL query string based on the query params
+// only published content is returned, held content waits for review
 func (c *Client) queryContent(params QueryParams, contentType string) ([]map[string]interface{}, error) {
 	var baseQuery string
 	var queryParams []interface{}
@@ -143,7 +144,7 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 					ELSE 'News'::text 
 				END as content_type,
 				CASE
-					WHEN '%[1]s' = 'stories' COALESCE((SELECT tier FROM audiobooks WHERE audiobooks.story_id = %[1].s.id), 'NONE')::text
+					WHEN '%[1]s' = 'stories' THEN COALESCE((SELECT tier FROM audiobooks WHERE audiobooks.story_id = %[1]s.id), 'NONE')::text
 					ELSE COALESCE((SELECT tier FROM audiobooks WHERE audiobooks.news_id = %[1]s.id), 'NONE')::text
 			END as audiobook_tier`, tableAlias)
 		}
@@ -163,7 +164,7 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 					SELECT * FROM (
 						(
 							%s
-							FROM stories stories
+							FROM (SELECT * FROM stories WHERE status = 'published') stories
 							WHERE NOT EXISTS (
 								SELECT 1 FROM accepted_content ac 
 								WHERE ac.classroom_id = $1 
@@ -173,7 +174,7 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 						UNION ALL
 						(
 							%s
-							FROM news news
+							FROM (SELECT * FROM news WHERE status = 'published') news
 							WHERE NOT EXISTS (
 								SELECT 1 FROM accepted_content ac 
 								WHERE ac.classroom_id = $1 
@@ -187,7 +188,7 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 			} else if contentType == "Story" {
 				baseQuery = fmt.Sprintf(`
 					%s
-					FROM stories stories
+					FROM (SELECT * FROM stories WHERE status = 'published') stories
 					WHERE NOT EXISTS (
 						SELECT 1 FROM accepted_content ac 
 						WHERE ac.classroom_id = $1 
@@ -196,7 +197,7 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 			} else {
 				baseQuery = fmt.Sprintf(`
 					%s
-					FROM news news
+					FROM (SELECT * FROM news WHERE status = 'published') news
 					WHERE NOT EXISTS (
 						SELECT 1 FROM accepted_content ac 
 						WHERE ac.classroom_id = $1 
@@ -209,14 +210,14 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 					SELECT * FROM (
 						(
 							%s
-							FROM stories stories
+							FROM (SELECT * FROM stories WHERE status = 'published') stories
 							INNER JOIN accepted_content ac ON stories.id = ac.story_id 
 							WHERE ac.classroom_id = $1
 						)
 						UNION ALL
 						(
 							%s
-							FROM news news
+							FROM (SELECT * FROM news WHERE status = 'published') news
 							INNER JOIN accepted_content ac ON news.id = ac.news_id 
 							WHERE ac.classroom_id = $1
 						)
@@ -225,13 +226,13 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 			} else if contentType == "Story" {
 				baseQuery = fmt.Sprintf(`
 					%s
-					FROM stories stories
+					FROM (SELECT * FROM stories WHERE status = 'published') stories
 					INNER JOIN accepted_content ac ON stories.id = ac.story_id 
 					WHERE ac.classroom_id = $1`, buildSelect("stories"))
 			} else {
 				baseQuery = fmt.Sprintf(`
 					%s
-					FROM news news
+					FROM (SELECT * FROM news WHERE status = 'published') news
 					INNER JOIN accepted_content ac ON news.id = ac.news_id 
 					WHERE ac.classroom_id = $1`, buildSelect("news"))
 			}
@@ -243,21 +244,21 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 					SELECT * FROM (
 						(
 							%s
-							FROM stories stories
+							FROM (SELECT * FROM stories WHERE status = 'published') stories
 							WHERE 1=1
 						)
 						UNION ALL
 						(
 							%s
-							FROM news news
+							FROM (SELECT * FROM news WHERE status = 'published') news
 							WHERE 1=1
 						)
 					) combined_results
 					WHERE 1=1`, buildSelect("stories"), buildSelect("news"))
 			} else if contentType == "Story" {
-				baseQuery = fmt.Sprintf(`%s FROM stories stories WHERE 1=1`, buildSelect("stories"))
+				baseQuery = fmt.Sprintf(`%s FROM (SELECT * FROM stories WHERE status = 'published') stories WHERE 1=1`, buildSelect("stories"))
 			} else {
-				baseQuery = fmt.Sprintf(`%s FROM news news WHERE 1=1`, buildSelect("news"))
+				baseQuery = fmt.Sprintf(`%s FROM (SELECT * FROM news WHERE status = 'published') news WHERE 1=1`, buildSelect("news"))
 			}
 		}
 	} else {
@@ -266,21 +267,21 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 				SELECT * FROM (
 					(
 						%s
-						FROM stories stories
+						FROM (SELECT * FROM stories WHERE status = 'published') stories
 						WHERE 1=1
 					)
 					UNION ALL
 					(
 						%s
-						FROM news news
+						FROM (SELECT * FROM news WHERE status = 'published') news
 						WHERE 1=1
 					)
 				) combined_results
 				WHERE 1=1`, buildSelect("stories"), buildSelect("news"))
 		} else if contentType == "Story" {
-			baseQuery = fmt.Sprintf(`%s FROM stories stories WHERE 1=1`, buildSelect("stories"))
+			baseQuery = fmt.Sprintf(`%s FROM (SELECT * FROM stories WHERE status = 'published') stories WHERE 1=1`, buildSelect("stories"))
 		} else {
-			baseQuery = fmt.Sprintf(`%s FROM news news WHERE 1=1`, buildSelect("news"))
+			baseQuery = fmt.Sprintf(`%s FROM (SELECT * FROM news WHERE status = 'published') news WHERE 1=1`, buildSelect("news"))
 		}
 	}
 
@@ -379,107 +380,19 @@ func (c *Client) queryContent(params QueryParams, contentType string) ([]map[str
 	return results, nil
 }
 
-// retrieves a question for the given content type, id, question type and CEFR level
-func (c *Client) GetContentQuestion(contentType string, contentID string, questionType string, cefrLevel string) (map[string]interface{}, error) {
-	var query string
-	if contentType == "Story" {
-		query = `
-			SELECT id, story_id, question_type, cefr_level, question, created_at 
-			FROM questions 
-			WHERE story_id = $1 AND question_type = $2 AND cefr_level = $3`
-	} else if contentType == "News" {
-		query = `
-			SELECT id, news_id, question_type, cefr_level, question, created_at 
-			FROM questions 
-			WHERE news_id = $1 AND question_type = $2 AND cefr_level = $3`
-	} else {
-		return nil, fmt.Errorf("invalid content type: %s", contentType)
-	}
-
-	var id int
-	var contentRefID string
-	var qType, cefr, question string
-	var createdAt time.Time
-
-	err := c.db.QueryRow(query, contentID, questionType, cefrLevel).Scan(
-		&id,
-		&contentRefID,
-		&qType,
-		&cefr,
-		&question,
-		&createdAt,
-	)
-
-	if err == sql.ErrNoRows {
-		return nil, nil // No question found
-	}
-	if err != nil {
-		return nil, fmt.Errorf("failed to query question: %v", err)
-	}
-
-	result := map[string]interface{}{
-		"id":            id,
-		"question_type": qType,
-		"cefr_level":    cefr,
-		"question":      question,
-		"created_at":    createdAt,
-	}
-
-	// Add the appropriate content ID field based on type
-	if contentType == "Story" {
-		result["story_id"] = contentRefID
-	} else {
-		result["news_id"] = contentRefID
-	}
-
-	return result, nil
-}
-
-// creates a new question for the given content (story/news)
-func (c *Client) CreateContentQuestion(contentType string, contentID string, questionType string, cefrLevel string, question string) error {
-	var query string
-	if contentType == "Story" {
-		query = `
-			INSERT INTO questions (story_id, question_type, cefr_level, question)
-			VALUES ($1, $2, $3, $4)`
-	} else if contentType == "News" {
-		query = `
-			INSERT INTO questions (news_id, question_type, cefr_level, question)
-			VALUES ($1, $2, $3, $4)`
-	} else {
-		return fmt.Errorf("invalid content type: %s", contentType)
-	}
-
-	result, err := c.db.Exec(query, contentID, questionType, cefrLevel, question)
-	if err != nil {
-		return fmt.Errorf("failed to insert question: %v", err)
-	}
-
-	rowsAffected, err := result.RowsAffected()
-	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %v", err)
-	}
-
-	if rowsAffected == 0 {
-		return fmt.Errorf("no rows were inserted")
-	}
-
-	return nil
-}
-
-// retrieves a single content row (news or story) by its ID
+// retrieves a single published content row (news or story) by its ID
 func (c *Client) GetContentByID(contentType string, contentID string) (map[string]interface{}, error) {
 	var query string
 	if contentType == "Story" {
 		query = `
 			SELECT id, title, language, topic, cefr_level, preview_text, created_at, date_created, pages 
 			FROM stories 
-			WHERE id = $1`
+			WHERE id = $1 AND status = 'published'`
 	} else if contentType == "News" {
 		query = `
 			SELECT id, title, language, topic, cefr_level, preview_text, created_at, date_created 
 			FROM news 
-			WHERE id = $1`
+			WHERE id = $1 AND status = 'published'`
 	} else {
 		return nil, fmt.Errorf("invalid content type: %s", contentType)
 	}
@@ -71,8 +71,17 @@ func (c *Client) VerifyClassroomOwnership(teacherID string, classroomID string)
 	return exists, nil
 }
 
-// set as teacher, student, admin, or none / ""
+// set as teacher, student, admin, editor, or none / ""
 func (c *Client) CheckAccountType(userID string, accountType string) (bool, error) {
+	// editors are staff accounts, independent of the other account types
+	if accountType == "editor" {
+		exists, err := c.CheckEditorStatus(userID)
+		if err != nil {
+			return false, fmt.Errorf("failed to check editor status: %v", err)
+		}
+		return exists, nil
+	}
+
 	// teacher check
 	exists, err := c.CheckTeacherStatus(userID)
 	if err != nil {
@@ -0,0 +1,198 @@
+package supabase
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"story-api/srs"
+)
+
+// VocabCard is a word a user saved, with its review schedule.
+type VocabCard struct {
+	ID          int
+	Word        string
+	Language    string
+	Sentence    string // the sentence the word was looked up in
+	Translation string
+	ContentType string // News, Story or empty when not saved from content
+	ContentID   string
+	Lookups     int
+	srs.Card
+	LastReviewedAt *time.Time // nil when never reviewed
+	CreatedAt      time.Time
+}
+
+const vocabSelect = `
+	SELECT id, word, language, sentence, translation,
+		CASE WHEN news_id IS NOT NULL THEN 'News' WHEN story_id IS NOT NULL THEN 'Story' ELSE '' END,
+		COALESCE(news_id, story_id)::text, lookups, ease_factor, interval_days, repetitions,
+		due_at, last_reviewed_at, created_at`
+
+func scanVocabCard(row rowScanner) (VocabCard, error) {
+	var card VocabCard
+	var contentID sql.NullString
+	var lastReviewedAt sql.NullTime
+	err := row.Scan(
+		&card.ID, &card.Word, &card.Language, &card.Sentence, &card.Translation,
+		&card.ContentType, &contentID, &card.Lookups, &card.EaseFactor, &card.IntervalDays, &card.Repetitions,
+		&card.DueAt, &lastReviewedAt, &card.CreatedAt,
+	)
+	card.ContentID = contentID.String
+	if lastReviewedAt.Valid {
+		card.LastReviewedAt = &lastReviewedAt.Time
+	}
+	return card, err
+}
+
+// SaveVocabCard adds a word to a user's deck, due for review straight away.
+// Saving a word that is already in the deck counts another lookup and keeps
+// its schedule, filling in the sentence, translation and source if the card
+// did not have them. Every save from a news or story is also logged against
+// that content, for the level estimate.
+func (c *Client) SaveVocabCard(userID string, card VocabCard) (VocabCard, error) {
+	var newsID, storyID interface{}
+	switch card.ContentType {
+	case "News":
+		newsID = card.ContentID
+	case "Story":
+		storyID = card.ContentID
+	case "":
+	default:
+		return VocabCard{}, fmt.Errorf("invalid content type: %s", card.ContentType)
+	}
+
+	tx, err := c.db.Begin()
+	if err != nil {
+		return VocabCard{}, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	var id int
+	err = tx.QueryRow(`
+		INSERT INTO vocab_cards (user_id, word, language, sentence, translation, news_id, story_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT ON CONSTRAINT unique_vocab_card
+		DO UPDATE SET
+			lookups = vocab_cards.lookups + 1,
+			sentence = COALESCE(NULLIF(vocab_cards.sentence, ''), EXCLUDED.sentence),
+			translation = COALESCE(NULLIF(vocab_cards.translation, ''), EXCLUDED.translation),
+			news_id = CASE WHEN vocab_cards.story_id IS NULL THEN COALESCE(vocab_cards.news_id, EXCLUDED.news_id) END,
+			story_id = CASE WHEN vocab_cards.news_id IS NULL THEN COALESCE(vocab_cards.story_id, EXCLUDED.story_id) END
+		RETURNING id`, userID, card.Word, card.Language, card.Sentence, card.Translation, newsID, storyID).Scan(&id)
+	if err != nil {
+		tx.Rollback()
+		return VocabCard{}, fmt.Errorf("failed to save vocab card: %v", err)
+	}
+	if card.ContentType != "" {
+		_, err = tx.Exec(`
+			INSERT INTO vocab_lookups (user_id, word, language, news_id, story_id)
+			VALUES ($1, $2, $3, $4, $5)
+		`, userID, card.Word, card.Language, newsID, storyID)
+		if err != nil {
+			tx.Rollback()
+			return VocabCard{}, fmt.Errorf("failed to log vocab lookup: %v", err)
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return VocabCard{}, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+
+	saved, err := c.GetVocabCard(userID, id)
+	if err != nil {
+		return VocabCard{}, err
+	}
+	return *saved, nil
+}
+
+// GetVocabCard returns a card of a user, or nil if they have no such card.
+func (c *Client) GetVocabCard(userID string, cardID int) (*VocabCard, error) {
+	card, err := scanVocabCard(c.db.QueryRow(vocabSelect+` FROM vocab_cards WHERE id = $1 AND user_id = $2`, cardID, userID))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vocab card: %v", err)
+	}
+	return &card, nil
+}
+
+// GetVocabCards returns a user's deck, newest first. language may be empty
+// for every language. With dueBefore set only the cards due by then are
+// returned, the longest overdue first.
+func (c *Client) GetVocabCards(userID string, language string, dueBefore *time.Time, page int, pageSize int) ([]VocabCard, error) {
+	query := vocabSelect + ` FROM vocab_cards WHERE user_id = $1 AND ($2 = '' OR language = $2)`
+	args := []interface{}{userID, language, pageSize, (page - 1) * pageSize}
+	if dueBefore != nil {
+		query += ` AND due_at <= $5 ORDER BY due_at, id`
+		args = append(args, *dueBefore)
+	} else {
+		query += ` ORDER BY created_at DESC, id DESC`
+	}
+	query += ` LIMIT $3 OFFSET $4`
+
+	rows, err := c.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query vocab cards: %v", err)
+	}
+	defer rows.Close()
+
+	cards := []VocabCard{}
+	for rows.Next() {
+		card, err := scanVocabCard(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan vocab card: %v", err)
+		}
+		cards = append(cards, card)
+	}
+	return cards, rows.Err()
+}
+
+// ReviewVocabCard stores the schedule a review gave a card of a user and
+// records the review. It returns false if the user has no such card or it was
+// not due at reviewedAt, as when the same review is sent twice.
+func (c *Client) ReviewVocabCard(userID string, cardID int, grade int, schedule srs.Card, reviewedAt time.Time) (bool, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+	result, err := tx.Exec(`
+		UPDATE vocab_cards
+		SET ease_factor = $3, interval_days = $4, repetitions = $5, due_at = $6, last_reviewed_at = $7
+		WHERE id = $1 AND user_id = $2 AND due_at <= $7
+	`, cardID, userID, schedule.EaseFactor, schedule.IntervalDays, schedule.Repetitions, schedule.DueAt, reviewedAt)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to update vocab card: %v", err)
+	}
+	if updated, err := result.RowsAffected(); err != nil || updated == 0 {
+		tx.Rollback()
+		return false, err
+	}
+
+	_, err = tx.Exec(`
+		INSERT INTO vocab_reviews (user_id, card_id, grade, ease_factor, interval_days, reviewed_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+	`, userID, cardID, grade, schedule.EaseFactor, schedule.IntervalDays, reviewedAt)
+	if err != nil {
+		tx.Rollback()
+		return false, fmt.Errorf("failed to insert vocab review: %v", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit transaction: %v", err)
+	}
+	return true, nil
+}
+
+// DeleteVocabCard removes a card and its reviews from a user's deck. It
+// returns false if the user has no such card.
+func (c *Client) DeleteVocabCard(userID string, cardID int) (bool, error) {
+	result, err := c.db.Exec(`DELETE FROM vocab_cards WHERE id = $1 AND user_id = $2`, cardID, userID)
+	if err != nil {
+		return false, fmt.Errorf("failed to delete vocab card: %v", err)
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to delete vocab card: %v", err)
+	}
+	return deleted > 0, nil
+}
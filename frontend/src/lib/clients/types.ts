@@ -473,7 +473,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/news": {
+    "/catalog/languages": {
         parameters: {
             query?: never;
             header?: never;
@@ -481,15 +481,12 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get news content
-         * @description Get news content by ID
+         * List supported languages
+         * @description List the languages content is generated in, with their CEFR levels, content types and topics
          */
         get: {
             parameters: {
-                query: {
-                    /** @description Content ID */
-                    id: string;
-                };
+                query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -502,25 +499,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetNewsResponse"];
-                    };
-                };
-                /** @description Bad Request */
-                400: {
-                    headers: {
-                        [name: string]: unknown;
-                    };
-                    content: {
-                        "application/json": components["schemas"]["models.ErrorResponse"];
-                    };
-                };
-                /** @description Not Found */
-                404: {
-                    headers: {
-                        [name: string]: unknown;
-                    };
-                    content: {
-                        "application/json": components["schemas"]["models.ErrorResponse"];
+                        "application/json": components["schemas"]["models.CatalogLanguagesResponse"];
                     };
                 };
                 /** @description Internal Server Error */
@@ -542,7 +521,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/news/query": {
+    "/catalog/topics": {
         parameters: {
             query?: never;
             header?: never;
@@ -550,22 +529,14 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get news content
-         * @description Get news content by ID
+         * List supported topics
+         * @description List the topics content is generated for, optionally only those available in one language
          */
         get: {
             parameters: {
-                query: {
-                    /** @description Language */
-                    language: string;
-                    /** @description CEFR */
-                    cefr: string;
-                    /** @description Subject */
-                    subject: string;
-                    /** @description Page */
-                    page: string;
-                    /** @description Page size */
-                    pagesize: string;
+                query?: {
+                    /** @description Only topics available in this language */
+                    language?: string;
                 };
                 header?: never;
                 path?: never;
@@ -579,7 +550,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.NewsItem"][];
+                        "application/json": components["schemas"]["models.CatalogTopicsResponse"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
             };
@@ -592,7 +572,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/organization": {
+    "/editor/content": {
         parameters: {
             query?: never;
             header?: never;
@@ -600,12 +580,21 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Check Organization for Teacher
-         * @description Check Organization for Teacher
+         * List content for review
+         * @description List news and stories with a status, newest first. Editors only.
          */
         get: {
             parameters: {
-                query?: never;
+                query?: {
+                    /** @description draft, in_review (default), published or retracted */
+                    status?: string;
+                    /** @description News, Story or All (default) */
+                    content_type?: string;
+                    /** @description Page */
+                    page?: string;
+                    /** @description Page size */
+                    pagesize?: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -618,11 +607,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.OrganizationResponse"];
+                        "application/json": components["schemas"]["models.EditorContentListResponse"];
                     };
                 };
-                /** @description Unauthorized */
-                401: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -630,8 +619,8 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
-                /** @description Not Found */
-                404: {
+                /** @description Forbidden */
+                403: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -649,32 +638,30 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/organization/create": {
+    "/editor/content/item": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Create Organization
-         * @description Create Organization. Automatically adds the calling user as a teacher.
+         * Get content for review
+         * @description Get news or a story whatever its status, with its body. News also has its sources and how each sentence relates to them. Editors only.
          */
-        post: {
+        get: {
             parameters: {
-                query?: never;
+                query: {
+                    /** @description News or Story */
+                    content_type: string;
+                    /** @description Content ID */
+                    id: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            /** @description Create organization request */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["models.CreateOrganizationRequest"];
-                };
-            };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -682,11 +669,20 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.CreateOrganizationResponse"];
+                        "application/json": components["schemas"]["models.EditorContentResponse"];
                     };
                 };
-                /** @description Unauthorized */
-                401: {
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -696,13 +692,15 @@ export interface paths {
                 };
             };
         };
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/organization/join": {
+    "/editor/content/publish": {
         parameters: {
             query?: never;
             header?: never;
@@ -712,8 +710,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Join Organization
-         * @description Join Organization that has been created by another admin.
+         * Publish content
+         * @description Publish a draft, in review or retracted news article or story, making it visible to learners. Editors only.
          */
         post: {
             parameters: {
@@ -722,10 +720,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Join organization request */
+            /** @description Publish request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.JoinOrganizationRequest"];
+                    "application/json": components["schemas"]["models.EditorStatusRequest"];
                 };
             };
             responses: {
@@ -735,11 +733,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.JoinOrganizationResponse"];
+                        "application/json": components["schemas"]["models.EditorStatusResponse"];
                     };
                 };
-                /** @description Unauthorized */
-                401: {
+                /** @description Forbidden */
+                403: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -747,54 +745,33 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
-            };
-        };
-        delete?: never;
-        options?: never;
-        head?: never;
-        patch?: never;
-        trace?: never;
-    };
-    "/organization/payments": {
-        parameters: {
-            query?: never;
-            header?: never;
-            path?: never;
-            cookie?: never;
-        };
-        /**
-         * Ping Payments
-         * @description Ping Payments
-         */
-        get: {
-            parameters: {
-                query?: never;
-                header?: never;
-                path?: never;
-                cookie?: never;
-            };
-            requestBody?: never;
-            responses: {
-                /** @description OK */
-                200: {
+                /** @description Not Found */
+                404: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.PaymentsResponse"];
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/organization/payments/cancel-subscription-eop": {
+    "/editor/content/retract": {
         parameters: {
             query?: never;
             header?: never;
@@ -804,8 +781,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Cancel a Stripe subscription at the end of the period
-         * @description Cancel a Stripe subscription at the end of the period
+         * Retract content
+         * @description Retract a news article or story, hiding it from learners. Editors only.
          */
         post: {
             parameters: {
@@ -814,10 +791,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Cancel subscription request */
+            /** @description Retract request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.CancelSubscriptionRequest"];
+                    "application/json": components["schemas"]["models.EditorStatusRequest"];
                 };
             };
             responses: {
@@ -827,11 +804,29 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.CancelSubscriptionResponse"];
+                        "application/json": components["schemas"]["models.EditorStatusResponse"];
                     };
                 };
-                /** @description Bad Request */
-                400: {
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -847,7 +842,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/organization/payments/create-checkout-session": {
+    "/editor/content/update": {
         parameters: {
             query?: never;
             header?: never;
@@ -857,8 +852,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Create a Stripe checkout session
-         * @description Creates a checkout session and redirects to Stripe's payment page
+         * Edit content
+         * @description Replace the title, preview text and body of news (body) or a story (story_pages). Fields that are not set are kept. Editing the body retires the questions and deletes the audiobook made from it; answers to retired questions are kept. Editors only.
          */
         post: {
             parameters: {
@@ -867,20 +862,20 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Create checkout session request */
+            /** @description Edit content request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.CreateCheckoutSessionRequest"];
+                    "application/json": components["schemas"]["models.EditorUpdateRequest"];
                 };
             };
             responses: {
-                /** @description Redirect to Stripe Checkout */
+                /** @description OK */
                 200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.CreateCheckoutSessionResponse"];
+                        "application/json": components["schemas"]["models.EditorContentItem"];
                     };
                 };
                 /** @description Bad Request */
@@ -892,6 +887,24 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
             };
         };
         delete?: never;
@@ -900,7 +913,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/profile": {
+    "/feed": {
         parameters: {
             query?: never;
             header?: never;
@@ -908,12 +921,17 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get user profile
-         * @description Get the user's profile information
+         * Get feed
+         * @description Get news and stories ranked for the user from their profile: content on their topics at their level first, with some content one level up, news and stories mixed and content they have read left out. Each item has its score and the reasons for it, and the weights used are returned with the page. Students only get content their teacher accepted.
          */
         get: {
             parameters: {
-                query?: never;
+                query?: {
+                    /** @description Page size, 10 by default and at most 50 */
+                    pagesize?: string;
+                    /** @description next_cursor of the previous page */
+                    cursor?: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -926,7 +944,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetProfileResponse"];
+                        "application/json": components["schemas"]["models.FeedResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
                 /** @description Not Found */
@@ -948,32 +975,28 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/profile/upsert": {
+    "/news": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Upsert user profile
-         * @description Create or update the user's profile
+         * Get news content
+         * @description Get news content by ID
          */
-        post: {
+        get: {
             parameters: {
-                query?: never;
+                query: {
+                    /** @description Content ID */
+                    id: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            /** @description Profile information */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["models.UpsertProfileRequest"];
-                };
-            };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -981,7 +1004,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.UpsertProfileResponse"];
+                        "application/json": components["schemas"]["models.GetNewsResponse"];
                     };
                 };
                 /** @description Bad Request */
@@ -993,8 +1016,17 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
-                /** @description Conflict */
-                409: {
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Internal Server Error */
+                500: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1004,13 +1036,15 @@ export interface paths {
                 };
             };
         };
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/progress": {
+    "/news/query": {
         parameters: {
             query?: never;
             header?: never;
@@ -1018,12 +1052,23 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get today's progress
-         * @description Get the user's progress for today
+         * Get news content
+         * @description Get news content by ID
          */
         get: {
             parameters: {
-                query?: never;
+                query: {
+                    /** @description Language */
+                    language: string;
+                    /** @description CEFR */
+                    cefr: string;
+                    /** @description Subject */
+                    subject: string;
+                    /** @description Page */
+                    page: string;
+                    /** @description Page size */
+                    pagesize: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -1036,7 +1081,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.TodayProgressResponse"];
+                        "application/json": components["schemas"]["models.NewsItem"][];
                     };
                 };
             };
@@ -1049,7 +1094,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/progress/increment": {
+    "/organization": {
         parameters: {
             query?: never;
             header?: never;
@@ -1057,15 +1102,12 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Increment questions completed
-         * @description Increment the number of questions completed for today
+         * Check Organization for Teacher
+         * @description Check Organization for Teacher
          */
         get: {
             parameters: {
-                query: {
-                    /** @description Amount to increment by */
-                    amount: number;
-                };
+                query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -1078,11 +1120,20 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.IncrementProgressResponse"];
+                        "application/json": components["schemas"]["models.OrganizationResponse"];
                     };
                 };
-                /** @description Bad Request */
-                400: {
+                /** @description Unauthorized */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1100,25 +1151,32 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/progress/streak": {
+    "/organization/create": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get streak information
-         * @description Get the user's current streak and completion status
+         * Create Organization
+         * @description Create Organization. Automatically adds the calling user as a teacher.
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Create organization request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.CreateOrganizationRequest"];
+                };
+            };
             responses: {
                 /** @description OK */
                 200: {
@@ -1126,20 +1184,27 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.StreakResponse"];
+                        "application/json": components["schemas"]["models.CreateOrganizationResponse"];
+                    };
+                };
+                /** @description Unauthorized */
+                401: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/qna": {
+    "/organization/join": {
         parameters: {
             query?: never;
             header?: never;
@@ -1149,8 +1214,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Get or generate a question
-         * @description Get an existing question or generate a new one for the given content
+         * Join Organization
+         * @description Join Organization that has been created by another admin.
          */
         post: {
             parameters: {
@@ -1159,10 +1224,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Question request parameters */
+            /** @description Join organization request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.GetQuestionRequest"];
+                    "application/json": components["schemas"]["models.JoinOrganizationRequest"];
                 };
             };
             responses: {
@@ -1172,11 +1237,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetQuestionResponse"];
+                        "application/json": components["schemas"]["models.JoinOrganizationResponse"];
                     };
                 };
-                /** @description Bad Request */
-                400: {
+                /** @description Unauthorized */
+                401: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1184,24 +1249,54 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
-                /** @description Not Found */
-                404: {
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/organization/payments": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Ping Payments
+         * @description Ping Payments
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.ErrorResponse"];
+                        "application/json": components["schemas"]["models.PaymentsResponse"];
                     };
                 };
             };
         };
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/qna/evaluate": {
+    "/organization/payments/cancel-subscription-eop": {
         parameters: {
             query?: never;
             header?: never;
@@ -1211,8 +1306,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Evaluate an answer
-         * @description Evaluate a user's answer to a question
+         * Cancel a Stripe subscription at the end of the period
+         * @description Cancel a Stripe subscription at the end of the period
          */
         post: {
             parameters: {
@@ -1221,10 +1316,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Answer evaluation request */
+            /** @description Cancel subscription request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.EvaluateAnswerRequest"];
+                    "application/json": components["schemas"]["models.CancelSubscriptionRequest"];
                 };
             };
             responses: {
@@ -1234,7 +1329,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.EvaluateAnswerResponse"];
+                        "application/json": components["schemas"]["models.CancelSubscriptionResponse"];
                     };
                 };
                 /** @description Bad Request */
@@ -1254,42 +1349,44 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/story": {
+    "/organization/payments/create-checkout-session": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get story page content
-         * @description Get story content by ID
+         * Create a Stripe checkout session
+         * @description Creates a checkout session and redirects to Stripe's payment page
          */
-        get: {
+        post: {
             parameters: {
-                query: {
-                    /** @description Content ID */
-                    id: string;
-                    /** @description Page */
-                    page: string;
-                };
+                query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Create checkout session request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.CreateCheckoutSessionRequest"];
+                };
+            };
             responses: {
-                /** @description OK */
+                /** @description Redirect to Stripe Checkout */
                 200: {
                     headers: {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetStoryPageResponse"];
+                        "application/json": components["schemas"]["models.CreateCheckoutSessionResponse"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1297,6 +1394,43 @@ export interface paths {
                         "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/profile": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get user profile
+         * @description Get the user's profile information
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.GetProfileResponse"];
+                    };
+                };
                 /** @description Not Found */
                 404: {
                     headers: {
@@ -1316,7 +1450,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/story/context": {
+    "/profile/level": {
         parameters: {
             query?: never;
             header?: never;
@@ -1324,15 +1458,12 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get story QNA context
-         * @description Get story QNA context by ID
+         * Get estimated level
+         * @description Estimate the user's CEFR level in their learning language from the questions they pass, the content they finish and the words they look up at each level, and recommend moving their skill level up or down. Nothing is stored, see /profile/level/apply.
          */
         get: {
             parameters: {
-                query: {
-                    /** @description Content ID */
-                    id: string;
-                };
+                query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -1345,7 +1476,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetStoryQNAContextResponse"];
+                        "application/json": components["schemas"]["models.LevelEstimateResponse"];
                     };
                 };
                 /** @description Not Found */
@@ -1367,31 +1498,976 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/story/query": {
+    "/profile/level/apply": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get story query
-         * @description Get story query by ID
+         * Apply estimated level
+         * @description Estimate the user's level as GET /profile/level does and store the estimate. With AUTO_LEVEL_ADJUST set, a confident estimate also replaces the skill level in their profile.
          */
-        get: {
+        post: {
             parameters: {
-                query: {
-                    /** @description Language */
-                    language: string;
-                    /** @description CEFR */
-                    cefr: string;
-                    /** @description Subject */
-                    subject: string;
-                    /** @description Page */
-                    page: string;
-                    /** @description Page Size */
-                    pagesize: string;
-                };
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.LevelEstimateResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/profile/upsert": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Upsert user profile
+         * @description Create or update the user's profile
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Profile information */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.UpsertProfileRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.UpsertProfileResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/progress": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get today's progress
+         * @description Get the user's progress for today
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.TodayProgressResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/progress/increment": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Increment questions completed
+         * @description Increment the number of questions completed for today
+         */
+        get: {
+            parameters: {
+                query: {
+                    /** @description Amount to increment by */
+                    amount: number;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.IncrementProgressResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/progress/streak": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get streak information
+         * @description Get the user's current streak and completion status
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.StreakResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/qna": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Get or generate a question
+         * @description Get a question of the content the user has not answered or has failed, generating one if the pool needs more
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Question request parameters */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.GetQuestionRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.GetQuestionResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/qna/evaluate": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Evaluate an answer
+         * @description Evaluate a user's answer to a question. Answers to multiple_choice, cloze and true_false questions are sent as a models.ObjectiveAnswerRequest, graded against the question's answer key and answered with a models.ObjectiveAnswerResponse.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Answer evaluation request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.EvaluateAnswerRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.EvaluateAnswerResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/qna/history": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get answer history
+         * @description Get the user's answers to questions, newest first, optionally only for one piece of content
+         */
+        get: {
+            parameters: {
+                query?: {
+                    /** @description News or Story, requires id */
+                    content_type?: string;
+                    /** @description Content ID */
+                    id?: string;
+                    /** @description Page */
+                    page?: string;
+                    /** @description Page size */
+                    pagesize?: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.QNAHistoryResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/reading/continue": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Continue reading
+         * @description Get the news and stories the user has started but not finished, the most recently read first, with the page to resume from
+         */
+        get: {
+            parameters: {
+                query?: {
+                    /** @description Maximum number of items, 10 by default and at most 50 */
+                    limit?: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ContinueReadingResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/reading/progress": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Update reading progress
+         * @description Record the page the user is on in a news article or story and the time they spent reading since the last update. Stories are complete on their last page, news when completed is set or percent_complete reaches 100.
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Reading position */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.UpdateReadingProgressRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ReadingProgressResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/search": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Search content
+         * @description Full-text search over the title, preview and body of published news and stories in a language, best match first. Words are matched by their stem in French, Spanish and English. The query takes words, "quoted phrases", or and -excluded words. Students in a classroom only find content accepted in it.
+         */
+        get: {
+            parameters: {
+                query: {
+                    /** @description Search query */
+                    q: string;
+                    /** @description Language */
+                    language: string;
+                    /** @description CEFR */
+                    cefr?: string;
+                    /** @description Subject */
+                    subject?: string;
+                    /** @description News or Story, both by default */
+                    content_type?: string;
+                    /** @description Page, 1 by default */
+                    page?: string;
+                    /** @description Page size, 10 by default and at most 50 */
+                    pagesize?: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.SearchResponse"];
+                    };
+                };
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/story": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get story page content
+         * @description Get story content by ID
+         */
+        get: {
+            parameters: {
+                query: {
+                    /** @description Content ID */
+                    id: string;
+                    /** @description Page */
+                    page: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.GetStoryPageResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/story/context": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get story QNA context
+         * @description Get story QNA context by ID
+         */
+        get: {
+            parameters: {
+                query: {
+                    /** @description Content ID */
+                    id: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.GetStoryQNAContextResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/story/query": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get story query
+         * @description Get story query by ID
+         */
+        get: {
+            parameters: {
+                query: {
+                    /** @description Language */
+                    language: string;
+                    /** @description CEFR */
+                    cefr: string;
+                    /** @description Subject */
+                    subject: string;
+                    /** @description Page */
+                    page: string;
+                    /** @description Page Size */
+                    pagesize: string;
+                };
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.StoryItem"][];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/student": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Check user student status
+         * @description Check if the user is a student and get their classroom info
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.StudentStatusResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/student/classroom": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get classroom info
+         * @description Get classroom info for the student
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.GetStudentClassroomResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/student/classroom/join": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        get?: never;
+        put?: never;
+        /**
+         * Join classroom
+         * @description Join a classroom as a student
+         */
+        post: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            /** @description Join classroom request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.JoinClassroomRequest"];
+                };
+            };
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.JoinClassroomResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teacher": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Check user teacher status
+         * @description Check if the user is a teacher
+         */
+        get: {
+            parameters: {
+                query?: never;
+                header?: never;
+                path?: never;
+                cookie?: never;
+            };
+            requestBody?: never;
+            responses: {
+                /** @description OK */
+                200: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.TeacherStatusResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+            };
+        };
+        put?: never;
+        post?: never;
+        delete?: never;
+        options?: never;
+        head?: never;
+        patch?: never;
+        trace?: never;
+    };
+    "/teacher/classroom": {
+        parameters: {
+            query?: never;
+            header?: never;
+            path?: never;
+            cookie?: never;
+        };
+        /**
+         * Get classrooms
+         * @description Get classrooms
+         */
+        get: {
+            parameters: {
+                query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -1404,7 +2480,16 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.StoryItem"][];
+                        "application/json": components["schemas"]["models.GetClassroomListResponse"];
+                    };
+                };
+                /** @description Forbidden */
+                403: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
                     };
                 };
             };
@@ -1417,25 +2502,32 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/student": {
+    "/teacher/classroom/accept": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Check user student status
-         * @description Check if the user is a student and get their classroom info
+         * Accept content
+         * @description Accept content
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Accept content request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.AcceptContentRequest"];
+                };
+            };
             responses: {
                 /** @description OK */
                 200: {
@@ -1443,7 +2535,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.StudentStatusResponse"];
+                        "application/json": components["schemas"]["models.AcceptContentResponse"];
                     };
                 };
                 /** @description Forbidden */
@@ -1457,15 +2549,13 @@ export interface paths {
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/student/classroom": {
+    "/teacher/classroom/content": {
         parameters: {
             query?: never;
             header?: never;
@@ -1473,12 +2563,29 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Get classroom info
-         * @description Get classroom info for the student
+         * Query classroom content
+         * @description Query classroom content
          */
         get: {
             parameters: {
-                query?: never;
+                query: {
+                    /** @description Language */
+                    language: string;
+                    /** @description CEFR */
+                    cefr: string;
+                    /** @description Subject */
+                    subject: string;
+                    /** @description Page */
+                    page: string;
+                    /** @description Page size */
+                    pagesize: string;
+                    /** @description Whitelist status */
+                    whitelist: string;
+                    /** @description Content type */
+                    content_type: string;
+                    /** @description Classroom ID */
+                    classroom_id: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
@@ -1491,7 +2598,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetStudentClassroomResponse"];
+                        "application/json": components["schemas"]["models.ClassroomContentItem"][];
                     };
                 };
                 /** @description Forbidden */
@@ -1513,7 +2620,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/student/classroom/join": {
+    "/teacher/classroom/create": {
         parameters: {
             query?: never;
             header?: never;
@@ -1523,8 +2630,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Join classroom
-         * @description Join a classroom as a student
+         * Create classroom
+         * @description Create classroom
          */
         post: {
             parameters: {
@@ -1533,10 +2640,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Join classroom request */
+            /** @description Create classroom request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.JoinClassroomRequest"];
+                    "application/json": components["schemas"]["models.CreateClassroomRequest"];
                 };
             };
             responses: {
@@ -1546,7 +2653,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.JoinClassroomResponse"];
+                        "application/json": components["schemas"]["models.CreateClassroomResponse"];
                     };
                 };
                 /** @description Forbidden */
@@ -1566,25 +2673,32 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/teacher": {
+    "/teacher/classroom/delete": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Check user teacher status
-         * @description Check if the user is a teacher
+         * Delete classroom
+         * @description Delete classroom
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Delete classroom request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.DeleteClassroomRequest"];
+                };
+            };
             responses: {
                 /** @description OK */
                 200: {
@@ -1592,7 +2706,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.TeacherStatusResponse"];
+                        "application/json": components["schemas"]["models.DeleteClassroomResponse"];
                     };
                 };
                 /** @description Forbidden */
@@ -1606,33 +2720,38 @@ export interface paths {
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom": {
+    "/teacher/classroom/reject": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
+        get?: never;
+        put?: never;
         /**
-         * Get classrooms
-         * @description Get classrooms
+         * Accept content
+         * @description Accept content
          */
-        get: {
+        post: {
             parameters: {
                 query?: never;
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            requestBody?: never;
+            /** @description Reject content request */
+            requestBody: {
+                content: {
+                    "application/json": components["schemas"]["models.RejectContentRequest"];
+                };
+            };
             responses: {
                 /** @description OK */
                 200: {
@@ -1640,7 +2759,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.GetClassroomListResponse"];
+                        "application/json": components["schemas"]["models.RejectContentResponse"];
                     };
                 };
                 /** @description Forbidden */
@@ -1654,15 +2773,13 @@ export interface paths {
                 };
             };
         };
-        put?: never;
-        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/accept": {
+    "/teacher/classroom/update": {
         parameters: {
             query?: never;
             header?: never;
@@ -1672,8 +2789,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Accept content
-         * @description Accept content
+         * Update classroom
+         * @description Update classroom
          */
         post: {
             parameters: {
@@ -1682,10 +2799,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Accept content request */
+            /** @description Update classroom request */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.AcceptContentRequest"];
+                    "application/json": components["schemas"]["models.UpdateClassroomRequest"];
                 };
             };
             responses: {
@@ -1695,7 +2812,7 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.AcceptContentResponse"];
+                        "application/json": components["schemas"]["models.UpdateClassroomResponse"];
                     };
                 };
                 /** @description Forbidden */
@@ -1715,7 +2832,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/content": {
+    "/vocab": {
         parameters: {
             query?: never;
             header?: never;
@@ -1723,28 +2840,18 @@ export interface paths {
             cookie?: never;
         };
         /**
-         * Query classroom content
-         * @description Query classroom content
+         * Get vocabulary deck
+         * @description Get the words the user saved, newest first
          */
         get: {
             parameters: {
-                query: {
-                    /** @description Language */
-                    language: string;
-                    /** @description CEFR */
-                    cefr: string;
-                    /** @description Subject */
-                    subject: string;
+                query?: {
+                    /** @description Only words of this language */
+                    language?: string;
                     /** @description Page */
-                    page: string;
+                    page?: string;
                     /** @description Page size */
-                    pagesize: string;
-                    /** @description Whitelist status */
-                    whitelist: string;
-                    /** @description Content type */
-                    content_type: string;
-                    /** @description Classroom ID */
-                    classroom_id: string;
+                    pagesize?: string;
                 };
                 header?: never;
                 path?: never;
@@ -1758,11 +2865,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.ClassroomContentItem"][];
+                        "application/json": components["schemas"]["models.VocabCardsResponse"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1780,7 +2887,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/create": {
+    "/vocab/delete": {
         parameters: {
             query?: never;
             header?: never;
@@ -1790,8 +2897,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Create classroom
-         * @description Create classroom
+         * Delete a card
+         * @description Remove a word and its reviews from the user's deck
          */
         post: {
             parameters: {
@@ -1800,10 +2907,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Create classroom request */
+            /** @description Card to delete */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.CreateClassroomRequest"];
+                    "application/json": components["schemas"]["models.DeleteVocabRequest"];
                 };
             };
             responses: {
@@ -1813,11 +2920,20 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.CreateClassroomResponse"];
+                        "application/json": components["schemas"]["models.DeleteVocabResponse"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1833,32 +2949,30 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/delete": {
+    "/vocab/due": {
         parameters: {
             query?: never;
             header?: never;
             path?: never;
             cookie?: never;
         };
-        get?: never;
-        put?: never;
         /**
-         * Delete classroom
-         * @description Delete classroom
+         * Get due cards
+         * @description Get the user's cards that are due for review, the longest overdue first
          */
-        post: {
+        get: {
             parameters: {
-                query?: never;
+                query?: {
+                    /** @description Only words of this language */
+                    language?: string;
+                    /** @description Maximum number of cards, 20 by default and at most 100 */
+                    limit?: string;
+                };
                 header?: never;
                 path?: never;
                 cookie?: never;
             };
-            /** @description Delete classroom request */
-            requestBody: {
-                content: {
-                    "application/json": components["schemas"]["models.DeleteClassroomRequest"];
-                };
-            };
+            requestBody?: never;
             responses: {
                 /** @description OK */
                 200: {
@@ -1866,11 +2980,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.DeleteClassroomResponse"];
+                        "application/json": components["schemas"]["models.VocabCardsResponse"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1880,13 +2994,15 @@ export interface paths {
                 };
             };
         };
+        put?: never;
+        post?: never;
         delete?: never;
         options?: never;
         head?: never;
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/reject": {
+    "/vocab/review": {
         parameters: {
             query?: never;
             header?: never;
@@ -1896,8 +3012,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Accept content
-         * @description Accept content
+         * Review a card
+         * @description Grade a review of a card from 0 to 5 and schedule its next review. Only cards that are due can be reviewed. Each review counts as a completed question towards the daily goal.
          */
         post: {
             parameters: {
@@ -1906,10 +3022,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Reject content request */
+            /** @description Review grade */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.RejectContentRequest"];
+                    "application/json": components["schemas"]["models.VocabReviewRequest"];
                 };
             };
             responses: {
@@ -1919,11 +3035,29 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.RejectContentResponse"];
+                        "application/json": components["schemas"]["models.VocabReviewResponse"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Not Found */
+                404: {
+                    headers: {
+                        [name: string]: unknown;
+                    };
+                    content: {
+                        "application/json": components["schemas"]["models.ErrorResponse"];
+                    };
+                };
+                /** @description Conflict */
+                409: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -1939,7 +3073,7 @@ export interface paths {
         patch?: never;
         trace?: never;
     };
-    "/teacher/classroom/update": {
+    "/vocab/save": {
         parameters: {
             query?: never;
             header?: never;
@@ -1949,8 +3083,8 @@ export interface paths {
         get?: never;
         put?: never;
         /**
-         * Update classroom
-         * @description Update classroom
+         * Save a word
+         * @description Add a word the user looked up to their vocabulary deck, due for review straight away. Saving a word already in the deck counts another lookup and keeps its schedule.
          */
         post: {
             parameters: {
@@ -1959,10 +3093,10 @@ export interface paths {
                 path?: never;
                 cookie?: never;
             };
-            /** @description Update classroom request */
+            /** @description Word to save */
             requestBody: {
                 content: {
-                    "application/json": components["schemas"]["models.UpdateClassroomRequest"];
+                    "application/json": components["schemas"]["models.SaveVocabRequest"];
                 };
             };
             responses: {
@@ -1972,11 +3106,11 @@ export interface paths {
                         [name: string]: unknown;
                     };
                     content: {
-                        "application/json": components["schemas"]["models.UpdateClassroomResponse"];
+                        "application/json": components["schemas"]["models.VocabCard"];
                     };
                 };
-                /** @description Forbidden */
-                403: {
+                /** @description Bad Request */
+                400: {
                     headers: {
                         [name: string]: unknown;
                     };
@@ -2044,6 +3178,21 @@ export interface paths {
 export type webhooks = Record<string, never>;
 export interface components {
     schemas: {
+        "dictionary.Entry": {
+            /** @description "m", "f" or empty */
+            gender?: string;
+            lemma?: string;
+            pos?: string;
+            translation?: string;
+        };
+        "dictionary.Token": {
+            /** @description short translation in this context */
+            gloss?: string;
+            lemma?: string;
+            /** @description index into the article's sentences, repeats included */
+            sentence?: number;
+            text?: string;
+        };
         "models.AcceptContentRequest": {
             /** @example 123 */
             classroom_id: string;
@@ -2097,14 +3246,59 @@ export interface components {
             /** @example true */
             success: boolean;
         };
-        "models.CancelSubscriptionRequest": Record<string, never>;
-        "models.CancelSubscriptionResponse": {
-            /** @example CLASSROOM */
-            canceled_plan: string;
-            /** @example 2025-03-24T12:00:00Z */
-            current_expiration: string;
-            /** @example true */
-            success: boolean;
+        "models.CancelSubscriptionRequest": Record<string, never>;
+        "models.CancelSubscriptionResponse": {
+            /** @example CLASSROOM */
+            canceled_plan: string;
+            /** @example 2025-03-24T12:00:00Z */
+            current_expiration: string;
+            /** @example true */
+            success: boolean;
+        };
+        "models.CatalogLanguage": {
+            /** @example [
+             *       "A1",
+             *       "A2",
+             *       "B1"
+             *     ] */
+            cefr_levels: string[];
+            /** @example [
+             *       "News",
+             *       "Story"
+             *     ] */
+            content_types: string[];
+            /** @example French */
+            language: string;
+            /** @example [
+             *       "Politics",
+             *       "Travel"
+             *     ] */
+            topics: string[];
+        };
+        "models.CatalogLanguagesResponse": {
+            languages: components["schemas"]["models.CatalogLanguage"][];
+        };
+        "models.CatalogTopic": {
+            /** @example [
+             *       "A1",
+             *       "A2",
+             *       "B1"
+             *     ] */
+            cefr_levels: string[];
+            /** @example [
+             *       "News"
+             *     ] */
+            content_types: string[];
+            /** @example [
+             *       "French",
+             *       "Spanish"
+             *     ] */
+            languages: string[];
+            /** @example Politics */
+            topic: string;
+        };
+        "models.CatalogTopicsResponse": {
+            topics: components["schemas"]["models.CatalogTopic"][];
         };
         "models.ClassroomContentItem": {
             /** @example NONE */
@@ -2142,6 +3336,47 @@ export interface components {
             /** @example 10 */
             students_count?: number;
         };
+        "models.ContinueReadingItem": {
+            /** @example B1 */
+            cefr_level: string;
+            /**
+             * @description not set until the content is finished
+             * @example 2025-02-26T13:08:13Z
+             */
+            completed_at?: string;
+            /** @example Story */
+            content_type: string;
+            /** @example 123 */
+            id: string;
+            /** @example French */
+            language: string;
+            /** @example 2 */
+            last_page?: number;
+            /** @example 5 */
+            pages?: number;
+            /** @example 60 */
+            percent_complete?: number;
+            /** @example Un résumé des nouvelles musicales... */
+            preview_text: string;
+            /**
+             * @example in_progress
+             * @enum {string}
+             */
+            reading_status: "in_progress" | "read";
+            /** @example 2025-02-26T13:01:13Z */
+            started_at: string;
+            /** @example 420 */
+            time_spent_seconds?: number;
+            /** @example L'actualité musicale en bref */
+            title: string;
+            /** @example Music */
+            topic: string;
+            /** @example 2025-02-26T13:08:13Z */
+            updated_at: string;
+        };
+        "models.ContinueReadingResponse": {
+            items: components["schemas"]["models.ContinueReadingItem"][];
+        };
         "models.CreateCheckoutSessionRequest": Record<string, never>;
         "models.CreateCheckoutSessionResponse": {
             /** @example https://checkout.stripe.com/c/pay/123 */
@@ -2177,8 +3412,121 @@ export interface components {
             /** @example Classroom deleted successfully */
             message: string;
         };
+        "models.DeleteVocabRequest": {
+            /** @example 12 */
+            card_id: number;
+        };
+        "models.DeleteVocabResponse": {
+            /** @example Card deleted successfully */
+            message: string;
+        };
         /** @enum {string} */
         "models.ERROR_CODE": "PROFILE_NOT_FOUND" | "NO_TRANSCRIPT" | "AUTH_REQUIRED" | "USAGE_LIMIT_REACHED" | "USAGE_RESTRICTED";
+        "models.EditorContentItem": {
+            /** @example B1 */
+            cefr_level: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2025-02-26T13:01:13.390612Z */
+            created_at: string;
+            /** @example 2025-02-26 */
+            date_created: string;
+            /** @example 2025-02-26T15:20:00Z */
+            edited_at?: string;
+            /** @example 2479 */
+            id: string;
+            /** @example French */
+            language: string;
+            moderation?: Record<string, never>;
+            /** @example 3 */
+            pages?: number;
+            /** @example Le Sénat a voté le budget... */
+            preview_text: string;
+            readability?: Record<string, never>;
+            /** @example Fixed a wrong date */
+            review_note?: string;
+            /** @example 2025-02-26T15:30:00Z */
+            reviewed_at?: string;
+            /** @example a1b2c3d4-... */
+            reviewed_by?: string;
+            /** @example in_review */
+            status: string;
+            /** @example Le budget est voté */
+            title: string;
+            /** @example Politics */
+            topic: string;
+        };
+        "models.EditorContentListResponse": {
+            items: components["schemas"]["models.EditorContentItem"][];
+        };
+        "models.EditorContentResponse": {
+            /** @example # Le budget est voté
+             *
+             *     Le Sénat... */
+            body?: string;
+            /** @example B1 */
+            cefr_level: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2025-02-26T13:01:13.390612Z */
+            created_at: string;
+            /** @example 2025-02-26 */
+            date_created: string;
+            /** @example 2025-02-26T15:20:00Z */
+            edited_at?: string;
+            /** @example 2479 */
+            id: string;
+            /** @example French */
+            language: string;
+            moderation?: Record<string, never>;
+            /** @example 3 */
+            pages?: number;
+            /** @example Le Sénat a voté le budget... */
+            preview_text: string;
+            readability?: Record<string, never>;
+            /** @example Fixed a wrong date */
+            review_note?: string;
+            /** @example 2025-02-26T15:30:00Z */
+            reviewed_at?: string;
+            /** @example a1b2c3d4-... */
+            reviewed_by?: string;
+            source_diff?: components["schemas"]["models.SentenceSupport"][];
+            sources?: components["schemas"]["storage.Source"][];
+            /** @example in_review */
+            status: string;
+            story_pages?: string[];
+            /** @example Le budget est voté */
+            title: string;
+            /** @example Politics */
+            topic: string;
+        };
+        "models.EditorStatusRequest": {
+            /** @example News */
+            content_type: string;
+            /** @example 2479 */
+            id: string;
+            /** @example Checked against the sources */
+            note?: string;
+        };
+        "models.EditorStatusResponse": {
+            /** @example published */
+            status: string;
+        };
+        "models.EditorUpdateRequest": {
+            /** @example # Le budget est voté
+             *
+             *     Le Sénat... */
+            body?: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2479 */
+            id: string;
+            /** @example Le Sénat a voté le budget... */
+            preview_text?: string;
+            story_pages?: string[];
+            /** @example Le budget est voté */
+            title?: string;
+        };
         "models.ErrorResponse": {
             /**
              * @example PROFILE_NOT_FOUND
@@ -2197,12 +3545,87 @@ export interface components {
             content: string;
             /** @example What does 'bonjour' mean? */
             question: string;
+            /**
+             * @description set to record the answer in the user's history
+             * @example 42
+             */
+            question_id?: number;
         };
         "models.EvaluateAnswerResponse": {
+            /** @example 7 */
+            attempt_id?: number;
+            /** @description mistakes in the answer, with their character offsets so they can be highlighted */
+            errors: components["schemas"]["qna.AnswerError"][];
             /** @example PASS */
             evaluation: string;
             /** @example Perfect! */
             explanation: string;
+            /** @description scores from 1 to 5 */
+            scores: components["schemas"]["qna.RubricScores"];
+        };
+        "models.FeedItem": {
+            /** @example B1 */
+            cefr_level: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2024-02-26T13:01:13Z */
+            created_at: string;
+            /** @example 2024-02-26 */
+            date_created: string;
+            /** @example 123 */
+            id: string;
+            /** @example French */
+            language: string;
+            /** @example 5 */
+            pages?: number;
+            /** @example Un résumé des nouvelles musicales... */
+            preview_text: string;
+            /**
+             * @example unread
+             * @enum {string}
+             */
+            reading_status: "unread" | "in_progress";
+            /**
+             * @description why the item scored what it did, in the order the weights were applied
+             * @example [
+             *       "topic",
+             *       "level",
+             *       "recent"
+             *     ]
+             */
+            reasons: string[];
+            /** @example 6.4 */
+            score?: number;
+            /** @example L'actualité musicale en bref */
+            title: string;
+            /** @example Music */
+            topic: string;
+        };
+        "models.FeedResponse": {
+            items: components["schemas"]["models.FeedItem"][];
+            /**
+             * @description pass as cursor to get the next page, not set on the last page
+             * @example MTc0MDU3NDg3MzAwMDAwMDoxMA
+             */
+            next_cursor?: string;
+            weights: components["schemas"]["models.FeedWeights"];
+        };
+        "models.FeedWeights": {
+            /** @example 1.5 */
+            in_progress?: number;
+            /** @example 2 */
+            level?: number;
+            /** @example 1 */
+            level_up?: number;
+            /** @example 2 */
+            recency?: number;
+            /**
+             * @description taken off for each item of the same content type directly before
+             * @example 2
+             */
+            repeat_type?: number;
+            /** @example 3 */
+            topic?: number;
         };
         "models.GetClassroomListResponse": {
             classrooms: components["schemas"]["models.ClassroomListItem"][];
@@ -2252,11 +3675,36 @@ export interface components {
              * @example vocab
              * @enum {string}
              */
-            question_type: "vocab" | "understanding";
+            question_type: "vocab" | "understanding" | "multiple_choice" | "cloze" | "true_false";
         };
         "models.GetQuestionResponse": {
-            /** @example What does 'bonjour' mean? */
+            /**
+             * @description how often the user has answered this question, and how their last answer was evaluated
+             * @example 1
+             */
+            attempts?: number;
+            /**
+             * @description the options to answer multiple_choice and most cloze questions with,
+             *     true_false questions are answered with "true" or "false"
+             * @example [
+             *       "Hello",
+             *       "Goodbye",
+             *       "Thank you",
+             *       "Please"
+             *     ]
+             */
+            choices?: string[];
+            /** @example FAIL */
+            last_evaluation?: string;
+            /**
+             * @description cloze questions have the missing word replaced by _____
+             * @example What does 'bonjour' mean?
+             */
             question: string;
+            /** @example 42 */
+            question_id: number;
+            /** @example vocab */
+            question_type: string;
         };
         "models.GetStoryPageResponse": {
             /** @example B1 */
@@ -2314,6 +3762,54 @@ export interface components {
             /** @example 123 */
             teacher_id: string;
         };
+        "models.LevelEstimateResponse": {
+            /**
+             * @description whether the estimate replaced the declared level
+             * @example true
+             */
+            applied?: boolean;
+            /** @example 0.8 */
+            confidence?: number;
+            /** @example B1 */
+            declared_level: string;
+            /** @example B2 */
+            estimated_level: string;
+            evidence: components["schemas"]["models.LevelEvidence"][];
+            /** @example French */
+            language: string;
+            /**
+             * @description how the declared level should change
+             * @example up
+             * @enum {string}
+             */
+            recommendation: "up" | "down" | "stay";
+            /**
+             * @description the level in the profile, after any automatic adjustment
+             * @example B2
+             */
+            skill_level: string;
+        };
+        "models.LevelEvidence": {
+            /** @example 12 */
+            attempts?: number;
+            /** @example 4 */
+            completed?: number;
+            /** @example B1 */
+            level: string;
+            /** @example 21 */
+            lookups?: number;
+            /**
+             * @description from 0 to 1, not set without answers or readings
+             * @example 0.78
+             */
+            mastery?: number;
+            /** @example 9 */
+            passed?: number;
+            /** @example 5 */
+            started?: number;
+            /** @example 1800 */
+            words_read?: number;
+        };
         "models.NewsItem": {
             /** @example NONE */
             audiobook_tier: string;
@@ -2329,6 +3825,12 @@ export interface components {
             language: string;
             /** @example Un résumé des nouvelles musicales... */
             preview_text: string;
+            /**
+             * @description the user's progress, not set if it could not be read
+             * @example unread
+             * @enum {string}
+             */
+            reading_status?: "unread" | "in_progress" | "read";
             /** @example L'actualité musicale en bref */
             title: string;
             /** @example Music */
@@ -2350,6 +3852,62 @@ export interface components {
             /** @example true */
             success: boolean;
         };
+        "models.QNAAttempt": {
+            /** @example Hello */
+            answer: string;
+            /** @example 7 */
+            attempt_id: number;
+            /** @example B1 */
+            cefr_level: string;
+            /** @example 123 */
+            content_id: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2025-02-26T13:01:13Z */
+            created_at: string;
+            errors?: components["schemas"]["qna.AnswerError"][];
+            /** @example PASS */
+            evaluation: string;
+            /** @example Perfect! */
+            explanation?: string;
+            /** @example What does 'bonjour' mean? */
+            question: string;
+            /** @example 42 */
+            question_id: number;
+            /** @example vocab */
+            question_type: string;
+            /** @description not set for answers evaluated before scores were given */
+            scores?: components["schemas"]["qna.RubricScores"];
+        };
+        "models.QNAHistoryResponse": {
+            attempts: components["schemas"]["models.QNAAttempt"][];
+        };
+        "models.ReadingProgressResponse": {
+            /**
+             * @description not set until the content is finished
+             * @example 2025-02-26T13:08:13Z
+             */
+            completed_at?: string;
+            /** @example Story */
+            content_type: string;
+            /** @example 123 */
+            id: string;
+            /** @example 2 */
+            last_page?: number;
+            /** @example 60 */
+            percent_complete?: number;
+            /**
+             * @example in_progress
+             * @enum {string}
+             */
+            reading_status: "in_progress" | "read";
+            /** @example 2025-02-26T13:01:13Z */
+            started_at: string;
+            /** @example 420 */
+            time_spent_seconds?: number;
+            /** @example 2025-02-26T13:08:13Z */
+            updated_at: string;
+        };
         "models.RejectContentRequest": {
             /** @example 123 */
             classroom_id: string;
@@ -2362,6 +3920,78 @@ export interface components {
             /** @example Content rejected successfully */
             message: string;
         };
+        "models.SaveVocabRequest": {
+            /** @example 123 */
+            content_id?: string;
+            /**
+             * @description the content the word was looked up in, if any
+             * @example News
+             * @enum {string}
+             */
+            content_type?: "News" | "Story";
+            /** @example French */
+            language: string;
+            /**
+             * @description the sentence the word was looked up in
+             * @example Le chat dort sur le lit.
+             */
+            sentence?: string;
+            /** @example cat */
+            translation?: string;
+            /** @example chat */
+            word: string;
+        };
+        "models.SearchResponse": {
+            results: components["schemas"]["models.SearchResult"][];
+        };
+        "models.SearchResult": {
+            /** @example B1 */
+            cefr_level: string;
+            /** @example News */
+            content_type: string;
+            /** @example 2024-02-26T13:01:13Z */
+            created_at: string;
+            /** @example 2024-02-26 */
+            date_created: string;
+            /** @example 123 */
+            id: string;
+            /** @example French */
+            language: string;
+            /** @example 5 */
+            pages?: number;
+            /** @example Les Français votent dimanche... */
+            preview_text: string;
+            /** @example 0.42 */
+            rank?: number;
+            /**
+             * @description plain text with the matches in <mark> tags
+             * @example Les <mark>élections</mark> municipales ont lieu dimanche
+             */
+            snippet: string;
+            /** @example Les élections municipales */
+            title: string;
+            /** @example Politics */
+            topic: string;
+        };
+        "models.SentenceSupport": {
+            /** @example 0.8 */
+            overlap: number;
+            /** @example Le Sénat a voté le budget mardi. */
+            sentence: string;
+            /**
+             * @description index into sources of the source sharing the most words, -1 if none
+             * @example 0
+             */
+            source: number;
+            /**
+             * @description names and numbers that are in none of the sources
+             * @example [
+             *       "Dupont",
+             *       "40"
+             *     ]
+             */
+            unsupported: string[];
+        };
         "models.SpeechToTextRequest": {
             /** @example base64-encoded-audio-content */
             audio_content: string;
@@ -2389,6 +4019,12 @@ export interface components {
             language: string;
             /** @example Un résumé des nouvelles musicales... */
             preview_text: string;
+            /**
+             * @description the user's progress, not set if it could not be read
+             * @example unread
+             * @enum {string}
+             */
+            reading_status?: "unread" | "in_progress" | "read";
             /** @example L'actualité musicale en bref */
             title: string;
             /** @example Music */
@@ -2460,6 +4096,32 @@ export interface components {
             /** @example Classroom updated successfully */
             message: string;
         };
+        "models.UpdateReadingProgressRequest": {
+            /** @example false */
+            completed?: boolean;
+            /**
+             * @example Story
+             * @enum {string}
+             */
+            content_type: "News" | "Story";
+            /** @example 123 */
+            id: string;
+            /**
+             * @description the page the user is on, 0 for news
+             * @example 2
+             */
+            page?: number;
+            /**
+             * @description defaults to the share of pages read for stories
+             * @example 60
+             */
+            percent_complete?: number;
+            /**
+             * @description time spent reading since the last update
+             * @example 95
+             */
+            seconds_spent?: number;
+        };
         "models.UpsertProfileRequest": {
             /** @example 3 */
             daily_questions_goal?: number;
@@ -2480,11 +4142,95 @@ export interface components {
             /** @example Profile updated successfully */
             message: string;
         };
+        "models.VocabCard": {
+            /** @example 12 */
+            card_id: number;
+            /** @example 123 */
+            content_id?: string;
+            /** @example News */
+            content_type?: string;
+            /** @example 2025-02-26T13:01:13Z */
+            created_at: string;
+            /** @example 2025-03-07T12:00:00Z */
+            due_at: string;
+            /** @example 2.5 */
+            ease_factor: number;
+            /** @example 6 */
+            interval_days?: number;
+            /** @example French */
+            language: string;
+            /**
+             * @description not set for cards that were never reviewed
+             * @example 2025-03-01T12:00:00Z
+             */
+            last_reviewed_at?: string;
+            /**
+             * @description how many times the word was saved
+             * @example 3
+             */
+            lookups: number;
+            /** @example 2 */
+            repetitions?: number;
+            /** @example Le chat dort sur le lit. */
+            sentence?: string;
+            /** @example cat */
+            translation?: string;
+            /** @example chat */
+            word: string;
+        };
+        "models.VocabCardsResponse": {
+            cards: components["schemas"]["models.VocabCard"][];
+        };
+        "models.VocabReviewRequest": {
+            /** @example 12 */
+            card_id: number;
+            /**
+             * @description 0 (no recollection) to 5 (perfect recall), 3 and above count as remembered
+             * @example 4
+             */
+            grade: number;
+        };
+        "models.VocabReviewResponse": {
+            card: components["schemas"]["models.VocabCard"];
+            /** @example false */
+            goal_met?: boolean;
+            /**
+             * @description today's progress, which counts each review as a completed question
+             * @example 5
+             */
+            questions_completed?: number;
+        };
         "models.WebhookResponse": {
             received?: boolean;
             type?: string;
         };
+        "qna.AnswerError": {
+            /** @example ils sont */
+            correction?: string;
+            /** @example 11 */
+            end?: number;
+            /** @example Ils is the plural of il. */
+            explanation?: string;
+            /** @example 4 */
+            start?: number;
+            /** @example il sont */
+            text?: string;
+            /** @example grammar */
+            type?: string;
+        };
+        "qna.RubricScores": {
+            /** @example 4 */
+            comprehension?: number;
+            /** @example 3 */
+            grammar?: number;
+            /** @example 5 */
+            vocabulary?: number;
+        };
         "storage.Dictionary": {
+            lemmas?: {
+                [key: string]: components["schemas"]["dictionary.Entry"];
+            };
+            tokens?: components["schemas"]["dictionary.Token"][];
             translations?: {
                 sentences?: {
                     [key: string]: string;
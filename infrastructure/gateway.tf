@@ -63,6 +63,23 @@ module "profile_upsert" {
   lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
 }
 
+module "profile_level" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.profile.resource_id
+  path_part   = "level"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "profile_level_apply" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.profile_level.resource_id
+  path_part   = "apply"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
 # QNA endpoints
 module "qna" {
   source      = "./api_gateway"
@@ -82,6 +99,14 @@ module "qna_evaluate" {
   lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
 }
 
+module "qna_history" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.qna.resource_id
+  path_part   = "history"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
 # Progress endpoints
 module "progress" {
   source      = "./api_gateway"
@@ -341,6 +366,174 @@ module "billing_cancel_subscription_eop" {
   lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
 }
 
+# Catalog endpoints
+module "catalog" {
+  source           = "./api_gateway"
+  rest_api_id      = aws_api_gateway_rest_api.story_api.id
+  parent_id        = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part        = "catalog"
+  is_resource_only = true
+  lambda_arn       = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "catalog_languages" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.catalog.resource_id
+  path_part   = "languages"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "catalog_topics" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.catalog.resource_id
+  path_part   = "topics"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+# Editor endpoints
+module "editor" {
+  source           = "./api_gateway"
+  rest_api_id      = aws_api_gateway_rest_api.story_api.id
+  parent_id        = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part        = "editor"
+  is_resource_only = true
+  lambda_arn       = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "editor_content" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.editor.resource_id
+  path_part   = "content"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "editor_content_item" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.editor_content.resource_id
+  path_part   = "item"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "editor_content_update" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.editor_content.resource_id
+  path_part   = "update"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "editor_content_publish" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.editor_content.resource_id
+  path_part   = "publish"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "editor_content_retract" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.editor_content.resource_id
+  path_part   = "retract"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+# Vocabulary endpoints
+module "vocab" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part   = "vocab"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "vocab_due" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.vocab.resource_id
+  path_part   = "due"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "vocab_save" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.vocab.resource_id
+  path_part   = "save"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "vocab_review" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.vocab.resource_id
+  path_part   = "review"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "vocab_delete" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.vocab.resource_id
+  path_part   = "delete"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+# Reading endpoints
+module "reading" {
+  source           = "./api_gateway"
+  rest_api_id      = aws_api_gateway_rest_api.story_api.id
+  parent_id        = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part        = "reading"
+  is_resource_only = true
+  lambda_arn       = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "reading_progress" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.reading.resource_id
+  path_part   = "progress"
+  http_method = "POST"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+module "reading_continue" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = module.reading.resource_id
+  path_part   = "continue"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+# Feed endpoints
+module "feed" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part   = "feed"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
+# Search endpoints
+module "search" {
+  source      = "./api_gateway"
+  rest_api_id = aws_api_gateway_rest_api.story_api.id
+  parent_id   = aws_api_gateway_rest_api.story_api.root_resource_id
+  path_part   = "search"
+  lambda_arn  = aws_lambda_function.story_api_lambda.invoke_arn
+}
+
 # Lambda permissions
 resource "aws_lambda_permission" "allow_apigateway" {
   statement_id  = "${terraform.workspace}-AllowExecutionFromAPIGateway"
@@ -447,8 +640,11 @@ resource "aws_api_gateway_deployment" "api_deployment" {
     module.news_query,
     module.profile,
     module.profile_upsert,
+    module.profile_level,
+    module.profile_level_apply,
     module.qna,
     module.qna_evaluate,
+    module.qna_history,
     module.progress,
     module.progress_streak,
     module.progress_increment,
@@ -477,7 +673,26 @@ resource "aws_api_gateway_deployment" "api_deployment" {
     module.billing_usage,
     module.billing_create_checkout_session,
     module.billing_cancel_subscription_eop,
-    module.webhook
+    module.webhook,
+    module.catalog,
+    module.catalog_languages,
+    module.catalog_topics,
+    module.editor,
+    module.editor_content,
+    module.editor_content_item,
+    module.editor_content_update,
+    module.editor_content_publish,
+    module.editor_content_retract,
+    module.vocab,
+    module.vocab_due,
+    module.vocab_save,
+    module.vocab_review,
+    module.vocab_delete,
+    module.reading,
+    module.reading_progress,
+    module.reading_continue,
+    module.feed,
+    module.search
   ]
 }
 
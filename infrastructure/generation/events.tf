@@ -17,4 +17,7 @@ resource "aws_lambda_event_source_mapping" "sqs_lambda_trigger" {
   event_source_arn = aws_sqs_queue.story_gen_queue.arn
   function_name    = aws_lambda_function.story_gen_lambda.arn
   batch_size       = 10 # 10 msgs per lambda
+
+  # the lambda reports which messages failed so only those are retried
+  function_response_types = ["ReportBatchItemFailures"]
 }
\ No newline at end of file
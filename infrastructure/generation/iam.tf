@@ -48,7 +48,8 @@ resource "aws_iam_role" "story_gen_role" {
           "Action" : [
             "sqs:ReceiveMessage",
             "sqs:DeleteMessage",
-            "sqs:GetQueueAttributes"
+            "sqs:GetQueueAttributes",
+            "sqs:SendMessage"
           ],
           # this ARN is untested
           "Resource" : "${aws_sqs_queue.story_gen_queue.arn}"
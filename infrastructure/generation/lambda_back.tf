@@ -27,8 +27,11 @@ resource "aws_lambda_function" "story_gen_lambda" {
       GOOGLE_API_KEY    = var.google_api_key
       COHERE_API_KEY    = var.cohere_api_key
       GEMINI_API_KEY    = var.gemini_api_key
+      LLM_PROVIDER      = "gemini"
+      MODERATION_MODE   = "llm"
+      READABILITY_MODE  = "enforce"
       STORY_BUCKET_NAME = var.story_gen_bucket_bucket
-      SQS_QUEUE_URL     = aws_sqs_queue.story_gen_queue.name
+      SQS_QUEUE_URL     = aws_sqs_queue.story_gen_queue.url
 
       SUPABASE_HOST     = var.supabase_host
       SUPABASE_PORT     = var.supabase_port
@@ -17,6 +17,7 @@ resource "aws_lambda_function" "queue_filler_lambda" {
   environment {
     variables = {
       SQS_QUEUE_URL     = aws_sqs_queue.story_gen_queue.name,
+      SEARCH_PROVIDER   = "tavily",
       TAVILY_API_KEY    = var.tavily_api_key,
       SUPABASE_HOST     = var.supabase_host,
       SUPABASE_PORT     = var.supabase_port,
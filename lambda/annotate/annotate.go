@@ -0,0 +1,43 @@
+// Package annotate fills in the lemma, part of speech, gender and in-context
+// gloss of each word in an article's dictionary.
+package annotate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"squeak-shared/dictionary"
+	"squeak-shared/textgen"
+)
+
+const (
+	SourceNone    = "none"
+	SourceLLM     = "llm"
+	SourceLexicon = "lexicon"
+)
+
+type Annotator interface {
+	// Annotate adds a token for each word of sentences to dict. The token's
+	// Sentence is the index into sentences.
+	Annotate(ctx context.Context, language string, sentences []string, dict *dictionary.Dictionary) error
+}
+
+// NewFromEnv returns the annotator selected by DICTIONARY_SOURCE, or nil if
+// annotation is turned off (the default).
+func NewFromEnv(generator textgen.TextGenerator) (Annotator, error) {
+	switch source := os.Getenv("DICTIONARY_SOURCE"); source {
+	case "", SourceNone:
+		return nil, nil
+	case SourceLLM:
+		return NewLLM(generator), nil
+	case SourceLexicon:
+		lexicon, err := LoadLexicon(os.Getenv("DICTIONARY_LEXICON_PATH"))
+		if err != nil {
+			return nil, err
+		}
+		return lexicon, nil
+	default:
+		return nil, fmt.Errorf("unknown DICTIONARY_SOURCE: %s", source)
+	}
+}
@@ -0,0 +1,97 @@
+package annotate
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"squeak-shared/dictionary"
+	"squeak-shared/textgen"
+)
+
+func TestLLMAnnotate(t *testing.T) {
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if !strings.Contains(prompt, "0: Nous mangeons.") || !strings.Contains(prompt, "1: Il a mangé une pomme.") {
+			t.Errorf("expected numbered sentences in the prompt, got %q", prompt)
+		}
+		return "```json\n" + `[
+			{"sentence": 0, "text": "Nous", "lemma": "nous", "pos": "pron", "gloss": "we", "translation": "we"},
+			{"sentence": 0, "text": "mangeons", "lemma": "manger", "pos": "VERB", "gloss": "(we) eat", "translation": "to eat"},
+			{"sentence": 1, "text": "mangé", "lemma": "manger", "pos": "VERB", "gloss": "eaten", "translation": "to eat"},
+			{"sentence": 1, "text": "pomme", "lemma": "pomme", "pos": "NOUN", "gender": "f", "gloss": "apple", "translation": "apple"},
+			{"sentence": 7, "text": "hors", "lemma": "hors", "pos": "ADP", "gloss": "out", "translation": "out"}
+		]` + "\n```", nil
+	}
+
+	dict := dictionary.New()
+	err := NewLLM(generator).Annotate(context.Background(), "French", []string{"Nous mangeons.", "Il a mangé une pomme."}, &dict)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(dict.Tokens) != 4 {
+		t.Fatalf("expected 4 tokens (out of range sentences dropped), got %d", len(dict.Tokens))
+	}
+	if dict.Tokens[2].Lemma != "manger" || dict.Tokens[2].Gloss != "eaten" || dict.Tokens[2].Sentence != 1 {
+		t.Errorf("unexpected token: %+v", dict.Tokens[2])
+	}
+	if entry := dict.Lemmas["nous"]; entry.PartOfSpeech != dictionary.PRON {
+		t.Errorf("expected the part of speech to be normalized, got %+v", entry)
+	}
+	if entry := dict.Lemmas["pomme"]; entry.Gender != "f" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+}
+
+func TestLLMAnnotateInvalidResponse(t *testing.T) {
+	generator := textgen.NewFake()
+	generator.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "I cannot do that.", nil
+	}
+
+	dict := dictionary.New()
+	if err := NewLLM(generator).Annotate(context.Background(), "French", []string{"Bonjour."}, &dict); err == nil {
+		t.Fatal("expected an error")
+	}
+}
+
+func TestLexiconAnnotate(t *testing.T) {
+	lexicon := NewLexicon(map[string]dictionary.Entry{
+		"Mangeons": {Lemma: "manger", PartOfSpeech: dictionary.VERB, Translation: "to eat"},
+		"pomme":    {Lemma: "pomme", PartOfSpeech: dictionary.NOUN, Gender: "f", Translation: "apple"},
+	})
+
+	dict := dictionary.New()
+	if err := lexicon.Annotate(context.Background(), "French", []string{"Nous mangeons.", "Une pomme."}, &dict); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(dict.Tokens) != 2 {
+		t.Fatalf("expected only known words to be annotated, got %+v", dict.Tokens)
+	}
+	if entry, ok := dict.Lookup("mangeons"); !ok || entry.Lemma != "manger" {
+		t.Errorf("unexpected entry: %+v", entry)
+	}
+	if dict.Tokens[1].Sentence != 1 || dict.Tokens[1].Gloss != "apple" {
+		t.Errorf("unexpected token: %+v", dict.Tokens[1])
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("DICTIONARY_SOURCE", "")
+	if annotator, err := NewFromEnv(textgen.NewFake()); err != nil || annotator != nil {
+		t.Errorf("expected annotation to be off by default, got %v, %v", annotator, err)
+	}
+
+	t.Setenv("DICTIONARY_SOURCE", SourceLLM)
+	if annotator, err := NewFromEnv(textgen.NewFake()); err != nil || annotator == nil {
+		t.Errorf("expected an LLM annotator, got %v, %v", annotator, err)
+	}
+
+	t.Setenv("DICTIONARY_SOURCE", SourceLexicon)
+	t.Setenv("DICTIONARY_LEXICON_PATH", "")
+	if _, err := NewFromEnv(textgen.NewFake()); err == nil {
+		t.Error("expected an error without a lexicon path")
+	}
+}
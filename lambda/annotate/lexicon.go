@@ -0,0 +1,58 @@
+package annotate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"squeak-shared/dictionary"
+	"squeak-shared/tokenize"
+)
+
+// Lexicon annotates words from a local file mapping lowercase surface forms to
+// dictionary entries, e.g. {"mangeons": {"lemma": "manger", "pos": "VERB",
+// "translation": "to eat"}}. It has no context, so the gloss is the entry's
+// translation and unknown words are skipped.
+type Lexicon struct {
+	entries map[string]dictionary.Entry
+}
+
+func NewLexicon(entries map[string]dictionary.Entry) *Lexicon {
+	lowered := make(map[string]dictionary.Entry, len(entries))
+	for form, entry := range entries {
+		lowered[strings.ToLower(form)] = entry
+	}
+	return &Lexicon{entries: lowered}
+}
+
+func LoadLexicon(path string) (*Lexicon, error) {
+	if path == "" {
+		return nil, fmt.Errorf("DICTIONARY_LEXICON_PATH environment variable not set")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lexicon: %v", err)
+	}
+
+	var entries map[string]dictionary.Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse lexicon: %v", err)
+	}
+	return NewLexicon(entries), nil
+}
+
+func (l *Lexicon) Annotate(ctx context.Context, language string, sentences []string, dict *dictionary.Dictionary) error {
+	tokenizer := tokenize.For(language)
+	for i, sentence := range sentences {
+		for _, word := range tokenizer.Words(sentence) {
+			entry, ok := l.entries[strings.ToLower(word)]
+			if !ok {
+				continue
+			}
+			dict.Add(dictionary.Token{Text: word, Sentence: i, Gloss: entry.Translation}, entry)
+		}
+	}
+	return nil
+}
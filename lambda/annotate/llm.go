@@ -0,0 +1,90 @@
+package annotate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"squeak-shared/dictionary"
+	"squeak-shared/textgen"
+
+	"story-gen-lambda/prompts"
+)
+
+const (
+	ANNOTATE_MODEL       = textgen.ModelFast
+	ANNOTATE_TEMPERATURE = 0.2
+	ANNOTATE_BATCH_SIZE  = 15
+)
+
+type llmToken struct {
+	Sentence     int    `json:"sentence"`
+	Text         string `json:"text"`
+	Lemma        string `json:"lemma"`
+	PartOfSpeech string `json:"pos"`
+	Gender       string `json:"gender"`
+	Gloss        string `json:"gloss"`
+	Translation  string `json:"translation"`
+}
+
+// LLM annotates sentences in batches, so each word is glossed in the context
+// of its sentence.
+type LLM struct {
+	generator textgen.TextGenerator
+}
+
+func NewLLM(generator textgen.TextGenerator) *LLM {
+	return &LLM{generator: generator}
+}
+
+func (a *LLM) Annotate(ctx context.Context, language string, sentences []string, dict *dictionary.Dictionary) error {
+	for start := 0; start < len(sentences); start += ANNOTATE_BATCH_SIZE {
+		end := min(start+ANNOTATE_BATCH_SIZE, len(sentences))
+
+		prompt := prompts.CreateAnnotationPrompt(language, sentences[start:end], start)
+		response, err := a.generator.Generate(ctx, prompt, textgen.Options{
+			Model:       ANNOTATE_MODEL,
+			Temperature: ANNOTATE_TEMPERATURE,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to annotate sentences %d-%d: %v", start, end-1, err)
+		}
+
+		tokens, err := parseTokens(response)
+		if err != nil {
+			return fmt.Errorf("failed to parse annotations for sentences %d-%d: %v", start, end-1, err)
+		}
+		for _, token := range tokens {
+			if token.Text == "" || token.Sentence < start || token.Sentence >= end {
+				continue
+			}
+			dict.Add(
+				dictionary.Token{Text: token.Text, Sentence: token.Sentence, Gloss: token.Gloss},
+				dictionary.Entry{
+					Lemma:        token.Lemma,
+					PartOfSpeech: strings.ToUpper(token.PartOfSpeech),
+					Gender:       token.Gender,
+					Translation:  token.Translation,
+				},
+			)
+		}
+	}
+	return nil
+}
+
+// parseTokens reads the JSON array out of a response, ignoring any markdown
+// code fence or text the model put around it.
+func parseTokens(response string) ([]llmToken, error) {
+	start := strings.Index(response, "[")
+	end := strings.LastIndex(response, "]")
+	if start == -1 || end < start {
+		return nil, fmt.Errorf("no JSON array in response")
+	}
+
+	var tokens []llmToken
+	if err := json.Unmarshal([]byte(response[start:end+1]), &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"story-gen-lambda/tts"
+
+	"squeak-shared/contentstore"
+	"squeak-shared/stripmd"
+)
+
+// uploadAudiobook writes one alignment JSON per page, at the keys the API's
+// GetAudiobookURL presigns. contentType is "News" or "Story".
+func uploadAudiobook(ctx context.Context, store contentstore.ContentStore, synthesizer tts.Synthesizer, contentType string, language string, CEFRLevel string, subject string, date string, pages []string) error {
+	for i, page := range pages {
+		plainText := stripmd.Strip(page)
+
+		response, err := synthesizer.Synthesize(ctx, plainText, language)
+		if err != nil {
+			return fmt.Errorf("failed to generate audiobook page %d: %v", i, err)
+		}
+
+		audiobookContent, err := buildAudiobookBody(plainText, response)
+		if err != nil {
+			return fmt.Errorf("failed to build audiobook page %d: %v", i, err)
+		}
+
+		audiobookPath := contentstore.AudiobookKey(language, CEFRLevel, subject, date, i, contentType)
+		if err := store.Put(ctx, audiobookPath, audiobookContent); err != nil {
+			return fmt.Errorf("failed to upload audiobook page %d: %v", i, err)
+		}
+	}
+	return nil
+}
+
+// processAudiobook uploads the audiobook and records it. tier is the tier
+// requested by the generation matrix, or empty to use the synthesizer's.
+func processAudiobook(ctx context.Context, supabaseClient GenerationDB, store contentstore.ContentStore, synthesizer tts.Synthesizer, contentType string, id int, tier string, language string, CEFRLevel string, subject string, date string, pages []string) error {
+	if err := uploadAudiobook(ctx, store, synthesizer, contentType, language, CEFRLevel, subject, date, pages); err != nil {
+		return err
+	}
+	if tier == "" {
+		tier = synthesizer.Tier()
+	}
+	if err := supabaseClient.InsertAudiobook(contentType, id, tier, len(pages)); err != nil {
+		return err
+	}
+	log.Printf("%s audiobook for %d uploaded with %d pages", contentType, id, len(pages))
+	return nil
+}
@@ -0,0 +1,72 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"story-gen-lambda/elevenlabs"
+	"story-gen-lambda/tts"
+
+	"squeak-shared/contentstore"
+)
+
+type failingSynthesizer struct{}
+
+func (failingSynthesizer) Synthesize(ctx context.Context, text string, language string) (*elevenlabs.ElevenLabsResponse, error) {
+	return nil, errors.New("quota exceeded")
+}
+
+func (failingSynthesizer) Tier() string {
+	return tts.TIER_PREMIUM
+}
+
+func TestUploadAudiobook(t *testing.T) {
+	ctx := context.Background()
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pages := []string{"# Le Titre\n\nIl était **une fois**.\n", "## Chapitre 1\n\nLa fin.\n"}
+	err = uploadAudiobook(ctx, store, tts.NewLocal(), "Story", "French", "B1", "Politics", "2025-01-31", pages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for i := range pages {
+		data, err := store.Get(ctx, contentstore.AudiobookKey("French", "B1", "Politics", "2025-01-31", i, "Story"))
+		if err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		var audiobook Audiobook
+		if err := json.Unmarshal(data, &audiobook); err != nil {
+			t.Fatalf("page %d: %v", i, err)
+		}
+		if audiobook.Audio == "" {
+			t.Errorf("page %d: expected audio", i)
+		}
+		if audiobook.Text == "" || len(audiobook.Alignment.Characters) != len([]rune(audiobook.Text)) {
+			t.Errorf("page %d: expected one alignment entry per character of %q", i, audiobook.Text)
+		}
+		if audiobook.Text[0] == '#' {
+			t.Errorf("page %d: expected markdown to be stripped, got %q", i, audiobook.Text)
+		}
+	}
+}
+
+func TestUploadAudiobookSynthesisFailure(t *testing.T) {
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = uploadAudiobook(context.Background(), store, failingSynthesizer{}, "News", "French", "B1", "Politics", "2025-01-31", []string{"Bonjour."})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, err := store.Get(context.Background(), contentstore.AudiobookKey("French", "B1", "Politics", "2025-01-31", 0, "News")); !errors.Is(err, contentstore.ErrNotFound) {
+		t.Errorf("expected nothing to be uploaded, got %v", err)
+	}
+}
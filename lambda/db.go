@@ -2,22 +2,55 @@ package main
 
 import (
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/jackc/pgx/v4"
 	"github.com/jackc/pgx/v4/stdlib"
+
+	"story-gen-lambda/moderate"
+	"story-gen-lambda/readability"
 )
 
 type Client struct {
 	db *sql.DB
 }
 
+// GenerationDB is the part of Client the generation pipeline uses, so it can
+// be replaced in tests.
+type GenerationDB interface {
+	GetCurrentNewsSources(topic string) ([]Result, error)
+	InsertNews(title, language, topic, cefrLevel, preview_text string, status string) (int, error)
+	InsertStory(title, language, topic, cefrLevel, preview_text string, pages int, status string) (int, error)
+	DeleteStory(id int) error
+	InsertAudiobook(contentType string, id int, tier string, pages int) error
+	SetReadability(contentType string, id int, metrics readability.Metrics) error
+	SetModeration(contentType string, id int, result moderate.Result) error
+	SetBodyText(contentType string, id int, text string) error
+	InsertQuestion(contentType string, id int, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) error
+	InsertGenerationFailure(failure GenerationFailure) error
+
+	ClaimGenerationJob(job GenerationJob) (GenerationJob, bool, error)
+	SaveGenerationDraft(id int, provider string, model string, draftKey string) error
+	SaveGenerationContent(id int, contentID int) error
+	CompleteGenerationJob(id int, outputKey string, contentID int) error
+	FailGenerationJob(id int, message string) error
+}
+
+var _ GenerationDB = (*Client)(nil)
+
 type Result struct {
 	Title   string  `json:"title"`
 	URL     string  `json:"url"`
 	Content string  `json:"content"`
 	Score   float64 `json:"score"`
+
+	Domain    string    `json:"domain,omitempty"`
+	ClusterID int       `json:"clusterId,omitempty"`
+	Quality   int       `json:"quality,omitempty"`
+	FirstSeen time.Time `json:"firstSeen,omitempty"`
 }
 
 func NewClient() (*Client, error) {
@@ -44,10 +77,10 @@ func (c *Client) Close() error {
 
 func (c *Client) GetCurrentNewsSources(topic string) ([]Result, error) {
 	rows, err := c.db.Query(`
-		SELECT title, url, content, score 
+		SELECT title, url, content, score, COALESCE(domain, ''), COALESCE(cluster_id, id), quality, created_at
 		FROM news_sources 
 		WHERE topic = $1
-		AND created_at >= NOW() - INTERVAL '23 hours'
+		AND COALESCE(last_seen_at, created_at) >= NOW() - INTERVAL '23 hours'
 		ORDER BY created_at DESC
 		LIMIT 100`, topic)
 	if err != nil {
@@ -58,7 +91,8 @@ func (c *Client) GetCurrentNewsSources(topic string) ([]Result, error) {
 	sources := []Result{}
 	for rows.Next() {
 		var source Result
-		if err := rows.Scan(&source.Title, &source.URL, &source.Content, &source.Score); err != nil {
+		if err := rows.Scan(&source.Title, &source.URL, &source.Content, &source.Score,
+			&source.Domain, &source.ClusterID, &source.Quality, &source.FirstSeen); err != nil {
 			return nil, fmt.Errorf("failed to scan news source: %v", err)
 		}
 		sources = append(sources, source)
@@ -67,39 +101,285 @@ func (c *Client) GetCurrentNewsSources(topic string) ([]Result, error) {
 	return sources, nil
 }
 
-func (c *Client) InsertNews(title, language, topic, cefrLevel, preview_text string) (int, error) {
+// InsertNews upserts the day's article of a language, level and topic. An
+// article regenerated over an existing one gets the stricter of the two
+// statuses (see regeneratedStatus), as its body is replaced.
+func (c *Client) InsertNews(title, language, topic, cefrLevel, preview_text string, status string) (int, error) {
+	tx, err := c.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %v", err)
+	}
+
+	var existing string
+	err = tx.QueryRow(`
+        SELECT status FROM news
+        WHERE topic = $1 AND language = $2 AND cefr_level = $3 AND date_created = NOW()::date
+        FOR UPDATE
+    `, topic, language, cefrLevel).Scan(&existing)
+	if err != nil && err != sql.ErrNoRows {
+		tx.Rollback()
+		return 0, fmt.Errorf("failed to get news status: %v", err)
+	}
+
 	query := `
-        INSERT INTO news (title, language, topic, cefr_level, preview_text, created_at, date_created)
-        VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+        INSERT INTO news (title, language, topic, cefr_level, preview_text, status, created_at, date_created)
+        VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
         ON CONFLICT ON CONSTRAINT unique_news_entry
         DO UPDATE SET
             title = EXCLUDED.title,
             preview_text = EXCLUDED.preview_text,
+            status = EXCLUDED.status,
             created_at = NOW()
         RETURNING id
     `
 
 	var id int
-	err := c.db.QueryRow(query, title, language, topic, cefrLevel, preview_text).Scan(&id)
+	err = tx.QueryRow(query, title, language, topic, cefrLevel, preview_text, regeneratedStatus(existing, status)).Scan(&id)
 	if err != nil {
+		tx.Rollback()
 		return 0, fmt.Errorf("failed to insert news: %v", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %v", err)
+	}
 	return id, nil
 }
 
-func (c *Client) InsertAudiobook(news_id int, tier string) error {
-	query := `
-		INSERT INTO audiobooks (news_id, tier)
-		VALUES ($1, $2)
-		ON CONFLICT ON CONSTRAINT unique_audiobook_entry
+// SetReadability stores the readability metrics of a news or stories row.
+func (c *Client) SetReadability(contentType string, id int, metrics readability.Metrics) error {
+	table := "news"
+	if contentType == "Story" {
+		table = "stories"
+	}
+	data, err := json.Marshal(metrics)
+	if err != nil {
+		return fmt.Errorf("failed to marshal readability metrics: %v", err)
+	}
+	if _, err := c.db.Exec(fmt.Sprintf("UPDATE %s SET readability = $1 WHERE id = $2", table), string(data), id); err != nil {
+		return fmt.Errorf("failed to set readability: %v", err)
+	}
+	return nil
+}
+
+// SetModeration stores the moderation result of a news or stories row.
+func (c *Client) SetModeration(contentType string, id int, result moderate.Result) error {
+	table := "news"
+	if contentType == "Story" {
+		table = "stories"
+	}
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to marshal moderation result: %v", err)
+	}
+	if _, err := c.db.Exec(fmt.Sprintf("UPDATE %s SET moderation = $1 WHERE id = $2", table), string(data), id); err != nil {
+		return fmt.Errorf("failed to set moderation: %v", err)
+	}
+	return nil
+}
+
+// SetBodyText stores the plain text of a news or stories row for full-text
+// search.
+func (c *Client) SetBodyText(contentType string, id int, text string) error {
+	table := "news"
+	if contentType == "Story" {
+		table = "stories"
+	}
+	if _, err := c.db.Exec(fmt.Sprintf("UPDATE %s SET body_text = $1 WHERE id = $2", table), text, id); err != nil {
+		return fmt.Errorf("failed to set body text: %v", err)
+	}
+	return nil
+}
+
+// InsertQuestion keeps an existing question at the same position of the pool
+// of the content, type and level, which the API may have written first.
+// choices and answerKey are only set for question types graded against an
+// answer key.
+func (c *Client) InsertQuestion(contentType string, id int, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) error {
+	column := "news_id"
+	if contentType == "Story" {
+		column = "story_id"
+	}
+	var choicesJSON, answerKeyValue interface{}
+	if choices != nil {
+		encoded, err := json.Marshal(choices)
+		if err != nil {
+			return fmt.Errorf("failed to encode choices: %v", err)
+		}
+		choicesJSON = string(encoded)
+	}
+	if answerKey != "" {
+		answerKeyValue = answerKey
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO questions (%s, question_type, cefr_level, position, question, choices, answer_key)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (%s, question_type, cefr_level, position) WHERE retired_at IS NULL DO NOTHING
+	`, column, column)
+	if _, err := c.db.Exec(query, id, questionType, cefrLevel, position, question, choicesJSON, answerKeyValue); err != nil {
+		return fmt.Errorf("failed to insert question: %v", err)
+	}
+	return nil
+}
+
+// contentType is "News" or "Story", the conflict targets are the partial
+// unique indexes on news_id and story_id
+func (c *Client) InsertAudiobook(contentType string, id int, tier string, pages int) error {
+	column := "news_id"
+	if contentType == "Story" {
+		column = "story_id"
+	}
+
+	query := fmt.Sprintf(`
+		INSERT INTO audiobooks (%[1]s, tier, pages)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (%[1]s) WHERE %[1]s IS NOT NULL
 		DO UPDATE SET
-			tier = EXCLUDED.tier
-	`
-	_, err := c.db.Exec(query, news_id, tier)
+			tier = EXCLUDED.tier,
+			pages = EXCLUDED.pages
+	`, column)
+	_, err := c.db.Exec(query, id, tier, pages)
 	if err != nil {
 		return fmt.Errorf("failed to insert audiobook: %v", err)
 	}
 
 	return nil
 }
+
+func (c *Client) InsertStory(title, language, topic, cefrLevel, preview_text string, pages int, status string) (int, error) {
+	query := `
+        INSERT INTO stories (title, language, topic, cefr_level, preview_text, pages, status, created_at, date_created)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, NOW(), NOW())
+        RETURNING id
+    `
+
+	var id int
+	err := c.db.QueryRow(query, title, language, topic, cefrLevel, preview_text, pages, status).Scan(&id)
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert story: %v", err)
+	}
+
+	return id, nil
+}
+
+func (c *Client) DeleteStory(id int) error {
+	_, err := c.db.Exec(`DELETE FROM stories WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete story: %v", err)
+	}
+
+	return nil
+}
+
+func (c *Client) InsertGenerationFailure(failure GenerationFailure) error {
+	query := `
+		INSERT INTO generation_failures (message_id, body, language, cefr_level, subject, content_type, error, reason, attempts)
+		VALUES ($1, $2, NULLIF($3, ''), NULLIF($4, ''), NULLIF($5, ''), NULLIF($6, ''), $7, $8, $9)
+	`
+	_, err := c.db.Exec(query,
+		failure.MessageID,
+		failure.Body,
+		failure.Request.Language,
+		failure.Request.CEFRLevel,
+		failure.Request.Subject,
+		failure.Request.ContentType,
+		failure.Error,
+		failure.Reason,
+		failure.Attempts,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert generation failure: %v", err)
+	}
+
+	return nil
+}
+
+// ClaimGenerationJob creates or takes over the job for a request. It returns
+// the job and whether this worker now owns it. A job is not claimed if it is
+// completed or is running within its lease.
+func (c *Client) ClaimGenerationJob(job GenerationJob) (GenerationJob, bool, error) {
+	query := fmt.Sprintf(`
+		INSERT INTO generation_jobs (language, cefr_level, subject, content_type, job_date, status, attempts, started_at)
+		VALUES ($1, $2, $3, $4, $5, 'running', 1, NOW())
+		ON CONFLICT ON CONSTRAINT unique_generation_job
+		DO UPDATE SET
+			status = 'running',
+			attempts = generation_jobs.attempts + 1,
+			started_at = NOW(),
+			updated_at = NOW(),
+			error = NULL
+		WHERE generation_jobs.status = 'failed'
+			OR (generation_jobs.status = 'running' AND generation_jobs.started_at < NOW() - INTERVAL '%d minutes')
+		RETURNING id, status, attempts, COALESCE(draft_key, ''), COALESCE(content_id, 0)
+	`, JOB_LEASE_MINUTES)
+
+	claimed := job
+	err := c.db.QueryRow(query, job.Language, job.CEFRLevel, job.Subject, job.ContentType, job.Date).
+		Scan(&claimed.ID, &claimed.Status, &claimed.Attempts, &claimed.DraftKey, &claimed.ContentID)
+	if err == nil {
+		return claimed, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return job, false, fmt.Errorf("failed to claim generation job: %v", err)
+	}
+
+	// the job exists and was not taken over
+	existing := job
+	err = c.db.QueryRow(`
+		SELECT id, status, attempts, COALESCE(draft_key, ''), COALESCE(content_id, 0)
+		FROM generation_jobs
+		WHERE language = $1 AND cefr_level = $2 AND subject = $3 AND content_type = $4 AND job_date = $5`,
+		job.Language, job.CEFRLevel, job.Subject, job.ContentType, job.Date,
+	).Scan(&existing.ID, &existing.Status, &existing.Attempts, &existing.DraftKey, &existing.ContentID)
+	if err != nil {
+		return job, false, fmt.Errorf("failed to get generation job: %v", err)
+	}
+	return existing, false, nil
+}
+
+func (c *Client) SaveGenerationDraft(id int, provider string, model string, draftKey string) error {
+	_, err := c.db.Exec(`
+		UPDATE generation_jobs
+		SET provider = $2, model = $3, draft_key = $4, generated_at = NOW(), updated_at = NOW()
+		WHERE id = $1`, id, provider, model, draftKey)
+	if err != nil {
+		return fmt.Errorf("failed to save generation draft: %v", err)
+	}
+	return nil
+}
+
+// SaveGenerationContent records the row a job inserted before the job is
+// completed, 0 clears it.
+func (c *Client) SaveGenerationContent(id int, contentID int) error {
+	_, err := c.db.Exec(`
+		UPDATE generation_jobs
+		SET content_id = NULLIF($2, 0), updated_at = NOW()
+		WHERE id = $1`, id, contentID)
+	if err != nil {
+		return fmt.Errorf("failed to save generation content: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) CompleteGenerationJob(id int, outputKey string, contentID int) error {
+	_, err := c.db.Exec(`
+		UPDATE generation_jobs
+		SET status = 'completed', output_key = $2, content_id = $3, error = NULL, completed_at = NOW(), updated_at = NOW()
+		WHERE id = $1`, id, outputKey, contentID)
+	if err != nil {
+		return fmt.Errorf("failed to complete generation job: %v", err)
+	}
+	return nil
+}
+
+func (c *Client) FailGenerationJob(id int, message string) error {
+	_, err := c.db.Exec(`
+		UPDATE generation_jobs
+		SET status = 'failed', error = $2, updated_at = NOW()
+		WHERE id = $1`, id, message)
+	if err != nil {
+		return fmt.Errorf("failed to mark generation job as failed: %v", err)
+	}
+	return nil
+}
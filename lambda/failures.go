@@ -0,0 +1,57 @@
+package main
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// a message is given up on and recorded after this many receives, even if
+// its error is retryable
+const MAX_GENERATION_ATTEMPTS = 3
+
+const (
+	FAILURE_PERMANENT = "permanent"
+	FAILURE_EXHAUSTED = "exhausted"
+)
+
+// permanentError marks a failure that will happen again on every retry, e.g.
+// a malformed message. Any other error is treated as retryable.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string {
+	return e.err.Error()
+}
+
+func (e permanentError) Unwrap() error {
+	return e.err
+}
+
+func permanent(err error) error {
+	return permanentError{err: err}
+}
+
+func isPermanent(err error) bool {
+	var target permanentError
+	return errors.As(err, &target)
+}
+
+type GenerationFailure struct {
+	MessageID string
+	Body      string
+	Request   GenerationRequest
+	Error     string
+	Reason    string
+	Attempts  int
+}
+
+func receiveCount(message events.SQSMessage) int {
+	count, err := strconv.Atoi(message.Attributes["ApproximateReceiveCount"])
+	if err != nil || count < 1 {
+		return 1
+	}
+	return count
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"context"
+
+	"squeak-shared/textgen"
+
+	"story-gen-lambda/prompts"
+)
+
+const (
+	STORY_MODEL = textgen.ModelQuality
+	NEWS_MODEL  = textgen.ModelQuality
+
+	STORY_TEMPERATURE = 1.5
+	NEWS_TEMPERATURE  = 1.2
+)
+
+// Client builds the story and news prompts and runs them on whichever LLM
+// provider it was given.
+//
+// USAGE
+// textGenerator, err := textgen.NewFromEnv(ctx)
+// generatorClient := generator.NewClient(textGenerator)
+// defer textGenerator.Close()
+
+// story, _ := generatorClient.GenerateStory(ctx, "French", "B1", "Politics")
+// article, _ := generatorClient.GenerateNewsArticle(ctx, "French", "B1", "today Politics news", "France's new president is here!")
+type Client struct {
+	generator textgen.TextGenerator
+}
+
+func NewClient(generator textgen.TextGenerator) *Client {
+	return &Client{
+		generator: generator,
+	}
+}
+
+func (c *Client) GenerateStory(ctx context.Context, language string, cefr string, topic string) (string, error) {
+	prompt := prompts.CreateStoryPrompt(language, cefr, topic)
+	return c.generator.Generate(ctx, prompt, textgen.Options{
+		Model:       STORY_MODEL,
+		Temperature: STORY_TEMPERATURE,
+	})
+}
+
+func (c *Client) GenerateNewsArticle(ctx context.Context, language string, cefr string, query string, web_results string) (string, error) {
+	prompt := prompts.CreateNewsArticlePrompt(language, cefr, query, web_results)
+	return c.generator.Generate(ctx, prompt, textgen.Options{
+		Model:       NEWS_MODEL,
+		Temperature: NEWS_TEMPERATURE,
+	})
+}
+
+func (c *Client) Provider() string {
+	return c.generator.Provider()
+}
+
+// StoryModel and NewsModel are the provider models the content is generated with.
+func (c *Client) StoryModel() string {
+	return textgen.ModelName(c.generator, STORY_MODEL)
+}
+
+func (c *Client) NewsModel() string {
+	return textgen.ModelName(c.generator, NEWS_MODEL)
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"squeak-shared/contentstore"
+)
+
+const (
+	JOB_RUNNING   = "running"
+	JOB_COMPLETED = "completed"
+	JOB_FAILED    = "failed"
+)
+
+// a running job is left alone for this long before another delivery may take
+// it over, it matches the lambda timeout
+const JOB_LEASE_MINUTES = 15
+
+// errJobBusy is returned when another worker holds the lease of the job for a
+// request. It is not a failure of the request, see processBatch.
+var errJobBusy = errors.New("job is being run by another worker")
+
+type GenerationJob struct {
+	ID          int
+	Language    string
+	CEFRLevel   string
+	Subject     string
+	ContentType string
+	Date        string
+	Status      string
+	Attempts    int
+	DraftKey    string
+	// ContentID is the row published for the job, saved as soon as it is
+	// inserted so a resumed job does not insert it again
+	ContentID int
+}
+
+// runJob claims the job for a request and runs generate and publish for it.
+// A completed job is skipped. If the job has a draft from an earlier attempt
+// publish is run on the draft without generating again, and is passed the job
+// so it can reuse the content an earlier attempt inserted. Once the job is
+// completed, completed is run with the published text and content ID for
+// optional work that must not hold the job open.
+func (w *worker) runJob(ctx context.Context, genRequest GenerationRequest, date string, generate func() (string, string, error), publish func(job GenerationJob, text string) (string, int, error), completed func(text string, contentID int)) error {
+	job, claimed, err := w.db.ClaimGenerationJob(GenerationJob{
+		Language:    genRequest.Language,
+		CEFRLevel:   genRequest.CEFRLevel,
+		Subject:     genRequest.Subject,
+		ContentType: genRequest.ContentType,
+		Date:        date,
+	})
+	if err != nil {
+		return err
+	}
+	if !claimed {
+		if job.Status == JOB_COMPLETED {
+			log.Printf("Job %d is already completed, skipping", job.ID)
+			return nil
+		}
+		return fmt.Errorf("job %d: %w", job.ID, errJobBusy)
+	}
+
+	var text string
+	var contentID int
+	err = func() error {
+		var err error
+		text, err = w.loadDraft(ctx, job)
+		if err != nil {
+			return err
+		}
+		if text == "" {
+			var model string
+			text, model, err = generate()
+			if err != nil {
+				return err
+			}
+			if err := w.saveDraft(ctx, job, text, model); err != nil {
+				return err
+			}
+		}
+
+		var outputKey string
+		outputKey, contentID, err = publish(job, text)
+		if err != nil {
+			return err
+		}
+		return w.db.CompleteGenerationJob(job.ID, outputKey, contentID)
+	}()
+	if err != nil {
+		if failErr := w.db.FailGenerationJob(job.ID, err.Error()); failErr != nil {
+			log.Printf("Failed to mark job %d as failed: %v", job.ID, failErr)
+		}
+		return err
+	}
+	if completed != nil {
+		completed(text, contentID)
+	}
+	return nil
+}
+
+func (w *worker) loadDraft(ctx context.Context, job GenerationJob) (string, error) {
+	if job.DraftKey == "" {
+		return "", nil
+	}
+	data, err := w.store.Get(ctx, job.DraftKey)
+	if errors.Is(err, contentstore.ErrNotFound) {
+		log.Printf("Draft %s of job %d is missing, generating again", job.DraftKey, job.ID)
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	log.Printf("Resuming job %d from draft %s", job.ID, job.DraftKey)
+	return string(data), nil
+}
+
+func (w *worker) saveDraft(ctx context.Context, job GenerationJob, text string, model string) error {
+	draftKey := contentstore.DraftKey(job.Language, job.CEFRLevel, job.Subject, job.ContentType, job.Date)
+	if err := w.store.Put(ctx, draftKey, []byte(text)); err != nil {
+		return fmt.Errorf("failed to save draft: %v", err)
+	}
+	return w.db.SaveGenerationDraft(job.ID, w.generator.Provider(), model, draftKey)
+}
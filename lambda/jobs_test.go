@@ -0,0 +1,189 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"story-gen-lambda/generator"
+
+	"squeak-shared/contentstore"
+	"squeak-shared/textgen"
+)
+
+const newsRequest = `{"language":"French","cefrLevel":"B1","subject":"Politics","contentType":"News"}`
+
+func TestDuplicateDeliveryIsNoOp(t *testing.T) {
+	db := newFakeDB()
+	w, _ := newTestWorker(t, db)
+	llm := textgen.NewFake()
+	w.generator = generator.NewClient(llm)
+
+	for i := 0; i < 2; i++ {
+		response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("news", newsRequest, 1)}})
+		if ids := failedIDs(response); len(ids) != 0 {
+			t.Fatalf("delivery %d: unexpected failures %v", i, ids)
+		}
+	}
+
+	if len(llm.Calls()) != 1 {
+		t.Errorf("expected the article to be generated once, got %d calls", len(llm.Calls()))
+	}
+	if len(db.news) != 1 {
+		t.Errorf("expected one insert, got %v", db.news)
+	}
+	job := db.jobs[0]
+	if job.Status != JOB_COMPLETED || job.ContentID != 1 || job.outputKey == "" {
+		t.Errorf("unexpected job: %+v", job)
+	}
+	if job.provider != textgen.ProviderFake || job.model != textgen.ModelQuality {
+		t.Errorf("expected the provider and model to be recorded, got %s %s", job.provider, job.model)
+	}
+}
+
+type failingStore struct {
+	contentstore.ContentStore
+	failPrefix string
+}
+
+func (s failingStore) Put(ctx context.Context, key string, data []byte) error {
+	if len(key) >= len(s.failPrefix) && key[:len(s.failPrefix)] == s.failPrefix {
+		return errors.New("slow down")
+	}
+	return s.ContentStore.Put(ctx, key, data)
+}
+
+func TestFailedJobResumesFromDraft(t *testing.T) {
+	db := newFakeDB()
+	w, store := newTestWorker(t, db)
+	llm := textgen.NewFake()
+	w.generator = generator.NewClient(llm)
+
+	// generation succeeds but publishing the article fails
+	w.store = failingStore{ContentStore: store, failPrefix: "french/"}
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("news", newsRequest, 1)}})
+	if ids := failedIDs(response); len(ids) != 1 {
+		t.Fatalf("expected the message to be retried, got %v", ids)
+	}
+	if job := db.jobs[0]; job.Status != JOB_FAILED || job.DraftKey == "" || job.err == "" {
+		t.Fatalf("expected a failed job with a draft, got %+v", job)
+	}
+
+	w.store = store
+	response = w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("news", newsRequest, 2)}})
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(llm.Calls()) != 1 {
+		t.Errorf("expected the retry to reuse the draft, got %d calls", len(llm.Calls()))
+	}
+	if job := db.jobs[0]; job.Status != JOB_COMPLETED || job.Attempts != 2 {
+		t.Errorf("unexpected job: %+v", job)
+	}
+}
+
+type fakeRequeuer struct {
+	bodies []string
+	delays []time.Duration
+	err    error
+}
+
+func (r *fakeRequeuer) Requeue(ctx context.Context, body string, delay time.Duration) error {
+	if r.err != nil {
+		return r.err
+	}
+	r.bodies = append(r.bodies, body)
+	r.delays = append(r.delays, delay)
+	return nil
+}
+
+// runningJobDB returns a database where another worker is running the job
+// for newsRequest.
+func runningJobDB() *fakeDB {
+	db := newFakeDB()
+	db.jobs = append(db.jobs, &fakeJob{GenerationJob: GenerationJob{
+		ID: 1, Language: "French", CEFRLevel: "B1", Subject: "Politics", ContentType: "News", Status: JOB_RUNNING,
+		Date: time.Now().UTC().Format("2006-01-02"),
+	}})
+	return db
+}
+
+func TestRunningJobIsRequeued(t *testing.T) {
+	db := runningJobDB()
+	w, _ := newTestWorker(t, db)
+	requeuer := &fakeRequeuer{}
+	w.requeuer = requeuer
+
+	// the last delivery that would count towards MAX_GENERATION_ATTEMPTS
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("news", newsRequest, MAX_GENERATION_ATTEMPTS)}})
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Errorf("expected the delivery to be replaced by a new message, got %v", ids)
+	}
+	if len(requeuer.bodies) != 1 || requeuer.bodies[0] != newsRequest || requeuer.delays[0] != JOB_BUSY_DELAY {
+		t.Errorf("expected the request to be requeued with a delay, got %v %v", requeuer.bodies, requeuer.delays)
+	}
+	if len(db.failures) != 0 || len(db.news) != 0 {
+		t.Errorf("expected nothing to be recorded or published, got %+v %v", db.failures, db.news)
+	}
+	if job := db.jobs[0]; job.Status != JOB_RUNNING || job.err != "" {
+		t.Errorf("expected the running job to be left alone, got %+v", job)
+	}
+}
+
+func TestRunningJobIsRetriedWithoutRequeuer(t *testing.T) {
+	for name, requeuer := range map[string]Requeuer{
+		"none":   nil,
+		"failed": &fakeRequeuer{err: errors.New("throttled")},
+	} {
+		db := runningJobDB()
+		w, _ := newTestWorker(t, db)
+		w.requeuer = requeuer
+
+		response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("news", newsRequest, MAX_GENERATION_ATTEMPTS)}})
+		if ids := failedIDs(response); len(ids) != 1 {
+			t.Errorf("%s: expected the message to be retried while the job is running, got %v", name, ids)
+		}
+		if len(db.failures) != 0 {
+			t.Errorf("%s: expected a busy job not to be recorded as a failure, got %+v", name, db.failures)
+		}
+	}
+}
+
+func TestResumedJobReusesInsertedStory(t *testing.T) {
+	db := newFakeDB()
+	w, store := newTestWorker(t, db)
+	request := GenerationRequest{Language: "French", CEFRLevel: "B1", Subject: "Fantasy", ContentType: "Story"}
+
+	// the lambda dies after inserting the story, before completing the job
+	db.crash = true
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatal("expected the first attempt to crash")
+			}
+		}()
+		w.generate(context.Background(), request)
+	}()
+	if job := db.jobs[0]; job.Status != JOB_RUNNING || job.ContentID != 1 {
+		t.Fatalf("expected a running job with its story saved, got %+v", job)
+	}
+
+	// the lease expires and the message is delivered again
+	db.crash = false
+	db.jobs[0].leaseExpired = true
+	if err := w.generate(context.Background(), request); err != nil {
+		t.Fatal(err)
+	}
+	if len(db.stories) != 1 || db.nextStoryID != 1 {
+		t.Errorf("expected the story to be inserted once, got %v", db.stories)
+	}
+	if job := db.jobs[0]; job.Status != JOB_COMPLETED || job.ContentID != 1 || job.Attempts != 2 {
+		t.Errorf("unexpected job: %+v", job)
+	}
+	if _, err := store.Get(context.Background(), contentstore.StoryPageKey("French", "B1", "Fantasy", "1", 0)); err != nil {
+		t.Errorf("expected the pages of story 1 to be uploaded: %v", err)
+	}
+}
@@ -3,9 +3,9 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
-	"os"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -14,13 +14,16 @@ import (
 	"strings"
 	"time"
 
-	"database/sql"
+	"story-gen-lambda/annotate"
+	"story-gen-lambda/generator"
+	"story-gen-lambda/moderate"
+	"story-gen-lambda/readability"
+	"story-gen-lambda/tts"
 
-	_ "github.com/lib/pq"
-
-	// "story-gen-lambda/elevenlabs"
-	"story-gen-lambda/gemini"
-	"story-gen-lambda/stripmd"
+	"squeak-shared/contentstore"
+	"squeak-shared/dictionary"
+	"squeak-shared/stripmd"
+	"squeak-shared/textgen"
 )
 
 type GenerationRequest struct {
@@ -29,6 +32,7 @@ type GenerationRequest struct {
 	Subject         string `json:"subject"`
 	ContentType     string `json:"contentType"`
 	CreateAudiobook bool   `json:"createAudiobook"`
+	AudiobookTier   string `json:"audiobookTier,omitempty"` // empty uses the synthesizer's tier
 }
 
 func buildInfoBlockFromNewsSources(sources []Result) string {
@@ -62,181 +66,343 @@ func generateTitleAndPreview(text string) (string, string) {
 	return title, previewText
 }
 
-func handler(ctx context.Context, sqsEvent events.SQSEvent) error {
-	log.Println("IX 5: Executing Aya Story Generation...")
-	log.Println("Processing SQS Events of length ", len(sqsEvent.Records))
+var language_ids = map[string]string{
+	"French":  "fr",
+	"Spanish": "es",
+}
 
-	connStr := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=require",
-		os.Getenv("SUPABASE_HOST"),
-		os.Getenv("SUPABASE_PORT"),
-		os.Getenv("SUPABASE_USER"),
-		os.Getenv("SUPABASE_PASSWORD"),
-		os.Getenv("SUPABASE_DATABASE"),
-	)
+var cefrLevels = map[string]bool{"A1": true, "A2": true, "B1": true, "B2": true, "C1": true, "C2": true}
+
+const providingTranslations = false
+
+// worker generates content for the requests of one SQS batch.
+type worker struct {
+	db          GenerationDB
+	store       contentstore.ContentStore
+	generator   *generator.Client
+	synthesizer tts.Synthesizer
+	annotator   annotate.Annotator
+	readability *readability.Checker // nil when READABILITY_MODE is off
+	moderator   *moderate.Moderator  // nil when MODERATION_MODE is off
+	questions   *questionWriter      // nil when QNA_LEVELS is off
+	requeuer    Requeuer             // nil when SQS_QUEUE_URL is not set
+
+	// news sources are shared by every request for the same subject
+	webResults map[string]string
+	webSources map[string][]Result
+}
 
-	db, err := sql.Open("postgres", connStr)
-	if err != nil {
-		log.Println("Database connection failed:", err)
-		return err
+func parseGenerationRequest(body string) (GenerationRequest, error) {
+	var request GenerationRequest
+	if err := json.Unmarshal([]byte(body), &request); err != nil {
+		return request, permanent(fmt.Errorf("failed to parse message: %v", err))
 	}
-	log.Println("Supabase connection success!")
-	defer db.Close()
-
-	language_ids := map[string]string{
-		"French":  "fr",
-		"Spanish": "es",
+	if request.ContentType == "" {
+		request.ContentType = "News"
 	}
-
-	providingTranslations := false
-	blankStoryDictionary := StoryDictionary{
-		Translations: struct {
-			Words     map[string]string `json:"words"`
-			Sentences map[string]string `json:"sentences"`
-		}{
-			Words:     make(map[string]string),
-			Sentences: make(map[string]string),
-		},
+	if request.Language == "" || request.Subject == "" {
+		return request, permanent(fmt.Errorf("language and subject are required"))
+	}
+	if !cefrLevels[request.CEFRLevel] {
+		return request, permanent(fmt.Errorf("invalid CEFR level: %s", request.CEFRLevel))
+	}
+	if request.ContentType != "News" && request.ContentType != "Story" {
+		return request, permanent(fmt.Errorf("invalid content type: %s", request.ContentType))
 	}
+	if request.AudiobookTier != "" && request.AudiobookTier != tts.TIER_BASIC && request.AudiobookTier != tts.TIER_PREMIUM {
+		return request, permanent(fmt.Errorf("invalid audiobook tier: %s", request.AudiobookTier))
+	}
+	return request, nil
+}
+
+// processBatch generates each message independently. Only messages that
+// failed with a retryable error are reported back to SQS, the rest are
+// deleted from the queue. Messages that can never succeed, or that have been
+// received MAX_GENERATION_ATTEMPTS times, are recorded in generation_failures.
+// A message whose job another worker is running has not failed: it is sent
+// again after JOB_BUSY_DELAY and is neither counted nor recorded.
+func (w *worker) processBatch(ctx context.Context, sqsEvent events.SQSEvent) events.SQSEventResponse {
+	response := events.SQSEventResponse{BatchItemFailures: []events.SQSBatchItemFailure{}}
 
-	generationRequests := []GenerationRequest{}
 	for _, message := range sqsEvent.Records {
-		var request GenerationRequest
-		err := json.Unmarshal([]byte(message.Body), &request)
-		if err != nil {
-			log.Println("Failed to parse message: ", err)
+		request, err := parseGenerationRequest(message.Body)
+		if err == nil {
+			err = w.generate(ctx, request)
+		}
+		if err == nil {
+			continue
+		}
+		if errors.Is(err, errJobBusy) {
+			if !w.requeue(ctx, message) {
+				response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: message.MessageId})
+			}
 			continue
 		}
-		generationRequests = append(generationRequests, request)
+
+		attempts := receiveCount(message)
+		if !isPermanent(err) && attempts < MAX_GENERATION_ATTEMPTS {
+			log.Printf("Message %s failed on attempt %d, will retry: %v", message.MessageId, attempts, err)
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: message.MessageId})
+			continue
+		}
+
+		reason := FAILURE_EXHAUSTED
+		if isPermanent(err) {
+			reason = FAILURE_PERMANENT
+		}
+		log.Printf("Message %s failed (%s), giving up: %v", message.MessageId, reason, err)
+		recordErr := w.db.InsertGenerationFailure(GenerationFailure{
+			MessageID: message.MessageId,
+			Body:      message.Body,
+			Request:   request,
+			Error:     err.Error(),
+			Reason:    reason,
+			Attempts:  attempts,
+		})
+		if recordErr != nil {
+			// keep the message so the failure is not lost
+			log.Printf("Failed to record failure of message %s: %v", message.MessageId, recordErr)
+			response.BatchItemFailures = append(response.BatchItemFailures, events.SQSBatchItemFailure{ItemIdentifier: message.MessageId})
+		}
 	}
-	log.Printf("Finished processing SQS Events of length %d with a final length of %d", len(sqsEvent.Records), len(generationRequests))
 
-	// pull news sources from supabase
-	supabaseClient, err := NewClient()
-	if err != nil {
-		log.Println("Failed to create supabase client:", err)
-		return err
+	log.Printf("Finished processing %d messages with %d to retry", len(sqsEvent.Records), len(response.BatchItemFailures))
+	return response
+}
+
+// requeue sends a message back to the queue as a new message after
+// JOB_BUSY_DELAY and returns whether it did. Otherwise the message is reported
+// back to SQS, which delivers it again after its visibility timeout.
+func (w *worker) requeue(ctx context.Context, message events.SQSMessage) bool {
+	if w.requeuer == nil {
+		log.Printf("Message %s waits for a running job, will retry", message.MessageId)
+		return false
+	}
+	if err := w.requeuer.Requeue(ctx, message.Body, JOB_BUSY_DELAY); err != nil {
+		log.Printf("Message %s waits for a running job, will retry: %v", message.MessageId, err)
+		return false
+	}
+	log.Printf("Message %s waits for a running job, requeued", message.MessageId)
+	return true
+}
+
+func (w *worker) generate(ctx context.Context, genRequest GenerationRequest) error {
+	log.Println("Generating", genRequest.ContentType, "for", genRequest.CEFRLevel)
+	date := time.Now().UTC().Format("2006-01-02")
+	if genRequest.ContentType == "Story" {
+		return w.generateStory(ctx, genRequest, date)
+	}
+	return w.generateNews(ctx, genRequest, date)
+}
+
+func (w *worker) generateStory(ctx context.Context, genRequest GenerationRequest, date string) error {
+	language := genRequest.Language
+	CEFRLevel := genRequest.CEFRLevel
+	subject := genRequest.Subject
+
+	generate := func() (string, string, error) {
+		storyText, err := w.generateReadable("Story", language, CEFRLevel, func() (string, error) {
+			return w.generator.GenerateStory(ctx, language, CEFRLevel, subject)
+		})
+		return storyText, w.generator.StoryModel(), err
 	}
-	defer supabaseClient.Close()
 
-	webResults := make(map[string]string)
-	webSources := make(map[string][]Result)
-	for i := 0; i < len(generationRequests); i++ {
-		subject := generationRequests[i].Subject
-		sources, err := supabaseClient.GetCurrentNewsSources(subject)
+	publish := func(job GenerationJob, storyText string) (string, int, error) {
+		status, review, err := w.moderate(ctx, "Story", storyText, language, nil)
 		if err != nil {
-			log.Println("Failed to get news sources:", err)
-			return err
+			return "", 0, err
 		}
-		infoBlock := buildInfoBlockFromNewsSources(sources)
-		webSources[subject] = sources
-		webResults[subject] = infoBlock
+
+		storyID, pages, err := processStoryRequest(ctx, w.db, w.store, job, storyText, language, CEFRLevel, subject, status)
+		if err != nil {
+			return "", 0, err
+		}
+		w.recordReadability("Story", storyID, storyText, language, CEFRLevel)
+		w.recordBodyText("Story", storyID, storyText)
+		w.recordModeration("Story", storyID, review)
+
+		if genRequest.CreateAudiobook {
+			// audiobooks are optional, the story is already published
+			err := processAudiobook(ctx, w.db, w.store, w.synthesizer, "Story", storyID, genRequest.AudiobookTier, language, CEFRLevel, subject, date, pages)
+			if err != nil {
+				log.Printf("Failed to create audiobook: %v", err)
+			}
+		}
+		return contentstore.StoryPageKey(language, CEFRLevel, subject, strconv.Itoa(storyID), 0), storyID, nil
 	}
 
-	apiKey := os.Getenv("GEMINI_API_KEY")
-	geminiClient, err := gemini.NewGeminiClient(apiKey)
+	completed := func(storyText string, storyID int) {
+		w.generateQuestions(ctx, "Story", storyID, language, CEFRLevel, contentstore.StoryContext(storyText))
+	}
+
+	return w.runJob(ctx, genRequest, date, generate, publish, completed)
+}
+
+func (w *worker) newsSources(subject string) (string, []Result, error) {
+	if infoBlock, ok := w.webResults[subject]; ok {
+		return infoBlock, w.webSources[subject], nil
+	}
+	sources, err := w.db.GetCurrentNewsSources(subject)
 	if err != nil {
-		log.Println("Failed to create Gemini client:", err)
-		return err
+		return "", nil, err
 	}
-	defer geminiClient.Client.Close()
+	sources = selectNewsSources(sources, time.Now())
+	w.webSources[subject] = sources
+	w.webResults[subject] = buildInfoBlockFromNewsSources(sources)
+	return w.webResults[subject], sources, nil
+}
 
-	for _, genRequest := range generationRequests {
-		log.Println("Generating story for", genRequest.CEFRLevel)
-		language := genRequest.Language
-		CEFRLevel := genRequest.CEFRLevel
-		subject := genRequest.Subject
-		createAudiobook := genRequest.CreateAudiobook
-		// News Generation
-		newsText, err := geminiClient.GenerateNewsArticle(language, CEFRLevel, "today "+subject+" news", webResults[subject])
+func (w *worker) generateNews(ctx context.Context, genRequest GenerationRequest, date string) error {
+	language := genRequest.Language
+	CEFRLevel := genRequest.CEFRLevel
+	subject := genRequest.Subject
 
-		if err == nil {
-			words, sentences := getWordsAndSentences(newsText)
-			dictionary := blankStoryDictionary
-			if providingTranslations {
-				dictionary, _ = generateTranslations(words, sentences, language_ids[language])
-			}
-			body, _ := buildNewsBody(newsText, dictionary, webSources[subject])
+	infoBlock, sources, err := w.newsSources(subject)
+	if err != nil {
+		return err
+	}
 
-			current_time := time.Now().UTC().Format("2006-01-02")
+	generate := func() (string, string, error) {
+		newsText, err := w.generateReadable("News", language, CEFRLevel, func() (string, error) {
+			return w.generator.GenerateNewsArticle(ctx, language, CEFRLevel, "today "+subject+" news", infoBlock)
+		})
+		return newsText, w.generator.NewsModel(), err
+	}
 
-			path := strings.ToLower(language) + "/" + CEFRLevel + "/" + subject + "/" + "News/"
-			push_path := path + CEFRLevel + "_News_" + subject + "_" + current_time + ".json"
+	// news rows are upserted on their day, so a resumed job needs nothing from
+	// the earlier attempt
+	publish := func(_ GenerationJob, newsText string) (string, int, error) {
+		status, review, err := w.moderate(ctx, "News", newsText, language, sources)
+		if err != nil {
+			return "", 0, err
+		}
 
-			if err := uploadStoryS3(
-				os.Getenv("STORY_BUCKET_NAME"),
-				push_path, body,
-			); err != nil {
-				log.Println(err)
-				return err
+		words, sentences := getWordsAndSentences(newsText, language)
+		dict := dictionary.New()
+		if providingTranslations {
+			dict, _ = generateTranslations(words, sentences, language_ids[language])
+		}
+		if w.annotator != nil {
+			// annotations are optional, the article is still published without them
+			if err := w.annotator.Annotate(ctx, language, getSentences(newsText, language), &dict); err != nil {
+				log.Printf("Failed to annotate dictionary: %v", err)
 			}
+		}
+		body, err := buildNewsBody(newsText, dict, sources)
+		if err != nil {
+			return "", 0, err
+		}
+
+		push_path := contentstore.NewsKey(language, CEFRLevel, subject, "News", date)
+		if err := w.store.Put(ctx, push_path, body); err != nil {
+			return "", 0, err
+		}
+		log.Printf("News uploaded with key '%s'", push_path)
 
-			title, previewText := generateTitleAndPreview(newsText)
-			_, err := supabaseClient.InsertNews(title, language, subject, CEFRLevel, previewText)
+		title, previewText := generateTitleAndPreview(newsText)
+		newsID, err := w.db.InsertNews(title, language, subject, CEFRLevel, previewText, status)
+		if err != nil {
+			return "", 0, err
+		}
+		w.recordReadability("News", newsID, newsText, language, CEFRLevel)
+		w.recordBodyText("News", newsID, newsText)
+		w.recordModeration("News", newsID, review)
+
+		if genRequest.CreateAudiobook {
+			// audiobooks are optional, the article is already published
+			err := processAudiobook(ctx, w.db, w.store, w.synthesizer, "News", newsID, genRequest.AudiobookTier, language, CEFRLevel, subject, date, []string{newsText})
 			if err != nil {
-				log.Println(err)
-				return err
+				log.Printf("Failed to create audiobook: %v", err)
 			}
+		}
+		return push_path, newsID, nil
+	}
 
-			// disabled automatic generation of audiobooks for news articles
-			// this code works before the system was changed for pages so its not quite right
-			// if uncommenting then fix
-			if createAudiobook {
-				// strip markdown from text
-				// plainText := stripmd.Strip(newsText)
-				//
-				// var voiceId string
-				// switch language {
-				// case "French":
-				// 	voiceId = elevenlabs.ELEVENLABS_FRENCH_VOICE_ID
-				// case "Spanish":
-				// 	voiceId = elevenlabs.ELEVENLABS_SPANISH_VOICE_ID
-				// default:
-				// 	log.Printf("Unsupported language for audiobook: %s", language)
-				// 	continue
-				// }
-				//
-				// elevenLabsResponse, err := elevenlabs.ElevenLabsTTSWithTiming(
-				// 	plainText,
-				// 	voiceId,
-				// 	os.Getenv("ELEVENLABS_API_KEY"),
-				// )
-				// if err != nil {
-				// 	log.Printf("Failed to generate audiobook: %v", err)
-				// 	continue
-				// }
-				//
-				// audiobookContent, err := buildAudiobookBody(plainText, elevenLabsResponse)
-				// if err != nil {
-				// 	log.Printf("Failed to build audiobook content: %v", err)
-				// 	continue
-				// }
-				//
-				// audiobookPath := path + "audiobook_" + CEFRLevel + "_News_" + subject + "_" + current_time + ".json"
-				// if err := uploadStoryS3(
-				// 	os.Getenv("STORY_BUCKET_NAME"),
-				// 	audiobookPath,
-				// 	audiobookContent,
-				// ); err != nil {
-				// 	log.Printf("Failed to upload audiobook: %v", err)
-				// 	continue
-				// }
-				//
-				// err = supabaseClient.InsertAudiobook(news_id, "PREMIUM")
-				// if err != nil {
-				// 	log.Printf("Failed to insert audiobook: %v", err)
-				// 	continue
-				// }
-			}
+	completed := func(newsText string, newsID int) {
+		w.generateQuestions(ctx, "News", newsID, language, CEFRLevel, newsText)
+	}
 
-		} else {
-			log.Println(err)
-			return err
-		}
+	return w.runJob(ctx, genRequest, date, generate, publish, completed)
+}
+
+// handler only returns an error, failing the whole batch, if it cannot start.
+// Failures of single messages are reported in the response.
+func handler(ctx context.Context, sqsEvent events.SQSEvent) (events.SQSEventResponse, error) {
+	log.Println("IX 5: Executing Aya Story Generation...")
+	log.Println("Processing SQS Events of length ", len(sqsEvent.Records))
+
+	supabaseClient, err := NewClient()
+	if err != nil {
+		log.Println("Failed to create supabase client:", err)
+		return events.SQSEventResponse{}, err
+	}
+	defer supabaseClient.Close()
+
+	store, err := contentstore.NewFromEnv(ctx)
+	if err != nil {
+		log.Println("Failed to create content store:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	textGenerator, err := textgen.NewFromEnv(ctx)
+	if err != nil {
+		log.Println("Failed to create text generator:", err)
+		return events.SQSEventResponse{}, err
+	}
+	defer textGenerator.Close()
+
+	synthesizer, err := tts.NewFromEnv()
+	if err != nil {
+		log.Println("Failed to create TTS synthesizer:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	annotator, err := annotate.NewFromEnv(textGenerator)
+	if err != nil {
+		log.Println("Failed to create dictionary annotator:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	readabilityChecker, err := readability.NewFromEnv()
+	if err != nil {
+		log.Println("Failed to create readability checker:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	moderator, err := moderate.NewFromEnv(textGenerator)
+	if err != nil {
+		log.Println("Failed to create moderator:", err)
+		return events.SQSEventResponse{}, err
 	}
 
-    return nil
+	questions, err := newQuestionWriterFromEnv(textGenerator)
+	if err != nil {
+		log.Println("Failed to create question writer:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	requeuer, err := newRequeuerFromEnv()
+	if err != nil {
+		log.Println("Failed to create requeuer:", err)
+		return events.SQSEventResponse{}, err
+	}
+
+	w := &worker{
+		db:          supabaseClient,
+		store:       store,
+		generator:   generator.NewClient(textGenerator),
+		synthesizer: synthesizer,
+		annotator:   annotator,
+		readability: readabilityChecker,
+		moderator:   moderator,
+		questions:   questions,
+		requeuer:    requeuer,
+		webResults:  make(map[string]string),
+		webSources:  make(map[string][]Result),
+	}
+	return w.processBatch(ctx, sqsEvent), nil
 }
 
 func main() {
-    lambda.Start(handler)
+	lambda.Start(handler)
 }
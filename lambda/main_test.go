@@ -0,0 +1,350 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"story-gen-lambda/generator"
+	"story-gen-lambda/moderate"
+	"story-gen-lambda/readability"
+	"story-gen-lambda/tts"
+
+	"squeak-shared/contentstore"
+	"squeak-shared/textgen"
+)
+
+type fakeDB struct {
+	news        []string
+	stories     map[int]int // id -> pages
+	audiobooks  map[string]int
+	tiers       map[string]string
+	readability map[string]readability.Metrics
+	status      map[string]string
+	moderation  map[string]moderate.Result
+	bodies      map[string]string
+	questions   map[string]string
+	answerKeys  map[string]string
+	questionErr error
+	failures    []GenerationFailure
+	sourcesErr  map[string]error
+	failureErr  error
+	nextStoryID int
+	jobs        []*fakeJob
+	// crash panics in SetBodyText, like a lambda dying after the insert
+	crash bool
+}
+
+type fakeJob struct {
+	GenerationJob
+	provider  string
+	model     string
+	outputKey string
+	err       string
+	// leaseExpired lets a running job be taken over
+	leaseExpired bool
+}
+
+func newFakeDB() *fakeDB {
+	return &fakeDB{
+		stories:     make(map[int]int),
+		audiobooks:  make(map[string]int),
+		tiers:       make(map[string]string),
+		readability: make(map[string]readability.Metrics),
+		status:      make(map[string]string),
+		moderation:  make(map[string]moderate.Result),
+		bodies:      make(map[string]string),
+		questions:   make(map[string]string),
+		answerKeys:  make(map[string]string),
+		sourcesErr:  make(map[string]error),
+	}
+}
+
+func (f *fakeDB) GetCurrentNewsSources(topic string) ([]Result, error) {
+	if err := f.sourcesErr[topic]; err != nil {
+		return nil, err
+	}
+	return []Result{{Title: "Source", URL: "https://example.com", Content: "Content", Score: 0.9}}, nil
+}
+
+func (f *fakeDB) InsertNews(title, language, topic, cefrLevel, preview_text string, status string) (int, error) {
+	key := language + "/" + cefrLevel + "/" + topic
+	for i, existing := range f.news {
+		if existing == key {
+			id := "News/" + strconv.Itoa(i+1)
+			f.status[id] = regeneratedStatus(f.status[id], status)
+			return i + 1, nil
+		}
+	}
+	f.news = append(f.news, key)
+	f.status["News/"+strconv.Itoa(len(f.news))] = status
+	return len(f.news), nil
+}
+
+func (f *fakeDB) InsertStory(title, language, topic, cefrLevel, preview_text string, pages int, status string) (int, error) {
+	f.nextStoryID++
+	f.stories[f.nextStoryID] = pages
+	f.status["Story/"+strconv.Itoa(f.nextStoryID)] = status
+	return f.nextStoryID, nil
+}
+
+func (f *fakeDB) DeleteStory(id int) error {
+	delete(f.stories, id)
+	return nil
+}
+
+func (f *fakeDB) InsertAudiobook(contentType string, id int, tier string, pages int) error {
+	f.audiobooks[contentType+"/"+strconv.Itoa(id)] = pages
+	f.tiers[contentType+"/"+strconv.Itoa(id)] = tier
+	return nil
+}
+
+func (f *fakeDB) SetReadability(contentType string, id int, metrics readability.Metrics) error {
+	f.readability[contentType+"/"+strconv.Itoa(id)] = metrics
+	return nil
+}
+
+func (f *fakeDB) SetModeration(contentType string, id int, result moderate.Result) error {
+	f.moderation[contentType+"/"+strconv.Itoa(id)] = result
+	return nil
+}
+
+func (f *fakeDB) SetBodyText(contentType string, id int, text string) error {
+	if f.crash {
+		panic("lambda timed out")
+	}
+	f.bodies[contentType+"/"+strconv.Itoa(id)] = text
+	return nil
+}
+
+func (f *fakeDB) InsertQuestion(contentType string, id int, questionType string, cefrLevel string, position int, question string, choices []string, answerKey string) error {
+	if f.questionErr != nil {
+		return f.questionErr
+	}
+	key := contentType + "/" + strconv.Itoa(id) + "/" + questionType + "/" + cefrLevel + "/" + strconv.Itoa(position)
+	if _, ok := f.questions[key]; !ok {
+		f.questions[key] = question
+		if answerKey != "" {
+			f.answerKeys[key] = answerKey
+		}
+	}
+	return nil
+}
+
+func (f *fakeDB) InsertGenerationFailure(failure GenerationFailure) error {
+	if f.failureErr != nil {
+		return f.failureErr
+	}
+	f.failures = append(f.failures, failure)
+	return nil
+}
+
+// ClaimGenerationJob treats every running job as within its lease unless
+// leaseExpired is set.
+func (f *fakeDB) ClaimGenerationJob(job GenerationJob) (GenerationJob, bool, error) {
+	for _, existing := range f.jobs {
+		if existing.Language == job.Language && existing.CEFRLevel == job.CEFRLevel && existing.Subject == job.Subject &&
+			existing.ContentType == job.ContentType && existing.Date == job.Date {
+			if existing.Status == JOB_COMPLETED || (existing.Status == JOB_RUNNING && !existing.leaseExpired) {
+				return existing.GenerationJob, false, nil
+			}
+			existing.Status = JOB_RUNNING
+			existing.Attempts++
+			existing.leaseExpired = false
+			return existing.GenerationJob, true, nil
+		}
+	}
+	job.ID = len(f.jobs) + 1
+	job.Status = JOB_RUNNING
+	job.Attempts = 1
+	f.jobs = append(f.jobs, &fakeJob{GenerationJob: job})
+	return job, true, nil
+}
+
+func (f *fakeDB) SaveGenerationDraft(id int, provider string, model string, draftKey string) error {
+	job := f.jobs[id-1]
+	job.provider, job.model, job.DraftKey = provider, model, draftKey
+	return nil
+}
+
+func (f *fakeDB) SaveGenerationContent(id int, contentID int) error {
+	f.jobs[id-1].ContentID = contentID
+	return nil
+}
+
+func (f *fakeDB) CompleteGenerationJob(id int, outputKey string, contentID int) error {
+	job := f.jobs[id-1]
+	job.Status, job.outputKey, job.ContentID = JOB_COMPLETED, outputKey, contentID
+	return nil
+}
+
+func (f *fakeDB) FailGenerationJob(id int, message string) error {
+	job := f.jobs[id-1]
+	job.Status, job.err = JOB_FAILED, message
+	return nil
+}
+
+func newTestWorker(t *testing.T, db *fakeDB) (*worker, contentstore.ContentStore) {
+	t.Helper()
+	store, err := contentstore.NewLocalStore(t.TempDir(), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &worker{
+		db:          db,
+		store:       store,
+		generator:   generator.NewClient(textgen.NewFake()),
+		synthesizer: tts.NewLocal(),
+		webResults:  make(map[string]string),
+		webSources:  make(map[string][]Result),
+	}, store
+}
+
+func message(id string, body string, receiveCount int) events.SQSMessage {
+	return events.SQSMessage{
+		MessageId:  id,
+		Body:       body,
+		Attributes: map[string]string{"ApproximateReceiveCount": strconv.Itoa(receiveCount)},
+	}
+}
+
+func failedIDs(response events.SQSEventResponse) []string {
+	ids := []string{}
+	for _, failure := range response.BatchItemFailures {
+		ids = append(ids, failure.ItemIdentifier)
+	}
+	return ids
+}
+
+func TestProcessBatchReportsOnlyRetryableFailures(t *testing.T) {
+	db := newFakeDB()
+	db.sourcesErr["NBA"] = errors.New("connection reset")
+	w, _ := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("ok", `{"language":"French","cefrLevel":"B1","subject":"Politics","contentType":"News"}`, 1),
+		message("malformed", `{"language":`, 1),
+		message("invalid", `{"language":"French","cefrLevel":"Z9","subject":"Politics"}`, 1),
+		message("retry", `{"language":"French","cefrLevel":"B1","subject":"NBA","contentType":"News"}`, 1),
+		message("legacy", `{"language":"Spanish","cefrLevel":"A2","subject":"Music"}`, 1),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 1 || ids[0] != "retry" {
+		t.Errorf("expected only the retryable message to be reported, got %v", ids)
+	}
+	if len(db.news) != 2 {
+		t.Errorf("expected 2 articles, got %v", db.news)
+	}
+	if len(db.failures) != 2 {
+		t.Fatalf("expected 2 recorded failures, got %+v", db.failures)
+	}
+	for _, failure := range db.failures {
+		if failure.Reason != FAILURE_PERMANENT {
+			t.Errorf("expected %s to be a permanent failure, got %s", failure.MessageID, failure.Reason)
+		}
+	}
+	if db.failures[1].Request.CEFRLevel != "Z9" || db.failures[0].Body != `{"language":` {
+		t.Errorf("expected the failures to keep the message, got %+v", db.failures)
+	}
+}
+
+func TestProcessBatchGivesUpAfterMaxAttempts(t *testing.T) {
+	db := newFakeDB()
+	db.sourcesErr["NBA"] = errors.New("connection reset")
+	w, _ := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("poison", `{"language":"French","cefrLevel":"B1","subject":"NBA"}`, MAX_GENERATION_ATTEMPTS),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Errorf("expected the message to be dropped, got %v", ids)
+	}
+	if len(db.failures) != 1 || db.failures[0].Reason != FAILURE_EXHAUSTED || db.failures[0].Attempts != MAX_GENERATION_ATTEMPTS {
+		t.Errorf("expected an exhausted failure, got %+v", db.failures)
+	}
+}
+
+func TestProcessBatchKeepsMessageWhenFailureCannotBeRecorded(t *testing.T) {
+	db := newFakeDB()
+	db.failureErr = errors.New("connection reset")
+	w, _ := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("malformed", `not json`, 1),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 1 || ids[0] != "malformed" {
+		t.Errorf("expected the message to be kept, got %v", ids)
+	}
+}
+
+func TestProcessBatchStoryWithAudiobook(t *testing.T) {
+	db := newFakeDB()
+	w, store := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("story", `{"language":"French","cefrLevel":"A1","subject":"Travel","contentType":"Story","createAudiobook":true}`, 1),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	pages, ok := db.stories[1]
+	if !ok || pages < 1 {
+		t.Fatalf("expected a story row, got %v", db.stories)
+	}
+	if db.audiobooks["Story/1"] != pages {
+		t.Errorf("expected an audiobook with %d pages, got %v", pages, db.audiobooks)
+	}
+	if db.tiers["Story/1"] != tts.TIER_BASIC {
+		t.Errorf("expected the synthesizer's tier without a requested tier, got %s", db.tiers["Story/1"])
+	}
+	if _, err := store.Get(context.Background(), contentstore.StoryContextKey("French", "A1", "Travel", "1")); err != nil {
+		t.Errorf("expected context.txt to be uploaded: %v", err)
+	}
+}
+
+func TestProcessBatchUsesRequestedAudiobookTier(t *testing.T) {
+	db := newFakeDB()
+	w, _ := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("premium", `{"language":"French","cefrLevel":"B1","subject":"Politics","contentType":"News","createAudiobook":true,"audiobookTier":"PREMIUM"}`, 1),
+		message("unknown", `{"language":"French","cefrLevel":"B1","subject":"Music","contentType":"News","createAudiobook":true,"audiobookTier":"GOLD"}`, 1),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(db.tiers) != 1 || db.tiers["News/1"] != tts.TIER_PREMIUM {
+		t.Errorf("expected one PREMIUM audiobook, got %v", db.tiers)
+	}
+	if len(db.failures) != 1 || db.failures[0].MessageID != "unknown" || db.failures[0].Reason != FAILURE_PERMANENT {
+		t.Errorf("expected the unknown tier to be a permanent failure, got %+v", db.failures)
+	}
+}
+
+func TestPublishedContentHasBodyText(t *testing.T) {
+	db := newFakeDB()
+	w, _ := newTestWorker(t, db)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{
+		message("news", `{"language":"French","cefrLevel":"B1","subject":"Politics","contentType":"News"}`, 1),
+		message("story", `{"language":"French","cefrLevel":"A1","subject":"Travel","contentType":"Story"}`, 1),
+	}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	for _, key := range []string{"News/1", "Story/1"} {
+		body := db.bodies[key]
+		if body == "" || strings.ContainsAny(body, "#*") {
+			t.Errorf("expected plain body text for %s, got %q", key, body)
+		}
+	}
+}
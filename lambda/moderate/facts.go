@@ -0,0 +1,114 @@
+package moderate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"squeak-shared/stripmd"
+	"squeak-shared/tokenize"
+)
+
+const (
+	// an article is flagged when at least MIN_UNSUPPORTED_FACTS of its named
+	// facts, and more than MAX_UNSUPPORTED_SHARE of them, are not in the sources
+	MIN_UNSUPPORTED_FACTS = 4
+	MAX_UNSUPPORTED_SHARE = 0.5
+	// how many unsupported facts are listed in the flag
+	MAX_LISTED_FACTS = 5
+)
+
+var numberPattern = regexp.MustCompile(`\d[\d.,]*\d|\d`)
+
+// namedFacts returns the names (runs of capitalized words not starting a
+// sentence) and numbers of at least two digits in text. Sources are often
+// in another language, so translated words like country names can show up
+// as unsupported; the thresholds leave room for that.
+func namedFacts(text string, language string) []string {
+	tokenizer := tokenize.For(language)
+	seen := map[string]bool{}
+	facts := []string{}
+	add := func(fact string) {
+		if fact != "" && !seen[strings.ToLower(fact)] {
+			seen[strings.ToLower(fact)] = true
+			facts = append(facts, fact)
+		}
+	}
+
+	for _, sentence := range tokenizer.Sentences(stripmd.Strip(text)) {
+		name := []string{}
+		for i, word := range tokenizer.Words(sentence) {
+			if i > 0 && isCapitalized(word) && !strings.HasSuffix(word, "'") {
+				name = append(name, word)
+				continue
+			}
+			add(strings.Join(name, " "))
+			name = name[:0]
+		}
+		add(strings.Join(name, " "))
+
+		for _, number := range numberPattern.FindAllString(sentence, -1) {
+			if digits := digitsOf(number); len(digits) >= 2 {
+				add(number)
+			}
+		}
+	}
+	return facts
+}
+
+func isCapitalized(word string) bool {
+	for _, r := range word {
+		return unicode.IsUpper(r)
+	}
+	return false
+}
+
+func digitsOf(number string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, number)
+}
+
+var wholeNumber = regexp.MustCompile(`^\d[\d.,]*$`)
+
+// supported reports whether a fact appears in the sources. Numbers are
+// compared by their digits, so 1,5 matches 1.5.
+func supported(fact string, sources string, sourceNumbers map[string]bool) bool {
+	if wholeNumber.MatchString(fact) {
+		return sourceNumbers[digitsOf(fact)]
+	}
+	return strings.Contains(sources, strings.ToLower(fact))
+}
+
+func checkFacts(text string, language string, sources []string) []Flag {
+	facts := namedFacts(text, language)
+	if len(facts) == 0 {
+		return nil
+	}
+
+	joined := strings.ToLower(strings.Join(sources, "\n"))
+	sourceNumbers := map[string]bool{}
+	for _, number := range numberPattern.FindAllString(joined, -1) {
+		sourceNumbers[digitsOf(number)] = true
+	}
+
+	unsupported := []string{}
+	for _, fact := range facts {
+		if !supported(fact, joined, sourceNumbers) {
+			unsupported = append(unsupported, fact)
+		}
+	}
+
+	if len(unsupported) < MIN_UNSUPPORTED_FACTS || float64(len(unsupported)) <= MAX_UNSUPPORTED_SHARE*float64(len(facts)) {
+		return nil
+	}
+	listed := unsupported[:min(len(unsupported), MAX_LISTED_FACTS)]
+	return []Flag{{
+		Category: FLAG_UNSUPPORTED_FACTS,
+		Detail:   fmt.Sprintf("%d of %d names and numbers are not in the sources: %s", len(unsupported), len(facts), strings.Join(listed, ", ")),
+	}}
+}
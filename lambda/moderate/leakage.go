@@ -0,0 +1,62 @@
+package moderate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"squeak-shared/stripmd"
+	"squeak-shared/tokenize"
+)
+
+// English words that are not also common French or Spanish words
+var englishWords = map[string]bool{
+	"the": true, "and": true, "of": true, "is": true, "are": true, "was": true, "were": true,
+	"with": true, "that": true, "this": true, "for": true, "you": true, "your": true, "it": true,
+	"be": true, "have": true, "has": true, "will": true, "would": true, "from": true, "they": true,
+	"which": true, "about": true, "there": true, "their": true, "what": true, "been": true, "but": true,
+}
+
+const (
+	// flag when more than this share of the words are English, at least
+	// MIN_ENGLISH_WORDS of them
+	MAX_ENGLISH_SHARE = 0.05
+	MIN_ENGLISH_WORDS = 5
+)
+
+func checkEnglish(text string, language string) []Flag {
+	if strings.EqualFold(language, "English") || strings.EqualFold(language, "en") {
+		return nil
+	}
+	words := tokenize.For(language).Words(stripmd.Strip(text))
+	english := 0
+	for _, word := range words {
+		if englishWords[strings.ToLower(word)] {
+			english++
+		}
+	}
+	if english < MIN_ENGLISH_WORDS || float64(english) <= MAX_ENGLISH_SHARE*float64(len(words)) {
+		return nil
+	}
+	return []Flag{{Category: FLAG_ENGLISH, Detail: fmt.Sprintf("%d of %d words are English", english, len(words))}}
+}
+
+// leftovers of the prompt or of the LLM talking to the requester
+var leakagePatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\bas an ai\b|\blanguage model\b|\ben tant qu'(ia|intelligence artificielle)\b|\bcomo (una )?(ia|modelo de lenguaje)\b`),
+	regexp.MustCompile(`(?i)^\s*(sure|certainly|of course)[!,.]`),
+	regexp.MustCompile(`(?i)\bhere(’s| is|'s) (your|the|an?) (article|story|text)\b|\bvoici (votre|l'|un) ?(article|histoire|texte)\b|\baquí (tienes|está) (el|tu|un|una) (artículo|historia|texto)\b`),
+	regexp.MustCompile(`(?i)\bi hope (this|you)\b|\blet me know\b`),
+	regexp.MustCompile(`(?i)\bcefr\b|\bword count\b|\b\d+\s*-\s*\d+ words\b`),
+	regexp.MustCompile(`(?i)\[(insert|placeholder|your|name|date)[^\]]*\]|\{\{[^}]*\}\}`),
+	regexp.MustCompile(`(?m)^(SOURCE \d+:|TITLE:|CONTENT:|RELEVANCE:)`),
+}
+
+func checkLeakage(text string) []Flag {
+	for _, pattern := range leakagePatterns {
+		if match := pattern.FindString(text); match != "" {
+			return []Flag{{Category: FLAG_PROMPT_LEAKAGE, Detail: strings.TrimSpace(match)}}
+		}
+	}
+	return nil
+}
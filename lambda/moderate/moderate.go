@@ -0,0 +1,92 @@
+// Package moderate reviews generated content before it is published. Content
+// with any flag is held for an editor instead of being shown to learners.
+package moderate
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"squeak-shared/textgen"
+)
+
+const (
+	ModeLLM   = "llm"   // every check, with the LLM classifying safety
+	ModeLocal = "local" // only the checks that need no LLM
+	ModeOff   = "off"
+)
+
+// Flag categories
+const (
+	FLAG_HATEFUL           = "hateful"
+	FLAG_SEXUAL            = "sexual"
+	FLAG_VIOLENT           = "violent"
+	FLAG_UNCLEAR           = "unclear" // the safety classifier gave no usable answer
+	FLAG_UNSUPPORTED_FACTS = "unsupported_facts"
+	FLAG_ENGLISH           = "english"
+	FLAG_PROMPT_LEAKAGE    = "prompt_leakage"
+)
+
+type Flag struct {
+	Category string `json:"category"`
+	Detail   string `json:"detail,omitempty"`
+}
+
+// Result is stored as JSON alongside the news or stories row.
+type Result struct {
+	Flags   []Flag   `json:"flags"`
+	Checked []string `json:"checked"` // the checks that ran
+}
+
+func (r Result) Flagged() bool {
+	return len(r.Flags) > 0
+}
+
+type Moderator struct {
+	llm textgen.TextGenerator // nil skips the safety classification
+}
+
+func New(llm textgen.TextGenerator) *Moderator {
+	return &Moderator{llm: llm}
+}
+
+// NewFromEnv returns the moderator selected by MODERATION_MODE (default
+// llm), or nil if moderation is off.
+func NewFromEnv(llm textgen.TextGenerator) (*Moderator, error) {
+	switch mode := os.Getenv("MODERATION_MODE"); mode {
+	case "", ModeLLM:
+		return New(llm), nil
+	case ModeLocal:
+		return New(nil), nil
+	case ModeOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown MODERATION_MODE: %s", mode)
+	}
+}
+
+// Review checks markdown content. sources is the text the content was
+// written from; pass nil for content that is not based on sources, such as
+// stories. An error means the review could not be done and should be retried.
+func (m *Moderator) Review(ctx context.Context, text string, language string, sources []string) (Result, error) {
+	result := Result{Flags: []Flag{}, Checked: []string{}}
+
+	if m.llm != nil {
+		flags, err := m.classify(ctx, text, language)
+		if err != nil {
+			return result, err
+		}
+		result.Flags = append(result.Flags, flags...)
+		result.Checked = append(result.Checked, "safety")
+	}
+
+	if sources != nil {
+		result.Flags = append(result.Flags, checkFacts(text, language, sources)...)
+		result.Checked = append(result.Checked, "facts")
+	}
+
+	result.Flags = append(result.Flags, checkEnglish(text, language)...)
+	result.Flags = append(result.Flags, checkLeakage(text)...)
+	result.Checked = append(result.Checked, "english", "leakage")
+	return result, nil
+}
@@ -0,0 +1,127 @@
+package moderate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"squeak-shared/textgen"
+)
+
+const cleanArticle = `# Le budget est voté
+
+Le Sénat a voté le budget mardi soir. Le ministre Jean Dupont a parlé pendant 40 minutes.
+
+Les députés vont voter la loi en 2025.`
+
+var cleanSources = []string{"Senate passes budget\nThe Sénat voted on Tuesday. Minister Jean Dupont spoke for 40 minutes. The Assemblée votes in 2025."}
+
+func TestReviewPassesCleanContent(t *testing.T) {
+	result, err := New(textgen.NewFake()).Review(context.Background(), cleanArticle, "French", cleanSources)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Flagged() {
+		t.Errorf("expected no flags, got %+v", result.Flags)
+	}
+	if strings.Join(result.Checked, ",") != "safety,facts,english,leakage" {
+		t.Errorf("unexpected checks: %v", result.Checked)
+	}
+}
+
+func TestReviewFlagsUnsafeContent(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "FAIL: violent, sexual", nil
+	}
+
+	result, err := New(llm).Review(context.Background(), cleanArticle, "French", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Flags) != 2 || result.Flags[0].Category != FLAG_VIOLENT || result.Flags[1].Category != FLAG_SEXUAL {
+		t.Errorf("expected violent and sexual flags, got %+v", result.Flags)
+	}
+}
+
+func TestReviewRetriesWhenTheClassifierFails(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "", errors.New("throttled")
+	}
+	if _, err := New(llm).Review(context.Background(), cleanArticle, "French", nil); err == nil {
+		t.Errorf("expected the classifier error")
+	}
+}
+
+func TestParseVerdict(t *testing.T) {
+	cases := map[string]string{
+		"PASS":             "",
+		"pass.":            "",
+		"FAIL: hateful":    FLAG_HATEFUL,
+		"FAIL":             FLAG_UNCLEAR,
+		"FAIL: boring":     FLAG_UNCLEAR,
+		"I cannot decide.": FLAG_UNCLEAR,
+	}
+	for reply, want := range cases {
+		flags := parseVerdict(reply)
+		got := ""
+		if len(flags) > 0 {
+			got = flags[0].Category
+		}
+		if got != want {
+			t.Errorf("parseVerdict(%q) = %q, want %q", reply, got, want)
+		}
+	}
+}
+
+func TestCheckFactsFlagsInventedNamesAndNumbers(t *testing.T) {
+	invented := `# Le budget
+
+Le Sénat a voté le budget. Selon Marie Curie, le Japon va payer 300 millions.
+
+Le président Barack Obama arrive en 1999 avec 12 ministres.`
+
+	flags := checkFacts(invented, "French", cleanSources)
+	if len(flags) != 1 || flags[0].Category != FLAG_UNSUPPORTED_FACTS {
+		t.Fatalf("expected an unsupported facts flag, got %+v", flags)
+	}
+	if !strings.Contains(flags[0].Detail, "Marie Curie") || !strings.Contains(flags[0].Detail, "300") {
+		t.Errorf("expected the invented facts to be listed, got %q", flags[0].Detail)
+	}
+	if flags := checkFacts(cleanArticle, "French", cleanSources); len(flags) != 0 {
+		t.Errorf("expected supported facts to pass, got %+v", flags)
+	}
+}
+
+func TestCheckEnglish(t *testing.T) {
+	mixed := "Le Sénat a voté le budget. The senate was happy and they said that it is good for the country."
+	if flags := checkEnglish(mixed, "French"); len(flags) != 1 || flags[0].Category != FLAG_ENGLISH {
+		t.Errorf("expected an english flag, got %+v", flags)
+	}
+	if flags := checkEnglish(cleanArticle, "French"); len(flags) != 0 {
+		t.Errorf("expected no english flag, got %+v", flags)
+	}
+	if flags := checkEnglish(mixed, "English"); len(flags) != 0 {
+		t.Errorf("expected english content to be skipped, got %+v", flags)
+	}
+}
+
+func TestCheckLeakage(t *testing.T) {
+	leaks := []string{
+		"Sure! Here is your article:\n\n# Le budget",
+		"# Le budget\n\nEn tant qu'IA, je ne peux pas...",
+		"# El presupuesto\n\nTexto de nivel A2 (CEFR), 120-160 words.",
+		"# Le budget\n\nLe ministre [insert name] a parlé.",
+		"TITLE: Budget\nCONTENT: ...",
+	}
+	for _, text := range leaks {
+		if flags := checkLeakage(text); len(flags) != 1 || flags[0].Category != FLAG_PROMPT_LEAKAGE {
+			t.Errorf("expected leakage in %q, got %+v", text, flags)
+		}
+	}
+	if flags := checkLeakage(cleanArticle); len(flags) != 0 {
+		t.Errorf("expected no leakage, got %+v", flags)
+	}
+}
@@ -0,0 +1,52 @@
+package moderate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"story-gen-lambda/prompts"
+
+	"squeak-shared/textgen"
+)
+
+var safetyCategories = map[string]bool{FLAG_HATEFUL: true, FLAG_SEXUAL: true, FLAG_VIOLENT: true}
+
+// classify asks the LLM whether the text is safe for learners.
+func (m *Moderator) classify(ctx context.Context, text string, language string) ([]Flag, error) {
+	reply, err := m.llm.Generate(ctx, prompts.CreateModerationPrompt(language, text), textgen.Options{
+		Model:       textgen.ModelFast,
+		Temperature: 0,
+		MaxTokens:   64,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to classify content: %v", err)
+	}
+	return parseVerdict(reply), nil
+}
+
+// parseVerdict reads "PASS" or "FAIL: category, ...". Anything else is
+// flagged as unclear so that a person looks at it.
+func parseVerdict(reply string) []Flag {
+	verdict := strings.TrimSpace(reply)
+	upper := strings.ToUpper(verdict)
+	if strings.HasPrefix(upper, "PASS") {
+		return nil
+	}
+	if !strings.HasPrefix(upper, "FAIL") {
+		return []Flag{{Category: FLAG_UNCLEAR, Detail: verdict}}
+	}
+
+	flags := []Flag{}
+	_, categories, _ := strings.Cut(verdict, ":")
+	for _, category := range strings.Split(categories, ",") {
+		category = strings.ToLower(strings.TrimSpace(category))
+		if safetyCategories[category] {
+			flags = append(flags, Flag{Category: category})
+		}
+	}
+	if len(flags) == 0 {
+		flags = append(flags, Flag{Category: FLAG_UNCLEAR, Detail: verdict})
+	}
+	return flags
+}
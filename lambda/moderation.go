@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"log"
+
+	"story-gen-lambda/moderate"
+)
+
+// Content status, see supabase/migrations. Public queries only show
+// published content.
+const (
+	CONTENT_PUBLISHED = "published"
+	CONTENT_IN_REVIEW = "in_review"
+	CONTENT_RETRACTED = "retracted"
+)
+
+// regeneratedStatus is the status of content regenerated over content stored
+// with existing, the stricter of the two: a flagged regeneration is held for
+// review, and content an editor holds or retracted is not published again
+// without them.
+func regeneratedStatus(existing string, status string) string {
+	if status == CONTENT_IN_REVIEW {
+		return CONTENT_IN_REVIEW
+	}
+	if existing == CONTENT_IN_REVIEW || existing == CONTENT_RETRACTED {
+		return existing
+	}
+	return status
+}
+
+// moderate reviews content before it is stored and returns the status to
+// store it with. sources is nil for stories. Without a moderator everything
+// is published.
+func (w *worker) moderate(ctx context.Context, contentType string, text string, language string, sources []Result) (string, *moderate.Result, error) {
+	if w.moderator == nil {
+		return CONTENT_PUBLISHED, nil, nil
+	}
+
+	var sourceTexts []string
+	if sources != nil {
+		sourceTexts = make([]string, 0, len(sources))
+		for _, source := range sources {
+			sourceTexts = append(sourceTexts, source.Title+"\n"+source.Content)
+		}
+	}
+
+	result, err := w.moderator.Review(ctx, text, language, sourceTexts)
+	if err != nil {
+		return "", nil, err
+	}
+	if result.Flagged() {
+		log.Printf("%s in %s held for review: %+v", contentType, language, result.Flags)
+		return CONTENT_IN_REVIEW, &result, nil
+	}
+	return CONTENT_PUBLISHED, &result, nil
+}
+
+// recordModeration stores the review of stored content. Like readability
+// metrics it is informational, the status is what hides flagged content.
+func (w *worker) recordModeration(contentType string, id int, result *moderate.Result) {
+	if result == nil {
+		return
+	}
+	if err := w.db.SetModeration(contentType, id, *result); err != nil {
+		log.Printf("Failed to record moderation of %s %d: %v", contentType, id, err)
+	}
+}
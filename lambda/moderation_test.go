@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"story-gen-lambda/generator"
+	"story-gen-lambda/moderate"
+
+	"squeak-shared/textgen"
+)
+
+// moderatedWorker returns a worker that writes a1Article and whose moderator
+// gets verdict from the LLM.
+func moderatedWorker(t *testing.T, db *fakeDB, verdict func() (string, error)) (*worker, *textgen.Fake) {
+	w, _ := newTestWorker(t, db)
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if strings.Contains(prompt, "PASS or FAIL") {
+			return verdict()
+		}
+		return a1Article, nil
+	}
+	w.generator = generator.NewClient(llm)
+	w.moderator = moderate.New(llm)
+	return w, llm
+}
+
+func TestFlaggedContentIsHeldForReview(t *testing.T) {
+	db := newFakeDB()
+	w, _ := moderatedWorker(t, db, func() (string, error) { return "FAIL: violent", nil })
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if db.status["News/1"] != CONTENT_IN_REVIEW {
+		t.Errorf("expected the article to be held for review, got %q", db.status["News/1"])
+	}
+	result := db.moderation["News/1"]
+	if len(result.Flags) != 1 || result.Flags[0].Category != moderate.FLAG_VIOLENT {
+		t.Errorf("expected the violent flag to be recorded, got %+v", result)
+	}
+}
+
+func TestCleanContentIsPublished(t *testing.T) {
+	db := newFakeDB()
+	w, _ := moderatedWorker(t, db, func() (string, error) { return "PASS", nil })
+
+	w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if db.status["News/1"] != CONTENT_PUBLISHED {
+		t.Errorf("expected the article to be published, got %q", db.status["News/1"])
+	}
+	if result, ok := db.moderation["News/1"]; !ok || result.Flagged() {
+		t.Errorf("expected a clean review to be recorded, got %+v", db.moderation)
+	}
+}
+
+func TestFlaggedRegenerationIsHeldForReview(t *testing.T) {
+	db := newFakeDB()
+	db.news = []string{"French/A1/Travel"}
+	db.status["News/1"] = CONTENT_PUBLISHED
+	w, _ := moderatedWorker(t, db, func() (string, error) { return "FAIL: violent", nil })
+
+	w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if len(db.news) != 1 || db.status["News/1"] != CONTENT_IN_REVIEW {
+		t.Errorf("expected the published article to be held for review, got %v %v", db.news, db.status)
+	}
+}
+
+func TestRegeneratedStatus(t *testing.T) {
+	for _, tc := range []struct{ existing, status, want string }{
+		{"", CONTENT_PUBLISHED, CONTENT_PUBLISHED},
+		{"draft", CONTENT_PUBLISHED, CONTENT_PUBLISHED},
+		{CONTENT_PUBLISHED, CONTENT_PUBLISHED, CONTENT_PUBLISHED},
+		{CONTENT_PUBLISHED, CONTENT_IN_REVIEW, CONTENT_IN_REVIEW},
+		{CONTENT_IN_REVIEW, CONTENT_PUBLISHED, CONTENT_IN_REVIEW},
+		{CONTENT_RETRACTED, CONTENT_PUBLISHED, CONTENT_RETRACTED},
+		{CONTENT_RETRACTED, CONTENT_IN_REVIEW, CONTENT_IN_REVIEW},
+	} {
+		if got := regeneratedStatus(tc.existing, tc.status); got != tc.want {
+			t.Errorf("%s regenerated as %s: got %s, want %s", tc.existing, tc.status, got, tc.want)
+		}
+	}
+}
+
+func TestFailedModerationIsRetriedWithoutRegenerating(t *testing.T) {
+	db := newFakeDB()
+	classifierDown := true
+	w, llm := moderatedWorker(t, db, func() (string, error) {
+		if classifierDown {
+			return "", errors.New("throttled")
+		}
+		return "PASS", nil
+	})
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+	if ids := failedIDs(response); len(ids) != 1 {
+		t.Fatalf("expected the message to be retried, got %v", ids)
+	}
+	if len(db.news) != 0 {
+		t.Fatalf("expected nothing to be stored before moderation, got %v", db.news)
+	}
+
+	classifierDown = false
+	w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 2)}})
+
+	generations := 0
+	for _, call := range llm.Calls() {
+		if !strings.Contains(call.Prompt, "PASS or FAIL") {
+			generations++
+		}
+	}
+	if generations != 1 {
+		t.Errorf("expected the draft to be reused, got %d generations", generations)
+	}
+	if db.status["News/1"] != CONTENT_PUBLISHED {
+		t.Errorf("expected the article to be published on retry, got %q", db.status["News/1"])
+	}
+}
@@ -1,6 +1,7 @@
 package prompts
 
 import (
+	"strconv"
 	"strings"
 )
 
@@ -14,12 +15,24 @@ var cefrPrompts = map[string]string{
 }
 
 
+// stories are split into pages, so they are much longer than news articles
+var storyLengthPrompts = map[string]string{
+	"A1": "Target 500-700 words.",
+	"A2": "Target 700-1000 words.",
+	"B1": "Target 1000-1500 words.",
+	"B2": "Target 1500-2000 words.",
+	"C1": "Target 2000-3000 words.",
+	"C2": "Target 3000-4000 words.",
+}
+
 func CreateStoryPrompt(language string, cefr string, topic string) string {
 	var sb strings.Builder
 	sb.WriteString("You are an LLM designed to write " + language + " fiction stories. ")
-	sb.WriteString("Using the topic of " + topic + ", write a fictional story that matches the writing complexity of " + cefr + " on the CEFR scale.")
+	sb.WriteString("Using the topic of " + topic + ", write a fictional story that matches the writing complexity of " + cefr + " on the CEFR scale. ")
 	sb.WriteString("Your story " + cefrPrompts[cefr] + " ")
-	sb.WriteString("Provide the story without preamble or other comment. Use markdown headers for titles, subtitles, and section titles.\n\n")
+	sb.WriteString("That length applies to each chapter, the whole story is longer. " + storyLengthPrompts[cefr] + " ")
+	sb.WriteString("Start with the title as a single '#' header and divide the story into chapters, each starting with a '##' header. ")
+	sb.WriteString("Provide the story without preamble or other comment.\n\n")
 
 	prompt := sb.String()
 	return prompt
@@ -40,4 +53,32 @@ func CreateNewsArticlePrompt(language string, cefr string, query string, web_res
 
 	prompt := sb.String()
 	return prompt
-}
\ No newline at end of file
+}
+
+func CreateAnnotationPrompt(language string, sentences []string, offset int) string {
+	var sb strings.Builder
+	sb.WriteString("You are an LLM designed to build a " + language + " learner's dictionary. ")
+	sb.WriteString("For every word in each numbered sentence below, give its dictionary form (lemma), its Universal Dependencies part of speech tag, its grammatical gender for nouns (\"m\" or \"f\", otherwise empty), ")
+	sb.WriteString("a short English gloss of the word as it is used in that sentence, and a short general English translation of the lemma.\n")
+	sb.WriteString("Respond with ONLY a JSON array, one object per word in order, like ")
+	sb.WriteString(`[{"sentence": 0, "text": "mangeons", "lemma": "manger", "pos": "VERB", "gender": "", "gloss": "(we) eat", "translation": "to eat"}]`)
+	sb.WriteString(". Use the sentence numbers given. Do not skip words and do not include punctuation.\n\n")
+	for i, sentence := range sentences {
+		sb.WriteString(strconv.Itoa(offset+i) + ": " + sentence + "\n")
+	}
+
+	prompt := sb.String()
+	return prompt
+}
+
+func CreateModerationPrompt(language string, text string) string {
+	var sb strings.Builder
+	sb.WriteString("You are an LLM reviewing a " + language + " text written for language learners, who may be school students, before it is published. ")
+	sb.WriteString("It FAILS if it contains hateful content, sexual content, or graphic or gratuitous violence. Factual, neutral reporting of wars, crimes or accidents is acceptable. ")
+	sb.WriteString("Answer with ONLY PASS or FAIL. If it fails, follow FAIL with a colon and the categories that apply, separated by commas, from: hateful, sexual, violent. ")
+	sb.WriteString("For example: FAIL: violent\n\n")
+	sb.WriteString(text)
+
+	prompt := sb.String()
+	return prompt
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"squeak-shared/qnagen"
+	"squeak-shared/textgen"
+)
+
+// questionWriter writes the QNA questions of new content so that the API does
+// not have to generate them while a learner waits.
+type questionWriter struct {
+	generator *qnagen.Generator
+	levels    []string // nil for the level of the content
+	poolSize  int
+}
+
+// newQuestionWriterFromEnv reads QNA_LEVELS, a comma separated list of the
+// CEFR levels to write questions for, or "all". It defaults to the level of
+// the content only, as every level takes about 24 LLM calls per item, and
+// "off" disables pre-generation, returning nil. QNA_POOL_SIZE is how many
+// questions of each type and level are written, 1 by default to keep a batch
+// within the lambda timeout; the API adds more as learners pass them.
+func newQuestionWriterFromEnv(llm textgen.TextGenerator) (*questionWriter, error) {
+	poolSize := 1
+	if value := os.Getenv("QNA_POOL_SIZE"); value != "" {
+		size, err := strconv.Atoi(value)
+		if err != nil || size < 1 || size > qnagen.POOL_SIZE {
+			return nil, fmt.Errorf("QNA_POOL_SIZE must be between 1 and %d: %s", qnagen.POOL_SIZE, value)
+		}
+		poolSize = size
+	}
+
+	value := strings.TrimSpace(os.Getenv("QNA_LEVELS"))
+	switch value {
+	case "":
+		return &questionWriter{generator: qnagen.New(llm), poolSize: poolSize}, nil
+	case "all":
+		return &questionWriter{generator: qnagen.New(llm), levels: qnagen.Levels, poolSize: poolSize}, nil
+	case "off":
+		return nil, nil
+	}
+
+	levels := []string{}
+	for _, level := range strings.Split(value, ",") {
+		level = strings.ToUpper(strings.TrimSpace(level))
+		if !cefrLevels[level] {
+			return nil, fmt.Errorf("invalid CEFR level in QNA_LEVELS: %s", level)
+		}
+		levels = append(levels, level)
+	}
+	return &questionWriter{generator: qnagen.New(llm), levels: levels, poolSize: poolSize}, nil
+}
+
+// generateQuestions writes a pool of questions of every type, for the levels
+// of the questionWriter, for content of level CEFRLevel. It is run once the
+// job of the content is completed. Like readability metrics the questions are
+// optional: the API generates any question that is missing when it is first
+// asked for, so they are left out if the lambda runs out of time.
+func (w *worker) generateQuestions(ctx context.Context, contentType string, id int, language string, CEFRLevel string, content string) {
+	if w.questions == nil {
+		return
+	}
+	levels := w.questions.levels
+	if levels == nil {
+		levels = []string{CEFRLevel}
+	}
+
+	written := 0
+	for _, level := range levels {
+		for _, questionType := range qnagen.QuestionTypes {
+			pool := []string{}
+			for position := 0; position < w.questions.poolSize; position++ {
+				if ctx.Err() != nil {
+					log.Printf("Stopped writing questions for %s %d: %v", contentType, id, ctx.Err())
+					log.Printf("Wrote %d questions for %s %d", written, contentType, id)
+					return
+				}
+				exercise, err := w.questions.generator.Exercise(ctx, questionType, level, language, content, pool)
+				if err != nil {
+					log.Printf("Failed to generate %s %s question for %s %d: %v", level, questionType, contentType, id, err)
+					continue
+				}
+				if err := w.db.InsertQuestion(contentType, id, questionType, level, position, exercise.Question, exercise.Choices, exercise.Answer); err != nil {
+					log.Printf("Failed to store %s %s question for %s %d: %v", level, questionType, contentType, id, err)
+					continue
+				}
+				pool = append(pool, exercise.Question)
+				written++
+			}
+		}
+	}
+	log.Printf("Wrote %d questions for %s %d", written, contentType, id)
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"story-gen-lambda/generator"
+
+	"squeak-shared/qnagen"
+	"squeak-shared/textgen"
+)
+
+// questionWorker returns a worker that writes a1Article and one question of
+// each type for each level.
+func questionWorker(t *testing.T, db *fakeDB, levels []string) (*worker, *textgen.Fake) {
+	w, _ := newTestWorker(t, db)
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		switch {
+		case strings.Contains(prompt, "write questions"):
+			return "What does chat mean?\n", nil
+		case strings.Contains(prompt, "write multiple choice questions"):
+			return `{"question": "De quelle couleur est le chat ?", "choices": ["Noir et blanc", "Roux", "Gris", "Blanc"], "answer": 0}`, nil
+		case strings.Contains(prompt, "write true or false questions"):
+			return `{"statement": "Le chat est noir et blanc.", "answer": true}`, nil
+		}
+		return a1Article, nil
+	}
+	w.generator = generator.NewClient(llm)
+	w.questions = &questionWriter{generator: qnagen.New(llm), levels: levels, poolSize: 1}
+	return w, llm
+}
+
+func TestQuestionsAreWrittenForEveryLevel(t *testing.T) {
+	db := newFakeDB()
+	w, llm := questionWorker(t, db, qnagen.Levels)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(db.questions) != len(qnagen.Levels)*len(qnagen.QuestionTypes) {
+		t.Fatalf("expected a question of every type and level, got %v", db.questions)
+	}
+	if question := db.questions["News/1/vocab/C2/0"]; question != "What does chat mean?" {
+		t.Errorf("unexpected question: %q", question)
+	}
+	if _, ok := db.answerKeys["News/1/vocab/C2/0"]; ok {
+		t.Error("expected vocab questions to have no answer key")
+	}
+	for key, answer := range map[string]string{
+		"News/1/multiple_choice/A1/0": "Noir et blanc",
+		"News/1/true_false/A1/0":      qnagen.TRUE,
+		"News/1/cloze/A1/0":           "petit",
+	} {
+		if db.answerKeys[key] != answer {
+			t.Errorf("expected %s to have the answer key %q, got %q", key, answer, db.answerKeys[key])
+		}
+	}
+	for _, call := range llm.Calls() {
+		if strings.Contains(call.Prompt, "write questions") && !strings.Contains(call.Prompt, "Marie a un petit chat.") {
+			t.Fatalf("expected the article in the question prompt, got %q", call.Prompt)
+		}
+	}
+}
+
+func TestQuestionPoolsAreDistinct(t *testing.T) {
+	db := newFakeDB()
+	w, llm := questionWorker(t, db, []string{"B1"})
+	w.questions.poolSize = 3
+	asked := 0
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if strings.Contains(prompt, "write questions") {
+			asked++
+			if asked == 2 {
+				return "Question 1", nil // repeats the first question
+			}
+			return "Question " + strconv.Itoa(asked), nil
+		}
+		return a1Article, nil
+	}
+
+	w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	vocab := []string{db.questions["News/1/vocab/B1/0"], db.questions["News/1/vocab/B1/1"], db.questions["News/1/vocab/B1/2"]}
+	if vocab[0] != "Question 1" || vocab[1] != "" || vocab[2] != "Question 3" {
+		t.Errorf("expected the repeated question to be skipped, got %q", vocab)
+	}
+	if free := len(db.questions) - len(db.answerKeys); free != 5 {
+		t.Errorf("expected 5 vocab and understanding questions, got %v", db.questions)
+	}
+	for _, call := range llm.Calls() {
+		if strings.Contains(call.Prompt, "write questions") && strings.Contains(call.Prompt, "Question 3") {
+			t.Errorf("expected the understanding pool not to avoid vocab questions, got %q", call.Prompt)
+		}
+	}
+}
+
+func TestQuestionsAreWrittenAfterTheJobCompletes(t *testing.T) {
+	db := newFakeDB()
+	w, llm := questionWorker(t, db, nil)
+	respond := llm.Respond
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		if strings.Contains(prompt, "questions") && db.jobs[0].Status != JOB_COMPLETED {
+			t.Errorf("expected the job to be completed before questions are written, got %s", db.jobs[0].Status)
+		}
+		return respond(prompt, opts)
+	}
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(db.questions) != len(qnagen.QuestionTypes) {
+		t.Fatalf("expected a question of every type at the level of the article only, got %v", db.questions)
+	}
+	for key := range db.questions {
+		if !strings.Contains(key, "/A1/") {
+			t.Errorf("expected only A1 questions, got %s", key)
+		}
+	}
+}
+
+func TestQuestionsStopWhenTheContextIsDone(t *testing.T) {
+	db := newFakeDB()
+	w, _ := questionWorker(t, db, qnagen.Levels)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	w.generateQuestions(ctx, "News", 1, "French", "A1", "Marie a un petit chat.")
+
+	if len(db.questions) != 0 {
+		t.Errorf("expected no questions once the context is done, got %v", db.questions)
+	}
+}
+
+func TestQuestionFailuresDoNotFailTheJob(t *testing.T) {
+	db := newFakeDB()
+	db.questionErr = errors.New("connection reset")
+	w, _ := questionWorker(t, db, []string{"A1"})
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("expected the article to be published without questions, got failures %v", ids)
+	}
+	if len(db.news) != 1 {
+		t.Errorf("expected the article to be stored, got %v", db.news)
+	}
+}
+
+func TestQuestionLevelsFromEnv(t *testing.T) {
+	t.Setenv("QNA_LEVELS", "off")
+	if writer, err := newQuestionWriterFromEnv(textgen.NewFake()); writer != nil || err != nil {
+		t.Errorf("expected off to disable questions, got %+v (%v)", writer, err)
+	}
+
+	t.Setenv("QNA_LEVELS", "a1, B2")
+	writer, err := newQuestionWriterFromEnv(textgen.NewFake())
+	if err != nil || len(writer.levels) != 2 || writer.levels[0] != "A1" || writer.levels[1] != "B2" {
+		t.Errorf("unexpected levels: %+v (%v)", writer, err)
+	}
+
+	t.Setenv("QNA_LEVELS", "D1")
+	if _, err := newQuestionWriterFromEnv(textgen.NewFake()); err == nil {
+		t.Error("expected an invalid level to be rejected")
+	}
+
+	t.Setenv("QNA_LEVELS", "all")
+	if writer, err := newQuestionWriterFromEnv(textgen.NewFake()); err != nil || len(writer.levels) != 6 {
+		t.Errorf("expected every level, got %+v (%v)", writer, err)
+	}
+
+	t.Setenv("QNA_LEVELS", "")
+	t.Setenv("QNA_POOL_SIZE", "2")
+	if writer, err := newQuestionWriterFromEnv(textgen.NewFake()); err != nil || writer.poolSize != 2 || writer.levels != nil {
+		t.Errorf("expected the level of the content by default, got %+v (%v)", writer, err)
+	}
+	t.Setenv("QNA_POOL_SIZE", "10")
+	if _, err := newQuestionWriterFromEnv(textgen.NewFake()); err == nil {
+		t.Error("expected a pool larger than qnagen.POOL_SIZE to be rejected")
+	}
+}
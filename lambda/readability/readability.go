@@ -0,0 +1,155 @@
+// Package readability checks that generated content matches its CEFR level:
+// its length and how long its sentences are. Vocabulary is not checked, as
+// that needs frequency lists graded up to each level, which are not shipped.
+package readability
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	"squeak-shared/stripmd"
+	"squeak-shared/tokenize"
+)
+
+const (
+	ModeEnforce = "enforce" // regenerate, then reject, content that fails
+	ModeRecord  = "record"  // only record the metrics
+	ModeOff     = "off"
+)
+
+// Limits are the bounds content of a level must stay within. Zero means no
+// limit.
+type Limits struct {
+	MinWords          int     `json:"minWords"`
+	MaxWords          int     `json:"maxWords"`
+	MaxSentenceLength float64 `json:"maxSentenceLength"`
+}
+
+// word ranges asked for in lambda/prompts, for news and for whole stories
+var newsWords = map[string][2]int{
+	"A1": {60, 120}, "A2": {120, 160}, "B1": {200, 300},
+	"B2": {300, 400}, "C1": {700, 1000}, "C2": {1400, 1900},
+}
+
+var storyWords = map[string][2]int{
+	"A1": {500, 700}, "A2": {700, 1000}, "B1": {1000, 1500},
+	"B2": {1500, 2000}, "C1": {2000, 3000}, "C2": {3000, 4000},
+}
+
+var maxSentenceLength = map[string]float64{
+	"A1": 12, "A2": 16, "B1": 22, "B2": 26, "C1": 32,
+}
+
+// LENGTH_TOLERANCE widens the prompted word range, LLMs rarely hit it exactly.
+const LENGTH_TOLERANCE = 0.25
+
+// LimitsFor returns the limits for News or Story content of a level.
+func LimitsFor(contentType string, level string) Limits {
+	words := newsWords
+	if contentType == "Story" {
+		words = storyWords
+	}
+	limits := Limits{MaxSentenceLength: maxSentenceLength[level]}
+	if bounds, ok := words[level]; ok {
+		limits.MinWords = int(math.Floor(float64(bounds[0]) * (1 - LENGTH_TOLERANCE)))
+		limits.MaxWords = int(math.Ceil(float64(bounds[1]) * (1 + LENGTH_TOLERANCE)))
+	}
+	return limits
+}
+
+// Metrics are what was measured for a piece of content. They are stored as
+// JSON alongside the news or stories row.
+type Metrics struct {
+	Language          string   `json:"language"`
+	Level             string   `json:"level"`
+	Words             int      `json:"words"`
+	Sentences         int      `json:"sentences"`
+	AvgSentenceLength float64  `json:"avgSentenceLength"`
+	LongestSentence   int      `json:"longestSentence"`
+	Limits            Limits   `json:"limits"`
+	Passed            bool     `json:"passed"`
+	Problems          []string `json:"problems,omitempty"`
+}
+
+type Checker struct {
+	enforce bool
+}
+
+// New builds a Checker. An enforcing checker asks for content that fails to
+// be regenerated or rejected.
+func New(enforce bool) *Checker {
+	return &Checker{enforce: enforce}
+}
+
+// NewFromEnv builds the Checker selected by READABILITY_MODE (default
+// enforce). It returns nil for off.
+func NewFromEnv() (*Checker, error) {
+	switch mode := os.Getenv("READABILITY_MODE"); mode {
+	case "", ModeEnforce:
+		return New(true), nil
+	case ModeRecord:
+		return New(false), nil
+	case ModeOff:
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unknown READABILITY_MODE: %s", mode)
+	}
+}
+
+func (c *Checker) Enforce() bool {
+	return c.enforce
+}
+
+// Verify measures markdown content and checks it against the limits for its
+// content type and level.
+func (c *Checker) Verify(text string, language string, level string, contentType string) Metrics {
+	metrics := c.Measure(text, language, level)
+	metrics.Limits = LimitsFor(contentType, level)
+	metrics.Problems = check(metrics)
+	metrics.Passed = len(metrics.Problems) == 0
+	return metrics
+}
+
+func check(m Metrics) []string {
+	problems := []string{}
+	if m.Limits.MinWords > 0 && m.Words < m.Limits.MinWords {
+		problems = append(problems, fmt.Sprintf("%d words is below the minimum of %d", m.Words, m.Limits.MinWords))
+	}
+	if m.Limits.MaxWords > 0 && m.Words > m.Limits.MaxWords {
+		problems = append(problems, fmt.Sprintf("%d words is above the maximum of %d", m.Words, m.Limits.MaxWords))
+	}
+	if m.Limits.MaxSentenceLength > 0 && m.AvgSentenceLength > m.Limits.MaxSentenceLength {
+		problems = append(problems, fmt.Sprintf("average sentence length %.1f is above %.0f", m.AvgSentenceLength, m.Limits.MaxSentenceLength))
+	}
+	return problems
+}
+
+// Measure computes the metrics of markdown content without checking them.
+func (c *Checker) Measure(text string, language string, level string) Metrics {
+	metrics := Metrics{Language: language, Level: level}
+	tokenizer := tokenize.For(language)
+
+	for _, sentence := range tokenizer.Sentences(stripmd.Strip(text)) {
+		words := 0
+		for _, word := range tokenizer.Words(sentence) {
+			// elided articles are part of the next word
+			if strings.HasSuffix(word, "'") || strings.HasSuffix(word, "’") {
+				continue
+			}
+			words++
+		}
+		if words == 0 {
+			continue
+		}
+		metrics.Sentences++
+		metrics.Words += words
+		metrics.LongestSentence = max(metrics.LongestSentence, words)
+	}
+
+	if metrics.Sentences > 0 {
+		metrics.AvgSentenceLength = math.Round(float64(metrics.Words)/float64(metrics.Sentences)*10) / 10
+	}
+	return metrics
+}
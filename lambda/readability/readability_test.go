@@ -0,0 +1,76 @@
+package readability
+
+import "testing"
+
+const frenchA1 = "# Le chat de Marie\n\nMarie a un petit chat. Le chat est noir et blanc. Il aime dormir sur le lit. " +
+	"Le matin, il mange du pain. Marie joue avec son chat dans le jardin. Elle est très contente. " +
+	"Le soir, ils regardent la télévision ensemble. Le chat reste sur la table. Marie aime beaucoup son ami. " +
+	"Ils sont heureux dans la petite maison."
+
+const frenchHard = "# L'économie\n\nLes perspectives macroéconomiques demeurent particulièrement incertaines, compte tenu de " +
+	"l'inflation persistante et des tensions géopolitiques qui bouleversent durablement les chaînes d'approvisionnement mondiales depuis plusieurs années."
+
+func TestMeasure(t *testing.T) {
+	metrics := New(true).Measure(frenchA1, "French", "A1")
+
+	if metrics.Words != 64 || metrics.Sentences != 11 {
+		t.Errorf("expected 64 words in 11 sentences, got %d in %d", metrics.Words, metrics.Sentences)
+	}
+	if metrics.LongestSentence != 8 || metrics.AvgSentenceLength != 5.8 {
+		t.Errorf("unexpected sentence lengths: %+v", metrics)
+	}
+}
+
+func TestVerify(t *testing.T) {
+	checker := New(true)
+
+	if metrics := checker.Verify(frenchA1, "French", "A1", "News"); !metrics.Passed {
+		t.Errorf("expected the A1 text to pass, got %v", metrics.Problems)
+	}
+
+	metrics := checker.Verify(frenchHard, "French", "A1", "News")
+	if metrics.Passed || len(metrics.Problems) != 2 {
+		t.Errorf("expected the text to be too short with too long sentences, got %v", metrics.Problems)
+	}
+
+	// C2 has no sentence length limit
+	metrics = checker.Verify(frenchHard, "French", "C2", "News")
+	if len(metrics.Problems) != 1 || metrics.Limits.MaxSentenceLength != 0 {
+		t.Errorf("expected only the length to be checked, got %v", metrics.Problems)
+	}
+
+	// stories are judged on the length of the whole story
+	if metrics := checker.Verify(frenchA1, "French", "A1", "Story"); metrics.Passed {
+		t.Errorf("expected a 64 word story to be too short")
+	}
+}
+
+func TestUnknownLanguageIsMeasured(t *testing.T) {
+	metrics := New(true).Verify(frenchA1, "German", "A1", "News")
+	if metrics.Words != 64 || !metrics.Passed {
+		t.Errorf("expected the default tokenizer to be used, got %+v", metrics)
+	}
+}
+
+func TestNewFromEnv(t *testing.T) {
+	t.Setenv("READABILITY_MODE", "")
+	if checker, err := NewFromEnv(); err != nil || !checker.Enforce() {
+		t.Errorf("expected an enforcing checker by default, got %v, %v", checker, err)
+	}
+	t.Setenv("READABILITY_MODE", "enforce")
+	if checker, err := NewFromEnv(); err != nil || !checker.Enforce() {
+		t.Errorf("expected an enforcing checker, got %v, %v", checker, err)
+	}
+	t.Setenv("READABILITY_MODE", "off")
+	if checker, err := NewFromEnv(); checker != nil || err != nil {
+		t.Errorf("expected no checker when off, got %v, %v", checker, err)
+	}
+	t.Setenv("READABILITY_MODE", "record")
+	if checker, err := NewFromEnv(); err != nil || checker.Enforce() {
+		t.Errorf("expected a recording checker, got %v, %v", checker, err)
+	}
+	t.Setenv("READABILITY_MODE", "strict")
+	if _, err := NewFromEnv(); err == nil {
+		t.Errorf("expected an error for an unknown mode")
+	}
+}
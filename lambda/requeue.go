@@ -0,0 +1,54 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/sqs"
+)
+
+// JOB_BUSY_DELAY is how long a request whose job is being run by another
+// worker waits in the queue before it is delivered again. By then the job is
+// usually completed, or its lease has expired and the request takes it over.
+const JOB_BUSY_DELAY = 5 * time.Minute
+
+// Requeuer sends a request back to the generation queue as a new message, so
+// the delivery it replaces is not counted as an attempt.
+type Requeuer interface {
+	Requeue(ctx context.Context, body string, delay time.Duration) error
+}
+
+type sqsRequeuer struct {
+	svc      *sqs.SQS
+	queueURL string
+}
+
+// newRequeuerFromEnv returns a requeuer for SQS_QUEUE_URL, or nil when it is
+// not set.
+func newRequeuerFromEnv() (Requeuer, error) {
+	queueURL := os.Getenv("SQS_QUEUE_URL")
+	if queueURL == "" {
+		return nil, nil
+	}
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create AWS session: %v", err)
+	}
+	return &sqsRequeuer{svc: sqs.New(sess), queueURL: queueURL}, nil
+}
+
+func (r *sqsRequeuer) Requeue(ctx context.Context, body string, delay time.Duration) error {
+	_, err := r.svc.SendMessageWithContext(ctx, &sqs.SendMessageInput{
+		QueueUrl:     aws.String(r.queueURL),
+		MessageBody:  aws.String(body),
+		DelaySeconds: aws.Int64(int64(delay / time.Second)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to requeue message: %v", err)
+	}
+	return nil
+}
@@ -0,0 +1,17 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"squeak-shared/stripmd"
+)
+
+// recordBodyText stores the plain text of published content so it can be
+// found by /search. Content without it is still found by its title and
+// preview, so failing to store it does not fail the job.
+func (w *worker) recordBodyText(contentType string, id int, text string) {
+	if err := w.db.SetBodyText(contentType, id, strings.TrimSpace(stripmd.Strip(text))); err != nil {
+		log.Printf("Failed to record body text of %s %d: %v", contentType, id, err)
+	}
+}
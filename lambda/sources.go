@@ -0,0 +1,72 @@
+package main
+
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	MAX_NEWS_SOURCES       = 8
+	MAX_SOURCES_PER_DOMAIN = 2
+	// a source's rank halves after this long
+	SOURCE_FRESHNESS_HALF_LIFE = 12 * time.Hour
+)
+
+// sourceRank combines the quality the queue filler gave a source with how
+// fresh it is. Sources stored before quality scoring fall back to relevance.
+func sourceRank(source Result, now time.Time) float64 {
+	quality := float64(source.Quality)
+	if quality == 0 {
+		quality = source.Score
+	}
+	if source.FirstSeen.IsZero() {
+		return quality
+	}
+	age := max(now.Sub(source.FirstSeen), 0)
+	return quality / (1 + float64(age)/float64(SOURCE_FRESHNESS_HALF_LIFE))
+}
+
+func sourceDomain(source Result) string {
+	if source.Domain != "" {
+		return source.Domain
+	}
+	parsed, err := url.Parse(source.URL)
+	if err != nil {
+		return source.URL
+	}
+	return strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+}
+
+// selectNewsSources picks a compact, diverse set of sources for the news
+// prompt: best ranked first, one per story cluster and at most
+// MAX_SOURCES_PER_DOMAIN from the same site.
+func selectNewsSources(sources []Result, now time.Time) []Result {
+	ranked := append([]Result(nil), sources...)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return sourceRank(ranked[i], now) > sourceRank(ranked[j], now)
+	})
+
+	clusters := make(map[int]bool)
+	domains := make(map[string]int)
+	selected := []Result{}
+	for _, source := range ranked {
+		if len(selected) == MAX_NEWS_SOURCES {
+			break
+		}
+		if source.ClusterID != 0 && clusters[source.ClusterID] {
+			continue
+		}
+		domain := sourceDomain(source)
+		if domains[domain] >= MAX_SOURCES_PER_DOMAIN {
+			continue
+		}
+		if source.ClusterID != 0 {
+			clusters[source.ClusterID] = true
+		}
+		domains[domain]++
+		selected = append(selected, source)
+	}
+	return selected
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestSelectNewsSources(t *testing.T) {
+	now := time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC)
+	sources := []Result{
+		{Title: "wire copy", URL: "https://b.example/1", Domain: "b.example", ClusterID: 1, Quality: 80, FirstSeen: now.Add(-time.Hour)},
+		{Title: "wire original", URL: "https://a.example/1", Domain: "a.example", ClusterID: 1, Quality: 90, FirstSeen: now.Add(-time.Hour)},
+		{Title: "stale", URL: "https://c.example/1", Domain: "c.example", ClusterID: 2, Quality: 95, FirstSeen: now.Add(-22 * time.Hour)},
+		{Title: "legacy", URL: "https://www.d.example/1", Score: 50},
+	}
+	for i := 0; i < 4; i++ {
+		sources = append(sources, Result{
+			Title: "same site " + strconv.Itoa(i), URL: "https://e.example/" + strconv.Itoa(i), Domain: "e.example",
+			ClusterID: 10 + i, Quality: 70, FirstSeen: now,
+		})
+	}
+
+	selected := selectNewsSources(sources, now)
+
+	titles := []string{}
+	for _, source := range selected {
+		titles = append(titles, source.Title)
+	}
+	want := []string{"wire original", "same site 0", "same site 1", "legacy", "stale"}
+	if len(titles) != len(want) {
+		t.Fatalf("expected %v, got %v", want, titles)
+	}
+	for i := range want {
+		if titles[i] != want[i] {
+			t.Fatalf("expected %v, got %v", want, titles)
+		}
+	}
+}
+
+func TestSelectNewsSourcesLimitsCount(t *testing.T) {
+	sources := []Result{}
+	for i := 0; i < 20; i++ {
+		sources = append(sources, Result{URL: "https://site" + strconv.Itoa(i) + ".example/", Score: float64(i)})
+	}
+	selected := selectNewsSources(sources, time.Now())
+	if len(selected) != MAX_NEWS_SOURCES || selected[0].Score != 19 {
+		t.Errorf("expected the %d most relevant sources, got %+v", MAX_NEWS_SOURCES, selected)
+	}
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+
+	"squeak-shared/contentstore"
+)
+
+// target number of words per story page, pages are split on markdown headers
+// and then on paragraphs so a page never ends mid-paragraph
+var storyPageWords = map[string]int{
+	"A1": 120,
+	"A2": 160,
+	"B1": 250,
+	"B2": 350,
+	"C1": 500,
+	"C2": 700,
+}
+
+const DEFAULT_STORY_PAGE_WORDS = 300
+
+func wordCount(text string) int {
+	return len(strings.Fields(text))
+}
+
+// splitSections splits markdown into blocks that each begin with a header,
+// any text before the first header is its own block
+func splitSections(text string) []string {
+	sections := []string{}
+	var current []string
+	for _, line := range strings.Split(text, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") && len(current) > 0 {
+			sections = append(sections, strings.Join(current, "\n"))
+			current = nil
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		sections = append(sections, strings.Join(current, "\n"))
+	}
+	return sections
+}
+
+func splitParagraphs(text string) []string {
+	paragraphs := []string{}
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		if strings.TrimSpace(paragraph) != "" {
+			paragraphs = append(paragraphs, strings.TrimSpace(paragraph))
+		}
+	}
+	return paragraphs
+}
+
+// splitStoryPages splits a generated story into pages of roughly maxWords
+// words. Sections (markdown headers) start a new page when the current page is
+// already half full, and oversized sections are split between paragraphs.
+func splitStoryPages(text string, maxWords int) []string {
+	if maxWords <= 0 {
+		maxWords = DEFAULT_STORY_PAGE_WORDS
+	}
+
+	pages := []string{}
+	var current []string
+	currentWords := 0
+	flush := func() {
+		if len(current) > 0 {
+			pages = append(pages, strings.Join(current, "\n\n")+"\n")
+			current = nil
+			currentWords = 0
+		}
+	}
+
+	for _, section := range splitSections(text) {
+		if strings.HasPrefix(strings.TrimSpace(section), "#") && currentWords >= maxWords/2 {
+			flush()
+		}
+		for _, paragraph := range splitParagraphs(section) {
+			words := wordCount(paragraph)
+			if currentWords > 0 && currentWords+words > maxWords {
+				flush()
+			}
+			current = append(current, paragraph)
+			currentWords += words
+		}
+	}
+	flush()
+
+	return pages
+}
+
+// processStoryRequest inserts the story and uploads its pages and context. The
+// story's id is saved on the job right after the insert, so if the lambda dies
+// before the job completes the retry uploads to the same story instead of
+// inserting another.
+func processStoryRequest(ctx context.Context, supabaseClient GenerationDB, store contentstore.ContentStore, job GenerationJob, storyText string, language string, CEFRLevel string, subject string, status string) (int, []string, error) {
+	pageWords, ok := storyPageWords[CEFRLevel]
+	if !ok {
+		pageWords = DEFAULT_STORY_PAGE_WORDS
+	}
+	pages := splitStoryPages(storyText, pageWords)
+	if len(pages) == 0 {
+		return 0, nil, fmt.Errorf("generated story is empty")
+	}
+
+	storyID, inserted := job.ContentID, false
+	if storyID != 0 {
+		log.Printf("Job %d already inserted story %d, uploading it again", job.ID, storyID)
+	} else {
+		title, previewText := generateTitleAndPreview(storyText)
+		var err error
+		storyID, err = supabaseClient.InsertStory(title, language, subject, CEFRLevel, previewText, len(pages), status)
+		if err != nil {
+			return 0, nil, err
+		}
+		inserted = true
+		if err := supabaseClient.SaveGenerationContent(job.ID, storyID); err != nil {
+			if err := supabaseClient.DeleteStory(storyID); err != nil {
+				log.Printf("Failed to clean up story %d: %v", storyID, err)
+			}
+			return 0, nil, err
+		}
+	}
+	id := strconv.Itoa(storyID)
+
+	// the row is inserted first so the id can be used in the keys,
+	// remove it again if the content could not be uploaded
+	uploadErr := func() error {
+		for i, page := range pages {
+			if err := store.Put(ctx, contentstore.StoryPageKey(language, CEFRLevel, subject, id, i), []byte(page)); err != nil {
+				return err
+			}
+		}
+		return store.Put(ctx, contentstore.StoryContextKey(language, CEFRLevel, subject, id), []byte(contentstore.StoryContext(storyText)))
+	}()
+	if uploadErr != nil {
+		// a story from an earlier attempt is kept for the next one
+		if inserted {
+			if err := supabaseClient.DeleteStory(storyID); err != nil {
+				log.Printf("Failed to clean up story %d: %v", storyID, err)
+			} else if err := supabaseClient.SaveGenerationContent(job.ID, 0); err != nil {
+				log.Printf("Failed to clear the story of job %d: %v", job.ID, err)
+			}
+		}
+		return 0, nil, fmt.Errorf("failed to upload story %d: %v", storyID, uploadErr)
+	}
+
+	log.Printf("Story %d uploaded with %d pages", storyID, len(pages))
+	return storyID, pages, nil
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"squeak-shared/contentstore"
+)
+
+func paragraph(words int) string {
+	return strings.TrimSpace(strings.Repeat("mot ", words))
+}
+
+func TestSplitStoryPages(t *testing.T) {
+	story := strings.Join([]string{
+		"# Le Titre",
+		paragraph(40),
+		paragraph(40),
+		"## Chapitre 1",
+		paragraph(55),
+		paragraph(55),
+		"## Chapitre 2",
+		paragraph(30),
+	}, "\n\n")
+
+	pages := splitStoryPages(story, 120)
+	if len(pages) != 3 {
+		t.Fatalf("expected 3 pages, got %d: %q", len(pages), pages)
+	}
+	if !strings.HasPrefix(pages[0], "# Le Titre") {
+		t.Errorf("expected the first page to start with the title, got %q", pages[0][:20])
+	}
+	if !strings.HasPrefix(pages[1], "## Chapitre 1") {
+		t.Errorf("expected the second page to start at chapter 1, got %q", pages[1][:20])
+	}
+
+	total := 0
+	for i, page := range pages {
+		words := wordCount(page)
+		if words > 130 {
+			t.Errorf("page %d has %d words", i, words)
+		}
+		total += words
+	}
+	if total != wordCount(story) {
+		t.Errorf("expected all %d words to be kept, got %d", wordCount(story), total)
+	}
+}
+
+func TestSplitStoryPagesWithoutHeaders(t *testing.T) {
+	story := strings.Join([]string{paragraph(50), paragraph(50), paragraph(50)}, "\n\n")
+
+	pages := splitStoryPages(story, 100)
+	if len(pages) != 2 {
+		t.Fatalf("expected 2 pages, got %d", len(pages))
+	}
+	if len(splitStoryPages("", 100)) != 0 {
+		t.Error("expected no pages for an empty story")
+	}
+}
+
+func TestStoryContext(t *testing.T) {
+	context := contentstore.StoryContext("# Le Titre\n\nIl était **une fois**.")
+	if strings.Contains(context, "#") || strings.Contains(context, "*") {
+		t.Errorf("expected markdown to be stripped, got %q", context)
+	}
+	if !strings.Contains(context, "Il était une fois.") {
+		t.Errorf("expected the story text, got %q", context)
+	}
+}
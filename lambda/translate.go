@@ -8,19 +8,18 @@ import (
 	"net/http"
 	"io"
 	"log"
-	"strings"
 
 	"fmt"
+
+	"squeak-shared/dictionary"
+	"squeak-shared/stripmd"
+	"squeak-shared/tokenize"
 )
 
 var GCP_API_BATCH_SIZE = 128
 
-type StoryDictionary struct {
-	Translations struct {
-		Words map[string]string `json:"words"`
-		Sentences map[string]string `json:"sentences"`
-	} `json:"translations"`
-}
+// the dictionary stored with each article, see squeak-shared/dictionary
+type StoryDictionary = dictionary.Dictionary
 
 type TranslateResponse struct {
 	Data struct {
@@ -30,35 +29,34 @@ type TranslateResponse struct {
 	} `json:"data"`
 }
 
-func getWordsAndSentences(story string) ([]string, []string) {
-	words := strings.Split(story, " ")
-	sentences := strings.Split(story, ".")
+// returns the unique words and sentences of a markdown story, in order of appearance
+func getWordsAndSentences(story string, language string) ([]string, []string) {
+	text := stripmd.Strip(story)
+	tokenizer := tokenize.For(language)
+	words := tokenize.Unique(tokenizer.Words(text))
+	sentences := tokenize.Unique(tokenizer.Sentences(text))
 	return words, sentences
 }
 
-// limited to languages using , and . and space as separators, so this needs to be modified for e.g Chinese
+// returns the sentences of a markdown story in order, repeats included. This is
+// what the Sentence of a dictionary token indexes.
+func getSentences(story string, language string) []string {
+	return tokenize.For(language).Sentences(stripmd.Strip(story))
+}
+
+// source should already be tokenized and unique, see getWordsAndSentences
 func batchTranslate(source []string, language string) (map[string]string, error) {
 	dict := make(map[string]string)
 
 	googleAPIKey := os.Getenv("GOOGLE_API_KEY")
 	if googleAPIKey == "" { return dict, errors.New("ERR: GOOGLE_API_KEY environment variable not set") }
 
-	// o(n*m)???
-	var new_source []string
-	for _, word := range source {
-		trimmedWord := strings.Trim(word, " .\n,#")
-		splitWords := strings.Split(trimmedWord, "\n")
-		for _, splitWord := range splitWords {
-			new_source = append(new_source, strings.Trim(splitWord, " .\n,#"))
-		}
-	}
-
 	pointer := 0
-	for (pointer < len(new_source)) {
-		end_pointer := min(pointer + GCP_API_BATCH_SIZE, len(new_source))
+	for (pointer < len(source)) {
+		end_pointer := min(pointer + GCP_API_BATCH_SIZE, len(source))
 
 		translatePayload := map[string]interface{}{
-			"q": new_source[pointer:end_pointer],
+			"q": source[pointer:end_pointer],
 			"source": language,
 			"target": "en",
 			"format": "text",
@@ -88,7 +86,7 @@ func batchTranslate(source []string, language string) (map[string]string, error)
 
 		if len(result.Data.Translations) > 0 {
 			for i := range len(result.Data.Translations) {
-				dict[new_source[pointer + i]] = result.Data.Translations[i].TranslatedText
+				dict[source[pointer + i]] = result.Data.Translations[i].TranslatedText
 			}
 		} else {
 			log.Println("No translations found in the response")
@@ -101,7 +99,7 @@ func batchTranslate(source []string, language string) (map[string]string, error)
 }
 
 func generateTranslations(words []string, sentences []string, language string) (StoryDictionary, error) {
-	dict := StoryDictionary{}
+	dict := dictionary.New()
 	dict.Translations.Words, _ = batchTranslate(words, language)
 	dict.Translations.Sentences, _ = batchTranslate(sentences, language)
 	return dict, nil
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGetWordsAndSentences(t *testing.T) {
+	story := "# L'actualité\n\nLe **président** parle. Le président part !\n"
+
+	words, sentences := getWordsAndSentences(story, "French")
+
+	wantWords := []string{"L'", "actualité", "Le", "président", "parle", "part"}
+	if !reflect.DeepEqual(words, wantWords) {
+		t.Errorf("words: got %q, want %q", words, wantWords)
+	}
+	wantSentences := []string{"L'actualité", "Le président parle.", "Le président part !"}
+	if !reflect.DeepEqual(sentences, wantSentences) {
+		t.Errorf("sentences: got %q, want %q", sentences, wantSentences)
+	}
+}
+
+func TestGetSentencesKeepsRepeats(t *testing.T) {
+	story := "Il pleut. Le président parle. Il pleut.\n"
+
+	want := []string{"Il pleut.", "Le président parle.", "Il pleut."}
+	if sentences := getSentences(story, "French"); !reflect.DeepEqual(sentences, want) {
+		t.Errorf("got %q, want %q", sentences, want)
+	}
+}
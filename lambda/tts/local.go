@@ -0,0 +1,71 @@
+package tts
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+
+	"story-gen-lambda/elevenlabs"
+)
+
+const (
+	LOCAL_SECONDS_PER_CHARACTER = 0.06
+	LOCAL_SAMPLE_RATE           = 8000
+)
+
+// Local is an offline stand-in for ElevenLabs. It returns silent audio that
+// is as long as the text would take to read, with evenly spaced character
+// timings, so the audiobook pipeline and reader can run without an API key.
+type Local struct{}
+
+func NewLocal() *Local {
+	return &Local{}
+}
+
+func (l *Local) Tier() string {
+	return TIER_BASIC
+}
+
+func (l *Local) Synthesize(ctx context.Context, text string, language string) (*elevenlabs.ElevenLabsResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	alignment := elevenlabs.AlignmentData{
+		Characters:                 []string{},
+		CharacterStartTimesSeconds: []float64{},
+		CharacterEndTimesSeconds:   []float64{},
+	}
+	for i, r := range []rune(text) {
+		alignment.Characters = append(alignment.Characters, string(r))
+		alignment.CharacterStartTimesSeconds = append(alignment.CharacterStartTimesSeconds, float64(i)*LOCAL_SECONDS_PER_CHARACTER)
+		alignment.CharacterEndTimesSeconds = append(alignment.CharacterEndTimesSeconds, float64(i+1)*LOCAL_SECONDS_PER_CHARACTER)
+	}
+
+	seconds := float64(len(alignment.Characters)) * LOCAL_SECONDS_PER_CHARACTER
+	return &elevenlabs.ElevenLabsResponse{
+		AudioBase64:         base64.StdEncoding.EncodeToString(silentWAV(int(seconds * LOCAL_SAMPLE_RATE))),
+		Alignment:           alignment,
+		NormalizedAlignment: alignment,
+	}, nil
+}
+
+// silentWAV is an 8-bit mono PCM WAV file of the given number of samples.
+func silentWAV(samples int) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+samples))
+	buf.WriteString("WAVEfmt ")
+	binary.Write(&buf, binary.LittleEndian, uint32(16))                // fmt chunk size
+	binary.Write(&buf, binary.LittleEndian, uint16(1))                 // PCM
+	binary.Write(&buf, binary.LittleEndian, uint16(1))                 // mono
+	binary.Write(&buf, binary.LittleEndian, uint32(LOCAL_SAMPLE_RATE)) // sample rate
+	binary.Write(&buf, binary.LittleEndian, uint32(LOCAL_SAMPLE_RATE)) // byte rate
+	binary.Write(&buf, binary.LittleEndian, uint16(1))                 // block align
+	binary.Write(&buf, binary.LittleEndian, uint16(8))                 // bits per sample
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(samples))
+	buf.Write(bytes.Repeat([]byte{128}, samples)) // 8-bit PCM silence is the midpoint
+	return buf.Bytes()
+}
@@ -0,0 +1,32 @@
+package tts
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+)
+
+func TestLocalSynthesize(t *testing.T) {
+	response, err := NewLocal().Synthesize(context.Background(), "Année", "French")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(response.Alignment.Characters) != 5 || response.Alignment.Characters[1] != "n" {
+		t.Errorf("unexpected characters: %q", response.Alignment.Characters)
+	}
+	if end := response.Alignment.CharacterEndTimesSeconds[4]; end != 5*LOCAL_SECONDS_PER_CHARACTER {
+		t.Errorf("unexpected end time: %v", end)
+	}
+
+	audio, err := base64.StdEncoding.DecodeString(response.AudioBase64)
+	if err != nil {
+		t.Fatalf("invalid base64: %v", err)
+	}
+	if string(audio[:4]) != "RIFF" || string(audio[8:12]) != "WAVE" {
+		t.Errorf("expected a WAV file, got header %q", audio[:12])
+	}
+	if samples := len(audio) - 44; samples != int(5*LOCAL_SECONDS_PER_CHARACTER*LOCAL_SAMPLE_RATE) {
+		t.Errorf("unexpected number of samples: %d", samples)
+	}
+}
@@ -0,0 +1,67 @@
+// Package tts turns audiobook page text into audio with per-character timing.
+package tts
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"story-gen-lambda/elevenlabs"
+)
+
+const (
+	ProviderElevenLabs = "elevenlabs"
+	ProviderLocal      = "local"
+)
+
+// Audiobook tiers as stored in audiobooks.tier, they decide which plan feature
+// a listen is counted against.
+const (
+	TIER_BASIC   = "BASIC"
+	TIER_PREMIUM = "PREMIUM"
+)
+
+type Synthesizer interface {
+	Synthesize(ctx context.Context, text string, language string) (*elevenlabs.ElevenLabsResponse, error)
+	Tier() string
+}
+
+// NewFromEnv builds the synthesizer selected by TTS_PROVIDER (default elevenlabs).
+func NewFromEnv() (Synthesizer, error) {
+	switch provider := os.Getenv("TTS_PROVIDER"); provider {
+	case "", ProviderElevenLabs:
+		return NewElevenLabs(os.Getenv("ELEVENLABS_API_KEY"))
+	case ProviderLocal:
+		return NewLocal(), nil
+	default:
+		return nil, fmt.Errorf("unknown TTS_PROVIDER: %s", provider)
+	}
+}
+
+type ElevenLabs struct {
+	apiKey string
+}
+
+func NewElevenLabs(apiKey string) (*ElevenLabs, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("ELEVENLABS_API_KEY environment variable not set")
+	}
+	return &ElevenLabs{apiKey: apiKey}, nil
+}
+
+func (e *ElevenLabs) Tier() string {
+	return TIER_PREMIUM
+}
+
+func (e *ElevenLabs) Synthesize(ctx context.Context, text string, language string) (*elevenlabs.ElevenLabsResponse, error) {
+	var voiceId string
+	switch language {
+	case "French":
+		voiceId = elevenlabs.ELEVENLABS_FRENCH_VOICE_ID
+	case "Spanish":
+		voiceId = elevenlabs.ELEVENLABS_SPANISH_VOICE_ID
+	default:
+		return nil, fmt.Errorf("unsupported language for audiobook: %s", language)
+	}
+	return elevenlabs.ElevenLabsTTSWithTiming(text, voiceId, e.apiKey)
+}
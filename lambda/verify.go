@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+)
+
+// MAX_READABILITY_ATTEMPTS is how many times content is generated before it
+// is rejected for being out of its CEFR band.
+const MAX_READABILITY_ATTEMPTS = 3
+
+// generateReadable calls generate until the content passes the readability
+// check. An enforcing checker rejects content that still fails after
+// MAX_READABILITY_ATTEMPTS with a permanent error.
+func (w *worker) generateReadable(contentType string, language string, CEFRLevel string, generate func() (string, error)) (string, error) {
+	if w.readability == nil {
+		return generate()
+	}
+
+	for attempt := 1; ; attempt++ {
+		text, err := generate()
+		if err != nil {
+			return "", err
+		}
+		metrics := w.readability.Verify(text, language, CEFRLevel, contentType)
+		if metrics.Passed || !w.readability.Enforce() {
+			return text, nil
+		}
+
+		problems := strings.Join(metrics.Problems, "; ")
+		if attempt == MAX_READABILITY_ATTEMPTS {
+			return "", permanent(fmt.Errorf("%s %s %s is out of band after %d attempts: %s", language, CEFRLevel, contentType, attempt, problems))
+		}
+		log.Printf("%s %s %s attempt %d is out of band, regenerating: %s", language, CEFRLevel, contentType, attempt, problems)
+	}
+}
+
+// recordReadability stores the metrics of published content. Metrics are
+// informational, so failing to store them does not fail the job.
+func (w *worker) recordReadability(contentType string, id int, text string, language string, CEFRLevel string) {
+	if w.readability == nil {
+		return
+	}
+	metrics := w.readability.Verify(text, language, CEFRLevel, contentType)
+	if err := w.db.SetReadability(contentType, id, metrics); err != nil {
+		log.Printf("Failed to record readability of %s %d: %v", contentType, id, err)
+	}
+}
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+
+	"story-gen-lambda/generator"
+	"story-gen-lambda/readability"
+
+	"squeak-shared/textgen"
+)
+
+const a1Request = `{"language":"French","cefrLevel":"A1","subject":"Travel","contentType":"News"}`
+
+const a1Article = "# Le chat de Marie\n\nMarie a un petit chat. Le chat est noir et blanc. Il aime dormir sur le lit. " +
+	"Le matin, il mange du pain. Marie joue avec son chat dans le jardin. Elle est très contente. " +
+	"Le soir, ils regardent la télévision ensemble. Le chat reste sur la table. Marie aime beaucoup son ami. " +
+	"Ils sont heureux dans la petite maison."
+
+const tooShort = "# Bonjour\n\nBonjour tout le monde."
+
+// scriptedWorker returns a worker whose LLM replies with responses in order,
+// repeating the last one.
+func scriptedWorker(t *testing.T, db *fakeDB, checker *readability.Checker, responses ...string) (*worker, *textgen.Fake) {
+	w, _ := newTestWorker(t, db)
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		i := min(len(llm.Calls()), len(responses)) - 1
+		return responses[i], nil
+	}
+	w.generator = generator.NewClient(llm)
+	w.readability = checker
+	return w, llm
+}
+
+func TestOutOfBandContentIsRegenerated(t *testing.T) {
+	db := newFakeDB()
+	w, llm := scriptedWorker(t, db, readability.New(true), tooShort, a1Article)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(llm.Calls()) != 2 {
+		t.Errorf("expected one regeneration, got %d calls", len(llm.Calls()))
+	}
+	metrics, ok := db.readability["News/1"]
+	if !ok || !metrics.Passed || metrics.Words != 64 {
+		t.Errorf("expected the passing metrics to be recorded, got %+v", db.readability)
+	}
+}
+
+func TestOutOfBandContentIsRejected(t *testing.T) {
+	db := newFakeDB()
+	w, llm := scriptedWorker(t, db, readability.New(true), tooShort)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("expected a rejection not to be retried, got %v", ids)
+	}
+	if len(llm.Calls()) != MAX_READABILITY_ATTEMPTS {
+		t.Errorf("expected %d attempts, got %d", MAX_READABILITY_ATTEMPTS, len(llm.Calls()))
+	}
+	if len(db.news) != 0 {
+		t.Errorf("expected nothing to be published, got %v", db.news)
+	}
+	if len(db.failures) != 1 || db.failures[0].Reason != FAILURE_PERMANENT {
+		t.Errorf("expected a permanent failure, got %+v", db.failures)
+	}
+}
+
+func TestRecordModePublishesOutOfBandContent(t *testing.T) {
+	db := newFakeDB()
+	w, llm := scriptedWorker(t, db, readability.New(false), tooShort)
+
+	response := w.processBatch(context.Background(), events.SQSEvent{Records: []events.SQSMessage{message("a1", a1Request, 1)}})
+
+	if ids := failedIDs(response); len(ids) != 0 {
+		t.Fatalf("unexpected failures: %v", ids)
+	}
+	if len(llm.Calls()) != 1 || len(db.news) != 1 {
+		t.Errorf("expected the article to be published as is, got %d calls and %v", len(llm.Calls()), db.news)
+	}
+	if metrics := db.readability["News/1"]; metrics.Passed || len(metrics.Problems) == 0 {
+		t.Errorf("expected the failing metrics to be recorded, got %+v", metrics)
+	}
+}
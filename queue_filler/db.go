@@ -35,11 +35,84 @@ func (c *Client) Close() error {
 	return c.db.Close()
 }
 
-func (c *Client) InsertNewsSource(source *NewsSource) error {
-	_, err := c.db.Exec("INSERT INTO news_sources (topic, title, url, content, score) VALUES ($1, $2, $3, $4, $5)",
-		source.Topic, source.Title, source.URL, source.Content, source.Score)
+// UpsertNewsSource stores a source unless the topic already has one with the
+// same canonical URL or content, in which case that row is marked as seen
+// again. It returns the id of a newly stored source, or 0 for a duplicate.
+func (c *Client) UpsertNewsSource(source *NewsSource) (int, error) {
+	result, err := c.db.Exec(`
+		UPDATE news_sources
+		SET last_seen_at = NOW(), score = GREATEST(score, $4), quality = GREATEST(quality, $5)
+		WHERE topic = $1 AND (canonical_url = $2 OR content_hash = $3)`,
+		source.Topic, source.CanonicalURL, source.ContentHash, source.Score, source.Quality)
 	if err != nil {
-		return fmt.Errorf("failed to insert news source: %v", err)
+		return 0, fmt.Errorf("failed to update news source: %v", err)
 	}
-	return nil
+	if updated, err := result.RowsAffected(); err == nil && updated > 0 {
+		return 0, nil
+	}
+
+	var clusterID *int
+	if source.ClusterID != 0 {
+		clusterID = &source.ClusterID
+	}
+
+	var id int
+	err = c.db.QueryRow(`
+		INSERT INTO news_sources (topic, title, url, content, score, canonical_url, domain, content_hash, cluster_id, quality, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		ON CONFLICT DO NOTHING
+		RETURNING id`,
+		source.Topic, source.Title, source.URL, source.Content, source.Score,
+		source.CanonicalURL, source.Domain, source.ContentHash, clusterID, source.Quality).Scan(&id)
+	if err == sql.ErrNoRows {
+		// inserted concurrently by another run
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to insert news source: %v", err)
+	}
+	return id, nil
+}
+
+// GetRecentNewsSources returns the topic's sources from the last two days,
+// for clustering new ones against.
+func (c *Client) GetRecentNewsSources(topic string) ([]StoredSource, error) {
+	rows, err := c.db.Query(`
+		SELECT id, COALESCE(cluster_id, id), title, content
+		FROM news_sources
+		WHERE topic = $1
+		AND COALESCE(last_seen_at, created_at) >= NOW() - INTERVAL '48 hours'
+		ORDER BY id`, topic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get recent news sources: %v", err)
+	}
+	defer rows.Close()
+
+	sources := []StoredSource{}
+	for rows.Next() {
+		var source StoredSource
+		if err := rows.Scan(&source.ID, &source.ClusterID, &source.Title, &source.Content); err != nil {
+			return nil, fmt.Errorf("failed to scan news source: %v", err)
+		}
+		sources = append(sources, source)
+	}
+	return sources, rows.Err()
+}
+
+func (c *Client) GetDomainRules() ([]DomainRule, error) {
+	rows, err := c.db.Query("SELECT COALESCE(topic, ''), domain, policy FROM news_source_domains")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get news source domains: %v", err)
+	}
+	defer rows.Close()
+
+	rules := []DomainRule{}
+	for rows.Next() {
+		var rule DomainRule
+		if err := rows.Scan(&rule.Topic, &rule.Domain, &rule.Policy); err != nil {
+			return nil, fmt.Errorf("failed to scan news source domain: %v", err)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, rows.Err()
 }
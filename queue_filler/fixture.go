@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Fixture replays recorded Tavily responses from a directory, so the filler
+// can run without network access. The response for a topic is read from
+// <topic>.json (lowercase, spaces as underscores), falling back to
+// default.json.
+type Fixture struct {
+	dir string
+}
+
+func NewFixture(dir string) (*Fixture, error) {
+	if dir == "" {
+		return nil, errors.New("SEARCH_FIXTURE_DIR environment variable not set")
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open search fixtures: %v", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("search fixtures %s is not a directory", dir)
+	}
+	return &Fixture{dir: dir}, nil
+}
+
+func (f *Fixture) Name() string {
+	return SearchProviderFixture
+}
+
+func fixtureName(topic string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(topic)), " ", "_") + ".json"
+}
+
+func (f *Fixture) Search(ctx context.Context, topic string, query string, maxResults int) (TavilyResponse, error) {
+	var response TavilyResponse
+
+	data, err := os.ReadFile(filepath.Join(f.dir, fixtureName(topic)))
+	if errors.Is(err, os.ErrNotExist) {
+		data, err = os.ReadFile(filepath.Join(f.dir, "default.json"))
+	}
+	if errors.Is(err, os.ErrNotExist) {
+		return TavilyResponse{Query: query, Results: []Result{}}, nil
+	}
+	if err != nil {
+		return response, fmt.Errorf("failed to read search fixture for %s: %v", topic, err)
+	}
+
+	if err := json.Unmarshal(data, &response); err != nil {
+		return response, fmt.Errorf("failed to parse search fixture for %s: %v", topic, err)
+	}
+	if maxResults > 0 && len(response.Results) > maxResults {
+		response.Results = response.Results[:maxResults]
+	}
+	response.Query = query
+	return response, nil
+}
@@ -21,6 +21,7 @@ type GenerationRequest struct {
 	Subject         string `json:"subject"`
 	ContentType     string `json:"contentType"`
 	CreateAudiobook bool   `json:"createAudiobook"`
+	AudiobookTier   string `json:"audiobookTier,omitempty"`
 }
 
 func handler(ctx context.Context) error {
@@ -31,95 +32,82 @@ func handler(ctx context.Context) error {
 	}))
 	sqsSvc := sqs.New(sess)
 
-	languages := []string{"French", "Spanish"}
-	cefrLevels := []string{"A1", "A2", "B1", "B2", "C1", "C2"}
-	subjects := []string{
-		"Politics", "Business", "Technology",
-		"Finance", "Gaming", "Music", "Entertainment",
-		"NBA", "NFL", "Football",
+	store, err := NewClient()
+	if err != nil {
+		log.Printf("Failed to create store: %v\n", err)
+		return err
 	}
-	contentTypes := []string{"News"}
+	defer store.Close()
 
-	rng := rand.New(rand.NewSource(time.Now().UnixNano()))
-	audiobookSubject := subjects[rng.Intn(len(subjects))]
-	log.Printf("Selected subject for audiobook generation: %s\n", audiobookSubject)
+	cells, err := store.GetDueGenerationMatrix()
+	if err != nil {
+		log.Printf("Failed to get generation matrix: %v\n", err)
+		return err
+	}
+	log.Printf("%d generation matrix entries are due\n", len(cells))
 
-	audiobookLevels := map[string]bool{
-		"A2": true,
-		"B1": true,
-		"C1": true,
+	audiobookSubject := ""
+	if subjects := audiobookSubjects(cells); len(subjects) > 0 {
+		rng := rand.New(rand.NewSource(time.Now().UnixNano()))
+		audiobookSubject = subjects[rng.Intn(len(subjects))]
+		log.Printf("Selected subject for audiobook generation: %s\n", audiobookSubject)
 	}
 
-	store, err := NewClient()
+	search, err := NewSearchProviderFromEnv()
 	if err != nil {
-		log.Printf("Failed to create store: %v\n", err)
+		log.Printf("Failed to create search provider: %v\n", err)
 		return err
 	}
-	defer store.Close()
+	log.Printf("Searching for news sources with %s\n", search.Name())
+
+	rules, err := store.GetDomainRules()
+	if err != nil {
+		log.Printf("Failed to get news source domain rules: %v\n", err)
+		return err
+	}
+	policy := NewDomainPolicy(rules)
 
-	for _, subject := range subjects {
-		resp, err := webSearch("today "+subject+" news", 20)
+	for _, subject := range newsSubjects(cells) {
+		resp, err := search.Search(ctx, subject, "today "+subject+" news", 20)
 		if err != nil {
 			log.Printf("Failed to search for %s: %v\n", subject, err)
 			continue
 		}
 
-		for _, result := range resp.Results {
-			source := NewsSource{
-				Topic:   subject,
-				Title:   result.Title,
-				URL:     result.URL,
-				Content: result.Content,
-				Score:   int(result.Score * 100),
-			}
-			err := store.InsertNewsSource(&source)
-			if err != nil {
-				log.Printf("Failed to insert news source: %v\n", err)
-				continue
-			}
+		stored, err := storeNewsSources(store, subject, resp.Results, policy)
+		if err != nil {
+			log.Printf("Failed to store news sources for %s: %v\n", subject, err)
+			continue
 		}
+		log.Printf("Stored %d new sources out of %d results for %s\n", stored, len(resp.Results), subject)
 	}
 
 	var batch []*sqs.SendMessageBatchRequestEntry
 	batchSize := 0
 
-	for _, language := range languages {
-		for _, cefrLevel := range cefrLevels {
-			for _, subject := range subjects {
-				for _, contentType := range contentTypes {
-					message := GenerationRequest{
-						Language:        language,
-						CEFRLevel:       cefrLevel,
-						Subject:         subject,
-						ContentType:     contentType,
-						CreateAudiobook: subject == audiobookSubject && audiobookLevels[cefrLevel],
-					}
-
-					messageBody, err := json.Marshal(message)
-					if err != nil {
-						log.Printf("Failed to marshal generation request: %v\n", err)
-						return err
-					}
-
-					// add to batch
-					batch = append(batch, &sqs.SendMessageBatchRequestEntry{
-						Id:          aws.String(strconv.Itoa(len(batch))), // unique ID for the batch entry
-						MessageBody: aws.String(string(messageBody)),
-					})
-					batchSize++
-
-					// send once at max size 10
-					if batchSize == 10 {
-						err := sendBatch(sqsSvc, queueURL, batch)
-						if err != nil {
-							return err
-						}
-						// Reset the batch
-						batch = []*sqs.SendMessageBatchRequestEntry{}
-						batchSize = 0
-					}
-				}
+	for _, message := range buildGenerationRequests(cells, audiobookSubject) {
+		messageBody, err := json.Marshal(message)
+		if err != nil {
+			log.Printf("Failed to marshal generation request: %v\n", err)
+			return err
+		}
+
+		// add to batch
+		batch = append(batch, &sqs.SendMessageBatchRequestEntry{
+			Id:          aws.String(strconv.Itoa(len(batch))), // unique ID for the batch entry
+			MessageBody: aws.String(string(messageBody)),
+		})
+		batchSize++
+
+		// send once at max size 10
+		if batchSize == 10 {
+			err := sendBatch(sqsSvc, queueURL, batch)
+			if err != nil {
+				return err
 			}
+			// Reset the batch
+			batch = []*sqs.SendMessageBatchRequestEntry{}
+			batchSize = 0
 		}
 	}
 
@@ -135,6 +123,41 @@ func handler(ctx context.Context) error {
 	return nil
 }
 
+type newsSourceStore interface {
+	GetRecentNewsSources(topic string) ([]StoredSource, error)
+	UpsertNewsSource(source *NewsSource) (int, error)
+}
+
+// storeNewsSources stores the usable, previously unseen results for a topic,
+// grouping near-duplicates with recent sources. It returns how many were new.
+func storeNewsSources(store newsSourceStore, topic string, results []Result, policy *DomainPolicy) (int, error) {
+	recent, err := store.GetRecentNewsSources(topic)
+	if err != nil {
+		return 0, err
+	}
+	clusters := newClusterer(recent)
+
+	stored := 0
+	for _, source := range prepareNewsSources(topic, results, policy) {
+		source.ClusterID = clusters.find(source.Title, source.Content)
+		id, err := store.UpsertNewsSource(&source)
+		if err != nil {
+			log.Printf("Failed to insert news source: %v\n", err)
+			continue
+		}
+		if id == 0 {
+			continue
+		}
+		stored++
+		clusterID := source.ClusterID
+		if clusterID == 0 {
+			clusterID = id
+		}
+		clusters.add(clusterID, source.Title, source.Content)
+	}
+	return stored, nil
+}
+
 // sends a batch of messages to SQS
 func sendBatch(sqsSvc *sqs.SQS, queueURL string, batch []*sqs.SendMessageBatchRequestEntry) error {
 	_, err := sqsSvc.SendMessageBatch(&sqs.SendMessageBatchInput{
@@ -0,0 +1,94 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+)
+
+// MatrixCell is one row of generation_matrix.
+type MatrixCell struct {
+	Language      string
+	CEFRLevel     string
+	Subject       string
+	ContentType   string
+	AudiobookTier string // empty for no audiobook
+	FrequencyDays int
+}
+
+// GetDueGenerationMatrix returns the enabled cells that have not been
+// generated within their frequency, according to generation_jobs.
+func (c *Client) GetDueGenerationMatrix() ([]MatrixCell, error) {
+	rows, err := c.db.Query(`
+		SELECT m.language, m.cefr_level, m.subject, m.content_type, COALESCE(m.audiobook_tier, ''), m.frequency_days
+		FROM generation_matrix m
+		WHERE m.enabled
+		AND NOT EXISTS (
+			SELECT 1 FROM generation_jobs j
+			WHERE j.language = m.language
+			AND j.cefr_level = m.cefr_level
+			AND j.subject = m.subject
+			AND j.content_type = m.content_type
+			AND j.status = 'completed'
+			AND j.job_date > CURRENT_DATE - m.frequency_days
+		)
+		ORDER BY m.language, m.cefr_level, m.subject, m.content_type`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get generation matrix: %v", err)
+	}
+	defer rows.Close()
+
+	cells := []MatrixCell{}
+	for rows.Next() {
+		var cell MatrixCell
+		if err := rows.Scan(&cell.Language, &cell.CEFRLevel, &cell.Subject, &cell.ContentType, &cell.AudiobookTier, &cell.FrequencyDays); err != nil {
+			return nil, fmt.Errorf("failed to scan generation matrix: %v", err)
+		}
+		cells = append(cells, cell)
+	}
+
+	return cells, rows.Err()
+}
+
+// newsSubjects are the subjects that need fresh news sources.
+func newsSubjects(cells []MatrixCell) []string {
+	return uniqueSubjects(cells, func(cell MatrixCell) bool { return cell.ContentType == "News" })
+}
+
+// audiobookSubjects are the subjects with at least one cell that gets audiobooks.
+func audiobookSubjects(cells []MatrixCell) []string {
+	return uniqueSubjects(cells, func(cell MatrixCell) bool { return cell.AudiobookTier != "" })
+}
+
+func uniqueSubjects(cells []MatrixCell, include func(MatrixCell) bool) []string {
+	seen := make(map[string]bool)
+	subjects := []string{}
+	for _, cell := range cells {
+		if include(cell) && !seen[cell.Subject] {
+			seen[cell.Subject] = true
+			subjects = append(subjects, cell.Subject)
+		}
+	}
+	sort.Strings(subjects)
+	return subjects
+}
+
+// buildGenerationRequests turns due cells into requests. To keep TTS costs
+// down, audiobooks are only requested for one subject per run.
+func buildGenerationRequests(cells []MatrixCell, audiobookSubject string) []GenerationRequest {
+	requests := make([]GenerationRequest, 0, len(cells))
+	for _, cell := range cells {
+		createAudiobook := cell.AudiobookTier != "" && cell.Subject == audiobookSubject
+		request := GenerationRequest{
+			Language:        cell.Language,
+			CEFRLevel:       cell.CEFRLevel,
+			Subject:         cell.Subject,
+			ContentType:     cell.ContentType,
+			CreateAudiobook: createAudiobook,
+		}
+		if createAudiobook {
+			request.AudiobookTier = cell.AudiobookTier
+		}
+		requests = append(requests, request)
+	}
+	return requests
+}
@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestBuildGenerationRequests(t *testing.T) {
+	cells := []MatrixCell{
+		{Language: "French", CEFRLevel: "B1", Subject: "Politics", ContentType: "News", AudiobookTier: "PREMIUM", FrequencyDays: 1},
+		{Language: "French", CEFRLevel: "A1", Subject: "Politics", ContentType: "News", FrequencyDays: 1},
+		{Language: "Spanish", CEFRLevel: "B1", Subject: "Music", ContentType: "Story", AudiobookTier: "BASIC", FrequencyDays: 7},
+	}
+
+	if subjects := newsSubjects(cells); len(subjects) != 1 || subjects[0] != "Politics" {
+		t.Errorf("expected only Politics to need news sources, got %v", subjects)
+	}
+	if subjects := audiobookSubjects(cells); len(subjects) != 2 || subjects[0] != "Music" {
+		t.Errorf("expected Music and Politics to have audiobooks, got %v", subjects)
+	}
+
+	requests := buildGenerationRequests(cells, "Politics")
+	if len(requests) != 3 {
+		t.Fatalf("expected a request per cell, got %d", len(requests))
+	}
+	if !requests[0].CreateAudiobook || requests[0].AudiobookTier != "PREMIUM" {
+		t.Errorf("expected a PREMIUM audiobook for the first cell, got %+v", requests[0])
+	}
+	if requests[1].CreateAudiobook || requests[1].AudiobookTier != "" {
+		t.Errorf("expected no audiobook for a cell without a tier, got %+v", requests[1])
+	}
+	if requests[2].CreateAudiobook || requests[2].ContentType != "Story" {
+		t.Errorf("expected only the selected subject to get audiobooks, got %+v", requests[2])
+	}
+}
@@ -0,0 +1,237 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// feeds older than this are not today's news
+const MAX_FEED_ITEM_AGE = 24 * time.Hour
+
+// Feeds reads RSS 2.0 and Atom feeds configured per topic. The config is a
+// JSON object from topic to feed URLs; feeds under "*" are read for every
+// topic, e.g. {"Politics": ["https://example.com/politics.rss"], "*": [...]}.
+type Feeds struct {
+	feeds      map[string][]string
+	httpClient *http.Client
+	now        func() time.Time
+}
+
+func LoadFeeds(path string) (*Feeds, error) {
+	if path == "" {
+		return nil, errors.New("SEARCH_FEEDS_PATH environment variable not set")
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read feeds: %v", err)
+	}
+	var feeds map[string][]string
+	if err := json.Unmarshal(data, &feeds); err != nil {
+		return nil, fmt.Errorf("failed to parse feeds: %v", err)
+	}
+	return NewFeeds(feeds), nil
+}
+
+func NewFeeds(feeds map[string][]string) *Feeds {
+	return &Feeds{
+		feeds:      feeds,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		now:        time.Now,
+	}
+}
+
+func (f *Feeds) Name() string {
+	return SearchProviderRSS
+}
+
+// Search reads every feed for the topic and returns the newest items first.
+// Feeds have no relevance score, so the score is based on age instead. Items
+// are returned as summaries, as most feeds only carry a teaser of the article.
+func (f *Feeds) Search(ctx context.Context, topic string, query string, maxResults int) (TavilyResponse, error) {
+	urls := append(append([]string{}, f.feeds[topic]...), f.feeds["*"]...)
+
+	items := []feedItem{}
+	for _, url := range urls {
+		feedItems, err := f.fetch(ctx, url)
+		if err != nil {
+			log.Printf("Failed to read feed %s: %v", url, err)
+			continue
+		}
+		items = append(items, feedItems...)
+	}
+	if len(urls) > 0 && len(items) == 0 {
+		return TavilyResponse{}, fmt.Errorf("no items in %d feeds for %s", len(urls), topic)
+	}
+
+	now := f.now()
+	fresh := []feedItem{}
+	for _, item := range items {
+		if item.published.IsZero() || now.Sub(item.published) <= MAX_FEED_ITEM_AGE {
+			fresh = append(fresh, item)
+		}
+	}
+	sort.SliceStable(fresh, func(i, j int) bool { return fresh[i].published.After(fresh[j].published) })
+	if maxResults > 0 && len(fresh) > maxResults {
+		fresh = fresh[:maxResults]
+	}
+
+	response := TavilyResponse{Query: query, Results: []Result{}}
+	for _, item := range fresh {
+		score := 0.5
+		if !item.published.IsZero() {
+			score = 1 - float64(max(now.Sub(item.published), 0))/float64(MAX_FEED_ITEM_AGE)/2
+		}
+		response.Results = append(response.Results, Result{
+			Title:   item.title,
+			URL:     item.link,
+			Content: item.content,
+			Score:   score,
+			Summary: true,
+		})
+	}
+	return response, nil
+}
+
+func (f *Feeds) fetch(ctx context.Context, url string) ([]feedItem, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("feed returned status %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	return parseFeed(body)
+}
+
+type feedItem struct {
+	title     string
+	link      string
+	content   string
+	published time.Time
+}
+
+type rssFeed struct {
+	Items []struct {
+		Title       string `xml:"title"`
+		Link        string `xml:"link"`
+		Description string `xml:"description"`
+		Encoded     string `xml:"http://purl.org/rss/1.0/modules/content/ encoded"`
+		PubDate     string `xml:"pubDate"`
+	} `xml:"channel>item"`
+}
+
+type atomFeed struct {
+	Entries []struct {
+		Title string `xml:"title"`
+		Links []struct {
+			Href string `xml:"href,attr"`
+			Rel  string `xml:"rel,attr"`
+		} `xml:"link"`
+		Summary   string `xml:"summary"`
+		Content   string `xml:"content"`
+		Published string `xml:"published"`
+		Updated   string `xml:"updated"`
+	} `xml:"entry"`
+}
+
+func parseFeed(data []byte) ([]feedItem, error) {
+	var root struct {
+		XMLName xml.Name
+	}
+	if err := xml.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("failed to parse feed: %v", err)
+	}
+
+	items := []feedItem{}
+	switch root.XMLName.Local {
+	case "rss":
+		var feed rssFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse rss feed: %v", err)
+		}
+		for _, item := range feed.Items {
+			content := item.Encoded
+			if content == "" {
+				content = item.Description
+			}
+			items = append(items, feedItem{
+				title:     strings.TrimSpace(item.Title),
+				link:      strings.TrimSpace(item.Link),
+				content:   htmlToText(content),
+				published: parseFeedTime(item.PubDate),
+			})
+		}
+	case "feed":
+		var feed atomFeed
+		if err := xml.Unmarshal(data, &feed); err != nil {
+			return nil, fmt.Errorf("failed to parse atom feed: %v", err)
+		}
+		for _, entry := range feed.Entries {
+			link := ""
+			for _, l := range entry.Links {
+				if l.Rel == "" || l.Rel == "alternate" {
+					link = l.Href
+					break
+				}
+			}
+			content := entry.Content
+			if content == "" {
+				content = entry.Summary
+			}
+			published := entry.Published
+			if published == "" {
+				published = entry.Updated
+			}
+			items = append(items, feedItem{
+				title:     strings.TrimSpace(entry.Title),
+				link:      strings.TrimSpace(link),
+				content:   htmlToText(content),
+				published: parseFeedTime(published),
+			})
+		}
+	default:
+		return nil, fmt.Errorf("unknown feed format: %s", root.XMLName.Local)
+	}
+	return items, nil
+}
+
+var feedTimeLayouts = []string{time.RFC1123Z, time.RFC1123, time.RFC3339, "Mon, 2 Jan 2006 15:04:05 -0700", "Mon, 2 Jan 2006 15:04:05 MST"}
+
+// parseFeedTime returns the zero time if the date is missing or unreadable.
+func parseFeedTime(value string) time.Time {
+	value = strings.TrimSpace(value)
+	for _, layout := range feedTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t
+		}
+	}
+	return time.Time{}
+}
+
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+func htmlToText(content string) string {
+	text := html.UnescapeString(htmlTag.ReplaceAllString(content, " "))
+	return strings.Join(strings.Fields(text), " ")
+}
@@ -2,21 +2,56 @@ package main
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"time"
 )
 
+const (
+	SearchProviderTavily  = "tavily"
+	SearchProviderRSS     = "rss"
+	SearchProviderFixture = "fixture"
+)
+
+// SearchProvider finds today's news for a topic. query is the free text
+// search used by web search providers; feed based providers use topic.
+type SearchProvider interface {
+	Search(ctx context.Context, topic string, query string, maxResults int) (TavilyResponse, error)
+	Name() string
+}
+
+// NewSearchProviderFromEnv builds the provider selected by SEARCH_PROVIDER
+// (default tavily).
+func NewSearchProviderFromEnv() (SearchProvider, error) {
+	switch provider := os.Getenv("SEARCH_PROVIDER"); provider {
+	case "", SearchProviderTavily:
+		return NewTavily(os.Getenv("TAVILY_API_KEY"))
+	case SearchProviderRSS:
+		return LoadFeeds(os.Getenv("SEARCH_FEEDS_PATH"))
+	case SearchProviderFixture:
+		return NewFixture(os.Getenv("SEARCH_FIXTURE_DIR"))
+	default:
+		return nil, fmt.Errorf("unknown SEARCH_PROVIDER: %s", provider)
+	}
+}
+
 type Result struct {
 	Title   string  `json:"title"`
 	URL     string  `json:"url"`
 	Content string  `json:"content"`
 	Score   float64 `json:"score"`
+	// Summary is set when Content is the publisher's summary of the article
+	// rather than an extract of it, as for feed items
+	Summary bool `json:"-"`
 }
 
+// TavilyResponse is what every SearchProvider returns, whatever the source.
 type TavilyResponse struct {
 	Query   string   `json:"query"`
 	Results []Result `json:"results"`
@@ -28,22 +63,46 @@ type NewsSource struct {
 	URL string `json:"url"`
 	Content string `json:"content"`
 	Score int `json:"score"`
+	CanonicalURL string `json:"canonicalUrl"`
+	Domain string `json:"domain"`
+	ContentHash string `json:"contentHash"`
+	ClusterID int `json:"clusterId"` // 0 when the source starts a new cluster
+	Quality int `json:"quality"`
 }
 
-// EXAMPLE: webSearch("today investing news", 20)
-func webSearch(query string, info_depth int) (TavilyResponse, error) {
-	tavilyAPIKey := os.Getenv("TAVILY_API_KEY")
-	emptyResponse := TavilyResponse{}
+const TAVILY_SEARCH_URL = "https://api.tavily.com/search"
 
-	if tavilyAPIKey == "" {
-		return emptyResponse, errors.New("ERR: TAVILY_API_KEY environment variable not set")
+// Tavily searches the web with https://tavily.com.
+type Tavily struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+func NewTavily(apiKey string) (*Tavily, error) {
+	if apiKey == "" {
+		return nil, errors.New("ERR: TAVILY_API_KEY environment variable not set")
 	}
+	return &Tavily{
+		apiKey:     apiKey,
+		url:        TAVILY_SEARCH_URL,
+		httpClient: &http.Client{Timeout: time.Minute},
+	}, nil
+}
+
+func (t *Tavily) Name() string {
+	return SearchProviderTavily
+}
+
+// EXAMPLE: Search(ctx, "Investing", "today investing news", 20)
+func (t *Tavily) Search(ctx context.Context, topic string, query string, maxResults int) (TavilyResponse, error) {
+	emptyResponse := TavilyResponse{}
 
 	tavilyPayload := map[string]interface{}{
 		"query":       query,
 		"topic":       "general",
 		"time_range":  "d",
-		"max_results": info_depth,
+		"max_results": maxResults,
 	}
 
 	log.Println("Marshalling Tavily Payload")
@@ -51,17 +110,16 @@ func webSearch(query string, info_depth int) (TavilyResponse, error) {
 	if err != nil {
 		return emptyResponse, err
 	}
-	req, err := http.NewRequest("POST", "https://api.tavily.com/search", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", t.url, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return emptyResponse, err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+tavilyAPIKey)
+	req.Header.Set("Authorization", "Bearer "+t.apiKey)
 
 	log.Println("Comitting REQ")
-	client := &http.Client{}
-	resp, err := client.Do(req)
+	resp, err := t.httpClient.Do(req)
 	if err != nil {
 		return emptyResponse, err
 	}
@@ -72,6 +130,9 @@ func webSearch(query string, info_depth int) (TavilyResponse, error) {
 	if err != nil {
 		return emptyResponse, err
 	}
+	if resp.StatusCode != http.StatusOK {
+		return emptyResponse, fmt.Errorf("tavily returned status %d: %s", resp.StatusCode, string(body))
+	}
 
 	log.Printf("Tavily API Response: %s", string(body))
 
@@ -0,0 +1,154 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestTavilySearch(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("missing bearer token")
+		}
+		json.NewDecoder(r.Body).Decode(&payload)
+		w.Write([]byte(`{"query":"today Politics news","results":[{"title":"T","url":"https://a.example","content":"C","score":0.5}]}`))
+	}))
+	defer server.Close()
+
+	tavily, err := NewTavily("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tavily.url = server.URL
+
+	response, err := tavily.Search(context.Background(), "Politics", "today Politics news", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Score != 0.5 {
+		t.Errorf("unexpected response: %+v", response)
+	}
+	if payload["query"] != "today Politics news" || payload["max_results"] != float64(5) {
+		t.Errorf("unexpected payload: %v", payload)
+	}
+
+	if _, err := NewTavily(""); err == nil {
+		t.Errorf("expected an error without an api key")
+	}
+}
+
+func TestFixtureSearch(t *testing.T) {
+	fixture, err := NewFixture("testdata/search")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := fixture.Search(context.Background(), "Politics", "today Politics news", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Results) != 1 || response.Results[0].Title != "Parliament approves the new budget after a long debate" {
+		t.Errorf("expected the first recorded politics result, got %+v", response.Results)
+	}
+
+	response, err = fixture.Search(context.Background(), "Science Fiction", "today Science Fiction news", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Results) != 1 || response.Query != "today Science Fiction news" {
+		t.Errorf("expected the default fixture, got %+v", response)
+	}
+
+	if _, err := NewFixture("testdata/missing"); err == nil {
+		t.Errorf("expected an error for a missing fixture directory")
+	}
+}
+
+const rssFixture = `<?xml version="1.0"?>
+<rss version="2.0" xmlns:content="http://purl.org/rss/1.0/modules/content/">
+<channel>
+  <item>
+    <title>Old news</title>
+    <link>https://rss.example/old</link>
+    <description>From last week.</description>
+    <pubDate>Fri, 24 Jan 2025 08:00:00 +0000</pubDate>
+  </item>
+  <item>
+    <title>Budget approved</title>
+    <link>https://rss.example/budget</link>
+    <description>Short teaser</description>
+    <content:encoded><![CDATA[<p>The budget was <b>approved</b> &amp; signed.</p>]]></content:encoded>
+    <pubDate>Fri, 31 Jan 2025 06:00:00 +0000</pubDate>
+  </item>
+</channel>
+</rss>`
+
+const atomFixture = `<?xml version="1.0" encoding="utf-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <entry>
+    <title>Election date set</title>
+    <link rel="alternate" href="https://atom.example/election"/>
+    <summary>The election will be in spring.</summary>
+    <updated>2025-01-31T10:00:00Z</updated>
+  </entry>
+</feed>`
+
+func TestFeedsSearch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/politics.rss":
+			w.Write([]byte(rssFixture))
+		case "/all.atom":
+			w.Write([]byte(atomFixture))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	feeds := NewFeeds(map[string][]string{
+		"Politics": {server.URL + "/politics.rss", server.URL + "/missing.rss"},
+		"*":        {server.URL + "/all.atom"},
+	})
+	feeds.now = func() time.Time { return time.Date(2025, 1, 31, 12, 0, 0, 0, time.UTC) }
+
+	response, err := feeds.Search(context.Background(), "Politics", "today Politics news", 20)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(response.Results) != 2 {
+		t.Fatalf("expected the two items from today, got %+v", response.Results)
+	}
+	election, budget := response.Results[0], response.Results[1]
+	if election.URL != "https://atom.example/election" || election.Content != "The election will be in spring." {
+		t.Errorf("unexpected atom result: %+v", election)
+	}
+	if !election.Summary || !budget.Summary {
+		t.Errorf("expected feed items to be summaries, got %+v", response.Results)
+	}
+	if budget.Content != "The budget was approved & signed." {
+		t.Errorf("expected the full content as text, got %q", budget.Content)
+	}
+	if election.Score <= budget.Score {
+		t.Errorf("expected newer items to score higher, got %v and %v", election.Score, budget.Score)
+	}
+}
+
+func TestSearchProviderFromEnv(t *testing.T) {
+	t.Setenv("SEARCH_PROVIDER", "fixture")
+	t.Setenv("SEARCH_FIXTURE_DIR", "testdata/search")
+	provider, err := NewSearchProviderFromEnv()
+	if err != nil || provider.Name() != SearchProviderFixture {
+		t.Errorf("expected the fixture provider, got %v, %v", provider, err)
+	}
+
+	t.Setenv("SEARCH_PROVIDER", "carrier-pigeon")
+	if _, err := NewSearchProviderFromEnv(); err == nil {
+		t.Errorf("expected an error for an unknown provider")
+	}
+}
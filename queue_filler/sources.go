@@ -0,0 +1,268 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	// results with less content than this are not worth a prompt slot
+	MIN_SOURCE_WORDS = 30
+	// summaries are a sentence or two by design, below this they are little
+	// more than a headline
+	MIN_SUMMARY_WORDS = 8
+	// word shingle overlap above which two articles are the same story
+	NEAR_DUPLICATE_SIMILARITY = 0.5
+	SHINGLE_SIZE              = 3
+)
+
+// query parameters that only track where a click came from
+var trackingParams = map[string]bool{
+	"fbclid": true, "gclid": true, "dclid": true, "msclkid": true,
+	"mc_cid": true, "mc_eid": true, "ref": true, "ref_src": true,
+	"cmpid": true, "ocid": true, "guccounter": true, "smid": true,
+}
+
+// canonicalURL normalizes a URL so the same article found through different
+// links compares equal: https, lowercase host without www., no fragment, no
+// tracking parameters, sorted query and no trailing slash or /amp suffix.
+func canonicalURL(raw string) (string, error) {
+	parsed, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse url %s: %v", raw, err)
+	}
+	if parsed.Host == "" {
+		return "", fmt.Errorf("url has no host: %s", raw)
+	}
+
+	host := strings.TrimPrefix(strings.ToLower(parsed.Hostname()), "www.")
+	if port := parsed.Port(); port != "" && port != "80" && port != "443" {
+		host += ":" + port
+	}
+
+	query := parsed.Query()
+	for key := range query {
+		if strings.HasPrefix(strings.ToLower(key), "utm_") || trackingParams[strings.ToLower(key)] {
+			query.Del(key)
+		}
+	}
+
+	path := strings.TrimSuffix(parsed.EscapedPath(), "/")
+	path = strings.TrimSuffix(path, "/amp")
+
+	canonical := "https://" + host + path
+	if encoded := query.Encode(); encoded != "" {
+		canonical += "?" + encoded
+	}
+	return canonical, nil
+}
+
+// urlDomain is the host of a canonical URL.
+func urlDomain(canonical string) string {
+	parsed, err := url.Parse(canonical)
+	if err != nil {
+		return ""
+	}
+	return parsed.Hostname()
+}
+
+// normalizeText lowercases text and keeps only letters and digits, separated
+// by single spaces.
+func normalizeText(text string) string {
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	return strings.Join(fields, " ")
+}
+
+// contentHash identifies identical content regardless of formatting.
+func contentHash(content string) string {
+	sum := sha256.Sum256([]byte(normalizeText(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+func shingles(text string) map[string]bool {
+	words := strings.Fields(normalizeText(text))
+	set := make(map[string]bool)
+	if len(words) < SHINGLE_SIZE {
+		if len(words) > 0 {
+			set[strings.Join(words, " ")] = true
+		}
+		return set
+	}
+	for i := 0; i+SHINGLE_SIZE <= len(words); i++ {
+		set[strings.Join(words[i:i+SHINGLE_SIZE], " ")] = true
+	}
+	return set
+}
+
+// similarity is the Jaccard similarity of two shingle sets.
+func similarity(a, b map[string]bool) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for shingle := range a {
+		if b[shingle] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+type DomainRule struct {
+	Topic  string // empty for every topic
+	Domain string
+	Policy string // "allow" or "deny"
+}
+
+// DomainPolicy applies news_source_domains rules. A rule for a domain also
+// covers its subdomains.
+type DomainPolicy struct {
+	allow map[string][]string // topic -> domains, "" for every topic
+	deny  map[string][]string
+}
+
+func NewDomainPolicy(rules []DomainRule) *DomainPolicy {
+	policy := &DomainPolicy{allow: map[string][]string{}, deny: map[string][]string{}}
+	for _, rule := range rules {
+		domain := strings.TrimPrefix(strings.ToLower(rule.Domain), "www.")
+		switch rule.Policy {
+		case "allow":
+			policy.allow[rule.Topic] = append(policy.allow[rule.Topic], domain)
+		case "deny":
+			policy.deny[rule.Topic] = append(policy.deny[rule.Topic], domain)
+		}
+	}
+	return policy
+}
+
+func matchesDomain(domain string, domains []string) bool {
+	for _, d := range domains {
+		if domain == d || strings.HasSuffix(domain, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allowed reports whether sources from domain may be stored for topic.
+// Denials win; a topic with allow rules only accepts those domains (or
+// domains allowed for every topic).
+func (p *DomainPolicy) Allowed(topic string, domain string) bool {
+	if matchesDomain(domain, p.deny[""]) || matchesDomain(domain, p.deny[topic]) {
+		return false
+	}
+	if len(p.allow[topic]) > 0 {
+		return p.Preferred(topic, domain)
+	}
+	return true
+}
+
+// Preferred reports whether domain is explicitly allowed for topic.
+func (p *DomainPolicy) Preferred(topic string, domain string) bool {
+	return matchesDomain(domain, p.allow[""]) || matchesDomain(domain, p.allow[topic])
+}
+
+// qualityScore rates a source from 0 to 100: mostly the search relevance,
+// plus how much usable content it has and whether its domain is allowlisted.
+// Freshness is left to the generation lambda, which knows when it reads it.
+func qualityScore(relevance float64, words int, preferred bool) int {
+	score := relevance * 60
+	score += 25 * float64(min(words, 300)) / 300
+	if preferred {
+		score += 15
+	}
+	return int(min(max(score, 0), 100))
+}
+
+// prepareNewsSources canonicalizes, filters and scores search results, drops
+// exact duplicates within the batch and orders them best first.
+func prepareNewsSources(topic string, results []Result, policy *DomainPolicy) []NewsSource {
+	seenURLs := make(map[string]bool)
+	seenHashes := make(map[string]bool)
+	sources := []NewsSource{}
+
+	for _, result := range results {
+		canonical, err := canonicalURL(result.URL)
+		if err != nil {
+			continue
+		}
+		domain := urlDomain(canonical)
+		if !policy.Allowed(topic, domain) {
+			continue
+		}
+		words := len(strings.Fields(result.Content))
+		if words < MIN_SOURCE_WORDS && (!result.Summary || words < MIN_SUMMARY_WORDS) {
+			continue
+		}
+		hash := contentHash(result.Content)
+		if seenURLs[canonical] || seenHashes[hash] {
+			continue
+		}
+		seenURLs[canonical] = true
+		seenHashes[hash] = true
+
+		sources = append(sources, NewsSource{
+			Topic:        topic,
+			Title:        result.Title,
+			URL:          result.URL,
+			Content:      result.Content,
+			Score:        int(result.Score * 100),
+			CanonicalURL: canonical,
+			Domain:       domain,
+			ContentHash:  hash,
+			Quality:      qualityScore(result.Score, words, policy.Preferred(topic, domain)),
+		})
+	}
+
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].Quality > sources[j].Quality })
+	return sources
+}
+
+// StoredSource is a recent news_sources row used for clustering.
+type StoredSource struct {
+	ID        int
+	ClusterID int // the id of the first source of the cluster
+	Title     string
+	Content   string
+}
+
+type clusterMember struct {
+	clusterID int
+	shingles  map[string]bool
+}
+
+// clusterer groups near-duplicate articles. New sources join the cluster of
+// the first similar source, or start their own.
+type clusterer struct {
+	members []clusterMember
+}
+
+func newClusterer(recent []StoredSource) *clusterer {
+	c := &clusterer{}
+	for _, source := range recent {
+		c.add(source.ClusterID, source.Title, source.Content)
+	}
+	return c
+}
+
+// find returns the cluster a source belongs to, or 0 if it is a new story.
+func (c *clusterer) find(title string, content string) int {
+	candidate := shingles(title + " " + content)
+	for _, member := range c.members {
+		if similarity(candidate, member.shingles) >= NEAR_DUPLICATE_SIMILARITY {
+			return member.clusterID
+		}
+	}
+	return 0
+}
+
+func (c *clusterer) add(clusterID int, title string, content string) {
+	c.members = append(c.members, clusterMember{clusterID: clusterID, shingles: shingles(title + " " + content)})
+}
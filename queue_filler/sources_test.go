@@ -0,0 +1,140 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCanonicalURL(t *testing.T) {
+	cases := map[string]string{
+		"http://www.Example.com/news/story/?utm_source=x&id=2&fbclid=abc#comments": "https://example.com/news/story?id=2",
+		"https://example.com/news/story/amp":                                       "https://example.com/news/story",
+		"https://example.com:8080/a?b=2&a=1":                                       "https://example.com:8080/a?a=1&b=2",
+	}
+	for raw, want := range cases {
+		got, err := canonicalURL(raw)
+		if err != nil {
+			t.Fatalf("canonicalURL(%q): %v", raw, err)
+		}
+		if got != want {
+			t.Errorf("canonicalURL(%q) = %q, want %q", raw, got, want)
+		}
+	}
+	if _, err := canonicalURL("not a url"); err == nil {
+		t.Errorf("expected an error for a url without a host")
+	}
+}
+
+func TestDomainPolicy(t *testing.T) {
+	policy := NewDomainPolicy([]DomainRule{
+		{Domain: "spam.example", Policy: "deny"},
+		{Topic: "NBA", Domain: "nba.com", Policy: "allow"},
+		{Topic: "NBA", Domain: "espn.com", Policy: "allow"},
+		{Topic: "NBA", Domain: "blog.espn.com", Policy: "deny"},
+	})
+
+	if policy.Allowed("Politics", "news.spam.example") {
+		t.Errorf("expected subdomains of a denied domain to be denied")
+	}
+	if !policy.Allowed("Politics", "lemonde.fr") {
+		t.Errorf("expected topics without allow rules to accept any domain")
+	}
+	if !policy.Allowed("NBA", "espn.com") || !policy.Allowed("NBA", "scores.nba.com") {
+		t.Errorf("expected allowlisted domains to be accepted")
+	}
+	if policy.Allowed("NBA", "blog.espn.com") || policy.Allowed("NBA", "lemonde.fr") {
+		t.Errorf("expected only allowlisted, non-denied domains for NBA")
+	}
+	if !policy.Preferred("NBA", "nba.com") || policy.Preferred("Politics", "nba.com") {
+		t.Errorf("expected nba.com to be preferred only for NBA")
+	}
+}
+
+func article(words string, n int) string {
+	return strings.TrimSpace(strings.Repeat(words+" ", n))
+}
+
+func TestPrepareNewsSourcesDedupesAndScores(t *testing.T) {
+	long := article("the senate passed the budget after a long night of debate", 5)
+	results := []Result{
+		{Title: "Budget passes", URL: "https://example.com/budget?utm_source=rss", Content: long, Score: 0.5},
+		{Title: "Budget passes", URL: "https://www.example.com/budget/", Content: long + " Updated.", Score: 0.6},
+		{Title: "Budget passes (copy)", URL: "https://mirror.example/budget", Content: strings.ToUpper(long), Score: 0.7},
+		{Title: "Too short", URL: "https://example.com/short", Content: "Just a teaser.", Score: 0.9},
+		{Title: "Short summary", URL: "https://example.com/summary", Content: "Just a teaser.", Score: 0.9, Summary: true},
+		{Title: "Not a summary", URL: "https://example.com/extract", Content: article("the vote was close", 3), Score: 0.9},
+		{Title: "Blocked", URL: "https://spam.example/budget", Content: article("totally different words about something else", 10), Score: 0.9},
+		{Title: "Bad url", URL: "::", Content: long, Score: 0.9},
+		{Title: "Other story", URL: "https://other.example/match", Content: article("the home team won the match in overtime", 6), Score: 0.9},
+	}
+	policy := NewDomainPolicy([]DomainRule{{Domain: "spam.example", Policy: "deny"}})
+
+	sources := prepareNewsSources("Politics", results, policy)
+	if len(sources) != 2 {
+		t.Fatalf("expected the budget story and the match, got %+v", sources)
+	}
+	if sources[0].Title != "Other story" || sources[0].Quality <= sources[1].Quality {
+		t.Errorf("expected sources ordered by quality, got %+v", sources)
+	}
+	if sources[1].CanonicalURL != "https://example.com/budget" || sources[1].Domain != "example.com" || sources[1].Score != 50 {
+		t.Errorf("unexpected budget source: %+v", sources[1])
+	}
+}
+
+func TestPrepareNewsSourcesKeepsSummaries(t *testing.T) {
+	summary := "The senate passed the budget after a long night of debate on Tuesday."
+	results := []Result{
+		{Title: "Budget passes", URL: "https://rss.example/budget", Content: summary, Score: 0.9, Summary: true},
+		{Title: "Budget passes", URL: "https://search.example/budget", Content: summary + " More.", Score: 0.9},
+	}
+
+	sources := prepareNewsSources("Politics", results, NewDomainPolicy(nil))
+	if len(sources) != 1 || sources[0].URL != "https://rss.example/budget" {
+		t.Fatalf("expected only the feed summary to be kept, got %+v", sources)
+	}
+}
+
+type fakeSourceStore struct {
+	recent []StoredSource
+	stored []NewsSource
+}
+
+func (f *fakeSourceStore) GetRecentNewsSources(topic string) ([]StoredSource, error) {
+	return f.recent, nil
+}
+
+func (f *fakeSourceStore) UpsertNewsSource(source *NewsSource) (int, error) {
+	for _, existing := range f.stored {
+		if existing.CanonicalURL == source.CanonicalURL || existing.ContentHash == source.ContentHash {
+			return 0, nil
+		}
+	}
+	f.stored = append(f.stored, *source)
+	return 100 + len(f.stored), nil
+}
+
+func TestStoreNewsSourcesClustersNearDuplicates(t *testing.T) {
+	wire := article("the central bank raised interest rates by a quarter point on tuesday citing inflation", 3)
+	store := &fakeSourceStore{recent: []StoredSource{
+		{ID: 7, ClusterID: 7, Title: "Rates rise", Content: wire},
+	}}
+	results := []Result{
+		{Title: "Rates rise", URL: "https://a.example/rates", Content: wire + " Markets fell.", Score: 0.8},
+		{Title: "New stadium", URL: "https://b.example/stadium", Content: article("the city approved plans for a new stadium near the river", 4), Score: 0.7},
+		{Title: "Stadium approved", URL: "https://c.example/stadium", Content: article("the city approved plans for a new stadium near the river", 4) + " More soon.", Score: 0.6},
+	}
+
+	stored, err := storeNewsSources(store, "Finance", results, NewDomainPolicy(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored != 3 {
+		t.Fatalf("expected 3 new sources, got %d", stored)
+	}
+	if store.stored[0].ClusterID != 7 {
+		t.Errorf("expected the wire story to join the recent cluster, got %d", store.stored[0].ClusterID)
+	}
+	if store.stored[1].ClusterID != 0 || store.stored[2].ClusterID != 102 {
+		t.Errorf("expected the second stadium story to join the first, got %d and %d", store.stored[1].ClusterID, store.stored[2].ClusterID)
+	}
+}
@@ -0,0 +1,80 @@
+package contentstore
+
+import (
+	"fmt"
+	"strings"
+
+	"squeak-shared/stripmd"
+)
+
+// Key layout shared by the generation lambda (writer) and the API (reader).
+// Changing any of these breaks access to content that is already stored.
+
+// NewsKey e.g. french/B1/Politics/News/B1_News_Politics_2025-01-31.json
+func NewsKey(language string, cefr string, subject string, contentType string, date string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s_%s_%s_%s.json",
+		strings.ToLower(language),
+		strings.ToUpper(cefr),
+		strings.Title(subject),
+		strings.Title(contentType),
+		strings.ToUpper(cefr),
+		strings.Title(contentType),
+		strings.Title(subject),
+		date,
+	)
+}
+
+// StoryPageKey e.g. french/B1/Politics/Story/12/page0.mdx, pages are zero-indexed.
+func StoryPageKey(language string, cefr string, subject string, id string, page int) string {
+	return fmt.Sprintf("%s/%s/%s/Story/%s/page%d.mdx",
+		strings.ToLower(language),
+		strings.ToUpper(cefr),
+		strings.Title(subject),
+		id,
+		page,
+	)
+}
+
+// StoryContextKey e.g. french/B1/Politics/Story/12/context.txt
+func StoryContextKey(language string, cefr string, subject string, id string) string {
+	return fmt.Sprintf("%s/%s/%s/Story/%s/context.txt",
+		strings.ToLower(language),
+		strings.ToUpper(cefr),
+		strings.Title(subject),
+		id,
+	)
+}
+
+// StoryContext is what is stored at StoryContextKey: the plain text of the
+// whole story, which QNA questions are generated and evaluated against.
+func StoryContext(text string) string {
+	return strings.TrimSpace(stripmd.Strip(text)) + "\n"
+}
+
+// AudiobookKey e.g. french/B1/Politics/News/audiobook_0_B1_News_Politics_2025-01-31.json
+func AudiobookKey(language string, cefr string, subject string, date string, page int, contentType string) string {
+	return fmt.Sprintf("%s/%s/%s/%s/audiobook_%d_%s_%s_%s_%s.json",
+		strings.ToLower(language),
+		strings.ToUpper(cefr),
+		strings.Title(subject),
+		contentType,
+		page,
+		strings.ToUpper(cefr),
+		contentType,
+		strings.Title(subject),
+		date,
+	)
+}
+
+// DraftKey e.g. drafts/french/B1/Politics/News/2025-01-31.md, the raw LLM
+// output of a generation job kept so a failed job can resume without
+// generating again.
+func DraftKey(language string, cefr string, subject string, contentType string, date string) string {
+	return fmt.Sprintf("drafts/%s/%s/%s/%s/%s.md",
+		strings.ToLower(language),
+		strings.ToUpper(cefr),
+		strings.Title(subject),
+		strings.Title(contentType),
+		date,
+	)
+}
@@ -0,0 +1,103 @@
+package contentstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// LocalStore keeps content under a directory on disk using the same key
+// layout as the bucket, so a local tree can be synced to or from S3 as is.
+type LocalStore struct {
+	dir     string
+	baseURL string
+}
+
+// NewLocalStore creates the directory if needed. When baseURL is set,
+// PresignGet returns baseURL/key (e.g. for a dev server serving the
+// directory); otherwise it returns a file:// URL.
+func NewLocalStore(dir string, baseURL string) (*LocalStore, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content directory %s: %v", dir, err)
+	}
+	if err := os.MkdirAll(absDir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create content directory %s: %v", absDir, err)
+	}
+	return &LocalStore{
+		dir:     absDir,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}, nil
+}
+
+// Dir is the root directory the store reads from and writes to.
+func (s *LocalStore) Dir() string {
+	return s.dir
+}
+
+func (s *LocalStore) path(key string) (string, error) {
+	p := filepath.Join(s.dir, filepath.FromSlash(key))
+	if p != s.dir && !strings.HasPrefix(p, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("key escapes content directory: %s", key)
+	}
+	return p, nil
+}
+
+func (s *LocalStore) Get(ctx context.Context, key string) ([]byte, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(p)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %v", key, err)
+	}
+	return content, nil
+}
+
+func (s *LocalStore) Put(ctx context.Context, key string, content []byte) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %v", key, err)
+	}
+	if err := os.WriteFile(p, content, 0o644); err != nil {
+		return fmt.Errorf("failed to write %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) Delete(ctx context.Context, key string) error {
+	p, err := s.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s *LocalStore) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	p, err := s.path(key)
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(p); errors.Is(err, fs.ErrNotExist) {
+		return "", ErrNotFound
+	}
+	if s.baseURL != "" {
+		return s.baseURL + "/" + key, nil
+	}
+	return "file://" + filepath.ToSlash(p), nil
+}
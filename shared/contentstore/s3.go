@@ -0,0 +1,97 @@
+package contentstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+)
+
+const DefaultRegion = "us-east-2"
+
+// S3Store keeps content in a single bucket. The client is created once and
+// reused across calls.
+type S3Store struct {
+	bucket  string
+	client  *s3.Client
+	presign *s3.PresignClient
+}
+
+func NewS3Store(ctx context.Context, bucket string) (*S3Store, error) {
+	cfg, err := config.LoadDefaultConfig(ctx, config.WithRegion(DefaultRegion))
+	if err != nil {
+		return nil, fmt.Errorf("unable to load SDK config: %v", err)
+	}
+
+	client := s3.NewFromConfig(cfg)
+	return &S3Store{
+		bucket:  bucket,
+		client:  client,
+		presign: s3.NewPresignClient(client),
+	}, nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		var noSuchKey *types.NoSuchKey
+		if errors.As(err, &noSuchKey) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to get object %s: %v", key, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read object %s: %v", key, err)
+	}
+	return content, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, content []byte) error {
+	_, err := s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(content),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to put object %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s *S3Store) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete object %s: %v", key, err)
+	}
+	return nil
+}
+
+func (s *S3Store) PresignGet(ctx context.Context, key string, expires time.Duration) (string, error) {
+	presigned, err := s.presign.PresignGetObject(ctx,
+		&s3.GetObjectInput{
+			Bucket: aws.String(s.bucket),
+			Key:    aws.String(key),
+		},
+		s3.WithPresignExpires(expires),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to presign object %s: %v", key, err)
+	}
+	return presigned.URL, nil
+}
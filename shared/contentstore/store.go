@@ -0,0 +1,54 @@
+// Package contentstore abstracts where generated content (news articles,
+// story pages, story context and audiobooks) is persisted, so the API and the
+// generation lambda can run against S3 in the cloud or a local directory
+// during development.
+package contentstore
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+)
+
+// ErrNotFound is returned by Get when no object exists for the key.
+var ErrNotFound = errors.New("content not found")
+
+type ContentStore interface {
+	Get(ctx context.Context, key string) ([]byte, error)
+	Put(ctx context.Context, key string, content []byte) error
+	// Delete removes the object, a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+	// PresignGet returns a URL that can be used to fetch the object directly,
+	// valid for at least the given duration.
+	PresignGet(ctx context.Context, key string, expires time.Duration) (string, error)
+}
+
+const (
+	BackendS3    = "s3"
+	BackendLocal = "local"
+)
+
+// NewFromEnv builds the store selected by CONTENT_STORE.
+//
+//	CONTENT_STORE=s3 (default)  uses STORY_BUCKET_NAME
+//	CONTENT_STORE=local         uses CONTENT_STORE_DIR and optionally CONTENT_STORE_BASE_URL
+func NewFromEnv(ctx context.Context) (ContentStore, error) {
+	switch backend := os.Getenv("CONTENT_STORE"); backend {
+	case "", BackendS3:
+		bucket := os.Getenv("STORY_BUCKET_NAME")
+		if bucket == "" {
+			return nil, fmt.Errorf("STORY_BUCKET_NAME must be set for the s3 content store")
+		}
+		return NewS3Store(ctx, bucket)
+	case BackendLocal:
+		dir := os.Getenv("CONTENT_STORE_DIR")
+		if dir == "" {
+			return nil, fmt.Errorf("CONTENT_STORE_DIR must be set for the local content store")
+		}
+		return NewLocalStore(dir, os.Getenv("CONTENT_STORE_BASE_URL"))
+	default:
+		return nil, fmt.Errorf("unknown CONTENT_STORE backend: %s", backend)
+	}
+}
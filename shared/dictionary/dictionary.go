@@ -0,0 +1,95 @@
+// Package dictionary is the format of the word/sentence dictionary stored
+// with each news article. It is written by the generation lambda and read by
+// the API.
+//
+// Translations is the original surface-form format and is kept so that older
+// files still decode. Lemmas and Tokens are the annotated format: one entry
+// per lemma, and one token per word occurrence with a gloss for its context.
+package dictionary
+
+import "strings"
+
+// Parts of speech use the Universal Dependencies tags.
+const (
+	NOUN  = "NOUN"
+	VERB  = "VERB"
+	ADJ   = "ADJ"
+	ADV   = "ADV"
+	PRON  = "PRON"
+	DET   = "DET"
+	ADP   = "ADP"
+	CONJ  = "CCONJ"
+	PROPN = "PROPN"
+	NUM   = "NUM"
+	AUX   = "AUX"
+	INTJ  = "INTJ"
+	PART  = "PART"
+	OTHER = "X"
+)
+
+type Entry struct {
+	Lemma        string `json:"lemma"`
+	PartOfSpeech string `json:"pos"`
+	Gender       string `json:"gender,omitempty"` // "m", "f" or empty
+	Translation  string `json:"translation"`
+}
+
+// Token is a single occurrence of a word in the text.
+type Token struct {
+	Text     string `json:"text"`
+	Lemma    string `json:"lemma"`
+	Sentence int    `json:"sentence"` // index into the article's sentences, repeats included
+	Gloss    string `json:"gloss"`    // short translation in this context
+}
+
+type Dictionary struct {
+	Translations struct {
+		Words     map[string]string `json:"words"`
+		Sentences map[string]string `json:"sentences"`
+	} `json:"translations"`
+	Lemmas map[string]Entry `json:"lemmas,omitempty"`
+	Tokens []Token          `json:"tokens,omitempty"`
+}
+
+func New() Dictionary {
+	d := Dictionary{}
+	d.Translations.Words = make(map[string]string)
+	d.Translations.Sentences = make(map[string]string)
+	return d
+}
+
+// LemmaKey is how lemmas are keyed in Lemmas.
+func LemmaKey(lemma string) string {
+	return strings.ToLower(strings.TrimSpace(lemma))
+}
+
+// Lookup returns the entry for a word as it appears in the text, first by
+// its annotated tokens, then by treating it as a lemma.
+func (d Dictionary) Lookup(word string) (Entry, bool) {
+	for _, token := range d.Tokens {
+		if strings.EqualFold(token.Text, word) {
+			entry, ok := d.Lemmas[LemmaKey(token.Lemma)]
+			return entry, ok
+		}
+	}
+	entry, ok := d.Lemmas[LemmaKey(word)]
+	return entry, ok
+}
+
+// Add records an annotated occurrence, creating the lemma entry the first time
+// it is seen.
+func (d *Dictionary) Add(token Token, entry Entry) {
+	if d.Lemmas == nil {
+		d.Lemmas = make(map[string]Entry)
+	}
+	key := LemmaKey(entry.Lemma)
+	if key == "" {
+		key = LemmaKey(token.Text)
+		entry.Lemma = key
+	}
+	if _, ok := d.Lemmas[key]; !ok {
+		d.Lemmas[key] = entry
+	}
+	token.Lemma = key
+	d.Tokens = append(d.Tokens, token)
+}
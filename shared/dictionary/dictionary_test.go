@@ -0,0 +1,47 @@
+package dictionary
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestDecodeLegacyFormat(t *testing.T) {
+	legacy := `{"translations":{"words":{"mange":"eats"},"sentences":{"Il mange.":"He eats."}}}`
+
+	var d Dictionary
+	if err := json.Unmarshal([]byte(legacy), &d); err != nil {
+		t.Fatalf("failed to decode legacy dictionary: %v", err)
+	}
+	if d.Translations.Words["mange"] != "eats" || d.Translations.Sentences["Il mange."] != "He eats." {
+		t.Errorf("unexpected translations: %+v", d.Translations)
+	}
+	if len(d.Lemmas) != 0 || len(d.Tokens) != 0 {
+		t.Errorf("expected no annotations, got %+v", d)
+	}
+
+	encoded, _ := json.Marshal(d)
+	if string(encoded) != legacy {
+		t.Errorf("expected legacy dictionaries to encode unchanged, got %s", encoded)
+	}
+}
+
+func TestAddAndLookup(t *testing.T) {
+	d := New()
+	d.Add(Token{Text: "mangeons", Sentence: 0, Gloss: "(we) eat"}, Entry{Lemma: "Manger", PartOfSpeech: VERB, Translation: "to eat"})
+	d.Add(Token{Text: "mangé", Sentence: 1, Gloss: "eaten"}, Entry{Lemma: "manger", PartOfSpeech: VERB, Translation: "to eat, to have eaten"})
+	d.Add(Token{Text: "pomme", Sentence: 1, Gloss: "apple"}, Entry{Lemma: "pomme", PartOfSpeech: NOUN, Gender: "f", Translation: "apple"})
+
+	if len(d.Lemmas) != 2 || len(d.Tokens) != 3 {
+		t.Fatalf("expected 2 lemmas and 3 tokens, got %d and %d", len(d.Lemmas), len(d.Tokens))
+	}
+	entry, ok := d.Lookup("Mangé")
+	if !ok || entry.Lemma != "Manger" || entry.Translation != "to eat" {
+		t.Errorf("unexpected entry for mangé: %+v", entry)
+	}
+	if entry, ok := d.Lookup("pomme"); !ok || entry.Gender != "f" {
+		t.Errorf("unexpected entry for pomme: %+v", entry)
+	}
+	if _, ok := d.Lookup("chat"); ok {
+		t.Error("expected no entry for an unknown word")
+	}
+}
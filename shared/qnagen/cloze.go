@@ -0,0 +1,132 @@
+package qnagen
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"squeak-shared/tokenize"
+)
+
+// CLOZE_BLANK replaces the missing word in a cloze question.
+const CLOZE_BLANK = "_____"
+
+const (
+	CLOZE_MIN_WORD_LENGTH = 4
+	CLOZE_CHOICES         = 4
+)
+
+// clozeMaxWords is the longest sentence, in words, used for a cloze question
+// at each CEFR band, so beginners are not given a paragraph to parse.
+var clozeMaxWords = map[byte]int{'A': 15, 'B': 25, 'C': 40}
+
+// CreateCloze blanks a word out of one of the content's own sentences, so it
+// needs no LLM call. The word is the longest lower case word of the first
+// sentence that is short enough for the level and not already in avoid, and
+// the choices are the word and other words of the content.
+func CreateCloze(language string, cefr string, content string, avoid []string) (Exercise, error) {
+	tokenizer := tokenize.For(language)
+	maxWords := clozeMaxWords['C']
+	if cefr != "" {
+		if limit, ok := clozeMaxWords[strings.ToUpper(cefr)[0]]; ok {
+			maxWords = limit
+		}
+	}
+
+	text := clozeText(content)
+	candidates := tokenize.Unique(clozeWords(tokenizer.Words(text)))
+	for _, sentence := range tokenizer.Sentences(text) {
+		words := tokenizer.Words(sentence)
+		if len(words) > maxWords {
+			continue
+		}
+		answer := ""
+		for _, word := range clozeWords(words) {
+			if utf8.RuneCountInString(word) > utf8.RuneCountInString(answer) {
+				answer = word
+			}
+		}
+		if answer == "" {
+			continue
+		}
+		question, ok := blank(sentence, answer)
+		if !ok || isDuplicate(question, avoid) {
+			continue
+		}
+		return Exercise{Question: question, Choices: clozeChoices(answer, candidates), Answer: answer}, nil
+	}
+	return Exercise{}, fmt.Errorf("no sentence to make a cloze question from")
+}
+
+// clozeText drops markdown headings and emphasis from content.
+func clozeText(content string) string {
+	lines := []string{}
+	for _, line := range strings.Split(content, "\n") {
+		if strings.HasPrefix(strings.TrimSpace(line), "#") {
+			continue
+		}
+		lines = append(lines, strings.NewReplacer("*", "", "_", "").Replace(line))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// clozeWords keeps the words worth blanking out: lower case, so not names,
+// and long enough not to be a function word.
+func clozeWords(words []string) []string {
+	kept := []string{}
+	for _, word := range words {
+		first, _ := utf8.DecodeRuneInString(word)
+		if unicode.IsLower(first) && !strings.ContainsRune(word, '\'') && utf8.RuneCountInString(word) >= CLOZE_MIN_WORD_LENGTH {
+			kept = append(kept, word)
+		}
+	}
+	return kept
+}
+
+// blank replaces the first whole word occurrence of word in sentence.
+func blank(sentence string, word string) (string, bool) {
+	for offset := 0; offset < len(sentence); {
+		i := strings.Index(sentence[offset:], word)
+		if i < 0 {
+			return "", false
+		}
+		start, end := offset+i, offset+i+len(word)
+		before, _ := utf8.DecodeLastRuneInString(sentence[:start])
+		after, _ := utf8.DecodeRuneInString(sentence[end:])
+		if !unicode.IsLetter(before) && !unicode.IsLetter(after) {
+			return sentence[:start] + CLOZE_BLANK + sentence[end:], true
+		}
+		offset = end
+	}
+	return "", false
+}
+
+// clozeChoices picks the words closest in length to the answer as
+// distractors, then shuffles. It returns nil when the content is too short to
+// have any, and the question is answered in free text instead.
+func clozeChoices(answer string, candidates []string) []string {
+	distractors := []string{}
+	for _, word := range candidates {
+		if !strings.EqualFold(word, answer) {
+			distractors = append(distractors, word)
+		}
+	}
+	if len(distractors) < CLOZE_CHOICES-1 {
+		return nil
+	}
+	length := utf8.RuneCountInString(answer)
+	distance := func(word string) int {
+		d := utf8.RuneCountInString(word) - length
+		if d < 0 {
+			return -d
+		}
+		return d
+	}
+	sort.SliceStable(distractors, func(i, j int) bool { return distance(distractors[i]) < distance(distractors[j]) })
+
+	choices := append([]string{answer}, distractors[:CLOZE_CHOICES-1]...)
+	shuffle(choices, answer)
+	return choices
+}
@@ -0,0 +1,170 @@
+package qnagen
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"unicode"
+
+	"squeak-shared/textgen"
+)
+
+const (
+	MULTIPLE_CHOICE_MODEL       = textgen.ModelFast
+	TRUE_FALSE_MODEL            = textgen.ModelFast
+	MULTIPLE_CHOICE_TEMPERATURE = 0.7
+	TRUE_FALSE_TEMPERATURE      = 0.7
+
+	MULTIPLE_CHOICE_CHOICES = 4
+)
+
+// Answer keys of true/false questions.
+const (
+	TRUE  = "true"
+	FALSE = "false"
+)
+
+// Exercise is a question with what is needed to grade it. Choices are set for
+// multiple choice and, when the content has enough words, cloze questions.
+// Answer is the answer key: the correct choice or missing word, or TRUE or
+// FALSE. It is empty for the question types evaluated by an LLM.
+type Exercise struct {
+	Question string
+	Choices  []string
+	Answer   string
+}
+
+// HasAnswerKey reports whether answers to a question type are graded against
+// a stored answer key instead of being evaluated by an LLM.
+func HasAnswerKey(questionType string) bool {
+	return questionType == MULTIPLE_CHOICE || questionType == CLOZE || questionType == TRUE_FALSE
+}
+
+// Grade checks an answer against the answer key, ignoring case, surrounding
+// spaces and punctuation.
+func Grade(answerKey string, answer string) bool {
+	clean := func(s string) string {
+		return strings.ToLower(strings.TrimFunc(s, func(r rune) bool {
+			return unicode.IsSpace(r) || unicode.IsPunct(r)
+		}))
+	}
+	return clean(answerKey) != "" && clean(answerKey) == clean(answer)
+}
+
+var multipleChoiceSchema = &textgen.Schema{
+	Type:     textgen.TypeObject,
+	Required: []string{"question", "choices", "answer"},
+	Properties: map[string]*textgen.Schema{
+		"question": {Type: textgen.TypeString},
+		"choices":  {Type: textgen.TypeArray, Items: &textgen.Schema{Type: textgen.TypeString}},
+		"answer":   {Type: textgen.TypeInteger, Description: "index of the correct choice"},
+	},
+}
+
+var trueFalseSchema = &textgen.Schema{
+	Type:     textgen.TypeObject,
+	Required: []string{"statement", "answer"},
+	Properties: map[string]*textgen.Schema{
+		"statement": {Type: textgen.TypeString},
+		"answer":    {Type: textgen.TypeBoolean},
+	},
+}
+
+// decodeJSON reads the JSON object in a response, ignoring anything around it.
+func decodeJSON(response string, v interface{}) error {
+	start, end := strings.Index(response, "{"), strings.LastIndex(response, "}")
+	if start < 0 || end < start {
+		return fmt.Errorf("no JSON object in response")
+	}
+	if err := json.Unmarshal([]byte(response[start:end+1]), v); err != nil {
+		return fmt.Errorf("invalid JSON: %v", err)
+	}
+	return nil
+}
+
+func (g *Generator) CreateMultipleChoice(ctx context.Context, cefr string, content string, avoid []string) (Exercise, error) {
+	response, err := g.llm.Generate(ctx, CreateMultipleChoicePrompt(cefr, content, avoid), textgen.Options{
+		Model:       MULTIPLE_CHOICE_MODEL,
+		Temperature: MULTIPLE_CHOICE_TEMPERATURE,
+		Schema:      multipleChoiceSchema,
+	})
+	if err != nil {
+		return Exercise{}, err
+	}
+
+	var parsed struct {
+		Question string   `json:"question"`
+		Choices  []string `json:"choices"`
+		Answer   int      `json:"answer"`
+	}
+	if err := decodeJSON(response, &parsed); err != nil {
+		return Exercise{}, err
+	}
+	exercise := Exercise{Question: strings.TrimSpace(parsed.Question)}
+	if exercise.Question == "" {
+		return Exercise{}, fmt.Errorf("empty question")
+	}
+	if len(parsed.Choices) != MULTIPLE_CHOICE_CHOICES || parsed.Answer < 0 || parsed.Answer >= len(parsed.Choices) {
+		return Exercise{}, fmt.Errorf("expected %d choices and the index of the answer, got %d choices and %d", MULTIPLE_CHOICE_CHOICES, len(parsed.Choices), parsed.Answer)
+	}
+	seen := map[string]bool{}
+	for _, choice := range parsed.Choices {
+		choice = strings.TrimSpace(choice)
+		if choice == "" || seen[strings.ToLower(choice)] {
+			return Exercise{}, fmt.Errorf("empty or repeated choice: %q", choice)
+		}
+		seen[strings.ToLower(choice)] = true
+		exercise.Choices = append(exercise.Choices, choice)
+	}
+	exercise.Answer = exercise.Choices[parsed.Answer]
+	if isDuplicate(exercise.Question, avoid) {
+		return Exercise{}, ErrDuplicate
+	}
+	// LLMs favour putting the correct choice first
+	shuffle(exercise.Choices, exercise.Question)
+	return exercise, nil
+}
+
+func (g *Generator) CreateTrueFalse(ctx context.Context, cefr string, content string, avoid []string) (Exercise, error) {
+	response, err := g.llm.Generate(ctx, CreateTrueFalsePrompt(cefr, content, avoid), textgen.Options{
+		Model:       TRUE_FALSE_MODEL,
+		Temperature: TRUE_FALSE_TEMPERATURE,
+		Schema:      trueFalseSchema,
+	})
+	if err != nil {
+		return Exercise{}, err
+	}
+
+	var parsed struct {
+		Statement string `json:"statement"`
+		Answer    *bool  `json:"answer"`
+	}
+	if err := decodeJSON(response, &parsed); err != nil {
+		return Exercise{}, err
+	}
+	exercise := Exercise{Question: strings.TrimSpace(parsed.Statement), Answer: FALSE}
+	if exercise.Question == "" || parsed.Answer == nil {
+		return Exercise{}, fmt.Errorf("expected a statement and whether it is true")
+	}
+	if *parsed.Answer {
+		exercise.Answer = TRUE
+	}
+	if isDuplicate(exercise.Question, avoid) {
+		return Exercise{}, ErrDuplicate
+	}
+	return exercise, nil
+}
+
+// shuffle reorders choices the same way every time for the same seed.
+func shuffle(choices []string, seed string) {
+	h := fnv.New64a()
+	h.Write([]byte(seed))
+	state := h.Sum64()
+	for i := len(choices) - 1; i > 0; i-- {
+		state = state*6364136223846793005 + 1442695040888963407
+		j := int((state >> 33) % uint64(i+1))
+		choices[i], choices[j] = choices[j], choices[i]
+	}
+}
@@ -0,0 +1,143 @@
+package qnagen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"squeak-shared/textgen"
+)
+
+func TestMultipleChoice(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return `{"question": "Où dort le chat ?", "choices": ["Sur le lit", "Dans le jardin", "Sous la table", "Au bureau"], "answer": 0}`, nil
+	}
+	g := New(llm)
+
+	exercise, err := g.Exercise(context.Background(), MULTIPLE_CHOICE, "A2", "fr", "Le chat dort sur le lit.", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exercise.Question != "Où dort le chat ?" || exercise.Answer != "Sur le lit" || len(exercise.Choices) != 4 {
+		t.Fatalf("unexpected exercise %+v", exercise)
+	}
+	if llm.Calls()[0].Options.Schema != multipleChoiceSchema {
+		t.Error("expected the multiple choice schema")
+	}
+
+	again, _ := g.Exercise(context.Background(), MULTIPLE_CHOICE, "A2", "fr", "Le chat dort sur le lit.", nil)
+	if strings.Join(again.Choices, "|") != strings.Join(exercise.Choices, "|") {
+		t.Errorf("expected the same order for the same question, got %v and %v", exercise.Choices, again.Choices)
+	}
+
+	if _, err := g.Exercise(context.Background(), MULTIPLE_CHOICE, "A2", "fr", "Le chat dort sur le lit.", []string{"où dort le chat ?"}); err != ErrDuplicate {
+		t.Errorf("expected ErrDuplicate, got %v", err)
+	}
+}
+
+func TestMultipleChoiceIsValidated(t *testing.T) {
+	for _, response := range []string{
+		`{"question": "Où ?", "choices": ["a", "b", "c"], "answer": 0}`,
+		`{"question": "Où ?", "choices": ["a", "b", "c", "d"], "answer": 4}`,
+		`{"question": "Où ?", "choices": ["a", "b", "c", "A"], "answer": 0}`,
+		`{"question": "", "choices": ["a", "b", "c", "d"], "answer": 0}`,
+		`not json`,
+	} {
+		llm := textgen.NewFake()
+		llm.Respond = func(prompt string, opts textgen.Options) (string, error) { return response, nil }
+		if _, err := New(llm).CreateMultipleChoice(context.Background(), "B1", "Le chat dort.", nil); err == nil {
+			t.Errorf("expected %s to be rejected", response)
+		}
+	}
+}
+
+func TestTrueFalse(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "```json\n{\"statement\": \"Le chat dort.\", \"answer\": true}\n```", nil
+	}
+	exercise, err := New(llm).Exercise(context.Background(), TRUE_FALSE, "A1", "fr", "Le chat dort.", nil)
+	if err != nil || exercise.Question != "Le chat dort." || exercise.Answer != TRUE || exercise.Choices != nil {
+		t.Fatalf("unexpected exercise %+v (%v)", exercise, err)
+	}
+
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return `{"statement": "Le chat mange."}`, nil
+	}
+	if _, err := New(llm).CreateTrueFalse(context.Background(), "A1", "Le chat dort.", nil); err == nil {
+		t.Error("expected a statement without an answer to be rejected")
+	}
+}
+
+func TestCloze(t *testing.T) {
+	content := "# Le marché\n\nMarie achète des **pommes** rouges au supermarché. Le vendeur sourit toujours aux clients fidèles. Paris est grande."
+	llm := textgen.NewFake()
+
+	exercise, err := New(llm).Exercise(context.Background(), CLOZE, "A2", "fr", content, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exercise.Question != "Marie achète des pommes rouges au _____." || exercise.Answer != "supermarché" {
+		t.Fatalf("unexpected exercise %+v", exercise)
+	}
+	if len(exercise.Choices) != CLOZE_CHOICES || !contains(exercise.Choices, "supermarché") {
+		t.Errorf("expected %d choices including the answer, got %v", CLOZE_CHOICES, exercise.Choices)
+	}
+	if len(llm.Calls()) != 0 {
+		t.Error("expected cloze questions to need no LLM call")
+	}
+
+	next, err := CreateCloze("fr", "A2", content, []string{exercise.Question})
+	if err != nil || next.Question != "Le vendeur sourit _____ aux clients fidèles." || next.Answer != "toujours" {
+		t.Fatalf("expected the next sentence, got %+v (%v)", next, err)
+	}
+
+	if _, err := CreateCloze("fr", "A2", "Paris est là.", nil); err == nil {
+		t.Error("expected content without a word to blank to be an error")
+	}
+}
+
+func TestClozeSkipsLongSentencesForBeginners(t *testing.T) {
+	long := "Les habitants du quartier se retrouvent chaque samedi matin devant la boulangerie pour discuter des nouvelles de la semaine."
+	if _, err := CreateCloze("fr", "A1", long, nil); err == nil {
+		t.Error("expected a long sentence to be skipped at A1")
+	}
+	exercise, err := CreateCloze("fr", "C1", long, nil)
+	if err != nil || exercise.Answer != "boulangerie" {
+		t.Errorf("unexpected exercise %+v (%v)", exercise, err)
+	}
+	if exercise.Choices != nil && len(exercise.Choices) != CLOZE_CHOICES {
+		t.Errorf("unexpected choices %v", exercise.Choices)
+	}
+}
+
+func TestGrade(t *testing.T) {
+	for _, tt := range []struct {
+		key, answer string
+		correct     bool
+	}{
+		{"marché", " Marché. ", true},
+		{TRUE, "TRUE", true},
+		{TRUE, "false", false},
+		{"Sur le lit", "sur le lit", true},
+		{"Sur le lit", "Dans le jardin", false},
+		{"", "", false},
+	} {
+		if got := Grade(tt.key, tt.answer); got != tt.correct {
+			t.Errorf("Grade(%q, %q) = %v, want %v", tt.key, tt.answer, got, tt.correct)
+		}
+	}
+	if !HasAnswerKey(CLOZE) || HasAnswerKey(VOCAB) {
+		t.Error("expected only the objective types to have answer keys")
+	}
+}
+
+func contains(choices []string, choice string) bool {
+	for _, c := range choices {
+		if c == choice {
+			return true
+		}
+	}
+	return false
+}
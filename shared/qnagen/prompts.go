@@ -0,0 +1,72 @@
+package qnagen
+
+import "strings"
+
+func CreateUnderstandingQuestionPrompt(cefr string, content string, avoid []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are an LLM designed to write questions for reading comprehension tests of other languages. ")
+	sb.WriteString("You will be a given a news article or story with a CEFR level of " + cefr + ". ")
+	sb.WriteString("Based on the content of this article, respond with a question that would sufficiently challenge someone with the proficiency of " + cefr + ". ")
+	sb.WriteString("Your question should test them on understanding of the content. ")
+	writeAvoid(&sb, avoid)
+	sb.WriteString("\n\nRespond with the question ONLY. Do NOT add any other preamble or comment.")
+	sb.WriteString("\n\nContent:\n" + content)
+
+	return sb.String()
+}
+
+func CreateVocabQuestionPrompt(cefr string, content string, avoid []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are an LLM designed to write questions for word memory of other languages. ")
+	sb.WriteString("You will be a given a news article or story with a CEFR level of " + cefr + ". ")
+	sb.WriteString("Based on the content of this article, respond with a question in the format of 'What does <word> mean?'. The chosen word must sufficiently challenge someone with the proficiency of " + cefr + ". ")
+	sb.WriteString("The question MUST be in the exact form 'What does <word> mean?'. The word MUST be a word that is present in the content.")
+	writeAvoid(&sb, avoid)
+	sb.WriteString("\n\nRespond with the question ONLY. Do NOT add any other preamble or comment.")
+	sb.WriteString("\n\nContent:\n" + content)
+
+	return sb.String()
+}
+
+// writeAvoid lists the questions already asked about the content so that
+// another one is written.
+func writeAvoid(sb *strings.Builder, avoid []string) {
+	if len(avoid) == 0 {
+		return
+	}
+	sb.WriteString("\n\nThese questions have already been asked about this content. Your question MUST be different from all of them:")
+	for _, question := range avoid {
+		sb.WriteString("\n- " + question)
+	}
+}
+
+func CreateMultipleChoicePrompt(cefr string, content string, avoid []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are an LLM designed to write multiple choice questions for reading comprehension tests of other languages. ")
+	sb.WriteString("You will be a given a news article or story with a CEFR level of " + cefr + ". ")
+	sb.WriteString("Based on the content of this article, write a question that would sufficiently challenge someone with the proficiency of " + cefr + ", with 4 choices of which exactly one is correct according to the content. ")
+	sb.WriteString("The wrong choices must be plausible but clearly contradicted by or absent from the content. Write the question and choices in the language of the content. ")
+	sb.WriteString("'answer' is the index (0 to 3) of the correct choice.")
+	writeAvoid(&sb, avoid)
+	sb.WriteString("\n\nRespond with JSON ONLY, matching the schema. Do NOT add any other preamble or comment.")
+	sb.WriteString("\n\nContent:\n" + content)
+
+	return sb.String()
+}
+
+func CreateTrueFalsePrompt(cefr string, content string, avoid []string) string {
+	var sb strings.Builder
+
+	sb.WriteString("You are an LLM designed to write true or false questions for reading comprehension tests of other languages. ")
+	sb.WriteString("You will be a given a news article or story with a CEFR level of " + cefr + ". ")
+	sb.WriteString("Based on the content of this article, write a statement about it that someone with the proficiency of " + cefr + " can judge as true or false. ")
+	sb.WriteString("The statement must be clearly true or clearly false according to the content alone. Write it in the language of the content.")
+	writeAvoid(&sb, avoid)
+	sb.WriteString("\n\nRespond with JSON ONLY, matching the schema. Do NOT add any other preamble or comment.")
+	sb.WriteString("\n\nContent:\n" + content)
+
+	return sb.String()
+}
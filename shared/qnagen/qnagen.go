@@ -0,0 +1,118 @@
+// Package qnagen writes the comprehension questions asked about news and
+// stories. Once content is created the generation lambda writes questions at
+// the content's own CEFR level, or at the levels listed in QNA_LEVELS ("all"
+// for every level), and the API writes any that are missing when they are
+// first asked for.
+package qnagen
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"squeak-shared/textgen"
+)
+
+// Question types, as stored in questions.question_type. Vocab and
+// understanding questions are answered in free text and evaluated by an LLM,
+// the others are graded against the answer key stored with them.
+const (
+	VOCAB           = "vocab"
+	UNDERSTANDING   = "understanding"
+	MULTIPLE_CHOICE = "multiple_choice"
+	CLOZE           = "cloze"
+	TRUE_FALSE      = "true_false"
+)
+
+const (
+	UNDERSTANDING_QUESTION_MODEL       = textgen.ModelFast
+	VOCAB_QUESTION_MODEL               = textgen.ModelFast
+	UNDERSTANDING_QUESTION_TEMPERATURE = 1.0
+	VOCAB_QUESTION_TEMPERATURE         = 0.3
+)
+
+var QuestionTypes = []string{VOCAB, UNDERSTANDING, MULTIPLE_CHOICE, CLOZE, TRUE_FALSE}
+
+// POOL_SIZE is how many questions of each type and level are written for a
+// piece of content, so that learners are not asked the same question forever.
+const POOL_SIZE = 3
+
+// ErrDuplicate is returned when the LLM repeats a question it was asked to avoid.
+var ErrDuplicate = errors.New("duplicate question")
+
+var Levels = []string{"A1", "A2", "B1", "B2", "C1", "C2"}
+
+// Generator builds the question prompts and runs them on whichever LLM
+// provider it was given.
+type Generator struct {
+	llm textgen.TextGenerator
+}
+
+func New(llm textgen.TextGenerator) *Generator {
+	return &Generator{llm: llm}
+}
+
+// CreateUnderstandingQuestion writes a question that is not one of avoid, the
+// questions already in the pool.
+func (g *Generator) CreateUnderstandingQuestion(ctx context.Context, cefr string, content string, avoid []string) (string, error) {
+	prompt := CreateUnderstandingQuestionPrompt(cefr, content, avoid)
+	return g.generate(ctx, prompt, UNDERSTANDING_QUESTION_MODEL, UNDERSTANDING_QUESTION_TEMPERATURE, avoid)
+}
+
+// CreateVocabQuestion writes a question that is not one of avoid, the
+// questions already in the pool.
+func (g *Generator) CreateVocabQuestion(ctx context.Context, cefr string, content string, avoid []string) (string, error) {
+	prompt := CreateVocabQuestionPrompt(cefr, content, avoid)
+	return g.generate(ctx, prompt, VOCAB_QUESTION_MODEL, VOCAB_QUESTION_TEMPERATURE, avoid)
+}
+
+// Exercise writes a question of one of the QuestionTypes, with its choices
+// and answer key when it has them.
+func (g *Generator) Exercise(ctx context.Context, questionType string, cefr string, language string, content string, avoid []string) (Exercise, error) {
+	switch questionType {
+	case MULTIPLE_CHOICE:
+		return g.CreateMultipleChoice(ctx, cefr, content, avoid)
+	case TRUE_FALSE:
+		return g.CreateTrueFalse(ctx, cefr, content, avoid)
+	case CLOZE:
+		return CreateCloze(language, cefr, content, avoid)
+	}
+	question, err := g.Question(ctx, questionType, cefr, content, avoid)
+	return Exercise{Question: question}, err
+}
+
+// Question writes a vocab or understanding question.
+func (g *Generator) Question(ctx context.Context, questionType string, cefr string, content string, avoid []string) (string, error) {
+	switch questionType {
+	case VOCAB:
+		return g.CreateVocabQuestion(ctx, cefr, content, avoid)
+	case UNDERSTANDING:
+		return g.CreateUnderstandingQuestion(ctx, cefr, content, avoid)
+	}
+	return "", fmt.Errorf("invalid question type: %s", questionType)
+}
+
+func (g *Generator) generate(ctx context.Context, prompt string, model string, temperature float32, avoid []string) (string, error) {
+	question, err := g.llm.Generate(ctx, prompt, textgen.Options{Model: model, Temperature: temperature})
+	if err != nil {
+		return "", err
+	}
+	question = strings.TrimSpace(question)
+	if question == "" {
+		return "", fmt.Errorf("empty question")
+	}
+	if isDuplicate(question, avoid) {
+		return "", ErrDuplicate
+	}
+	return question, nil
+}
+
+func isDuplicate(question string, avoid []string) bool {
+	for _, previous := range avoid {
+		if strings.EqualFold(question, strings.TrimSpace(previous)) {
+			return true
+		}
+	}
+	return false
+}
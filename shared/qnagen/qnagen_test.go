@@ -0,0 +1,59 @@
+package qnagen
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"squeak-shared/textgen"
+)
+
+func TestQuestion(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "  What does chat mean?\n", nil
+	}
+	g := New(llm)
+
+	question, err := g.Question(context.Background(), VOCAB, "A2", "Le chat dort.", nil)
+	if err != nil || question != "What does chat mean?" {
+		t.Fatalf("unexpected question %q (%v)", question, err)
+	}
+	call := llm.Calls()[0]
+	if !strings.Contains(call.Prompt, "'What does <word> mean?'") || !strings.Contains(call.Prompt, "Le chat dort.") {
+		t.Errorf("expected the vocab prompt with the content, got %q", call.Prompt)
+	}
+	if call.Options.Temperature != VOCAB_QUESTION_TEMPERATURE {
+		t.Errorf("unexpected temperature %v", call.Options.Temperature)
+	}
+
+	if _, err := g.Question(context.Background(), "essay", "A2", "Le chat dort.", nil); err == nil {
+		t.Error("expected an unknown question type to be rejected")
+	}
+}
+
+func TestEmptyQuestionIsAnError(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "\n", nil
+	}
+	if _, err := New(llm).CreateUnderstandingQuestion(context.Background(), "B1", "Le chat dort.", nil); err == nil {
+		t.Error("expected an empty question to be an error")
+	}
+}
+
+func TestQuestionAvoidsThePool(t *testing.T) {
+	llm := textgen.NewFake()
+	llm.Respond = func(prompt string, opts textgen.Options) (string, error) {
+		return "what does chat mean?", nil
+	}
+	avoid := []string{"What does chat mean?", "What does lit mean?"}
+
+	_, err := New(llm).CreateVocabQuestion(context.Background(), "A2", "Le chat dort sur le lit.", avoid)
+	if err != ErrDuplicate {
+		t.Errorf("expected a repeated question to be a duplicate, got %v", err)
+	}
+	if prompt := llm.Calls()[0].Prompt; !strings.Contains(prompt, "- What does lit mean?") {
+		t.Errorf("expected the pool in the prompt, got %q", prompt)
+	}
+}
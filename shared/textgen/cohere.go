@@ -0,0 +1,143 @@
+package textgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const COHERE_CHAT_URL = "https://api.cohere.com/v2/chat"
+
+var cohereModels = map[string]string{
+	ModelFast:    "command-r-08-2024",
+	ModelQuality: "command-r-plus-08-2024",
+}
+
+type cohereMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type cohereRequest struct {
+	Model          string                `json:"model"`
+	Messages       []cohereMessage       `json:"messages"`
+	Temperature    float32               `json:"temperature"`
+	MaxTokens      int                   `json:"max_tokens"`
+	ResponseFormat *cohereResponseFormat `json:"response_format,omitempty"`
+}
+
+type cohereResponseFormat struct {
+	Type       string  `json:"type"`
+	JSONSchema *Schema `json:"json_schema,omitempty"`
+}
+
+// https://docs.cohere.com/reference/chat
+type cohereResponse struct {
+	Message struct {
+		Content []struct {
+			Text string `json:"text"`
+			Type string `json:"type"`
+		} `json:"content"`
+	} `json:"message"`
+	FinishReason string `json:"finish_reason"`
+}
+
+type Cohere struct {
+	apiKey     string
+	url        string
+	httpClient *http.Client
+}
+
+func NewCohere(apiKey string) (*Cohere, error) {
+	if apiKey == "" {
+		return nil, fmt.Errorf("COHERE_API_KEY environment variable not set")
+	}
+	return &Cohere{
+		apiKey:     apiKey,
+		url:        COHERE_CHAT_URL,
+		httpClient: &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (c *Cohere) Provider() string {
+	return ProviderCohere
+}
+
+func (c *Cohere) Close() error {
+	return nil
+}
+
+func (c *Cohere) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	messages := make([]cohereMessage, 0, len(opts.History)+1)
+	for _, message := range opts.History {
+		role := message.Role
+		if role == "model" {
+			role = "assistant"
+		}
+		messages = append(messages, cohereMessage{Role: role, Content: message.Text})
+	}
+	messages = append(messages, cohereMessage{Role: "user", Content: prompt})
+
+	request := cohereRequest{
+		Model:       resolveModel(cohereModels, opts.Model),
+		Messages:    messages,
+		Temperature: opts.Temperature,
+		MaxTokens:   maxTokens(opts),
+	}
+	if opts.Schema != nil {
+		request.ResponseFormat = &cohereResponseFormat{Type: "json_object", JSONSchema: opts.Schema}
+	}
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cohere request: %v", err)
+	}
+
+	return withRetry(ctx, func() (string, error) {
+		return c.send(ctx, payload)
+	})
+}
+
+func (c *Cohere) send(ctx context.Context, payload []byte) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.url, bytes.NewReader(payload))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("cohere returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var response cohereResponse
+	if err := json.Unmarshal(body, &response); err != nil {
+		return "", fmt.Errorf("failed to unmarshal cohere response: %v", err)
+	}
+
+	var sb strings.Builder
+	for _, content := range response.Message.Content {
+		if content.Type == "" || content.Type == "text" {
+			sb.WriteString(content.Text)
+		}
+	}
+	if sb.Len() == 0 {
+		return "", fmt.Errorf("cohere returned no content (finish reason %s)", response.FinishReason)
+	}
+	return sb.String(), nil
+}
@@ -0,0 +1,72 @@
+package textgen
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+)
+
+// Call records a single Generate call made to a Fake.
+type Call struct {
+	Prompt  string
+	Options Options
+}
+
+// Fake is a deterministic, offline TextGenerator. By default it answers
+// requests with a Schema with the smallest JSON matching it, PASS/FAIL
+// prompts with PASS and anything else with a short markdown document derived
+// from a hash of the prompt. Set Respond to script replies.
+type Fake struct {
+	Respond func(prompt string, opts Options) (string, error)
+
+	mu    sync.Mutex
+	calls []Call
+}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Provider() string {
+	return ProviderFake
+}
+
+func (f *Fake) Close() error {
+	return nil
+}
+
+func (f *Fake) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	f.mu.Lock()
+	f.calls = append(f.calls, Call{Prompt: prompt, Options: opts})
+	respond := f.Respond
+	f.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if respond != nil {
+		return respond(prompt, opts)
+	}
+	return defaultFakeResponse(prompt, opts), nil
+}
+
+// Calls returns the calls made so far, oldest first.
+func (f *Fake) Calls() []Call {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]Call(nil), f.calls...)
+}
+
+func defaultFakeResponse(prompt string, opts Options) string {
+	if opts.Schema != nil {
+		return opts.Schema.fakeJSON()
+	}
+	if strings.Contains(prompt, "PASS or FAIL") {
+		return "PASS"
+	}
+	h := fnv.New32a()
+	h.Write([]byte(prompt))
+	return fmt.Sprintf("# Fake response %08x\n\nThis text was produced by the fake LLM provider. It is the same every time for the same prompt.\n", h.Sum32())
+}
@@ -0,0 +1,74 @@
+package textgen
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/generative-ai-go/genai"
+	"google.golang.org/api/option"
+)
+
+const (
+	GEMINI_TOP_K = 40
+	GEMINI_TOP_P = 0.95
+)
+
+var geminiModels = map[string]string{
+	ModelFast:    "gemini-1.5-flash",
+	ModelQuality: "gemini-1.5-pro",
+}
+
+type Gemini struct {
+	client *genai.Client
+}
+
+func NewGemini(ctx context.Context, apiKey string) (*Gemini, error) {
+	client, err := genai.NewClient(ctx, option.WithAPIKey(apiKey))
+	if err != nil {
+		return nil, fmt.Errorf("error creating Gemini client: %v", err)
+	}
+	return &Gemini{client: client}, nil
+}
+
+func (g *Gemini) Provider() string {
+	return ProviderGemini
+}
+
+func (g *Gemini) Close() error {
+	return g.client.Close()
+}
+
+func (g *Gemini) Generate(ctx context.Context, prompt string, opts Options) (string, error) {
+	model := g.client.GenerativeModel(resolveModel(geminiModels, opts.Model))
+	model.SetTemperature(opts.Temperature)
+	model.SetTopK(GEMINI_TOP_K)
+	model.SetTopP(GEMINI_TOP_P)
+	model.SetMaxOutputTokens(int32(maxTokens(opts)))
+	model.ResponseMIMEType = "text/plain"
+	if opts.Schema != nil {
+		model.ResponseMIMEType = "application/json"
+		model.ResponseSchema = opts.Schema.gemini()
+	}
+
+	history := make([]*genai.Content, 0, len(opts.History))
+	for _, message := range opts.History {
+		history = append(history, &genai.Content{
+			Role:  message.Role,
+			Parts: []genai.Part{genai.Text(message.Text)},
+		})
+	}
+
+	return withRetry(ctx, func() (string, error) {
+		session := model.StartChat()
+		session.History = history
+
+		resp, err := session.SendMessage(ctx, genai.Text(prompt))
+		if err != nil {
+			return "", err
+		}
+		if len(resp.Candidates) == 0 || resp.Candidates[0].Content == nil || len(resp.Candidates[0].Content.Parts) == 0 {
+			return "", fmt.Errorf("gemini returned no content")
+		}
+		return fmt.Sprintf("%v", resp.Candidates[0].Content.Parts[0]), nil
+	})
+}
@@ -0,0 +1,98 @@
+package textgen
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/google/generative-ai-go/genai"
+)
+
+// Schema types.
+const (
+	TypeObject  = "object"
+	TypeArray   = "array"
+	TypeString  = "string"
+	TypeInteger = "integer"
+	TypeNumber  = "number"
+	TypeBoolean = "boolean"
+)
+
+// Schema is the JSON a response must match when set in Options. It is the
+// subset of JSON schema that every provider supports, and marshals to JSON
+// schema. The provider is only asked for JSON: callers must still validate
+// the response, as not every model follows the schema exactly.
+type Schema struct {
+	Type        string             `json:"type"`
+	Description string             `json:"description,omitempty"`
+	Enum        []string           `json:"enum,omitempty"`
+	Minimum     *float64           `json:"minimum,omitempty"` // numbers only, not enforced by Gemini
+	Maximum     *float64           `json:"maximum,omitempty"`
+	Items       *Schema            `json:"items,omitempty"`
+	Properties  map[string]*Schema `json:"properties,omitempty"`
+	Required    []string           `json:"required,omitempty"`
+}
+
+var geminiTypes = map[string]genai.Type{
+	TypeObject:  genai.TypeObject,
+	TypeArray:   genai.TypeArray,
+	TypeString:  genai.TypeString,
+	TypeInteger: genai.TypeInteger,
+	TypeNumber:  genai.TypeNumber,
+	TypeBoolean: genai.TypeBoolean,
+}
+
+func (s *Schema) gemini() *genai.Schema {
+	if s == nil {
+		return nil
+	}
+	schema := &genai.Schema{
+		Type:        geminiTypes[s.Type],
+		Description: s.Description,
+		Enum:        s.Enum,
+		Items:       s.Items.gemini(),
+		Required:    s.Required,
+	}
+	if len(s.Enum) > 0 {
+		schema.Format = "enum"
+	}
+	if s.Properties != nil {
+		schema.Properties = make(map[string]*genai.Schema, len(s.Properties))
+		for name, property := range s.Properties {
+			schema.Properties[name] = property.gemini()
+		}
+	}
+	return schema
+}
+
+// fakeJSON is the smallest JSON matching s: required properties only, empty
+// arrays, the first value of enums, the minimum of numbers and zero values
+// otherwise.
+func (s *Schema) fakeJSON() string {
+	switch s.Type {
+	case TypeObject:
+		required := append([]string(nil), s.Required...)
+		sort.Strings(required)
+		fields := make([]string, 0, len(required))
+		for _, name := range required {
+			if property, ok := s.Properties[name]; ok {
+				fields = append(fields, fmt.Sprintf("%q:%s", name, property.fakeJSON()))
+			}
+		}
+		return "{" + strings.Join(fields, ",") + "}"
+	case TypeArray:
+		return "[]"
+	case TypeString:
+		if len(s.Enum) > 0 {
+			return fmt.Sprintf("%q", s.Enum[0])
+		}
+		return `"fake"`
+	case TypeBoolean:
+		return "false"
+	}
+	if s.Minimum != nil {
+		return strconv.FormatFloat(*s.Minimum, 'f', -1, 64)
+	}
+	return "0"
+}
@@ -0,0 +1,125 @@
+// Package textgen is the single interface the API and the generation lambda
+// use to talk to an LLM. Providers are selected with LLM_PROVIDER so the same
+// code can run against Gemini, Cohere or a deterministic offline fake.
+package textgen
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"os"
+	"time"
+)
+
+const (
+	ProviderGemini = "gemini"
+	ProviderCohere = "cohere"
+	ProviderFake   = "fake"
+)
+
+// Model tiers. Callers pick a tier and each provider maps it to one of its
+// own models; any other value is passed through to the provider unchanged.
+const (
+	ModelFast    = "fast"
+	ModelQuality = "quality"
+)
+
+const (
+	DEFAULT_MAX_TOKENS = 8192
+	MAX_RETRIES        = 5
+)
+
+// Message is a prior turn of the conversation. Role is "user" or "model".
+type Message struct {
+	Role string
+	Text string
+}
+
+type Options struct {
+	Model       string
+	Temperature float32
+	MaxTokens   int // 0 means DEFAULT_MAX_TOKENS
+	History     []Message
+	Schema      *Schema // when set the response is JSON matching it
+}
+
+type TextGenerator interface {
+	Generate(ctx context.Context, prompt string, opts Options) (string, error)
+	Provider() string
+	Close() error
+}
+
+// NewFromEnv builds the generator selected by LLM_PROVIDER (default gemini).
+func NewFromEnv(ctx context.Context) (TextGenerator, error) {
+	switch provider := os.Getenv("LLM_PROVIDER"); provider {
+	case "", ProviderGemini:
+		return NewGemini(ctx, os.Getenv("GEMINI_API_KEY"))
+	case ProviderCohere:
+		return NewCohere(os.Getenv("COHERE_API_KEY"))
+	case ProviderFake:
+		return NewFake(), nil
+	default:
+		return nil, fmt.Errorf("unknown LLM_PROVIDER: %s", provider)
+	}
+}
+
+var providerModels = map[string]map[string]string{
+	ProviderGemini: geminiModels,
+	ProviderCohere: cohereModels,
+}
+
+// ModelName is the provider's model that a tier (or model name) resolves to,
+// for recording which model produced some content.
+func ModelName(generator TextGenerator, model string) string {
+	models, ok := providerModels[generator.Provider()]
+	if !ok {
+		if model == "" {
+			return ModelFast
+		}
+		return model
+	}
+	return resolveModel(models, model)
+}
+
+func maxTokens(opts Options) int {
+	if opts.MaxTokens <= 0 {
+		return DEFAULT_MAX_TOKENS
+	}
+	return opts.MaxTokens
+}
+
+func resolveModel(models map[string]string, model string) string {
+	if resolved, ok := models[model]; ok {
+		return resolved
+	}
+	if model == "" {
+		return models[ModelFast]
+	}
+	return model
+}
+
+// withRetry calls fn up to MAX_RETRIES times with exponential backoff
+// (2^attempt * 100ms), stopping early if the context is cancelled.
+func withRetry(ctx context.Context, fn func() (string, error)) (string, error) {
+	var lastErr error
+	for attempt := 0; attempt < MAX_RETRIES; attempt++ {
+		if attempt > 0 {
+			backoffDuration := time.Duration(math.Pow(2, float64(attempt))) * 100 * time.Millisecond
+			select {
+			case <-time.After(backoffDuration):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		result, err := fn()
+		if err == nil {
+			return result, nil
+		}
+		lastErr = err
+		log.Printf("Attempt %d failed: %v", attempt+1, err)
+	}
+
+	return "", fmt.Errorf("failed after %d attempts, last error: %v", MAX_RETRIES, lastErr)
+}
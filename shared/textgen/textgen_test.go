@@ -0,0 +1,139 @@
+package textgen
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFakeIsDeterministic(t *testing.T) {
+	fake := NewFake()
+	ctx := context.Background()
+
+	first, _ := fake.Generate(ctx, "write a story", Options{})
+	second, _ := fake.Generate(ctx, "write a story", Options{})
+	other, _ := fake.Generate(ctx, "write a different story", Options{})
+	if first != second {
+		t.Errorf("expected identical output for identical prompts, got %q and %q", first, second)
+	}
+	if first == other {
+		t.Errorf("expected different output for different prompts")
+	}
+
+	verdict, _ := fake.Generate(ctx, "Answer with ONLY PASS or FAIL.", Options{})
+	if verdict != "PASS" {
+		t.Errorf("expected PASS, got %q", verdict)
+	}
+	if len(fake.Calls()) != 4 {
+		t.Errorf("expected 4 recorded calls, got %d", len(fake.Calls()))
+	}
+}
+
+func TestResolveModel(t *testing.T) {
+	if got := resolveModel(geminiModels, ModelQuality); got != "gemini-1.5-pro" {
+		t.Errorf("expected quality tier to map to gemini-1.5-pro, got %s", got)
+	}
+	if got := resolveModel(geminiModels, ""); got != "gemini-1.5-flash" {
+		t.Errorf("expected empty model to use the fast tier, got %s", got)
+	}
+	if got := resolveModel(cohereModels, "c4ai-aya-expanse-32b"); got != "c4ai-aya-expanse-32b" {
+		t.Errorf("expected explicit model to pass through, got %s", got)
+	}
+}
+
+func TestModelName(t *testing.T) {
+	cohere, err := NewCohere("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ModelName(cohere, ModelQuality); got != "command-r-plus-08-2024" {
+		t.Errorf("expected the cohere quality model, got %s", got)
+	}
+	if got := ModelName(NewFake(), ModelQuality); got != ModelQuality {
+		t.Errorf("expected the fake to report the tier, got %s", got)
+	}
+}
+
+func TestCohereGenerate(t *testing.T) {
+	var received cohereRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer key" {
+			t.Errorf("missing bearer token")
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"message":{"content":[{"type":"text","text":"Bonjour"}]},"finish_reason":"COMPLETE"}`))
+	}))
+	defer server.Close()
+
+	cohere, err := NewCohere("key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	cohere.url = server.URL
+
+	result, err := cohere.Generate(context.Background(), "Say hello", Options{
+		Model:       ModelQuality,
+		Temperature: 0.5,
+		History:     []Message{{Role: "user", Text: "hi"}, {Role: "model", Text: "hello"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != "Bonjour" {
+		t.Errorf("expected Bonjour, got %q", result)
+	}
+	if received.Model != "command-r-plus-08-2024" || received.MaxTokens != DEFAULT_MAX_TOKENS {
+		t.Errorf("unexpected request: %+v", received)
+	}
+	if len(received.Messages) != 3 || received.Messages[1].Role != "assistant" || received.Messages[2].Content != "Say hello" {
+		t.Errorf("unexpected messages: %+v", received.Messages)
+	}
+}
+
+var one = 1.0
+
+var scoreSchema = &Schema{
+	Type:     TypeObject,
+	Required: []string{"verdict", "score", "notes"},
+	Properties: map[string]*Schema{
+		"verdict": {Type: TypeString, Enum: []string{"PASS", "FAIL"}},
+		"score":   {Type: TypeInteger, Minimum: &one},
+		"notes":   {Type: TypeArray, Items: &Schema{Type: TypeString}},
+		"extra":   {Type: TypeString},
+	},
+}
+
+func TestFakeAnswersSchemasWithJSON(t *testing.T) {
+	result, err := NewFake().Generate(context.Background(), "Answer PASS or FAIL", Options{Schema: scoreSchema})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result != `{"notes":[],"score":1,"verdict":"PASS"}` {
+		t.Errorf("unexpected JSON: %s", result)
+	}
+}
+
+func TestSchemaProviders(t *testing.T) {
+	gemini := scoreSchema.gemini()
+	if gemini.Properties["verdict"].Format != "enum" || gemini.Properties["notes"].Items == nil || len(gemini.Required) != 3 {
+		t.Errorf("unexpected gemini schema: %+v", gemini)
+	}
+
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewDecoder(r.Body).Decode(&received)
+		w.Write([]byte(`{"message":{"content":[{"type":"text","text":"{}"}]}}`))
+	}))
+	defer server.Close()
+	cohere, _ := NewCohere("key")
+	cohere.url = server.URL
+	if _, err := cohere.Generate(context.Background(), "Score this", Options{Schema: scoreSchema}); err != nil {
+		t.Fatal(err)
+	}
+	format, _ := received["response_format"].(map[string]interface{})
+	if format["type"] != "json_object" || format["json_schema"].(map[string]interface{})["type"] != "object" {
+		t.Errorf("unexpected response format: %v", received["response_format"])
+	}
+}
@@ -0,0 +1,93 @@
+package tokenize
+
+import (
+	"strings"
+	"sync"
+)
+
+// Rules are the language specific parts of tokenization. Everything else
+// (letters, digits, punctuation, CJK fallback) is handled the same way for all
+// languages.
+type Rules struct {
+	// Elisions are lowercase prefixes, including the apostrophe, that are split
+	// off the following word, e.g. "l'" in "l'actualité".
+	Elisions []string
+	// Clitics are lowercase pronouns joined to a verb with a hyphen that are
+	// split off, e.g. "moi" in "donne-moi". Euphonic "-t-" is dropped.
+	Clitics []string
+	// Abbreviations are lowercase tokens, including the final period, that do
+	// not end a sentence, e.g. "mme." or "etc.".
+	Abbreviations []string
+	// Contractions are lowercase words split into the words they contract,
+	// e.g. "del" into "de" and "el".
+	Contractions map[string][]string
+	// Enclitics are lowercase pronouns joined to the end of a verb without a
+	// hyphen, e.g. "lo" in "hacerlo" or "me" and "lo" in "dámelo". Up to two
+	// are split off, see splitEnclitics.
+	Enclitics []string
+	// StressedEnclitics are the Enclitics only split off a word with a written
+	// accent, because without one words like "suerte" or "primeros" look like
+	// an infinitive followed by them.
+	StressedEnclitics []string
+	// VerbEndings are the lowercase endings of the infinitives and gerunds
+	// that Enclitics are split off, e.g. "ar" or "iendo".
+	VerbEndings []string
+	// Unsplit are lowercase words left whole that would otherwise be split
+	// by the rules above, e.g. "carlos".
+	Unsplit []string
+}
+
+var (
+	mu    sync.RWMutex
+	rules = map[string]Rules{}
+)
+
+// Register sets the rules for a language. Each name (e.g. "French" and "fr")
+// is matched case-insensitively. Registering a name again replaces its rules.
+func Register(r Rules, names ...string) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, name := range names {
+		rules[strings.ToLower(name)] = r
+	}
+}
+
+// RulesFor returns the rules for a language, or empty rules if the language
+// has none registered.
+func RulesFor(language string) Rules {
+	mu.RLock()
+	defer mu.RUnlock()
+	return rules[strings.ToLower(language)]
+}
+
+var commonAbbreviations = []string{"etc.", "p.", "pp.", "no.", "vol.", "ex.", "cf."}
+
+func init() {
+	Register(Rules{
+		Elisions: []string{"l'", "d'", "j'", "m'", "t'", "s'", "n'", "c'", "qu'", "jusqu'", "lorsqu'", "puisqu'", "quoiqu'", "presqu'"},
+		Clitics:  []string{"moi", "toi", "lui", "nous", "vous", "leur", "le", "la", "les", "y", "en", "je", "tu", "il", "elle", "on", "ils", "elles", "ce"},
+		Abbreviations: append([]string{
+			"m.", "mm.", "mme.", "mmes.", "mlle.", "mlles.", "dr.", "pr.", "me.", "st.", "ste.",
+			"av.", "apr.", "env.", "bd.", "éd.", "janv.", "févr.", "avr.", "juil.", "sept.", "oct.", "nov.", "déc.",
+		}, commonAbbreviations...),
+	}, "French", "fr")
+
+	Register(Rules{
+		Contractions:      map[string][]string{"al": {"a", "el"}, "del": {"de", "el"}},
+		Enclitics:         []string{"me", "te", "se", "nos", "os", "lo", "la", "los", "las", "le", "les"},
+		StressedEnclitics: []string{"me", "te", "nos", "os"},
+		VerbEndings:       []string{"ar", "er", "ir", "ír", "ando", "iendo", "yendo"},
+		Unsplit:           []string{"carla", "carlos", "carles", "charla", "charlas", "perla", "perlas"},
+		Abbreviations: append([]string{
+			"sr.", "sra.", "srta.", "sres.", "dr.", "dra.", "lic.", "ing.", "ud.", "uds.", "vd.", "vds.", "d.", "dña.",
+			"núm.", "pág.", "aprox.", "avda.", "ej.", "admón.", "ene.", "feb.", "abr.", "ago.", "sept.", "oct.", "nov.", "dic.",
+		}, commonAbbreviations...),
+	}, "Spanish", "es")
+
+	Register(Rules{
+		Abbreviations: append([]string{
+			"mr.", "mrs.", "ms.", "dr.", "prof.", "sr.", "jr.", "st.", "vs.", "e.g.", "i.e.", "inc.", "ltd.", "co.",
+			"jan.", "feb.", "mar.", "apr.", "aug.", "sept.", "oct.", "nov.", "dec.",
+		}, commonAbbreviations...),
+	}, "English", "en")
+}
@@ -0,0 +1,418 @@
+// Package tokenize splits plain text into words and sentences for the
+// content dictionaries. Language specific behaviour (elision, clitics,
+// contractions and abbreviations) comes from a per-language Rules set, see Register.
+//
+// Text is expected to already have its markdown stripped. Han, Hiragana and
+// Katakana have no spaces to split on, so each character is its own word.
+package tokenize
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type Tokenizer struct {
+	elisions          [][]rune
+	clitics           map[string]bool
+	abbreviations     map[string]bool
+	contractions      map[string][]string
+	enclitics         []string
+	stressedEnclitics map[string]bool
+	verbEndings       []string
+	unsplit           map[string]bool
+}
+
+// For returns a Tokenizer using the rules registered for language.
+func For(language string) *Tokenizer {
+	return New(RulesFor(language))
+}
+
+func New(r Rules) *Tokenizer {
+	t := &Tokenizer{
+		clitics:           make(map[string]bool),
+		abbreviations:     make(map[string]bool),
+		contractions:      make(map[string][]string),
+		stressedEnclitics: make(map[string]bool),
+		unsplit:           make(map[string]bool),
+	}
+	for _, elision := range r.Elisions {
+		t.elisions = append(t.elisions, []rune(normalize(elision)))
+	}
+	// longest first so "jusqu'" wins over "qu'"
+	sort.Slice(t.elisions, func(i, j int) bool { return len(t.elisions[i]) > len(t.elisions[j]) })
+	for _, clitic := range r.Clitics {
+		t.clitics[normalize(clitic)] = true
+	}
+	for _, abbreviation := range r.Abbreviations {
+		t.abbreviations[normalize(abbreviation)] = true
+	}
+	for contraction, words := range r.Contractions {
+		t.contractions[normalize(contraction)] = words
+	}
+	for _, enclitic := range r.Enclitics {
+		t.enclitics = append(t.enclitics, normalize(enclitic))
+	}
+	// longest first so "los" wins over "os"
+	sort.SliceStable(t.enclitics, func(i, j int) bool { return len(t.enclitics[i]) > len(t.enclitics[j]) })
+	for _, enclitic := range r.StressedEnclitics {
+		t.stressedEnclitics[normalize(enclitic)] = true
+	}
+	for _, ending := range r.VerbEndings {
+		t.verbEndings = append(t.verbEndings, normalize(ending))
+	}
+	for _, word := range r.Unsplit {
+		t.unsplit[normalize(word)] = true
+	}
+	return t
+}
+
+func normalize(s string) string {
+	return strings.ToLower(strings.NewReplacer("’", "'", "‘", "'", "‐", "-", "‑", "-").Replace(s))
+}
+
+func isCJK(r rune) bool {
+	return unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana)
+}
+
+func isWordRune(r rune) bool {
+	return (unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r)) && !isCJK(r)
+}
+
+func isApostrophe(r rune) bool {
+	return r == '\'' || r == '’' || r == '‘'
+}
+
+func isHyphen(r rune) bool {
+	return r == '-' || r == '‐' || r == '‑'
+}
+
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
+
+// Words returns the words of text in order, duplicates included. Punctuation,
+// numbers and symbols are dropped, elided articles and hyphenated clitics are
+// returned as their own words.
+func (t *Tokenizer) Words(text string) []string {
+	runes := []rune(text)
+	words := []string{}
+	emit := func(word string) {
+		if hasLetter(word) {
+			words = append(words, word)
+		}
+	}
+
+	for i := 0; i < len(runes); {
+		if isCJK(runes[i]) {
+			emit(string(runes[i]))
+			i++
+			continue
+		}
+		if !isWordRune(runes[i]) {
+			i++
+			continue
+		}
+
+		start := i
+		for i < len(runes) {
+			if isWordRune(runes[i]) {
+				i++
+				continue
+			}
+			// apostrophes and hyphens only join two word characters
+			if (isApostrophe(runes[i]) || isHyphen(runes[i])) && i+1 < len(runes) && isWordRune(runes[i+1]) {
+				i++
+				continue
+			}
+			break
+		}
+		for _, word := range t.splitWord(runes[start:i]) {
+			emit(word)
+		}
+	}
+	return words
+}
+
+// splitWord applies the elision and clitic rules to a single token.
+func (t *Tokenizer) splitWord(word []rune) []string {
+	parts := []string{}
+	for {
+		elision := t.matchElision(word)
+		if elision == 0 {
+			break
+		}
+		parts = append(parts, string(word[:elision]))
+		word = word[elision:]
+	}
+
+	if len(t.clitics) == 0 {
+		return append(parts, t.splitJoined(string(word))...)
+	}
+
+	pieces := strings.FieldsFunc(string(word), isHyphen)
+	clitics := []string{}
+	for len(pieces) > 1 && t.clitics[normalize(pieces[len(pieces)-1])] {
+		clitics = append([]string{pieces[len(pieces)-1]}, clitics...)
+		pieces = pieces[:len(pieces)-1]
+	}
+	// euphonic t, e.g. "a-t-il"
+	if len(clitics) > 0 && len(pieces) > 1 && normalize(pieces[len(pieces)-1]) == "t" {
+		pieces = pieces[:len(pieces)-1]
+	}
+	if len(clitics) == 0 {
+		return append(parts, t.splitJoined(string(word))...)
+	}
+	parts = append(parts, strings.Join(pieces, "-"))
+	return append(parts, clitics...)
+}
+
+// splitJoined applies the contraction and enclitic rules to a word without
+// hyphens.
+func (t *Tokenizer) splitJoined(word string) []string {
+	lower := normalize(word)
+	if t.unsplit[lower] || strings.ContainsFunc(word, isHyphen) {
+		return []string{word}
+	}
+	if words, ok := t.contractions[lower]; ok {
+		parts := append([]string{}, words...)
+		// keep the capital of "Del" or "Al" at the start of a sentence
+		if first, _ := utf8.DecodeRuneInString(word); unicode.IsUpper(first) && len(parts) > 0 {
+			head, size := utf8.DecodeRuneInString(parts[0])
+			parts[0] = string(unicode.ToUpper(head)) + parts[0][size:]
+		}
+		return parts
+	}
+	if parts := t.splitEnclitics([]rune(word), nil); parts != nil {
+		return parts
+	}
+	return []string{word}
+}
+
+// splitEnclitics splits up to two enclitics off the end of word, e.g.
+// "hacerlo" into "hacer" and "lo". What is left must end in a verb ending,
+// or have a written accent and either end in one once the accent is dropped
+// (the gerund of "haciéndolo") or be followed by two enclitics (the
+// imperative of "dámelo"). An accent the enclitics made necessary is dropped.
+// It returns nil if word is not split.
+func (t *Tokenizer) splitEnclitics(word []rune, enclitics []string) []string {
+	if len(enclitics) == 2 {
+		return nil
+	}
+	lower := normalize(string(word))
+	for _, enclitic := range t.enclitics {
+		if !strings.HasSuffix(lower, enclitic) {
+			continue
+		}
+		stem := word[:len(word)-utf8.RuneCountInString(enclitic)]
+		if len(stem) < 2 {
+			continue
+		}
+		found := append([]string{string(word[len(stem):])}, enclitics...)
+		if parts := t.splitStem(stem, found); parts != nil {
+			return parts
+		}
+		if parts := t.splitEnclitics(stem, found); parts != nil {
+			return parts
+		}
+	}
+	return nil
+}
+
+// splitStem returns the stem followed by the enclitics if the stem is a verb
+// they can be split off, see splitEnclitics, or nil.
+func (t *Tokenizer) splitStem(stem []rune, enclitics []string) []string {
+	accented := hasAccent(stem)
+	if !accented {
+		for _, enclitic := range enclitics {
+			if t.stressedEnclitics[normalize(enclitic)] {
+				return nil
+			}
+		}
+	}
+	if t.hasVerbEnding(string(stem)) {
+		// e.g. the accent of "oír" in "oírlo" is its own
+		return append([]string{string(stem)}, enclitics...)
+	}
+	if !accented {
+		return nil
+	}
+	unaccented := removeAccents(stem)
+	if t.hasVerbEnding(unaccented) || len(enclitics) == 2 {
+		return append([]string{unaccented}, enclitics...)
+	}
+	return nil
+}
+
+func (t *Tokenizer) hasVerbEnding(word string) bool {
+	lower := normalize(word)
+	for _, ending := range t.verbEndings {
+		if strings.HasSuffix(lower, ending) {
+			return true
+		}
+	}
+	return false
+}
+
+var accents = map[rune]rune{'á': 'a', 'é': 'e', 'í': 'i', 'ó': 'o', 'ú': 'u', 'Á': 'A', 'É': 'E', 'Í': 'I', 'Ó': 'O', 'Ú': 'U'}
+
+func hasAccent(word []rune) bool {
+	for _, r := range word {
+		if _, ok := accents[r]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func removeAccents(word []rune) string {
+	unaccented := make([]rune, len(word))
+	for i, r := range word {
+		if plain, ok := accents[r]; ok {
+			r = plain
+		}
+		unaccented[i] = r
+	}
+	return string(unaccented)
+}
+
+// matchElision returns the length of the elided prefix of word, or 0.
+func (t *Tokenizer) matchElision(word []rune) int {
+	for _, elision := range t.elisions {
+		if len(word) <= len(elision) {
+			continue
+		}
+		if normalize(string(word[:len(elision)])) == string(elision) {
+			return len(elision)
+		}
+	}
+	return 0
+}
+
+func isTerminator(r rune) bool {
+	switch r {
+	case '.', '!', '?', '…', '。', '！', '？':
+		return true
+	}
+	return false
+}
+
+func isCloser(r rune) bool {
+	switch r {
+	case '"', '\'', '’', '”', '»', ')', ']', '}', '」', '』', '）':
+		return true
+	}
+	return false
+}
+
+func isOpener(r rune) bool {
+	switch r {
+	case '"', '\'', '‘', '“', '«', '(', '[', '{', '¿', '¡', '「', '『', '（':
+		return true
+	}
+	return false
+}
+
+// Sentences returns the sentences of text in order, duplicates included.
+// Sentences end at a terminator followed by whitespace (or directly after a
+// CJK terminator) and at line breaks, so markdown headings stay on their own.
+// Abbreviations, initials, decimal numbers and terminators followed by a
+// lowercase word (e.g. « Bonjour ! » dit-il) do not end a sentence.
+func (t *Tokenizer) Sentences(text string) []string {
+	runes := []rune(text)
+	sentences := []string{}
+	emit := func(sentence []rune) {
+		s := strings.TrimSpace(string(sentence))
+		if hasLetter(s) {
+			sentences = append(sentences, s)
+		}
+	}
+
+	start := 0
+	for i := 0; i < len(runes); i++ {
+		if runes[i] == '\n' {
+			emit(runes[start:i])
+			start = i + 1
+			continue
+		}
+		if !isTerminator(runes[i]) {
+			continue
+		}
+		if runes[i] == '.' && t.isAbbreviation(runes[start:i+1]) {
+			continue
+		}
+
+		// consume "?!", "..." and any closing quotes, including the French
+		// spacing before them
+		end := i + 1
+		for end < len(runes) && (isTerminator(runes[end]) || isCloser(runes[end])) {
+			end++
+		}
+		if next := skipSpaces(runes, end); next < len(runes) && isCloser(runes[next]) {
+			end = next
+			for end < len(runes) && isCloser(runes[end]) {
+				end++
+			}
+		}
+
+		cjk := runes[i] == '。' || runes[i] == '！' || runes[i] == '？'
+		if end < len(runes) && !cjk && !unicode.IsSpace(runes[end]) {
+			i = end - 1
+			continue
+		}
+		if next := skipSpaces(runes, end); next < len(runes) && unicode.IsLower(runes[next]) {
+			i = end - 1
+			continue
+		}
+
+		emit(runes[start:end])
+		start = end
+		i = end - 1
+	}
+	emit(runes[start:])
+	return sentences
+}
+
+func skipSpaces(runes []rune, i int) int {
+	for i < len(runes) && runes[i] != '\n' && unicode.IsSpace(runes[i]) {
+		i++
+	}
+	return i
+}
+
+// isAbbreviation reports whether the period ending sentence belongs to an
+// abbreviation or an initial rather than ending the sentence.
+func (t *Tokenizer) isAbbreviation(sentence []rune) bool {
+	start := len(sentence) - 1
+	for start > 0 && !unicode.IsSpace(sentence[start-1]) {
+		start--
+	}
+	word := sentence[start:]
+	for len(word) > 0 && isOpener(word[0]) {
+		word = word[1:]
+	}
+	if t.abbreviations[normalize(string(word))] {
+		return true
+	}
+	// single letter initials, e.g. "J. K. Rowling"
+	return len(word) == 2 && unicode.IsUpper(word[0])
+}
+
+// Unique returns tokens without duplicates, keeping the first occurrence.
+func Unique(tokens []string) []string {
+	seen := make(map[string]bool, len(tokens))
+	unique := []string{}
+	for _, token := range tokens {
+		if !seen[token] {
+			seen[token] = true
+			unique = append(unique, token)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,80 @@
+package tokenize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		language string
+		text     string
+		want     []string
+	}{
+		{"French", "L'actualité d’aujourd'hui : « Qu'il vienne ! »", []string{"L'", "actualité", "d’", "aujourd'hui", "Qu'", "il", "vienne"}},
+		{"French", "Donne-moi ça, a-t-il dit. C'est peut-être vrai.", []string{"Donne", "moi", "ça", "a", "il", "dit", "C'", "est", "peut-être", "vrai"}},
+		{"French", "jusqu'à 3,5 millions", []string{"jusqu'", "à", "millions"}},
+		{"Spanish", "¿Dónde está el niño? ¡Dímelo ya!", []string{"Dónde", "está", "el", "niño", "Di", "me", "lo", "ya"}},
+		{"Spanish", "Del centro al parque", []string{"De", "el", "centro", "a", "el", "parque"}},
+		{"Spanish", "Quiero hacerlo, irse y oírlo; ¡dámelo! Estaba haciéndolo.", []string{"Quiero", "hacer", "lo", "ir", "se", "y", "oír", "lo", "da", "me", "lo", "Estaba", "haciendo", "lo"}},
+		{"Spanish", "Carlos tuvo suerte: los primeros artículos de la película, rápidamente.", []string{"Carlos", "tuvo", "suerte", "los", "primeros", "artículos", "de", "la", "película", "rápidamente"}},
+		{"English", "don't stop - it's 10:30", []string{"don't", "stop", "it's"}},
+		{"Chinese", "我爱北京。", []string{"我", "爱", "北", "京"}},
+	}
+	for _, tt := range tests {
+		if got := For(tt.language).Words(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s Words(%q)\n got  %q\n want %q", tt.language, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestSentences(t *testing.T) {
+	tests := []struct {
+		language string
+		text     string
+		want     []string
+	}{
+		{
+			"French",
+			"Le Titre\nM. Dupont est arrivé à 9.30 heures. « Bonjour ! » dit-il. Il a parlé à Mme. Martin, etc. puis il est parti...",
+			[]string{"Le Titre", "M. Dupont est arrivé à 9.30 heures.", "« Bonjour ! » dit-il.", "Il a parlé à Mme. Martin, etc. puis il est parti..."},
+		},
+		{
+			"Spanish",
+			"¿Qué pasa? ¡Nada! El Sr. López llegó. J. K. Rowling escribió.",
+			[]string{"¿Qué pasa?", "¡Nada!", "El Sr. López llegó.", "J. K. Rowling escribió."},
+		},
+		{
+			"French",
+			"« Où vas-tu ? » Elle ne répond pas.",
+			[]string{"« Où vas-tu ? »", "Elle ne répond pas."},
+		},
+		{
+			"Chinese",
+			"我爱北京。你呢？",
+			[]string{"我爱北京。", "你呢？"},
+		},
+	}
+	for _, tt := range tests {
+		if got := For(tt.language).Sentences(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s Sentences(%q)\n got  %q\n want %q", tt.language, tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestRegister(t *testing.T) {
+	Register(Rules{Elisions: []string{"dell'"}}, "Italian", "it")
+	defer Register(Rules{}, "Italian", "it")
+
+	want := []string{"dell'", "arte"}
+	if got := For("IT").Words("dell'arte"); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	want := []string{"le", "chat", "Le"}
+	if got := Unique([]string{"le", "chat", "le", "Le"}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,19 @@
+-- Generation requests the lambda gave up on, either because they can never
+-- succeed (malformed or invalid requests) or because they failed too many times.
+CREATE TABLE IF NOT EXISTS generation_failures (
+    id SERIAL PRIMARY KEY,
+    message_id TEXT NOT NULL,
+    body TEXT NOT NULL,
+    language TEXT DEFAULT NULL,
+    cefr_level TEXT DEFAULT NULL,
+    subject TEXT DEFAULT NULL,
+    content_type TEXT DEFAULT NULL,
+    error TEXT NOT NULL,
+    reason TEXT NOT NULL CHECK (reason IN ('permanent', 'exhausted')),
+    attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_generation_failures_created_at ON generation_failures(created_at);
+
+ALTER TABLE generation_failures ENABLE ROW LEVEL SECURITY;
@@ -0,0 +1,35 @@
+-- One row per piece of content the generation lambda is asked to produce on a
+-- given day. A redelivered request finds its job and does nothing if it is
+-- completed, or resumes from the saved draft if generation already happened.
+CREATE TABLE IF NOT EXISTS generation_jobs (
+    id SERIAL PRIMARY KEY,
+    language TEXT NOT NULL,
+    cefr_level TEXT NOT NULL,
+    subject TEXT NOT NULL,
+    content_type TEXT NOT NULL,
+    job_date DATE NOT NULL DEFAULT CURRENT_DATE,
+    status TEXT NOT NULL DEFAULT 'running' CHECK (status IN ('running', 'completed', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0,
+    provider TEXT DEFAULT NULL,
+    model TEXT DEFAULT NULL,
+    draft_key TEXT DEFAULT NULL,
+    output_key TEXT DEFAULT NULL,
+    content_id INTEGER DEFAULT NULL,
+    error TEXT DEFAULT NULL,
+    started_at TIMESTAMP DEFAULT NULL,
+    generated_at TIMESTAMP DEFAULT NULL,
+    completed_at TIMESTAMP DEFAULT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE generation_jobs
+DROP CONSTRAINT IF EXISTS unique_generation_job;
+
+ALTER TABLE generation_jobs
+ADD CONSTRAINT unique_generation_job
+UNIQUE (language, cefr_level, subject, content_type, job_date);
+
+CREATE INDEX IF NOT EXISTS idx_generation_jobs_status ON generation_jobs(status);
+
+ALTER TABLE generation_jobs ENABLE ROW LEVEL SECURITY;
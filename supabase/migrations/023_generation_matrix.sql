@@ -0,0 +1,42 @@
+-- What the queue filler asks the generation lambda to produce. Each row is a
+-- language, CEFR level, subject and content type. Audiobooks are made for
+-- rows with an audiobook_tier, and a row is only generated once every
+-- frequency_days days.
+CREATE TABLE IF NOT EXISTS generation_matrix (
+    id SERIAL PRIMARY KEY,
+    language TEXT NOT NULL,
+    cefr_level TEXT NOT NULL CHECK (cefr_level IN ('A1', 'A2', 'B1', 'B2', 'C1', 'C2')),
+    subject TEXT NOT NULL,
+    content_type TEXT NOT NULL DEFAULT 'News' CHECK (content_type IN ('News', 'Story')),
+    audiobook_tier TEXT DEFAULT NULL CHECK (audiobook_tier IN ('BASIC', 'PREMIUM')),
+    frequency_days INTEGER NOT NULL DEFAULT 1 CHECK (frequency_days >= 1),
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE generation_matrix
+DROP CONSTRAINT IF EXISTS unique_generation_matrix_entry;
+
+ALTER TABLE generation_matrix
+ADD CONSTRAINT unique_generation_matrix_entry
+UNIQUE (language, cefr_level, subject, content_type);
+
+ALTER TABLE generation_matrix ENABLE ROW LEVEL SECURITY;
+
+-- the matrix that used to be hard coded in the queue filler
+INSERT INTO generation_matrix (language, cefr_level, subject, content_type, audiobook_tier)
+SELECT
+    languages.language,
+    levels.cefr_level,
+    subjects.subject,
+    'News',
+    CASE WHEN levels.cefr_level IN ('A2', 'B1', 'C1') THEN 'PREMIUM' ELSE NULL END
+FROM (VALUES ('French'), ('Spanish')) AS languages(language)
+CROSS JOIN (VALUES ('A1'), ('A2'), ('B1'), ('B2'), ('C1'), ('C2')) AS levels(cefr_level)
+CROSS JOIN (VALUES
+    ('Politics'), ('Business'), ('Technology'),
+    ('Finance'), ('Gaming'), ('Music'), ('Entertainment'),
+    ('NBA'), ('NFL'), ('Football')
+) AS subjects(subject)
+ON CONFLICT ON CONSTRAINT unique_generation_matrix_entry DO NOTHING;
@@ -0,0 +1,32 @@
+-- Deduplication and quality scoring for news_sources. The queue filler
+-- canonicalizes URLs and hashes content so the same article is stored once
+-- per topic, and groups near-duplicate articles (e.g. the same wire story on
+-- several sites) under the id of the first one seen.
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS canonical_url TEXT;
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS domain TEXT;
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS content_hash TEXT;
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS cluster_id INTEGER REFERENCES news_sources(id) ON DELETE SET NULL;
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS quality INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE news_sources ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP;
+
+UPDATE news_sources SET last_seen_at = created_at WHERE last_seen_at IS NULL;
+ALTER TABLE news_sources ALTER COLUMN last_seen_at SET DEFAULT CURRENT_TIMESTAMP;
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_news_source_url ON news_sources (topic, canonical_url);
+CREATE UNIQUE INDEX IF NOT EXISTS unique_news_source_content ON news_sources (topic, content_hash);
+CREATE INDEX IF NOT EXISTS idx_news_sources_topic_last_seen ON news_sources (topic, last_seen_at);
+
+-- Per-topic domain rules for news sources. A NULL topic applies to every
+-- topic. Denied domains are never stored. If a topic has allow rules, only
+-- those domains are stored for it; allowed domains also score higher.
+CREATE TABLE IF NOT EXISTS news_source_domains (
+    id SERIAL PRIMARY KEY,
+    topic TEXT DEFAULT NULL,
+    domain TEXT NOT NULL,
+    policy TEXT NOT NULL CHECK (policy IN ('allow', 'deny')),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS unique_news_source_domain ON news_source_domains (COALESCE(topic, ''), domain);
+
+ALTER TABLE news_source_domains ENABLE ROW LEVEL SECURITY;
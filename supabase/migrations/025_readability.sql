@@ -0,0 +1,5 @@
+-- Readability metrics measured by the generation lambda: word count,
+-- sentence length and whether the content was within the limits for its CEFR
+-- level.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS readability JSONB;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS readability JSONB;
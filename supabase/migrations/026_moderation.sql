@@ -0,0 +1,16 @@
+-- Moderation of generated content. The generation lambda reviews content
+-- before storing it; flagged content is stored as pending_review, with the
+-- flags in moderation, and is hidden from learners until an editor reviews it.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+
+ALTER TABLE news DROP CONSTRAINT IF EXISTS news_status_check;
+ALTER TABLE news ADD CONSTRAINT news_status_check CHECK (status IN ('published', 'pending_review'));
+ALTER TABLE stories DROP CONSTRAINT IF EXISTS stories_status_check;
+ALTER TABLE stories ADD CONSTRAINT stories_status_check CHECK (status IN ('published', 'pending_review'));
+
+ALTER TABLE news ADD COLUMN IF NOT EXISTS moderation JSONB;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS moderation JSONB;
+
+CREATE INDEX IF NOT EXISTS idx_news_status ON news (status);
+CREATE INDEX IF NOT EXISTS idx_stories_status ON stories (status);
@@ -0,0 +1,30 @@
+-- Editorial review of generated content. Content moves through
+-- draft -> in_review -> published -> retracted; only published content is
+-- shown to learners. Editors are internal staff who review, edit, publish and
+-- retract content through the /editor routes.
+CREATE TABLE IF NOT EXISTS public.editors (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+  created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+  CONSTRAINT unique_editor_user_id UNIQUE (user_id)
+);
+
+ALTER TABLE public.editors ENABLE ROW LEVEL SECURITY;
+
+-- content held by moderation now waits in_review
+ALTER TABLE news DROP CONSTRAINT IF EXISTS news_status_check;
+ALTER TABLE stories DROP CONSTRAINT IF EXISTS stories_status_check;
+UPDATE news SET status = 'in_review' WHERE status = 'pending_review';
+UPDATE stories SET status = 'in_review' WHERE status = 'pending_review';
+ALTER TABLE news ADD CONSTRAINT news_status_check CHECK (status IN ('draft', 'in_review', 'published', 'retracted'));
+ALTER TABLE stories ADD CONSTRAINT stories_status_check CHECK (status IN ('draft', 'in_review', 'published', 'retracted'));
+
+-- the last review: who published or retracted the content, when and why
+ALTER TABLE news ADD COLUMN IF NOT EXISTS reviewed_by UUID REFERENCES auth.users(id) ON DELETE SET NULL;
+ALTER TABLE news ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE news ADD COLUMN IF NOT EXISTS review_note TEXT;
+ALTER TABLE news ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS reviewed_by UUID REFERENCES auth.users(id) ON DELETE SET NULL;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS reviewed_at TIMESTAMP;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS review_note TEXT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
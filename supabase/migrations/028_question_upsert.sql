@@ -0,0 +1,24 @@
+-- Questions are written by the generation lambda when content is created and,
+-- for content it missed, by the API when they are first asked for. Both upsert
+-- with ON CONFLICT, which cannot use deferrable constraints, so the unique
+-- constraints are recreated as immediate ones.
+
+-- Keep the first question written for each content, type and level.
+DELETE FROM questions duplicate
+USING questions kept
+WHERE duplicate.story_id = kept.story_id
+    AND duplicate.question_type = kept.question_type
+    AND duplicate.cefr_level = kept.cefr_level
+    AND duplicate.id > kept.id;
+
+DELETE FROM questions duplicate
+USING questions kept
+WHERE duplicate.news_id = kept.news_id
+    AND duplicate.question_type = kept.question_type
+    AND duplicate.cefr_level = kept.cefr_level
+    AND duplicate.id > kept.id;
+
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_story_question;
+ALTER TABLE questions ADD CONSTRAINT unique_story_question UNIQUE (story_id, question_type, cefr_level);
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_news_question;
+ALTER TABLE questions ADD CONSTRAINT unique_news_question UNIQUE (news_id, question_type, cefr_level);
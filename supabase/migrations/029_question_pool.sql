@@ -0,0 +1,31 @@
+-- A pool of questions per content, type and level instead of a single one.
+-- position numbers the questions of a pool, the unique constraints keep their
+-- names so the upserts of the API and the generation lambda still use them.
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS position INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_story_question;
+ALTER TABLE questions ADD CONSTRAINT unique_story_question UNIQUE (story_id, question_type, cefr_level, position);
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_news_question;
+ALTER TABLE questions ADD CONSTRAINT unique_news_question UNIQUE (news_id, question_type, cefr_level, position);
+
+-- Every answer a user gives, with its evaluation, so /qna can serve questions
+-- they have not seen or have failed and show their history.
+CREATE TABLE IF NOT EXISTS question_attempts (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    question_id INTEGER NOT NULL REFERENCES questions(id) ON DELETE CASCADE,
+    answer TEXT NOT NULL,
+    evaluation TEXT NOT NULL,
+    explanation TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE question_attempts ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view own question attempts" ON question_attempts;
+CREATE POLICY "Users can view own question attempts"
+    ON question_attempts FOR SELECT
+    USING (auth.uid() = user_id);
+
+CREATE INDEX IF NOT EXISTS question_attempts_user_question_idx ON question_attempts(user_id, question_id, created_at);
+CREATE INDEX IF NOT EXISTS question_attempts_user_created_idx ON question_attempts(user_id, created_at DESC);
@@ -0,0 +1,4 @@
+-- The rubric scores and the errors found in an answer, as returned by
+-- /qna/evaluate: {"scores": {...}, "errors": [...]}. NULL for attempts
+-- evaluated before answers were scored.
+ALTER TABLE question_attempts ADD COLUMN IF NOT EXISTS feedback JSONB;
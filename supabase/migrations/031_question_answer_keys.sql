@@ -0,0 +1,7 @@
+-- Multiple choice, cloze and true/false questions are graded against an answer
+-- key instead of by an LLM. choices is the JSON array of options shown to the
+-- learner (NULL for free text questions), answer_key is the correct choice,
+-- missing word or 'true'/'false'. questions has RLS on and no policies, so
+-- answer keys are only readable through the API.
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS choices JSONB;
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS answer_key TEXT;
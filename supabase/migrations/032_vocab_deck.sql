@@ -0,0 +1,50 @@
+-- The words a user saved while reading, with the sentence they met the word
+-- in, scheduled for review with SM-2 (see api/srs). A word is saved once per
+-- user and language; saving it again counts another lookup.
+CREATE TABLE IF NOT EXISTS vocab_cards (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    word TEXT NOT NULL,
+    language TEXT NOT NULL,
+    sentence TEXT NOT NULL DEFAULT '',
+    translation TEXT NOT NULL DEFAULT '',
+    news_id INTEGER REFERENCES news(id) ON DELETE SET NULL,
+    story_id INTEGER REFERENCES stories(id) ON DELETE SET NULL,
+    lookups INTEGER NOT NULL DEFAULT 1,
+    ease_factor DOUBLE PRECISION NOT NULL DEFAULT 2.5,
+    interval_days INTEGER NOT NULL DEFAULT 0,
+    repetitions INTEGER NOT NULL DEFAULT 0,
+    due_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_reviewed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_vocab_card UNIQUE (user_id, language, word),
+    CONSTRAINT vocab_card_source CHECK (news_id IS NULL OR story_id IS NULL)
+);
+
+-- Every review of a card, with the schedule it produced.
+CREATE TABLE IF NOT EXISTS vocab_reviews (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    card_id INTEGER NOT NULL REFERENCES vocab_cards(id) ON DELETE CASCADE,
+    grade INTEGER NOT NULL CHECK (grade BETWEEN 0 AND 5),
+    ease_factor DOUBLE PRECISION NOT NULL,
+    interval_days INTEGER NOT NULL,
+    reviewed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+ALTER TABLE vocab_cards ENABLE ROW LEVEL SECURITY;
+ALTER TABLE vocab_reviews ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view own vocab cards" ON vocab_cards;
+CREATE POLICY "Users can view own vocab cards"
+    ON vocab_cards FOR SELECT
+    USING (auth.uid() = user_id);
+
+DROP POLICY IF EXISTS "Users can view own vocab reviews" ON vocab_reviews;
+CREATE POLICY "Users can view own vocab reviews"
+    ON vocab_reviews FOR SELECT
+    USING (auth.uid() = user_id);
+
+CREATE INDEX IF NOT EXISTS vocab_cards_user_due_idx ON vocab_cards(user_id, due_at);
+CREATE INDEX IF NOT EXISTS vocab_reviews_card_idx ON vocab_reviews(card_id, reviewed_at);
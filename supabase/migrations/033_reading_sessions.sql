@@ -0,0 +1,31 @@
+-- Where each user is in each news article or story: the page they are on,
+-- how far they have got, how long they have spent reading and when they
+-- finished. One row per user and content, updated as they read.
+CREATE TABLE IF NOT EXISTS reading_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    news_id INTEGER REFERENCES news(id) ON DELETE CASCADE,
+    story_id INTEGER REFERENCES stories(id) ON DELETE CASCADE,
+    last_page INTEGER NOT NULL DEFAULT 0,
+    percent_complete DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (percent_complete BETWEEN 0 AND 100),
+    time_spent_seconds INTEGER NOT NULL DEFAULT 0,
+    completed_at TIMESTAMP,
+    started_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT exclusive_reading_content CHECK (
+        (story_id IS NULL AND news_id IS NOT NULL) OR
+        (story_id IS NOT NULL AND news_id IS NULL)
+    ),
+    CONSTRAINT unique_news_reading UNIQUE (user_id, news_id),
+    CONSTRAINT unique_story_reading UNIQUE (user_id, story_id)
+);
+
+ALTER TABLE reading_sessions ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view own reading sessions" ON reading_sessions;
+CREATE POLICY "Users can view own reading sessions"
+    ON reading_sessions FOR SELECT
+    USING (auth.uid() = user_id);
+
+CREATE INDEX IF NOT EXISTS reading_sessions_user_updated_idx ON reading_sessions(user_id, updated_at DESC);
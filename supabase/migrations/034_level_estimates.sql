@@ -0,0 +1,25 @@
+-- Each user's estimated CEFR level per language, from their answers, reading
+-- completion and word lookups at each level (see api/cefr). One row per user
+-- and language, replaced every time /profile/level is requested. applied is
+-- set when the estimate replaced the skill level in their profile.
+CREATE TABLE IF NOT EXISTS level_estimates (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    language TEXT NOT NULL,
+    declared_level TEXT NOT NULL,
+    estimated_level TEXT NOT NULL,
+    confidence DOUBLE PRECISION NOT NULL CHECK (confidence BETWEEN 0 AND 1),
+    recommendation TEXT NOT NULL CHECK (recommendation IN ('up', 'down', 'stay')),
+    evidence JSONB NOT NULL DEFAULT '[]',
+    applied BOOLEAN NOT NULL DEFAULT FALSE,
+    estimated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT unique_level_estimate UNIQUE (user_id, language)
+);
+
+ALTER TABLE level_estimates ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view own level estimates" ON level_estimates;
+CREATE POLICY "Users can view own level estimates"
+    ON level_estimates FOR SELECT
+    USING (auth.uid() = user_id);
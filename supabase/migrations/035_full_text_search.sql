@@ -0,0 +1,34 @@
+-- Full-text search over news and stories. body_text is the plain text of the
+-- content in the content store, written by the generation lambda when it
+-- stores content and by the API when an editor changes it. search_vector
+-- weighs the title over the preview over the body, and is built with the
+-- text search configuration of the content's language so words are stemmed
+-- as the language does. Content stored before body_text is searched by its
+-- title and preview only.
+ALTER TABLE news ADD COLUMN IF NOT EXISTS body_text TEXT;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS body_text TEXT;
+
+CREATE OR REPLACE FUNCTION search_config(language TEXT) RETURNS regconfig
+    LANGUAGE sql IMMUTABLE PARALLEL SAFE
+    AS $$
+        SELECT CASE language
+            WHEN 'French' THEN 'pg_catalog.french'::regconfig
+            WHEN 'Spanish' THEN 'pg_catalog.spanish'::regconfig
+            WHEN 'English' THEN 'pg_catalog.english'::regconfig
+            ELSE 'pg_catalog.simple'::regconfig
+        END
+    $$;
+
+ALTER TABLE news ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(search_config(language), COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector(search_config(language), COALESCE(preview_text, '')), 'B') ||
+    setweight(to_tsvector(search_config(language), COALESCE(body_text, '')), 'C')
+) STORED;
+ALTER TABLE stories ADD COLUMN IF NOT EXISTS search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector(search_config(language), COALESCE(title, '')), 'A') ||
+    setweight(to_tsvector(search_config(language), COALESCE(preview_text, '')), 'B') ||
+    setweight(to_tsvector(search_config(language), COALESCE(body_text, '')), 'C')
+) STORED;
+
+CREATE INDEX IF NOT EXISTS news_search_idx ON news USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS stories_search_idx ON stories USING GIN (search_vector);
@@ -0,0 +1,14 @@
+-- Questions made from a body an editor has since changed are retired instead
+-- of deleted, so the answers users gave to them are kept for their history
+-- and level. Only questions that are not retired are served, and only they
+-- are unique per position, so a pool can be generated again from the new
+-- body. The upserts of the API and the generation lambda name the columns of
+-- the partial indexes instead of the constraints.
+ALTER TABLE questions ADD COLUMN IF NOT EXISTS retired_at TIMESTAMP;
+
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_story_question;
+ALTER TABLE questions DROP CONSTRAINT IF EXISTS unique_news_question;
+CREATE UNIQUE INDEX IF NOT EXISTS questions_story_position_idx
+    ON questions(story_id, question_type, cefr_level, position) WHERE retired_at IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS questions_news_position_idx
+    ON questions(news_id, question_type, cefr_level, position) WHERE retired_at IS NULL;
@@ -0,0 +1,34 @@
+-- Every word a user saved from a news or story, one row per save. The level
+-- estimate (see api/cefr) counts the lookups made in each content it read
+-- against its length; vocab_cards keeps one row per word with the content it
+-- was first saved from, so it cannot tell which content later lookups came
+-- from. Lookups are kept when the card is deleted.
+CREATE TABLE IF NOT EXISTS vocab_lookups (
+    id SERIAL PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES auth.users(id) ON DELETE CASCADE,
+    word TEXT NOT NULL,
+    language TEXT NOT NULL,
+    news_id INTEGER REFERENCES news(id) ON DELETE CASCADE,
+    story_id INTEGER REFERENCES stories(id) ON DELETE CASCADE,
+    looked_up_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT vocab_lookup_source CHECK ((news_id IS NULL) <> (story_id IS NULL))
+);
+
+ALTER TABLE vocab_lookups ENABLE ROW LEVEL SECURITY;
+
+DROP POLICY IF EXISTS "Users can view own vocab lookups" ON vocab_lookups;
+CREATE POLICY "Users can view own vocab lookups"
+    ON vocab_lookups FOR SELECT
+    USING (auth.uid() = user_id);
+
+CREATE INDEX IF NOT EXISTS vocab_lookups_user_news_idx ON vocab_lookups(user_id, news_id);
+CREATE INDEX IF NOT EXISTS vocab_lookups_user_story_idx ON vocab_lookups(user_id, story_id);
+
+-- Lookups saved before the log existed are attributed to the content their
+-- card was first saved from, as the level estimate did until now.
+INSERT INTO vocab_lookups (user_id, word, language, news_id, story_id, looked_up_at)
+SELECT v.user_id, v.word, v.language, v.news_id, v.story_id, v.created_at
+FROM vocab_cards v, generate_series(1, v.lookups)
+WHERE (v.news_id IS NOT NULL OR v.story_id IS NOT NULL)
+    AND NOT EXISTS (SELECT 1 FROM vocab_lookups);